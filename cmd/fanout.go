@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/auth"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	profileFanOutStatusOK     = "ok"
+	profileFanOutStatusFailed = "failed"
+)
+
+var (
+	fanOutProfiles    string
+	fanOutAllProfiles bool
+
+	// profileFanOutRunner runs one profile's command and returns its stdout.
+	profileFanOutRunner = runProfileCommand
+	listFanOutProfiles  = listStoredProfileNames
+)
+
+// profileFanOutReadCommands are the leaf command names safe to fan out.
+// Fan-out is limited to reads so one invocation cannot mutate several accounts.
+var profileFanOutReadCommands = map[string]bool{
+	"get":           true,
+	"list":          true,
+	"latest":        true,
+	"status":        true,
+	"info":          true,
+	"view":          true,
+	"relationships": true,
+	"access-report": true,
+	"audit":         true,
+}
+
+// ProfileFanOutResult is the merged output of a command run across profiles.
+type ProfileFanOutResult struct {
+	Data     []any                  `json:"data"`
+	Profiles []ProfileFanOutOutcome `json:"profiles"`
+}
+
+// ProfileFanOutOutcome records how one profile's run went.
+type ProfileFanOutOutcome struct {
+	Profile string `json:"profile"`
+	Status  string `json:"status"`
+	Items   int    `json:"items"`
+	Error   string `json:"error,omitempty"`
+}
+
+func bindProfileFanOutFlags(fs *flag.FlagSet) {
+	fanOutProfiles = ""
+	fanOutAllProfiles = false
+	fs.StringVar(&fanOutProfiles, "profiles", "", "Run a read command once per named profile (comma-separated) and merge the results")
+	fs.BoolVar(&fanOutAllProfiles, "all-profiles", false, "Run a read command once per stored profile and merge the results")
+}
+
+func profileFanOutRequested() bool {
+	return strings.TrimSpace(fanOutProfiles) != "" || fanOutAllProfiles
+}
+
+// runProfileFanOut runs the parsed command once per profile in child processes,
+// so each run gets its own credentials, and merges their JSON output.
+func runProfileFanOut(ctx context.Context, root *ffcli.Command) error {
+	profiles, err := resolveFanOutProfiles()
+	if err != nil {
+		return err
+	}
+
+	commandArgs := root.FlagSet.Args()
+	commandName := getCommandName(root, commandArgs)
+	path := strings.Fields(commandName)
+	if len(path) < 2 || !profileFanOutReadCommands[path[len(path)-1]] {
+		return shared.UsageErrorf("--profiles and --all-profiles only support read commands (list, get, latest, status, ...); got %q", strings.TrimPrefix(commandName, "asc "))
+	}
+
+	childArgs := append(profileFanOutRootArgs(root.FlagSet), commandArgs...)
+	outputs := make([][]byte, len(profiles))
+	errs := make([]error, len(profiles))
+	var wg sync.WaitGroup
+	for i, profile := range profiles {
+		wg.Go(func() {
+			outputs[i], errs[i] = profileFanOutRunner(ctx, profile, childArgs)
+		})
+	}
+	wg.Wait()
+
+	result := ProfileFanOutResult{Data: []any{}}
+	failed := 0
+	for i, profile := range profiles {
+		outcome := ProfileFanOutOutcome{Profile: profile, Status: profileFanOutStatusOK}
+		var items []any
+		err := errs[i]
+		if err == nil {
+			items, err = profileFanOutItems(outputs[i], profile)
+		}
+		if err != nil {
+			failed++
+			outcome.Status = profileFanOutStatusFailed
+			outcome.Error = err.Error()
+			fmt.Fprintf(os.Stderr, "Error: profile %s: %s\n", profile, outcome.Error)
+		} else {
+			outcome.Items = len(items)
+			result.Data = append(result.Data, items...)
+		}
+		result.Profiles = append(result.Profiles, outcome)
+	}
+
+	pretty := slices.Contains(commandArgs, "--pretty") || slices.Contains(commandArgs, "--pretty=true")
+	if err := shared.PrintOutput(result, "json", pretty); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return shared.NewReportedError(fmt.Errorf("%d of %d profiles failed", failed, len(profiles)))
+	}
+	return nil
+}
+
+func resolveFanOutProfiles() ([]string, error) {
+	if strings.TrimSpace(shared.SelectedProfile()) != "" {
+		return nil, shared.UsageError("--profile cannot be combined with --profiles or --all-profiles")
+	}
+	if fanOutAllProfiles && strings.TrimSpace(fanOutProfiles) != "" {
+		return nil, shared.UsageError("--profiles and --all-profiles are mutually exclusive")
+	}
+
+	var profiles []string
+	if fanOutAllProfiles {
+		names, err := listFanOutProfiles()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list profiles: %w", err)
+		}
+		profiles = names
+	} else {
+		for _, name := range shared.SplitCSV(fanOutProfiles) {
+			if !slices.Contains(profiles, name) {
+				profiles = append(profiles, name)
+			}
+		}
+	}
+	if len(profiles) == 0 {
+		return nil, shared.UsageError("no profiles to run; store one with `asc auth login --name ...`")
+	}
+	return profiles, nil
+}
+
+func listStoredProfileNames() ([]string, error) {
+	credentials, err := auth.ListCredentials()
+	if err != nil {
+		if _, ok := errors.AsType[*auth.CredentialsWarning](err); !ok {
+			return nil, err
+		}
+	}
+	var names []string
+	for _, cred := range credentials {
+		name := strings.TrimSpace(cred.Name)
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// profileFanOutRootArgs re-serializes root flags for child runs, leaving out
+// profile selection and report flags the parent handles itself.
+func profileFanOutRootArgs(fs *flag.FlagSet) []string {
+	skip := map[string]bool{
+		"profile":      true,
+		"profiles":     true,
+		"all-profiles": true,
+		"report":       true,
+		"report-file":  true,
+		"version":      true,
+	}
+	var args []string
+	fs.Visit(func(f *flag.Flag) {
+		if !skip[f.Name] {
+			args = append(args, "--"+f.Name+"="+f.Value.String())
+		}
+	})
+	return args
+}
+
+// profileFanOutItems splits a command's JSON output into items and tags each
+// object with the profile it came from. List responses contribute their data
+// entries; any other value is a single item.
+func profileFanOutItems(output []byte, profile string) ([]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("output is not JSON (fan-out requires --output json): %w", err)
+	}
+
+	var items []any
+	switch typed := value.(type) {
+	case []any:
+		items = typed
+	case map[string]any:
+		switch data := typed["data"].(type) {
+		case []any:
+			items = data
+		case map[string]any:
+			items = []any{data}
+		default:
+			items = []any{typed}
+		}
+	default:
+		items = []any{typed}
+	}
+
+	for i, item := range items {
+		if object, ok := item.(map[string]any); ok {
+			object["profile"] = profile
+		} else {
+			items[i] = map[string]any{"profile": profile, "value": item}
+		}
+	}
+	return items, nil
+}
+
+func runProfileCommand(ctx context.Context, profile string, args []string) ([]byte, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate asc executable: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, executable, append([]string{"--profile", profile}, args...)...)
+	cmd.Env = append(os.Environ(), "ASC_DEFAULT_OUTPUT=json")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if message := lastStderrLine(stderr.String()); message != "" {
+			return nil, errors.New(message)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+func lastStderrLine(stderr string) string {
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line != "" {
+			return strings.TrimPrefix(line, "Error: ")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+func stubProfileFanOutRunner(t *testing.T, fn func(ctx context.Context, profile string, args []string) ([]byte, error)) {
+	t.Helper()
+	previous := profileFanOutRunner
+	profileFanOutRunner = fn
+	t.Cleanup(func() {
+		profileFanOutRunner = previous
+		shared.SetSelectedProfile("")
+	})
+}
+
+func TestRun_ProfilesFanOutMergesResultsAndReportsFailures(t *testing.T) {
+	resetReportFlags(t)
+
+	var seenArgs []string
+	stubProfileFanOutRunner(t, func(ctx context.Context, profile string, args []string) ([]byte, error) {
+		switch profile {
+		case "alpha":
+			seenArgs = args
+			return []byte(`{"data":[{"type":"apps","id":"1"},{"type":"apps","id":"2"}],"links":{}}`), nil
+		case "beta":
+			return nil, errors.New("invalid private key")
+		default:
+			return []byte(`{"data":[{"type":"apps","id":"3"}]}`), nil
+		}
+	})
+
+	var code int
+	stdout, stderr := captureCommandOutput(t, func() {
+		code = Run([]string{"--profiles", "alpha,beta,gamma", "apps", "list", "--limit", "5"}, "1.0.0")
+	})
+	if code != ExitError {
+		t.Fatalf("Run() exit code = %d, want %d (stderr %q)", code, ExitError, stderr)
+	}
+	if !reflect.DeepEqual(seenArgs, []string{"apps", "list", "--limit", "5"}) {
+		t.Fatalf("unexpected child args %v", seenArgs)
+	}
+	if !strings.Contains(stderr, "Error: profile beta: invalid private key") {
+		t.Fatalf("expected per-profile failure in stderr, got %q", stderr)
+	}
+
+	var result ProfileFanOutResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse output %q: %v", stdout, err)
+	}
+	var tagged []string
+	for _, item := range result.Data {
+		object := item.(map[string]any)
+		tagged = append(tagged, object["profile"].(string)+":"+object["id"].(string))
+	}
+	if !reflect.DeepEqual(tagged, []string{"alpha:1", "alpha:2", "gamma:3"}) {
+		t.Fatalf("unexpected merged data %v", tagged)
+	}
+	want := []ProfileFanOutOutcome{
+		{Profile: "alpha", Status: profileFanOutStatusOK, Items: 2},
+		{Profile: "beta", Status: profileFanOutStatusFailed, Error: "invalid private key"},
+		{Profile: "gamma", Status: profileFanOutStatusOK, Items: 1},
+	}
+	if !reflect.DeepEqual(result.Profiles, want) {
+		t.Fatalf("profiles = %+v, want %+v", result.Profiles, want)
+	}
+}
+
+func TestRun_AllProfilesUsesStoredProfiles(t *testing.T) {
+	resetReportFlags(t)
+
+	previousList := listFanOutProfiles
+	listFanOutProfiles = func() ([]string, error) { return []string{"one", "two"}, nil }
+	t.Cleanup(func() { listFanOutProfiles = previousList })
+
+	stubProfileFanOutRunner(t, func(ctx context.Context, profile string, args []string) ([]byte, error) {
+		return []byte(`{"app":{"id":"` + profile + `"},"summary":{"health":"green"}}`), nil
+	})
+
+	var code int
+	stdout, _ := captureCommandOutput(t, func() {
+		code = Run([]string{"--all-profiles", "status", "--app", "123"}, "1.0.0")
+	})
+	if code != ExitSuccess {
+		t.Fatalf("Run() exit code = %d, want %d", code, ExitSuccess)
+	}
+	if strings.Count(stdout, `"profile":"one"`) != 2 || strings.Count(stdout, `"profile":"two"`) != 2 {
+		t.Fatalf("expected one status object and one outcome per profile, got %s", stdout)
+	}
+}
+
+func TestRun_ProfilesFanOutRejectsWrites(t *testing.T) {
+	resetReportFlags(t)
+
+	stubProfileFanOutRunner(t, func(ctx context.Context, profile string, args []string) ([]byte, error) {
+		t.Fatal("runner should not be called for write commands")
+		return nil, nil
+	})
+
+	var code int
+	_, stderr := captureCommandOutput(t, func() {
+		code = Run([]string{"--profiles", "a,b", "apps", "update", "--id", "1"}, "1.0.0")
+	})
+	if code != ExitUsage {
+		t.Fatalf("Run() exit code = %d, want %d", code, ExitUsage)
+	}
+	if !strings.Contains(stderr, "only support read commands") {
+		t.Fatalf("unexpected stderr %q", stderr)
+	}
+}
+
+func TestProfileFanOutRootArgs(t *testing.T) {
+	fs := flag.NewFlagSet("asc", flag.ContinueOnError)
+	fs.String("profile", "", "")
+	fs.String("profiles", "", "")
+	fs.String("report", "", "")
+	fs.Bool("strict-auth", false, "")
+	if err := fs.Parse([]string{"--profiles", "a,b", "--report", "junit", "--strict-auth"}); err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if got := profileFanOutRootArgs(fs); !reflect.DeepEqual(got, []string{"--strict-auth=true"}) {
+		t.Fatalf("unexpected root args %v", got)
+	}
+}
+
+func TestProfileFanOutItems(t *testing.T) {
+	items, err := profileFanOutItems([]byte(`{"data":{"id":"9"}}`), "p")
+	if err != nil || len(items) != 1 || items[0].(map[string]any)["profile"] != "p" {
+		t.Fatalf("unexpected single-resource items %v, %v", items, err)
+	}
+	if _, err := profileFanOutItems([]byte("ID  NAME\n1   App"), "p"); err == nil || !strings.Contains(err.Error(), "--output json") {
+		t.Fatalf("expected non-JSON output error, got %v", err)
+	}
+}
@@ -32,6 +32,7 @@ func RootCommand(version string) *ffcli.Command {
 
 	root.FlagSet.BoolVar(&versionRequested, "version", false, "Print version and exit")
 	shared.BindRootFlags(root.FlagSet)
+	bindProfileFanOutFlags(root.FlagSet)
 
 	var (
 		rootSubcommandNames     []string
@@ -65,7 +65,12 @@ func Run(args []string, versionInfo string) int {
 	}
 
 	start := time.Now()
-	runErr := root.Run(runCtx)
+	var runErr error
+	if profileFanOutRequested() {
+		runErr = runProfileFanOut(runCtx, root)
+	} else {
+		runErr = root.Run(runCtx)
+	}
 	elapsed := time.Since(start)
 
 	// Get command name (full subcommand path)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"strings"
 )
 
@@ -141,3 +142,22 @@ func (c *Client) DeleteBetaFeedbackScreenshotSubmission(ctx context.Context, sub
 	_, err := c.do(ctx, "DELETE", path, nil)
 	return err
 }
+
+// DownloadBetaFeedbackScreenshot streams a feedback screenshot from its signed URL.
+// The URL is fetched without App Store Connect credentials.
+func (c *Client) DownloadBetaFeedbackScreenshot(ctx context.Context, downloadURL string) (*ReportDownload, error) {
+	downloadURL = strings.TrimSpace(downloadURL)
+	parsedURL, err := url.Parse(downloadURL)
+	if err != nil || downloadURL == "" {
+		return nil, fmt.Errorf("invalid screenshot URL %q", downloadURL)
+	}
+	if parsedURL.Scheme != "https" || parsedURL.Hostname() == "" {
+		return nil, fmt.Errorf("rejected screenshot URL %q (expected https)", downloadURL)
+	}
+
+	resp, err := c.doStreamNoAuth(ctx, "GET", downloadURL, "")
+	if err != nil {
+		return nil, err
+	}
+	return &ReportDownload{Body: resp.Body, ContentLength: resp.ContentLength}, nil
+}
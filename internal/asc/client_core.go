@@ -475,6 +475,8 @@ type Client struct {
 	jwtMu              sync.Mutex
 	cachedJWT          string
 	cachedJWTExpiresAt time.Time
+	scopedTokens       bool
+	scopedJWTs         map[string]cachedToken
 }
 
 // NewClient creates a new ASC client.
@@ -491,6 +493,20 @@ func NewClientWithHTTPClient(keyID, issuerID, privateKeyPath string, httpClient
 	return newClientWithHTTPClient(keyID, issuerID, privateKeyPath, httpClient)
 }
 
+// NewClientWithPrivateKey creates a new ASC client from an in-memory private key.
+func NewClientWithPrivateKey(keyID, issuerID string, key *ecdsa.PrivateKey) (*Client, error) {
+	if key == nil {
+		return nil, fmt.Errorf("private key is required")
+	}
+	return &Client{
+		httpClient:   newDefaultHTTPClient(ResolveTimeout()),
+		keyID:        keyID,
+		issuerID:     issuerID,
+		privateKey:   key,
+		scopedTokens: ResolveScopedTokensEnabled(),
+	}, nil
+}
+
 func newDefaultHTTPClient(timeout time.Duration) *http.Client {
 	transport, ok := http.DefaultTransport.(*http.Transport)
 	if !ok {
@@ -523,9 +539,10 @@ func newClientWithHTTPClient(keyID, issuerID, privateKeyPath string, httpClient
 	}
 
 	return &Client{
-		httpClient: httpClient,
-		keyID:      keyID,
-		issuerID:   issuerID,
-		privateKey: key,
+		httpClient:   httpClient,
+		keyID:        keyID,
+		issuerID:     issuerID,
+		privateKey:   key,
+		scopedTokens: ResolveScopedTokensEnabled(),
 	}, nil
 }
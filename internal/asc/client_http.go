@@ -13,18 +13,10 @@ import (
 	"strconv"
 	"strings"
 	"time"
-
-	"github.com/golang-jwt/jwt/v5"
 )
 
 // newRequest creates a new HTTP request with JWT authentication
 func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
-	// Generate JWT token
-	token, err := c.generateJWT()
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate JWT: %w", err)
-	}
-
 	url := path
 	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
 		url = BaseURL + path
@@ -34,6 +26,11 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body io.Re
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	token, err := c.tokenForRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT: %w", err)
+	}
+
 	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
@@ -64,24 +61,8 @@ func (c *Client) generateJWT() (string, error) {
 
 // GenerateJWT generates a JWT for ASC API authentication.
 func GenerateJWT(keyID, issuerID string, privateKey *ecdsa.PrivateKey) (string, error) {
-	now := time.Now()
-	claims := jwt.RegisteredClaims{
-		Issuer:    issuerID,
-		Audience:  jwt.ClaimStrings{"appstoreconnect-v1"},
-		IssuedAt:  jwt.NewNumericDate(now),
-		ExpiresAt: jwt.NewNumericDate(now.Add(tokenLifetime)),
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
-	token.Header["kid"] = keyID
-
-	// Sign with the private key
-	signedToken, err := token.SignedString(privateKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to sign token: %w", err)
-	}
-
-	return signedToken, nil
+	signedToken, _, err := GenerateScopedJWT(keyID, issuerID, privateKey, nil, tokenLifetime)
+	return signedToken, err
 }
 
 // do performs an HTTP request and returns the response.
@@ -648,9 +629,14 @@ func sanitizeTerminal(input string) string {
 	return b.String()
 }
 
-// IsNotFound checks if the error is a "not found" error
+// IsNotFound checks if the error is a "not found" error, either by API error
+// code or by HTTP status (report downloads return a bare 404).
 func IsNotFound(err error) bool {
-	return errors.Is(err, ErrNotFound)
+	if errors.Is(err, ErrNotFound) {
+		return true
+	}
+	apiErr, ok := errors.AsType[*APIError](err)
+	return ok && apiErr.StatusCode == http.StatusNotFound
 }
 
 // IsUnauthorized checks if the error is an "unauthorized" error
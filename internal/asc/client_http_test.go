@@ -3130,10 +3130,44 @@ func TestGetEndpoints_ReturnsParseError(t *testing.T) {
 	}
 }
 
+func TestIsNotFound_MatchesBare404Status(t *testing.T) {
+	if !IsNotFound(&APIError{StatusCode: http.StatusNotFound}) {
+		t.Fatal("expected IsNotFound to match a 404 without an error code")
+	}
+	if IsNotFound(&APIError{StatusCode: http.StatusGone}) {
+		t.Fatal("expected IsNotFound to ignore other statuses without an error code")
+	}
+	if IsNotFound(&APIError{Code: "FORBIDDEN", StatusCode: http.StatusForbidden}) {
+		t.Fatal("expected IsNotFound to ignore forbidden errors")
+	}
+
+	response := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       io.NopCloser(strings.NewReader("Not Found")),
+		Header:     http.Header{"Content-Type": []string{"text/plain"}},
+	}
+	client := newTestClient(t, nil, response)
+	_, err := client.DownloadFinanceReport(context.Background(), FinanceReportParams{
+		VendorNumber: "12345678",
+		ReportType:   FinanceReportTypeFinancial,
+		RegionCode:   "US",
+		ReportDate:   "2025-12",
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !IsNotFound(err) {
+		t.Fatalf("expected a bare 404 report download to be not found, got %v", err)
+	}
+}
+
 func TestIsNotFoundAndUnauthorized(t *testing.T) {
 	if !IsNotFound(&APIError{Code: "NOT_FOUND", Title: "The specified resource does not exist"}) {
 		t.Fatal("expected IsNotFound to return true")
 	}
+	if !IsNotFound(fmt.Errorf("download report: %w", &APIError{StatusCode: http.StatusNotFound})) {
+		t.Fatal("expected IsNotFound to match a wrapped 404 without an error code")
+	}
 	if IsNotFound(fmt.Errorf("something else")) {
 		t.Fatal("expected IsNotFound to return false")
 	}
@@ -9325,6 +9359,9 @@ func TestListReviewSubmissionsGlobal_WithFilters(t *testing.T) {
 		if values.Get("filter[state]") != "READY_FOR_REVIEW" {
 			t.Fatalf("expected filter[state]=READY_FOR_REVIEW, got %q", values.Get("filter[state]"))
 		}
+		if values.Get("include") != "appStoreVersionForReview" {
+			t.Fatalf("expected include=appStoreVersionForReview, got %q", values.Get("include"))
+		}
 		assertAuthorized(t, req)
 	}, response)
 
@@ -9333,6 +9370,7 @@ func TestListReviewSubmissionsGlobal_WithFilters(t *testing.T) {
 		WithReviewSubmissionsApps([]string{"app-1"}),
 		WithReviewSubmissionsPlatforms([]string{"IOS"}),
 		WithReviewSubmissionsStates([]string{"READY_FOR_REVIEW"}),
+		WithReviewSubmissionsInclude([]string{"appStoreVersionForReview"}),
 	); err != nil {
 		t.Fatalf("ListReviewSubmissions() error: %v", err)
 	}
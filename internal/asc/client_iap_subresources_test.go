@@ -461,6 +461,22 @@ func TestGetInAppPurchasePricePointEqualizations(t *testing.T) {
 	}
 }
 
+func TestGetInAppPurchasePricePointEqualizations_WithLimit(t *testing.T) {
+	response := jsonResponse(http.StatusOK, `{"data":[]}`)
+	client := newTestClient(t, func(req *http.Request) {
+		if req.URL.Path != "/v1/inAppPurchasePricePoints/price-1/equalizations" {
+			t.Fatalf("expected path /v1/inAppPurchasePricePoints/price-1/equalizations, got %s", req.URL.Path)
+		}
+		if req.URL.Query().Get("limit") != "200" {
+			t.Fatalf("expected limit=200, got %q", req.URL.Query().Get("limit"))
+		}
+	}, response)
+
+	if _, err := client.GetInAppPurchasePricePointEqualizations(context.Background(), "price-1", WithIAPPricePointsLimit(200)); err != nil {
+		t.Fatalf("GetInAppPurchasePricePointEqualizations() error: %v", err)
+	}
+}
+
 func TestGetInAppPurchasePriceScheduleManualPrices_WithLimit(t *testing.T) {
 	response := jsonResponse(http.StatusOK, `{"data":[]}`)
 	client := newTestClient(t, func(req *http.Request) {
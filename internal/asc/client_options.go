@@ -275,6 +275,9 @@ type LinkagesOption func(*linkagesQuery)
 // PricePointsOption is a functional option for GetAppPricePoints.
 type PricePointsOption func(*pricePointsQuery)
 
+// AppPriceSchedulePricesOption is a functional option for app price schedule prices.
+type AppPriceSchedulePricesOption func(*appPriceSchedulePricesQuery)
+
 // AccessibilityDeclarationsOption is a functional option for accessibility declarations.
 type AccessibilityDeclarationsOption func(*accessibilityDeclarationsQuery)
 
@@ -400,6 +403,13 @@ func WithFeedbackIncludeScreenshots() FeedbackOption {
 	}
 }
 
+// WithFeedbackIncludeBuild includes the related build and its version number.
+func WithFeedbackIncludeBuild() FeedbackOption {
+	return func(q *feedbackQuery) {
+		q.includeBuild = true
+	}
+}
+
 // WithCrashDeviceModels filters crashes by device model(s).
 func WithCrashDeviceModels(models []string) CrashOption {
 	return func(q *crashQuery) {
@@ -1010,6 +1020,13 @@ func WithCrashSort(sort string) CrashOption {
 	}
 }
 
+// WithCrashIncludeBuild includes the related build and its version number.
+func WithCrashIncludeBuild() CrashOption {
+	return func(q *crashQuery) {
+		q.includeBuild = true
+	}
+}
+
 // WithRating filters reviews by star rating (1-5).
 func WithRating(rating int) ReviewOption {
 	return func(r *reviewQuery) {
@@ -1028,6 +1045,14 @@ func WithTerritory(territory string) ReviewOption {
 	}
 }
 
+// WithReviewPublishedResponse filters reviews by whether a developer response
+// has been published.
+func WithReviewPublishedResponse(exists bool) ReviewOption {
+	return func(r *reviewQuery) {
+		r.publishedResponse = &exists
+	}
+}
+
 // WithReviewSort sets the sort order for reviews.
 func WithReviewSort(sort string) ReviewOption {
 	return func(r *reviewQuery) {
@@ -1689,6 +1714,13 @@ func WithReviewSubmissionsApps(appIDs []string) ReviewSubmissionsOption {
 	}
 }
 
+// WithReviewSubmissionsInclude includes related resources for review submissions.
+func WithReviewSubmissionsInclude(include []string) ReviewSubmissionsOption {
+	return func(q *reviewSubmissionsQuery) {
+		q.include = normalizeList(include)
+	}
+}
+
 // WithReviewSubmissionItemsLimit sets the max number of review submission items to return.
 func WithReviewSubmissionItemsLimit(limit int) ReviewSubmissionItemsOption {
 	return func(q *reviewSubmissionItemsQuery) {
@@ -3162,6 +3194,31 @@ func WithPricePointsTerritory(territory string) PricePointsOption {
 	}
 }
 
+// WithAppPriceSchedulePricesLimit sets the max number of schedule prices to return.
+func WithAppPriceSchedulePricesLimit(limit int) AppPriceSchedulePricesOption {
+	return func(q *appPriceSchedulePricesQuery) {
+		if limit > 0 {
+			q.limit = limit
+		}
+	}
+}
+
+// WithAppPriceSchedulePricesNextURL uses a next page URL directly.
+func WithAppPriceSchedulePricesNextURL(next string) AppPriceSchedulePricesOption {
+	return func(q *appPriceSchedulePricesQuery) {
+		if strings.TrimSpace(next) != "" {
+			q.nextURL = strings.TrimSpace(next)
+		}
+	}
+}
+
+// WithAppPriceSchedulePricesInclude includes related resources (appPricePoint, territory).
+func WithAppPriceSchedulePricesInclude(include []string) AppPriceSchedulePricesOption {
+	return func(q *appPriceSchedulePricesQuery) {
+		q.include = normalizeList(include)
+	}
+}
+
 // WithAppCustomProductPagesLimit sets the max number of custom product pages to return.
 func WithAppCustomProductPagesLimit(limit int) AppCustomProductPagesOption {
 	return func(q *appCustomProductPagesQuery) {
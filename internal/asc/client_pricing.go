@@ -97,9 +97,22 @@ func (c *Client) GetAppPricePoint(ctx context.Context, pricePointID string) (*Ap
 }
 
 // GetAppPricePointEqualizations retrieves equalized price points for a price point.
-func (c *Client) GetAppPricePointEqualizations(ctx context.Context, pricePointID string) (*AppPricePointsV3Response, error) {
+func (c *Client) GetAppPricePointEqualizations(ctx context.Context, pricePointID string, opts ...PricePointsOption) (*AppPricePointsV3Response, error) {
+	query := &pricePointsQuery{}
+	for _, opt := range opts {
+		opt(query)
+	}
+
 	pricePointID = strings.TrimSpace(pricePointID)
 	path := fmt.Sprintf("/v3/appPricePoints/%s/equalizations", pricePointID)
+	if query.nextURL != "" {
+		if err := validateNextURL(query.nextURL); err != nil {
+			return nil, fmt.Errorf("appPricePointEqualizations: %w", err)
+		}
+		path = query.nextURL
+	} else if queryString := buildPricePointsQuery(query); queryString != "" {
+		path += "?" + queryString
+	}
 
 	data, err := c.do(ctx, "GET", path, nil)
 	if err != nil {
@@ -191,22 +204,60 @@ func (c *Client) GetAppPriceScheduleByID(ctx context.Context, scheduleID string)
 // CreateAppPriceSchedule creates an app price schedule with a manual price.
 func (c *Client) CreateAppPriceSchedule(ctx context.Context, appID string, attrs AppPriceScheduleCreateAttributes) (*AppPriceScheduleResponse, error) {
 	appID = strings.TrimSpace(appID)
-	pricePointID := strings.TrimSpace(attrs.PricePointID)
-	startDate := strings.TrimSpace(attrs.StartDate)
 	baseTerritoryID := strings.ToUpper(strings.TrimSpace(attrs.BaseTerritoryID))
 	if appID == "" {
 		return nil, fmt.Errorf("app ID is required")
 	}
-	if pricePointID == "" {
-		return nil, fmt.Errorf("price point ID is required")
-	}
-	if startDate == "" {
-		return nil, fmt.Errorf("start date is required")
+
+	prices := attrs.Prices
+	if len(prices) == 0 {
+		pricePointID := strings.TrimSpace(attrs.PricePointID)
+		startDate := strings.TrimSpace(attrs.StartDate)
+		if pricePointID == "" {
+			return nil, fmt.Errorf("price point ID is required")
+		}
+		if startDate == "" {
+			return nil, fmt.Errorf("start date is required")
+		}
+		prices = []AppPriceSchedulePrice{{PricePointID: pricePointID, StartDate: startDate}}
 	}
 	if baseTerritoryID == "" {
 		return nil, fmt.Errorf("base territory ID is required")
 	}
 
+	included := make([]AppPriceCreateResource, 0, len(prices))
+	relationshipData := make([]ResourceData, 0, len(prices))
+	for idx, price := range prices {
+		pricePointID := strings.TrimSpace(price.PricePointID)
+		if pricePointID == "" {
+			return nil, fmt.Errorf("price point ID is required")
+		}
+		resourceID := appPriceScheduleManualPriceID
+		if idx > 0 {
+			resourceID = fmt.Sprintf("${local-manual-price-%d}", idx+1)
+		}
+		relationshipData = append(relationshipData, ResourceData{
+			Type: ResourceTypeAppPrices,
+			ID:   resourceID,
+		})
+		included = append(included, AppPriceCreateResource{
+			Type: ResourceTypeAppPrices,
+			ID:   resourceID,
+			Attributes: AppPriceAttributes{
+				StartDate: strings.TrimSpace(price.StartDate),
+				EndDate:   strings.TrimSpace(price.EndDate),
+			},
+			Relationships: AppPriceRelationships{
+				AppPricePoint: Relationship{
+					Data: ResourceData{
+						Type: ResourceTypeAppPricePoints,
+						ID:   pricePointID,
+					},
+				},
+			},
+		})
+	}
+
 	payload := AppPriceScheduleCreateRequest{
 		Data: AppPriceScheduleCreateData{
 			Type: ResourceTypeAppPriceSchedules,
@@ -224,30 +275,11 @@ func (c *Client) CreateAppPriceSchedule(ctx context.Context, appID string, attrs
 					},
 				},
 				ManualPrices: RelationshipList{
-					Data: []ResourceData{
-						{
-							Type: ResourceTypeAppPrices,
-							ID:   appPriceScheduleManualPriceID,
-						},
-					},
-				},
-			},
-		},
-		Included: []AppPriceCreateResource{
-			{
-				Type:       ResourceTypeAppPrices,
-				ID:         appPriceScheduleManualPriceID,
-				Attributes: AppPriceAttributes{StartDate: startDate},
-				Relationships: AppPriceRelationships{
-					AppPricePoint: Relationship{
-						Data: ResourceData{
-							Type: ResourceTypeAppPricePoints,
-							ID:   pricePointID,
-						},
-					},
+					Data: relationshipData,
 				},
 			},
 		},
+		Included: included,
 	}
 
 	body, err := BuildRequestBody(payload)
@@ -314,9 +346,22 @@ func (c *Client) GetAppPriceScheduleBaseTerritoryRelationship(ctx context.Contex
 }
 
 // GetAppPriceScheduleManualPrices retrieves manual prices for a schedule.
-func (c *Client) GetAppPriceScheduleManualPrices(ctx context.Context, scheduleID string) (*AppPricesResponse, error) {
+func (c *Client) GetAppPriceScheduleManualPrices(ctx context.Context, scheduleID string, opts ...AppPriceSchedulePricesOption) (*AppPricesResponse, error) {
+	query := &appPriceSchedulePricesQuery{}
+	for _, opt := range opts {
+		opt(query)
+	}
+
 	scheduleID = strings.TrimSpace(scheduleID)
 	path := fmt.Sprintf("/v1/appPriceSchedules/%s/manualPrices", scheduleID)
+	if query.nextURL != "" {
+		if err := validateNextURL(query.nextURL); err != nil {
+			return nil, fmt.Errorf("appPriceScheduleManualPrices: %w", err)
+		}
+		path = query.nextURL
+	} else if queryString := buildAppPriceSchedulePricesQuery(query); queryString != "" {
+		path += "?" + queryString
+	}
 
 	data, err := c.do(ctx, "GET", path, nil)
 	if err != nil {
@@ -23,6 +23,7 @@ type feedbackQuery struct {
 	testerIDs                 []string
 	sort                      string
 	includeScreenshots        bool
+	includeBuild              bool
 }
 
 type crashQuery struct {
@@ -35,13 +36,15 @@ type crashQuery struct {
 	buildPreReleaseVersionIDs []string
 	testerIDs                 []string
 	sort                      string
+	includeBuild              bool
 }
 
 type reviewQuery struct {
 	listQuery
-	rating    int
-	territory string
-	sort      string
+	rating            int
+	territory         string
+	sort              string
+	publishedResponse *bool
 }
 
 type appsQuery struct {
@@ -245,6 +248,7 @@ type reviewSubmissionsQuery struct {
 	platforms []string
 	states    []string
 	appIDs    []string
+	include   []string
 }
 
 type reviewSubmissionItemsQuery struct {
@@ -546,6 +550,11 @@ type pricePointsQuery struct {
 	territory string
 }
 
+type appPriceSchedulePricesQuery struct {
+	listQuery
+	include []string
+}
+
 type accessibilityDeclarationsQuery struct {
 	listQuery
 	deviceFamilies []string
@@ -602,6 +611,9 @@ func buildReviewQuery(opts []ReviewOption) string {
 	if query.rating >= 1 && query.rating <= 5 {
 		values.Set("filter[rating]", fmt.Sprintf("%d", query.rating))
 	}
+	if query.publishedResponse != nil {
+		values.Set("exists[publishedResponse]", strconv.FormatBool(*query.publishedResponse))
+	}
 	if query.sort != "" {
 		values.Set("sort", query.sort)
 	}
@@ -748,6 +760,10 @@ func buildFeedbackQuery(query *feedbackQuery) string {
 	if query.sort != "" {
 		values.Set("sort", query.sort)
 	}
+	if query.includeBuild {
+		values.Set("include", "build")
+		values.Set("fields[builds]", "version")
+	}
 	addLimit(values, query.limit)
 	return values.Encode()
 }
@@ -764,6 +780,10 @@ func buildCrashQuery(query *crashQuery) string {
 	if query.sort != "" {
 		values.Set("sort", query.sort)
 	}
+	if query.includeBuild {
+		values.Set("include", "build")
+		values.Set("fields[builds]", "version")
+	}
 	addLimit(values, query.limit)
 	return values.Encode()
 }
@@ -1295,6 +1315,7 @@ func buildReviewSubmissionsQuery(query *reviewSubmissionsQuery) string {
 	addCSV(values, "filter[platform]", query.platforms)
 	addCSV(values, "filter[state]", query.states)
 	addCSV(values, "filter[app]", query.appIDs)
+	addCSV(values, "include", query.include)
 	addLimit(values, query.limit)
 	return values.Encode()
 }
@@ -1536,3 +1557,10 @@ func buildPricePointsQuery(query *pricePointsQuery) string {
 	addLimit(values, query.limit)
 	return values.Encode()
 }
+
+func buildAppPriceSchedulePricesQuery(query *appPriceSchedulePricesQuery) string {
+	values := url.Values{}
+	addCSV(values, "include", query.include)
+	addLimit(values, query.limit)
+	return values.Encode()
+}
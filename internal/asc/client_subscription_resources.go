@@ -484,6 +484,131 @@ func (c *Client) CreateSubscriptionPromotionalOffer(ctx context.Context, subscri
 	return &response, nil
 }
 
+// CreateSubscriptionPromotionalOfferWithPrices creates a promotional offer and
+// its per-territory prices in one request.
+func (c *Client) CreateSubscriptionPromotionalOfferWithPrices(ctx context.Context, subscriptionID string, attrs SubscriptionPromotionalOfferCreateAttributes, prices []SubscriptionPromotionalOfferPrice) (*SubscriptionPromotionalOfferResponse, error) {
+	subscriptionID = strings.TrimSpace(subscriptionID)
+	if subscriptionID == "" {
+		return nil, fmt.Errorf("subscription ID is required")
+	}
+	priceData, included, err := buildSubscriptionPromotionalOfferInlinePrices(prices)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := SubscriptionPromotionalOfferCreateRequest{
+		Data: SubscriptionPromotionalOfferCreateData{
+			Type:       ResourceTypeSubscriptionPromotionalOffers,
+			Attributes: attrs,
+			Relationships: SubscriptionPromotionalOfferRelationships{
+				Subscription: Relationship{
+					Data: ResourceData{
+						Type: ResourceTypeSubscriptions,
+						ID:   subscriptionID,
+					},
+				},
+				Prices: RelationshipList{Data: priceData},
+			},
+		},
+		Included: included,
+	}
+
+	body, err := BuildRequestBody(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := c.do(ctx, http.MethodPost, "/v1/subscriptionPromotionalOffers", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response SubscriptionPromotionalOfferResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &response, nil
+}
+
+// UpdateSubscriptionPromotionalOfferWithPrices replaces a promotional offer's
+// prices with newly created per-territory prices.
+func (c *Client) UpdateSubscriptionPromotionalOfferWithPrices(ctx context.Context, offerID string, prices []SubscriptionPromotionalOfferPrice) (*SubscriptionPromotionalOfferResponse, error) {
+	priceData, included, err := buildSubscriptionPromotionalOfferInlinePrices(prices)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := SubscriptionPromotionalOfferUpdateRequest{
+		Data: SubscriptionPromotionalOfferUpdateData{
+			Type: ResourceTypeSubscriptionPromotionalOffers,
+			ID:   strings.TrimSpace(offerID),
+			Relationships: &SubscriptionPromotionalOfferUpdateRelationships{
+				Prices: RelationshipList{Data: priceData},
+			},
+		},
+		Included: included,
+	}
+
+	body, err := BuildRequestBody(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/v1/subscriptionPromotionalOffers/%s", strings.TrimSpace(offerID))
+	data, err := c.do(ctx, http.MethodPatch, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response SubscriptionPromotionalOfferResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &response, nil
+}
+
+func buildSubscriptionPromotionalOfferInlinePrices(prices []SubscriptionPromotionalOfferPrice) ([]ResourceData, []SubscriptionPromotionalOfferPriceInlineCreate, error) {
+	if len(prices) == 0 {
+		return nil, nil, fmt.Errorf("at least one price is required")
+	}
+
+	priceData := make([]ResourceData, 0, len(prices))
+	included := make([]SubscriptionPromotionalOfferPriceInlineCreate, 0, len(prices))
+	for idx, price := range prices {
+		territoryID := strings.ToUpper(strings.TrimSpace(price.TerritoryID))
+		if territoryID == "" {
+			return nil, nil, fmt.Errorf("territory ID is required")
+		}
+		resourceID := fmt.Sprintf("${local-price-%d}", idx+1)
+		priceData = append(priceData, ResourceData{
+			Type: ResourceTypeSubscriptionPromotionalOfferPrices,
+			ID:   resourceID,
+		})
+		inline := SubscriptionPromotionalOfferPriceInlineCreate{
+			Type: ResourceTypeSubscriptionPromotionalOfferPrices,
+			ID:   resourceID,
+			Relationships: SubscriptionPromotionalOfferPriceRelationships{
+				Territory: Relationship{
+					Data: ResourceData{
+						Type: ResourceTypeTerritories,
+						ID:   territoryID,
+					},
+				},
+			},
+		}
+		if pricePointID := strings.TrimSpace(price.PricePointID); pricePointID != "" {
+			inline.Relationships.SubscriptionPricePoint = &Relationship{
+				Data: ResourceData{
+					Type: ResourceTypeSubscriptionPricePoints,
+					ID:   pricePointID,
+				},
+			}
+		}
+		included = append(included, inline)
+	}
+	return priceData, included, nil
+}
+
 // UpdateSubscriptionPromotionalOffer updates a promotional offer.
 func (c *Client) UpdateSubscriptionPromotionalOffer(ctx context.Context, offerID string, priceIDs []string) (*SubscriptionPromotionalOfferResponse, error) {
 	priceIDs = normalizeList(priceIDs)
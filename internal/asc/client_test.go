@@ -58,6 +58,24 @@ func TestBuildReviewQuery_InvalidRating(t *testing.T) {
 	}
 }
 
+func TestBuildReviewQuery_PublishedResponse(t *testing.T) {
+	values, err := url.ParseQuery(buildReviewQuery([]ReviewOption{WithReviewPublishedResponse(false)}))
+	if err != nil {
+		t.Fatalf("failed to parse query: %v", err)
+	}
+	if got := values.Get("exists[publishedResponse]"); got != "false" {
+		t.Fatalf("expected exists[publishedResponse]=false, got %q", got)
+	}
+
+	values, err = url.ParseQuery(buildReviewQuery(nil))
+	if err != nil {
+		t.Fatalf("failed to parse query: %v", err)
+	}
+	if values.Has("exists[publishedResponse]") {
+		t.Fatalf("expected no exists[publishedResponse] filter, got %q", values.Get("exists[publishedResponse]"))
+	}
+}
+
 func TestBuildFeedbackQuery(t *testing.T) {
 	query := &feedbackQuery{}
 	opts := []FeedbackOption{
@@ -124,6 +142,29 @@ func TestBuildFeedbackQuery_IncludesScreenshots(t *testing.T) {
 	}
 }
 
+func TestBuildFeedbackAndCrashQuery_IncludeBuild(t *testing.T) {
+	feedback := &feedbackQuery{}
+	WithFeedbackIncludeBuild()(feedback)
+	crash := &crashQuery{}
+	WithCrashIncludeBuild()(crash)
+
+	for name, raw := range map[string]string{
+		"feedback": buildFeedbackQuery(feedback),
+		"crash":    buildCrashQuery(crash),
+	} {
+		values, err := url.ParseQuery(raw)
+		if err != nil {
+			t.Fatalf("%s: failed to parse query: %v", name, err)
+		}
+		if got := values.Get("include"); got != "build" {
+			t.Fatalf("%s: expected include=build, got %q", name, got)
+		}
+		if got := values.Get("fields[builds]"); got != "version" {
+			t.Fatalf("%s: expected fields[builds]=version, got %q", name, got)
+		}
+	}
+}
+
 func TestBuildCrashQuery(t *testing.T) {
 	query := &crashQuery{}
 	opts := []CrashOption{
@@ -14,6 +14,8 @@ type AppStoreVersionAttributes struct {
 	AppStoreState   string   `json:"appStoreState,omitempty"`
 	AppVersionState string   `json:"appVersionState,omitempty"`
 	CreatedDate     string   `json:"createdDate,omitempty"`
+	// EarliestReleaseDate is set only for scheduled releases.
+	EarliestReleaseDate string `json:"earliestReleaseDate,omitempty"`
 }
 
 // AppStoreVersionCreateAttributes describes app store version create payload attributes.
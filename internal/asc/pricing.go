@@ -52,6 +52,15 @@ type AppPriceScheduleCreateAttributes struct {
 	PricePointID    string `json:"-"`
 	StartDate       string `json:"-"`
 	BaseTerritoryID string `json:"-"`
+	// Prices replaces PricePointID and StartDate with a full set of manual prices.
+	Prices []AppPriceSchedulePrice `json:"-"`
+}
+
+// AppPriceSchedulePrice describes one manual price in a schedule create request.
+type AppPriceSchedulePrice struct {
+	PricePointID string
+	StartDate    string
+	EndDate      string
 }
 
 // AppPriceScheduleCreateRequest is a request to create a price schedule.
@@ -131,6 +131,21 @@ func TestGetAppPricePointEqualizations(t *testing.T) {
 	}
 }
 
+func TestGetAppPricePointEqualizations_WithLimit(t *testing.T) {
+	client := newTestClient(t, func(req *http.Request) {
+		if req.URL.Path != "/v3/appPricePoints/pp-1/equalizations" {
+			t.Fatalf("expected path /v3/appPricePoints/pp-1/equalizations, got %s", req.URL.Path)
+		}
+		if req.URL.Query().Get("limit") != "200" {
+			t.Fatalf("expected limit=200, got %q", req.URL.Query().Get("limit"))
+		}
+	}, jsonResponse(http.StatusOK, `{"data":[]}`))
+
+	if _, err := client.GetAppPricePointEqualizations(context.Background(), "pp-1", WithPricePointsLimit(200)); err != nil {
+		t.Fatalf("GetAppPricePointEqualizations() error: %v", err)
+	}
+}
+
 func TestGetAppPriceSchedule(t *testing.T) {
 	resp := AppPriceScheduleResponse{
 		Data: Resource[AppPriceScheduleAttributes]{
@@ -208,6 +223,27 @@ func TestGetAppPriceScheduleManualPrices(t *testing.T) {
 	}
 }
 
+func TestGetAppPriceScheduleManualPrices_WithInclude(t *testing.T) {
+	client := newTestClient(t, func(req *http.Request) {
+		if got := req.URL.Query().Get("include"); got != "appPricePoint,territory" {
+			t.Fatalf("expected include appPricePoint,territory, got %q", got)
+		}
+		if got := req.URL.Query().Get("limit"); got != "200" {
+			t.Fatalf("expected limit=200, got %q", got)
+		}
+	}, jsonResponse(http.StatusOK, `{"data":[]}`))
+
+	_, err := client.GetAppPriceScheduleManualPrices(
+		context.Background(),
+		"schedule-1",
+		WithAppPriceSchedulePricesInclude([]string{"appPricePoint", "territory"}),
+		WithAppPriceSchedulePricesLimit(200),
+	)
+	if err != nil {
+		t.Fatalf("GetAppPriceScheduleManualPrices() error: %v", err)
+	}
+}
+
 func TestGetAppPriceScheduleAutomaticPrices(t *testing.T) {
 	resp := AppPricesResponse{
 		Data: []Resource[AppPriceAttributes]{{Type: ResourceTypeAppPrices, ID: "price-1"}},
@@ -306,6 +342,35 @@ func TestCreateAppPriceSchedule(t *testing.T) {
 	}
 }
 
+func TestCreateAppPriceSchedule_WithPrices(t *testing.T) {
+	client := newTestClient(t, func(req *http.Request) {
+		var createReq AppPriceScheduleCreateRequest
+		if err := json.NewDecoder(req.Body).Decode(&createReq); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if len(createReq.Included) != 2 || len(createReq.Data.Relationships.ManualPrices.Data) != 2 {
+			t.Fatalf("expected 2 manual prices, got %+v", createReq)
+		}
+		if createReq.Included[0].Attributes.StartDate != "" || createReq.Included[0].Attributes.EndDate != "2026-11-20" {
+			t.Fatalf("unexpected first price window: %+v", createReq.Included[0].Attributes)
+		}
+		if createReq.Included[1].ID != "${local-manual-price-2}" || createReq.Included[1].Attributes.StartDate != "2026-11-20" {
+			t.Fatalf("unexpected second price: %+v", createReq.Included[1])
+		}
+	}, jsonResponse(http.StatusCreated, `{"data":{"type":"appPriceSchedules","id":"schedule-1"}}`))
+
+	_, err := client.CreateAppPriceSchedule(context.Background(), "app-1", AppPriceScheduleCreateAttributes{
+		BaseTerritoryID: "USA",
+		Prices: []AppPriceSchedulePrice{
+			{PricePointID: "pp-1", EndDate: "2026-11-20"},
+			{PricePointID: "pp-2", StartDate: "2026-11-20"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateAppPriceSchedule() error: %v", err)
+	}
+}
+
 func TestGetAppAvailabilityV2(t *testing.T) {
 	resp := AppAvailabilityV2Response{
 		Data: Resource[AppAvailabilityV2Attributes]{
@@ -0,0 +1,171 @@
+package asc
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// MaxTokenLifetime is the longest token lifetime App Store Connect accepts.
+	MaxTokenLifetime = 20 * time.Minute
+	// DefaultTokenLifetime is the lifetime used for tokens minted by the client.
+	DefaultTokenLifetime = tokenLifetime
+
+	// scopedTokenCacheLimit bounds the per-scope token cache; paginated
+	// requests produce a distinct scope per cursor.
+	scopedTokenCacheLimit = 256
+)
+
+type scopedClaims struct {
+	jwt.RegisteredClaims
+	Scope []string `json:"scope,omitempty"`
+}
+
+type cachedToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// GenerateScopedJWT generates a JWT restricted to the given scopes.
+// Scopes use Apple's "GET /v1/apps?filter[platform]=IOS" form; an empty list
+// produces an unrestricted token. It returns the token and its expiry.
+func GenerateScopedJWT(keyID, issuerID string, privateKey *ecdsa.PrivateKey, scopes []string, lifetime time.Duration) (string, time.Time, error) {
+	if lifetime <= 0 || lifetime > MaxTokenLifetime {
+		return "", time.Time{}, fmt.Errorf("token lifetime must be between 1s and %s", MaxTokenLifetime)
+	}
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		value, err := NormalizeTokenScope(scope)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		normalized = append(normalized, value)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(lifetime)
+	claims := scopedClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuerID,
+			Audience:  jwt.ClaimStrings{"appstoreconnect-v1"},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Scope: normalized,
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = keyID
+
+	signedToken, err := token.SignedString(privateKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
+	}
+	return signedToken, expiresAt, nil
+}
+
+// NormalizeTokenScope validates a token scope and upper-cases its method.
+// Apple only honors scopes for GET requests.
+func NormalizeTokenScope(scope string) (string, error) {
+	method, path, ok := strings.Cut(strings.TrimSpace(scope), " ")
+	path = strings.TrimSpace(path)
+	if !ok || path == "" {
+		return "", fmt.Errorf("invalid token scope %q: expected \"GET /v1/path\"", scope)
+	}
+	method = strings.ToUpper(method)
+	if method != http.MethodGet {
+		return "", fmt.Errorf("invalid token scope %q: only GET scopes are supported", scope)
+	}
+	if !strings.HasPrefix(path, "/") {
+		return "", fmt.Errorf("invalid token scope %q: path must start with /", scope)
+	}
+	return method + " " + path, nil
+}
+
+// ResolveScopedTokensEnabled reports whether ASC_SCOPED_TOKENS asks read-only
+// requests to use per-request scoped tokens.
+func ResolveScopedTokensEnabled() bool {
+	value, ok := envValue("ASC_SCOPED_TOKENS")
+	if !ok {
+		return false
+	}
+	switch strings.ToLower(value) {
+	case "1", "true", "yes", "y", "on":
+		return true
+	}
+	return false
+}
+
+// SetScopedTokens enables or disables per-request scoped tokens for GET requests.
+func (c *Client) SetScopedTokens(enabled bool) {
+	c.jwtMu.Lock()
+	defer c.jwtMu.Unlock()
+	c.scopedTokens = enabled
+}
+
+// GenerateToken mints a token with the client's credentials.
+func (c *Client) GenerateToken(scopes []string, lifetime time.Duration) (string, time.Time, error) {
+	return GenerateScopedJWT(c.keyID, c.issuerID, c.privateKey, scopes, lifetime)
+}
+
+// tokenForRequest returns the bearer token for req. With scoped tokens enabled,
+// GET requests to the App Store Connect API get a token limited to that request.
+func (c *Client) tokenForRequest(req *http.Request) (string, error) {
+	c.jwtMu.Lock()
+	scoped := c.scopedTokens
+	c.jwtMu.Unlock()
+	if scoped {
+		if scope, ok := requestTokenScope(req); ok {
+			return c.scopedJWT(scope)
+		}
+	}
+	return c.generateJWT()
+}
+
+func (c *Client) scopedJWT(scope string) (string, error) {
+	now := time.Now()
+
+	c.jwtMu.Lock()
+	defer c.jwtMu.Unlock()
+
+	if cached, ok := c.scopedJWTs[scope]; ok && now.Before(cached.expiresAt.Add(-jwtRefreshSkew)) {
+		return cached.token, nil
+	}
+
+	signedToken, expiresAt, err := GenerateScopedJWT(c.keyID, c.issuerID, c.privateKey, []string{scope}, tokenLifetime)
+	if err != nil {
+		return "", err
+	}
+	if c.scopedJWTs == nil || len(c.scopedJWTs) >= scopedTokenCacheLimit {
+		c.scopedJWTs = make(map[string]cachedToken)
+	}
+	c.scopedJWTs[scope] = cachedToken{token: signedToken, expiresAt: expiresAt}
+	return signedToken, nil
+}
+
+// requestTokenScope builds the scope for a GET request to the App Store
+// Connect API. Other hosts (downloads, notary) keep the unscoped token.
+func requestTokenScope(req *http.Request) (string, bool) {
+	if req.Method != http.MethodGet || req.URL == nil {
+		return "", false
+	}
+	base, err := url.Parse(BaseURL)
+	if err != nil || !strings.EqualFold(req.URL.Host, base.Host) {
+		return "", false
+	}
+	scope := http.MethodGet + " " + req.URL.Path
+	if req.URL.RawQuery != "" {
+		query, err := url.QueryUnescape(req.URL.RawQuery)
+		if err != nil {
+			query = req.URL.RawQuery
+		}
+		scope += "?" + query
+	}
+	return scope, true
+}
@@ -0,0 +1,98 @@
+package asc
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func parseTestClaims(t *testing.T, token string) *scopedClaims {
+	t.Helper()
+
+	claims := &scopedClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		t.Fatalf("ParseUnverified() error: %v", err)
+	}
+	return claims
+}
+
+func TestGenerateScopedJWT_IncludesScopeAndLifetime(t *testing.T) {
+	client := newTestClient(t, nil, jsonResponse(200, `{"data":[]}`))
+
+	token, expiresAt, err := client.GenerateToken([]string{"get  /v1/apps?filter[platform]=IOS"}, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("GenerateToken() error: %v", err)
+	}
+	claims := parseTestClaims(t, token)
+	if !reflect.DeepEqual(claims.Scope, []string{"GET /v1/apps?filter[platform]=IOS"}) {
+		t.Fatalf("unexpected scope claim %v", claims.Scope)
+	}
+	if got := claims.ExpiresAt.Sub(claims.IssuedAt.Time); got != 5*time.Minute {
+		t.Fatalf("expected 5m lifetime, got %s", got)
+	}
+	if !claims.ExpiresAt.Equal(expiresAt.Truncate(time.Second)) {
+		t.Fatalf("expiry mismatch: claim %s, returned %s", claims.ExpiresAt, expiresAt)
+	}
+
+	if _, _, err := client.GenerateToken(nil, 21*time.Minute); err == nil {
+		t.Fatal("expected lifetime above 20m to fail")
+	}
+	if _, _, err := client.GenerateToken([]string{"POST /v1/apps"}, time.Minute); err == nil || !strings.Contains(err.Error(), "only GET") {
+		t.Fatalf("expected non-GET scope to fail, got %v", err)
+	}
+}
+
+func TestGenerateJWT_OmitsScopeClaim(t *testing.T) {
+	client := newTestClient(t, nil, jsonResponse(200, `{"data":[]}`))
+
+	token, err := GenerateJWT(client.keyID, client.issuerID, client.privateKey)
+	if err != nil {
+		t.Fatalf("GenerateJWT() error: %v", err)
+	}
+	if claims := parseTestClaims(t, token); len(claims.Scope) != 0 {
+		t.Fatalf("expected no scope claim, got %v", claims.Scope)
+	}
+}
+
+func TestNewRequest_ScopedTokensForGETRequests(t *testing.T) {
+	client := newTestClient(t, nil, jsonResponse(200, `{"data":[]}`))
+	client.SetScopedTokens(true)
+
+	scopeOf := func(method, path string) []string {
+		t.Helper()
+		req, err := client.newRequest(context.Background(), method, path, nil)
+		if err != nil {
+			t.Fatalf("newRequest() error: %v", err)
+		}
+		return parseTestClaims(t, strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")).Scope
+	}
+
+	if got := scopeOf(http.MethodGet, "/v1/apps?filter%5Bplatform%5D=IOS"); !reflect.DeepEqual(got, []string{"GET /v1/apps?filter[platform]=IOS"}) {
+		t.Fatalf("unexpected GET scope %v", got)
+	}
+	if got := scopeOf(http.MethodPatch, "/v1/apps/1"); len(got) != 0 {
+		t.Fatalf("expected unscoped token for writes, got %v", got)
+	}
+	if got := scopeOf(http.MethodGet, "https://example.com/download"); len(got) != 0 {
+		t.Fatalf("expected unscoped token for other hosts, got %v", got)
+	}
+
+	first := client.scopedJWTs["GET /v1/apps?filter[platform]=IOS"].token
+	if again, _ := client.scopedJWT("GET /v1/apps?filter[platform]=IOS"); again != first {
+		t.Fatal("expected scoped token to be reused while valid")
+	}
+}
+
+func TestResolveScopedTokensEnabled(t *testing.T) {
+	for value, want := range map[string]bool{"1": true, "on": true, "TRUE": true, "0": false, "": false} {
+		t.Setenv("ASC_SCOPED_TOKENS", value)
+		if got := ResolveScopedTokensEnabled(); got != want {
+			t.Fatalf("ASC_SCOPED_TOKENS=%q: got %v, want %v", value, got, want)
+		}
+	}
+}
@@ -189,7 +189,8 @@ type SubscriptionPromotionalOfferCreateData struct {
 
 // SubscriptionPromotionalOfferCreateRequest is a request to create a promotional offer.
 type SubscriptionPromotionalOfferCreateRequest struct {
-	Data SubscriptionPromotionalOfferCreateData `json:"data"`
+	Data     SubscriptionPromotionalOfferCreateData          `json:"data"`
+	Included []SubscriptionPromotionalOfferPriceInlineCreate `json:"included,omitempty"`
 }
 
 // SubscriptionPromotionalOfferUpdateRelationships describes relationships for promotional offer updates.
@@ -206,7 +207,28 @@ type SubscriptionPromotionalOfferUpdateData struct {
 
 // SubscriptionPromotionalOfferUpdateRequest is a request to update a promotional offer.
 type SubscriptionPromotionalOfferUpdateRequest struct {
-	Data SubscriptionPromotionalOfferUpdateData `json:"data"`
+	Data     SubscriptionPromotionalOfferUpdateData          `json:"data"`
+	Included []SubscriptionPromotionalOfferPriceInlineCreate `json:"included,omitempty"`
+}
+
+// SubscriptionPromotionalOfferPrice describes a promotional offer price created inline.
+// PricePointID may be empty for free trials.
+type SubscriptionPromotionalOfferPrice struct {
+	TerritoryID  string
+	PricePointID string
+}
+
+// SubscriptionPromotionalOfferPriceRelationships describes inline promotional offer price relationships.
+type SubscriptionPromotionalOfferPriceRelationships struct {
+	Territory              Relationship  `json:"territory"`
+	SubscriptionPricePoint *Relationship `json:"subscriptionPricePoint,omitempty"`
+}
+
+// SubscriptionPromotionalOfferPriceInlineCreate describes inline creation data for promotional offer prices.
+type SubscriptionPromotionalOfferPriceInlineCreate struct {
+	Type          ResourceType                                   `json:"type"`
+	ID            string                                         `json:"id,omitempty"`
+	Relationships SubscriptionPromotionalOfferPriceRelationships `json:"relationships"`
 }
 
 // SubscriptionPromotionalOfferPriceAttributes describes promotional offer price resources.
@@ -48,6 +48,8 @@ type subscriptionImagesQuery struct {
 
 type subscriptionIntroductoryOffersQuery struct {
 	listQuery
+	include          []string
+	pricePointFields []string
 }
 
 type subscriptionPromotionalOffersQuery struct {
@@ -56,6 +58,8 @@ type subscriptionPromotionalOffersQuery struct {
 
 type subscriptionPromotionalOfferPricesQuery struct {
 	listQuery
+	include          []string
+	pricePointFields []string
 }
 
 type subscriptionOfferCodesQuery struct {
@@ -141,6 +145,20 @@ func WithSubscriptionIntroductoryOffersNextURL(next string) SubscriptionIntroduc
 	}
 }
 
+// WithSubscriptionIntroductoryOffersInclude sets the relationships to include (e.g., "territory", "subscriptionPricePoint").
+func WithSubscriptionIntroductoryOffersInclude(include []string) SubscriptionIntroductoryOffersOption {
+	return func(q *subscriptionIntroductoryOffersQuery) {
+		q.include = normalizeList(include)
+	}
+}
+
+// WithSubscriptionIntroductoryOffersPricePointFields sets fields for included subscriptionPricePoints.
+func WithSubscriptionIntroductoryOffersPricePointFields(fields []string) SubscriptionIntroductoryOffersOption {
+	return func(q *subscriptionIntroductoryOffersQuery) {
+		q.pricePointFields = normalizeList(fields)
+	}
+}
+
 // WithSubscriptionPromotionalOffersLimit sets the max number of offers to return.
 func WithSubscriptionPromotionalOffersLimit(limit int) SubscriptionPromotionalOffersOption {
 	return func(q *subscriptionPromotionalOffersQuery) {
@@ -177,6 +195,20 @@ func WithSubscriptionPromotionalOfferPricesNextURL(next string) SubscriptionProm
 	}
 }
 
+// WithSubscriptionPromotionalOfferPricesInclude sets the relationships to include (e.g., "territory", "subscriptionPricePoint").
+func WithSubscriptionPromotionalOfferPricesInclude(include []string) SubscriptionPromotionalOfferPricesOption {
+	return func(q *subscriptionPromotionalOfferPricesQuery) {
+		q.include = normalizeList(include)
+	}
+}
+
+// WithSubscriptionPromotionalOfferPricesPricePointFields sets fields for included subscriptionPricePoints.
+func WithSubscriptionPromotionalOfferPricesPricePointFields(fields []string) SubscriptionPromotionalOfferPricesOption {
+	return func(q *subscriptionPromotionalOfferPricesQuery) {
+		q.pricePointFields = normalizeList(fields)
+	}
+}
+
 // WithSubscriptionOfferCodesLimit sets the max number of offer codes to return.
 func WithSubscriptionOfferCodesLimit(limit int) SubscriptionOfferCodesOption {
 	return func(q *subscriptionOfferCodesQuery) {
@@ -338,6 +370,8 @@ func buildSubscriptionImagesQuery(query *subscriptionImagesQuery) string {
 
 func buildSubscriptionIntroductoryOffersQuery(query *subscriptionIntroductoryOffersQuery) string {
 	values := url.Values{}
+	addCSV(values, "include", query.include)
+	addCSV(values, "fields[subscriptionPricePoints]", query.pricePointFields)
 	addLimit(values, query.limit)
 	return values.Encode()
 }
@@ -350,6 +384,8 @@ func buildSubscriptionPromotionalOffersQuery(query *subscriptionPromotionalOffer
 
 func buildSubscriptionPromotionalOfferPricesQuery(query *subscriptionPromotionalOfferPricesQuery) string {
 	values := url.Values{}
+	addCSV(values, "include", query.include)
+	addCSV(values, "fields[subscriptionPricePoints]", query.pricePointFields)
 	addLimit(values, query.limit)
 	return values.Encode()
 }
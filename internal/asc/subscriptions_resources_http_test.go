@@ -486,6 +486,54 @@ func TestUpdateSubscriptionPromotionalOffer(t *testing.T) {
 	}
 }
 
+func TestCreateSubscriptionPromotionalOfferWithPrices(t *testing.T) {
+	response := jsonResponse(http.StatusCreated, `{"data":{"type":"subscriptionPromotionalOffers","id":"offer-1","attributes":{"name":"Spring"}}}`)
+	client := newTestClient(t, func(req *http.Request) {
+		if req.Method != http.MethodPost {
+			t.Fatalf("expected POST, got %s", req.Method)
+		}
+		if req.URL.Path != "/v1/subscriptionPromotionalOffers" {
+			t.Fatalf("expected path /v1/subscriptionPromotionalOffers, got %s", req.URL.Path)
+		}
+		var payload SubscriptionPromotionalOfferCreateRequest
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if len(payload.Data.Relationships.Prices.Data) != 2 || payload.Data.Relationships.Prices.Data[0].ID != "${local-price-1}" {
+			t.Fatalf("unexpected price relationships: %+v", payload.Data.Relationships.Prices.Data)
+		}
+		if len(payload.Included) != 2 {
+			t.Fatalf("expected 2 included prices, got %d", len(payload.Included))
+		}
+		first := payload.Included[0]
+		if first.Type != ResourceTypeSubscriptionPromotionalOfferPrices || first.ID != "${local-price-1}" {
+			t.Fatalf("unexpected included price: %+v", first)
+		}
+		if first.Relationships.Territory.Data.ID != "USA" || first.Relationships.SubscriptionPricePoint == nil || first.Relationships.SubscriptionPricePoint.Data.ID != "pp-usa" {
+			t.Fatalf("unexpected included price relationships: %+v", first.Relationships)
+		}
+		if second := payload.Included[1]; second.Relationships.Territory.Data.ID != "GBR" || second.Relationships.SubscriptionPricePoint != nil {
+			t.Fatalf("expected territory-only second price, got %+v", second.Relationships)
+		}
+		assertAuthorized(t, req)
+	}, response)
+
+	attrs := SubscriptionPromotionalOfferCreateAttributes{
+		Name:            "Spring",
+		OfferCode:       "SPRING",
+		Duration:        SubscriptionOfferDurationOneMonth,
+		OfferMode:       SubscriptionOfferModePayUpFront,
+		NumberOfPeriods: 1,
+	}
+	prices := []SubscriptionPromotionalOfferPrice{
+		{TerritoryID: "usa", PricePointID: "pp-usa"},
+		{TerritoryID: "GBR"},
+	}
+	if _, err := client.CreateSubscriptionPromotionalOfferWithPrices(context.Background(), "sub-1", attrs, prices); err != nil {
+		t.Fatalf("CreateSubscriptionPromotionalOfferWithPrices() error: %v", err)
+	}
+}
+
 func TestDeleteSubscriptionPromotionalOffer(t *testing.T) {
 	response := jsonResponse(http.StatusNoContent, `{}`)
 	client := newTestClient(t, func(req *http.Request) {
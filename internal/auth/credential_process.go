@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/config"
+)
+
+const (
+	credentialProcessTimeout   = 2 * time.Minute
+	credentialProcessMaxOutput = 64 * 1024
+)
+
+// ProcessCredentials are credentials returned by a credential_process command.
+// The private key only ever lives in memory.
+type ProcessCredentials struct {
+	KeyID      string
+	IssuerID   string
+	PrivateKey *ecdsa.PrivateKey
+}
+
+type credentialProcessOutput struct {
+	KeyID      string `json:"key_id"`
+	IssuerID   string `json:"issuer_id"`
+	PrivateKey string `json:"private_key"`
+}
+
+var (
+	credentialProcessMu    sync.Mutex
+	credentialProcessCache = map[string]*ProcessCredentials{}
+
+	credentialProcessRunner = runCredentialProcessCommand
+)
+
+// CredentialProcessCommand returns the credential_process command and the
+// path it was read from. An empty command means no credential process is
+// configured. Only the global config or an explicit ASC_CONFIG_PATH is read:
+// a repo-local .asc/config.json must not be able to run commands just because
+// asc was invoked inside a cloned repository.
+func CredentialProcessCommand() (string, string, error) {
+	path, err := config.TrustedPath()
+	if err != nil {
+		return "", "", err
+	}
+	return credentialProcessCommandAt(path)
+}
+
+// IgnoredLocalCredentialProcess returns the path of a repo-local config that
+// sets credential_process, which CredentialProcessCommand ignores. It returns
+// an empty path when there is none.
+func IgnoredLocalCredentialProcess() string {
+	activePath, err := config.Path()
+	if err != nil {
+		return ""
+	}
+	trustedPath, err := config.TrustedPath()
+	if err != nil || activePath == trustedPath {
+		return ""
+	}
+	if command, _, err := credentialProcessCommandAt(activePath); err != nil || command == "" {
+		return ""
+	}
+	return activePath
+}
+
+func credentialProcessCommandAt(path string) (string, string, error) {
+	cfg, err := config.LoadAt(path)
+	if err != nil {
+		if errors.Is(err, config.ErrNotFound) {
+			return "", path, nil
+		}
+		return "", path, err
+	}
+	return strings.TrimSpace(cfg.CredentialProcess), path, nil
+}
+
+// ResolveProcessCredentials runs the credential process once per command line
+// and caches the result in memory for the rest of the run.
+func ResolveProcessCredentials(ctx context.Context, command string) (*ProcessCredentials, error) {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return nil, fmt.Errorf("credential_process is empty")
+	}
+
+	credentialProcessMu.Lock()
+	defer credentialProcessMu.Unlock()
+	if cached, ok := credentialProcessCache[command]; ok {
+		return cached, nil
+	}
+
+	output, err := credentialProcessRunner(ctx, command)
+	if err != nil {
+		return nil, fmt.Errorf("credential_process: %w", err)
+	}
+	creds, err := parseCredentialProcessOutput(output)
+	if err != nil {
+		return nil, fmt.Errorf("credential_process: %w", err)
+	}
+	credentialProcessCache[command] = creds
+	return creds, nil
+}
+
+func parseCredentialProcessOutput(data []byte) (*ProcessCredentials, error) {
+	var output credentialProcessOutput
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&output); err != nil {
+		return nil, fmt.Errorf("invalid JSON output: %w", err)
+	}
+
+	keyID := strings.TrimSpace(output.KeyID)
+	issuerID := strings.TrimSpace(output.IssuerID)
+	var missing []string
+	if keyID == "" {
+		missing = append(missing, "key_id")
+	}
+	if issuerID == "" {
+		missing = append(missing, "issuer_id")
+	}
+	if strings.TrimSpace(output.PrivateKey) == "" {
+		missing = append(missing, "private_key")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("output is missing %s", strings.Join(missing, ", "))
+	}
+
+	pemData := output.PrivateKey
+	if strings.Contains(pemData, "\\n") && !strings.Contains(pemData, "\n") {
+		pemData = strings.ReplaceAll(pemData, "\\n", "\n")
+	}
+	key, err := ParsePrivateKey([]byte(pemData))
+	if err != nil {
+		return nil, fmt.Errorf("private_key: %w", err)
+	}
+	return &ProcessCredentials{KeyID: keyID, IssuerID: issuerID, PrivateKey: key}, nil
+}
+
+// runCredentialProcessCommand runs the command through the platform shell so
+// config values can use pipes and quoting. Stderr is passed through so the
+// secrets manager can prompt or explain failures.
+func runCredentialProcessCommand(ctx context.Context, command string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	}
+	var stdout limitedBuffer
+	stdout.limit = credentialProcessMaxOutput
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("command timed out after %s", credentialProcessTimeout)
+		}
+		return nil, fmt.Errorf("command failed: %w", err)
+	}
+	if stdout.overflow {
+		return nil, fmt.Errorf("output exceeds %d bytes", credentialProcessMaxOutput)
+	}
+	return stdout.Bytes(), nil
+}
+
+type limitedBuffer struct {
+	bytes.Buffer
+	limit    int
+	overflow bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		b.overflow = true
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func testCredentialProcessPEM(t *testing.T) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey() error: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestParseCredentialProcessOutput(t *testing.T) {
+	pemData := testCredentialProcessPEM(t)
+
+	// Secrets managers often return the key with escaped newlines.
+	escaped := strings.ReplaceAll(pemData, "\n", `\n`)
+	payload, _ := json.Marshal(map[string]string{"key_id": " KEY123 ", "issuer_id": "ISSUER", "private_key": escaped})
+	creds, err := parseCredentialProcessOutput(payload)
+	if err != nil {
+		t.Fatalf("parseCredentialProcessOutput() error: %v", err)
+	}
+	if creds.KeyID != "KEY123" || creds.IssuerID != "ISSUER" || creds.PrivateKey == nil {
+		t.Fatalf("unexpected credentials: %+v", creds)
+	}
+
+	tests := map[string]string{
+		`{"key_id":"KEY"}`: "missing issuer_id, private_key",
+		`{"key_id":"KEY","issuer_id":"ISS","private_key":"nope"}`: "invalid PEM data",
+		`{"key_id":"KEY","token":"x"}`:                            "unknown field",
+		`not json`:                                                "invalid JSON output",
+	}
+	for input, want := range tests {
+		if _, err := parseCredentialProcessOutput([]byte(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("parseCredentialProcessOutput(%s) error = %v, want %q", input, err, want)
+		}
+	}
+}
+
+func TestResolveProcessCredentials_CachesInMemory(t *testing.T) {
+	pemData := testCredentialProcessPEM(t)
+	payload, _ := json.Marshal(map[string]string{"key_id": "KEY", "issuer_id": "ISSUER", "private_key": pemData})
+
+	calls := 0
+	previousRunner := credentialProcessRunner
+	credentialProcessRunner = func(ctx context.Context, command string) ([]byte, error) {
+		calls++
+		return payload, nil
+	}
+	t.Cleanup(func() {
+		credentialProcessRunner = previousRunner
+		credentialProcessMu.Lock()
+		credentialProcessCache = map[string]*ProcessCredentials{}
+		credentialProcessMu.Unlock()
+	})
+
+	for range 2 {
+		creds, err := ResolveProcessCredentials(context.Background(), "vault read asc")
+		if err != nil {
+			t.Fatalf("ResolveProcessCredentials() error: %v", err)
+		}
+		if creds.KeyID != "KEY" {
+			t.Fatalf("unexpected key ID %q", creds.KeyID)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected the command to run once, ran %d times", calls)
+	}
+}
+
+func TestRunCredentialProcessCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses /bin/sh")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "creds.json")
+	if err := os.WriteFile(path, []byte(`{"key_id":"KEY"}`), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	output, err := runCredentialProcessCommand(context.Background(), "cat '"+path+"'")
+	if err != nil {
+		t.Fatalf("runCredentialProcessCommand() error: %v", err)
+	}
+	if string(output) != `{"key_id":"KEY"}` {
+		t.Fatalf("unexpected output %q", output)
+	}
+
+	if _, err := runCredentialProcessCommand(context.Background(), "exit 3"); err == nil || !strings.Contains(err.Error(), "command failed") {
+		t.Fatalf("expected failure for non-zero exit, got %v", err)
+	}
+}
+
+func TestCredentialProcessCommandFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv("ASC_CONFIG_PATH", path)
+
+	command, _, err := CredentialProcessCommand()
+	if err != nil || command != "" {
+		t.Fatalf("expected no command without config, got %q, %v", command, err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"credential_process":" op read op://vault/asc "}`), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	command, configPath, err := CredentialProcessCommand()
+	if err != nil {
+		t.Fatalf("CredentialProcessCommand() error: %v", err)
+	}
+	if command != "op read op://vault/asc" || configPath != path {
+		t.Fatalf("unexpected command %q from %q", command, configPath)
+	}
+}
+
+func TestCredentialProcessCommandIgnoresLocalConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("ASC_CONFIG_PATH", "")
+	t.Setenv("ASC_BYPASS_KEYCHAIN", "1")
+
+	repo, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("EvalSymlinks() error: %v", err)
+	}
+	localDir := filepath.Join(repo, ".asc")
+	if err := os.MkdirAll(localDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	localPath := filepath.Join(localDir, "config.json")
+	if err := os.WriteFile(localPath, []byte(`{"credential_process":"touch pwned"}`), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	nested := filepath.Join(repo, "Sources")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	previousDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd() error: %v", err)
+	}
+	if err := os.Chdir(nested); err != nil {
+		t.Fatalf("Chdir() error: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(previousDir)
+	})
+
+	originalRunner := credentialProcessRunner
+	credentialProcessRunner = func(context.Context, string) ([]byte, error) {
+		t.Fatal("credential_process from a repo-local config must not run")
+		return nil, nil
+	}
+	t.Cleanup(func() { credentialProcessRunner = originalRunner })
+
+	command, path, err := CredentialProcessCommand()
+	if err != nil {
+		t.Fatalf("CredentialProcessCommand() error: %v", err)
+	}
+	if command != "" || path != filepath.Join(home, ".asc", "config.json") {
+		t.Fatalf("expected global config without a command, got %q from %q", command, path)
+	}
+	if got := IgnoredLocalCredentialProcess(); got != localPath {
+		t.Fatalf("IgnoredLocalCredentialProcess() = %q, want %q", got, localPath)
+	}
+
+	section := inspectCredentialProcess()
+	if !sectionHasStatus(section, DoctorWarn, "repo-local configs cannot run commands") {
+		t.Fatalf("expected ignored local credential_process warning, got %#v", section.Checks)
+	}
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -63,6 +64,7 @@ func doctor(options DoctorOptions, resolver MigrationSuggestionResolver) DoctorR
 	sections := []DoctorSection{
 		inspectStorage(options),
 		inspectProfiles(),
+		inspectCredentialProcess(),
 		inspectPrivateKeys(options),
 		inspectEnvironment(),
 		inspectTempKeys(options),
@@ -245,6 +247,46 @@ func inspectProfiles() DoctorSection {
 	return DoctorSection{Title: "Profiles", Checks: checks}
 }
 
+func inspectCredentialProcess() DoctorSection {
+	command, path, err := CredentialProcessCommand()
+	if err != nil {
+		return DoctorSection{Title: "Credential Process", Checks: []DoctorCheck{{
+			Status:  DoctorWarn,
+			Message: fmt.Sprintf("Failed to read credential_process from config: %v", err),
+		}}}
+	}
+	checks := []DoctorCheck{}
+	if localPath := IgnoredLocalCredentialProcess(); localPath != "" {
+		checks = append(checks, DoctorCheck{
+			Status:         DoctorWarn,
+			Message:        fmt.Sprintf("credential_process in %s is ignored; repo-local configs cannot run commands", localPath),
+			Recommendation: "Move credential_process to ~/.asc/config.json or point ASC_CONFIG_PATH at the config you trust",
+		})
+	}
+	if command == "" {
+		return DoctorSection{Title: "Credential Process", Checks: checks}
+	}
+
+	checks = append(checks, DoctorCheck{
+		Status:  DoctorInfo,
+		Message: fmt.Sprintf("credential_process is configured in %s and is used when no profile is selected", path),
+	})
+	creds, err := ResolveProcessCredentials(context.Background(), command)
+	if err != nil {
+		checks = append(checks, DoctorCheck{
+			Status:         DoctorFail,
+			Message:        err.Error(),
+			Recommendation: "Fix the credential_process command so it prints key_id, issuer_id, and private_key as JSON",
+		})
+		return DoctorSection{Title: "Credential Process", Checks: checks}
+	}
+	checks = append(checks, DoctorCheck{
+		Status:  DoctorOK,
+		Message: fmt.Sprintf("credential_process returned a valid ECDSA key (Key ID: %s)", creds.KeyID),
+	})
+	return DoctorSection{Title: "Credential Process", Checks: checks}
+}
+
 func inspectPrivateKeys(options DoctorOptions) DoctorSection {
 	checks := []DoctorCheck{}
 	credentials, err := ListCredentials()
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PreviousProfileSuffix is appended to a profile name to keep its
+// rotated-out key until the rotation is finalized.
+const PreviousProfileSuffix = ".previous"
+
+// ErrCredentialNotFound is returned by FindCredential when no stored
+// credential has the requested name.
+var ErrCredentialNotFound = errors.New("credentials not found")
+
+// PreviousProfileName returns the profile that holds name's previous key.
+func PreviousProfileName(name string) string {
+	return strings.TrimSpace(name) + PreviousProfileSuffix
+}
+
+// FindCredential returns the stored credential with the given name.
+func FindCredential(name string) (Credential, error) {
+	name = strings.TrimSpace(name)
+	credentials, err := ListCredentials()
+	if err != nil {
+		if _, ok := errors.AsType[*CredentialsWarning](err); !ok {
+			return Credential{}, err
+		}
+	}
+	for _, cred := range credentials {
+		if strings.TrimSpace(cred.Name) == name {
+			return cred, nil
+		}
+	}
+	return Credential{}, fmt.Errorf("%w for profile %q", ErrCredentialNotFound, name)
+}
+
+// CheckNoPendingRotation returns an error while name.previous exists, so an
+// unfinalized rotation is never overwritten by another one.
+func CheckNoPendingRotation(name string) error {
+	name = strings.TrimSpace(name)
+	previous, err := FindCredential(PreviousProfileName(name))
+	if errors.Is(err, ErrCredentialNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check for a pending rotation: %w", err)
+	}
+	return fmt.Errorf("rotation already in progress for profile %q (key %s is kept as %q); finalize it before rotating again", name, previous.KeyID, previous.Name)
+}
+
+// RotateCredentials stores a new key under name and keeps the current one as
+// name.previous, in the same store the profile already lives in. If storing
+// the new key fails, the previous copy is removed so the profile is unchanged.
+// The default profile is preserved. It returns the rotated-out credential and
+// fails while an earlier rotation has not been finalized.
+func RotateCredentials(name, keyID, issuerID, keyPath string) (Credential, error) {
+	name = strings.TrimSpace(name)
+	existing, err := FindCredential(name)
+	if err != nil {
+		return Credential{}, err
+	}
+	if err := CheckNoPendingRotation(name); err != nil {
+		return Credential{}, err
+	}
+	if existing.KeyID == keyID {
+		return Credential{}, fmt.Errorf("profile %q already uses key %s", name, keyID)
+	}
+	defaultKey, err := defaultName()
+	if err != nil {
+		return Credential{}, err
+	}
+
+	previousName := PreviousProfileName(name)
+	if err := storeCredentialLike(existing, previousName, existing.KeyID, existing.IssuerID, existing.PrivateKeyPath); err != nil {
+		return Credential{}, fmt.Errorf("failed to keep previous key as %q: %w", previousName, err)
+	}
+	if err := storeCredentialLike(existing, name, keyID, issuerID, keyPath); err != nil {
+		_ = RemoveCredentials(previousName)
+		return Credential{}, fmt.Errorf("failed to store new key: %w", err)
+	}
+	if defaultKey != "" {
+		if err := saveDefaultName(defaultKey); err != nil {
+			return Credential{}, fmt.Errorf("failed to restore default profile %q: %w", defaultKey, err)
+		}
+	}
+	return existing, nil
+}
+
+// FinalizeRotation removes the previous key kept by RotateCredentials and
+// returns it.
+func FinalizeRotation(name string) (Credential, error) {
+	previous, err := FindCredential(PreviousProfileName(name))
+	if errors.Is(err, ErrCredentialNotFound) {
+		return Credential{}, fmt.Errorf("no rotation in progress for profile %q", strings.TrimSpace(name))
+	}
+	if err != nil {
+		return Credential{}, err
+	}
+	if err := RemoveCredentials(previous.Name); err != nil {
+		return Credential{}, err
+	}
+	return previous, nil
+}
+
+func storeCredentialLike(source Credential, name, keyID, issuerID, keyPath string) error {
+	if source.Source == "config" && strings.TrimSpace(source.SourcePath) != "" {
+		return StoreCredentialsConfigAt(name, keyID, issuerID, keyPath, source.SourcePath)
+	}
+	return StoreCredentials(name, keyID, issuerID, keyPath)
+}
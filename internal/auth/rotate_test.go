@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/config"
+)
+
+func TestRotateCredentials_KeepsPreviousUntilFinalized(t *testing.T) {
+	withSeparateKeyrings(t)
+
+	if err := StoreCredentials("ci", "OLDKEY", "ISS", "/tmp/AuthKey_OLDKEY.p8"); err != nil {
+		t.Fatalf("StoreCredentials() error: %v", err)
+	}
+	if err := StoreCredentials("personal", "MYKEY", "ISS", "/tmp/AuthKey_MYKEY.p8"); err != nil {
+		t.Fatalf("StoreCredentials() error: %v", err)
+	}
+
+	previous, err := RotateCredentials("ci", "NEWKEY", "ISS", "/tmp/AuthKey_NEWKEY.p8")
+	if err != nil {
+		t.Fatalf("RotateCredentials() error: %v", err)
+	}
+	if previous.KeyID != "OLDKEY" {
+		t.Fatalf("expected previous key OLDKEY, got %q", previous.KeyID)
+	}
+
+	current, err := FindCredential("ci")
+	if err != nil || current.KeyID != "NEWKEY" || current.PrivateKeyPath != "/tmp/AuthKey_NEWKEY.p8" {
+		t.Fatalf("unexpected rotated credential %+v, %v", current, err)
+	}
+	kept, err := FindCredential("ci.previous")
+	if err != nil || kept.KeyID != "OLDKEY" {
+		t.Fatalf("unexpected previous credential %+v, %v", kept, err)
+	}
+	if name, _ := defaultName(); name != "personal" {
+		t.Fatalf("expected default profile to stay personal, got %q", name)
+	}
+
+	if _, err := RotateCredentials("ci", "NEWKEY", "ISS", "/tmp/AuthKey_NEWKEY.p8"); err == nil {
+		t.Fatal("expected rotating to the same key to fail")
+	}
+	if _, err := RotateCredentials("ci", "NEWERKEY", "ISS", "/tmp/AuthKey_NEWERKEY.p8"); err == nil || !strings.Contains(err.Error(), "rotation already in progress") {
+		t.Fatalf("expected pending rotation error, got %v", err)
+	}
+	if kept, err := FindCredential("ci.previous"); err != nil || kept.KeyID != "OLDKEY" {
+		t.Fatalf("expected previous key to stay OLDKEY, got %+v, %v", kept, err)
+	}
+
+	removed, err := FinalizeRotation("ci")
+	if err != nil || removed.KeyID != "OLDKEY" {
+		t.Fatalf("FinalizeRotation() = %+v, %v", removed, err)
+	}
+	if _, err := FindCredential("ci.previous"); err == nil {
+		t.Fatal("expected previous credential to be removed")
+	}
+	if _, err := FinalizeRotation("ci"); err == nil {
+		t.Fatal("expected a second finalize to fail")
+	}
+}
+
+func TestRotateCredentials_StaysInConfigFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv("ASC_CONFIG_PATH", configPath)
+	t.Setenv("ASC_BYPASS_KEYCHAIN", "1")
+
+	if err := config.SaveAt(configPath, &config.Config{
+		DefaultKeyName: "ci",
+		Keys:           []config.Credential{{Name: "ci", KeyID: "OLDKEY", IssuerID: "ISS", PrivateKeyPath: "/tmp/old.p8"}},
+	}); err != nil {
+		t.Fatalf("SaveAt() error: %v", err)
+	}
+
+	if _, err := RotateCredentials("ci", "NEWKEY", "ISS", "/tmp/new.p8"); err != nil {
+		t.Fatalf("RotateCredentials() error: %v", err)
+	}
+
+	cfg, err := config.LoadAt(configPath)
+	if err != nil {
+		t.Fatalf("LoadAt() error: %v", err)
+	}
+	if cfg.DefaultKeyName != "ci" || cfg.KeyID != "NEWKEY" {
+		t.Fatalf("expected ci to stay default with the new key, got %q/%q", cfg.DefaultKeyName, cfg.KeyID)
+	}
+	keys := map[string]string{}
+	for _, cred := range cfg.Keys {
+		keys[cred.Name] = cred.KeyID
+	}
+	if keys["ci"] != "NEWKEY" || keys["ci.previous"] != "OLDKEY" {
+		t.Fatalf("unexpected stored keys %v", keys)
+	}
+}
+
+func TestCheckNoPendingRotation_PropagatesLookupErrors(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv("ASC_CONFIG_PATH", configPath)
+	t.Setenv("ASC_BYPASS_KEYCHAIN", "1")
+
+	if err := CheckNoPendingRotation("ci"); err != nil {
+		t.Fatalf("expected no pending rotation without a config, got %v", err)
+	}
+
+	if err := os.WriteFile(configPath, []byte("{not json"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+	if err := CheckNoPendingRotation("ci"); err == nil || errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("expected the config error to be returned, got %v", err)
+	}
+}
@@ -25,7 +25,8 @@ Examples:
   asc analytics get --request-id "REQUEST_ID"
   asc analytics reports get --report-id "REPORT_ID"
   asc analytics instances relationships --instance-id "INSTANCE_ID"
-  asc analytics download --request-id "REQUEST_ID" --instance-id "INSTANCE_ID"`,
+  asc analytics download --request-id "REQUEST_ID" --instance-id "INSTANCE_ID"
+  asc analytics mirror --app "APP_ID" --dir ./analytics --since 2026-01-01`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
@@ -37,6 +38,7 @@ Examples:
 			AnalyticsInstancesCommand(),
 			AnalyticsSegmentsCommand(),
 			AnalyticsDownloadCommand(),
+			AnalyticsMirrorCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
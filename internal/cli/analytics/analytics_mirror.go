@@ -0,0 +1,484 @@
+package analytics
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	analyticsMirrorStatusDownloaded = "downloaded"
+	analyticsMirrorStatusPlanned    = "planned"
+	analyticsMirrorStatusFailed     = "failed"
+)
+
+// AnalyticsMirrorFile is one report segment handled by a mirror run.
+type AnalyticsMirrorFile struct {
+	Category    string `json:"category"`
+	Report      string `json:"report"`
+	Date        string `json:"date"`
+	Granularity string `json:"granularity,omitempty"`
+	InstanceID  string `json:"instanceId"`
+	SegmentID   string `json:"segmentId,omitempty"`
+	Path        string `json:"path,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+}
+
+// AnalyticsMirrorSummary counts what a mirror run did.
+type AnalyticsMirrorSummary struct {
+	Requests         int `json:"requests"`
+	Reports          int `json:"reports"`
+	Instances        int `json:"instances"`
+	AlreadyMirrored  int `json:"alreadyMirrored"`
+	Downloaded       int `json:"downloaded"`
+	Planned          int `json:"planned,omitempty"`
+	Failed           int `json:"failed"`
+	UnchangedSegment int `json:"unchangedSegments"`
+}
+
+// AnalyticsMirrorResult is the output of analytics mirror.
+type AnalyticsMirrorResult struct {
+	AppID   string                 `json:"appId"`
+	Dir     string                 `json:"dir"`
+	Since   string                 `json:"since,omitempty"`
+	DryRun  bool                   `json:"dryRun"`
+	Summary AnalyticsMirrorSummary `json:"summary"`
+	Files   []AnalyticsMirrorFile  `json:"files"`
+}
+
+// AnalyticsMirrorCommand mirrors analytics report segments into a directory.
+func AnalyticsMirrorCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("mirror", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	dir := fs.String("dir", "", "Directory to mirror reports into")
+	since := fs.String("since", "", "Only mirror report instances dated on or after this day (YYYY-MM-DD)")
+	accessType := fs.String("access-type", string(asc.AnalyticsAccessTypeOngoing), "Report requests to mirror: ONGOING or ONE_TIME_SNAPSHOT")
+	category := fs.String("category", "", "Only mirror one report category (e.g. APP_USAGE)")
+	dryRun := fs.Bool("dry-run", false, "List segments that would be downloaded without writing files")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "mirror",
+		ShortUsage: "asc analytics mirror --app APP_ID --dir DIR [flags]",
+		ShortHelp:  "Incrementally mirror analytics reports into a local directory.",
+		LongHelp: `Incrementally mirror analytics reports into a local directory.
+
+Walks every report category, report, and instance of the app's analytics
+report requests, downloads segments that are not in the local manifest yet,
+verifies their checksums, and decompresses them as:
+
+  <dir>/<category>/<report-name>/<date>.csv
+
+Non-daily instances add the granularity (<date>-weekly.csv) and instances
+with several segments add a part number (<date>-part2.csv). The manifest
+(<dir>/.asc-analytics-manifest.json) tracks mirrored instance IDs and segment
+checksums, so re-running only fetches new data. This makes the command safe
+to run from a nightly cron job.
+
+Create the report request first with:
+  asc analytics request --app "APP_ID" --access-type ONGOING
+
+Examples:
+  asc analytics mirror --app "APP_ID" --dir ./analytics --since 2026-01-01
+  asc analytics mirror --app "APP_ID" --dir ./analytics --category APP_USAGE --dry-run --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
+				return flag.ErrHelp
+			}
+			mirrorDir := strings.TrimSpace(*dir)
+			if mirrorDir == "" {
+				fmt.Fprintln(os.Stderr, "Error: --dir is required")
+				return flag.ErrHelp
+			}
+			sinceDate := strings.TrimSpace(*since)
+			if sinceDate != "" {
+				parsed, err := time.Parse("2006-01-02", sinceDate)
+				if err != nil {
+					return shared.UsageError("--since must be in YYYY-MM-DD format")
+				}
+				sinceDate = parsed.Format("2006-01-02")
+			}
+			normalizedAccessType, err := normalizeAnalyticsAccessType(*accessType)
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("analytics mirror: %w", err)
+			}
+			manifest, err := loadAnalyticsMirrorManifest(mirrorDir, resolvedAppID)
+			if err != nil {
+				return fmt.Errorf("analytics mirror: %w", err)
+			}
+
+			mirror := &analyticsMirror{
+				client:     client,
+				dir:        mirrorDir,
+				since:      sinceDate,
+				category:   strings.ToUpper(strings.TrimSpace(*category)),
+				accessType: normalizedAccessType,
+				dryRun:     *dryRun,
+				manifest:   manifest,
+				result: &AnalyticsMirrorResult{
+					AppID:  resolvedAppID,
+					Dir:    mirrorDir,
+					Since:  sinceDate,
+					DryRun: *dryRun,
+					Files:  []AnalyticsMirrorFile{},
+				},
+			}
+			if err := mirror.run(ctx, resolvedAppID); err != nil {
+				return fmt.Errorf("analytics mirror: %w", err)
+			}
+
+			result := mirror.result
+			if err := shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderAnalyticsMirrorResult(result, false) },
+				func() error { return renderAnalyticsMirrorResult(result, true) },
+			); err != nil {
+				return err
+			}
+			if result.Summary.Failed > 0 {
+				return shared.NewReportedError(fmt.Errorf("analytics mirror: %d segment(s) failed", result.Summary.Failed))
+			}
+			return nil
+		},
+	}
+}
+
+type analyticsMirror struct {
+	client     *asc.Client
+	dir        string
+	since      string
+	category   string
+	accessType asc.AnalyticsAccessType
+	dryRun     bool
+	manifest   *analyticsMirrorManifest
+	result     *AnalyticsMirrorResult
+}
+
+func (m *analyticsMirror) run(ctx context.Context, appID string) error {
+	requests, err := m.fetchRequests(ctx, appID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch report requests: %w", err)
+	}
+	if len(requests) == 0 {
+		return fmt.Errorf("no %s analytics report request for app %s; create one with `asc analytics request --app %s --access-type %s`", m.accessType, appID, appID, m.accessType)
+	}
+	m.result.Summary.Requests = len(requests)
+
+	for _, request := range requests {
+		reportsCtx, cancel := shared.ContextWithTimeout(ctx)
+		reports, _, err := fetchAnalyticsReports(reportsCtx, m.client, request.ID, 0, "", true)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to fetch reports for request %s: %w", request.ID, err)
+		}
+		for _, report := range reports {
+			if m.category != "" && !strings.EqualFold(report.Attributes.Category, m.category) {
+				continue
+			}
+			m.result.Summary.Reports++
+			if err := m.mirrorReport(ctx, report); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *analyticsMirror) fetchRequests(ctx context.Context, appID string) ([]asc.AnalyticsReportRequestResource, error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	firstPage, err := m.client.GetAnalyticsReportRequests(requestCtx, appID, asc.WithAnalyticsReportRequestsLimit(analyticsMaxLimit))
+	if err != nil {
+		return nil, err
+	}
+	paginated, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return m.client.GetAnalyticsReportRequests(ctx, appID, asc.WithAnalyticsReportRequestsNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	typed, ok := paginated.(*asc.AnalyticsReportRequestsResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected pagination response type")
+	}
+
+	var requests []asc.AnalyticsReportRequestResource
+	for _, request := range typed.Data {
+		if request.Attributes.AccessType == m.accessType {
+			requests = append(requests, request)
+		}
+	}
+	return requests, nil
+}
+
+func (m *analyticsMirror) mirrorReport(ctx context.Context, report asc.Resource[asc.AnalyticsReportAttributes]) error {
+	instancesCtx, cancel := shared.ContextWithTimeout(ctx)
+	instances, err := fetchAnalyticsReportInstances(instancesCtx, m.client, report.ID)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to fetch instances for report %q: %w", report.Attributes.Name, err)
+	}
+	sort.SliceStable(instances, func(i, j int) bool {
+		return analyticsMirrorInstanceDate(instances[i].Attributes) < analyticsMirrorInstanceDate(instances[j].Attributes)
+	})
+
+	for _, instance := range instances {
+		date := analyticsMirrorInstanceDate(instance.Attributes)
+		if m.since != "" && date < m.since {
+			continue
+		}
+		m.result.Summary.Instances++
+		if item, ok := m.manifest.Instances[instance.ID]; ok && item.Complete {
+			m.result.Summary.AlreadyMirrored++
+			continue
+		}
+		if err := m.mirrorInstance(ctx, report, instance, date); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *analyticsMirror) mirrorInstance(ctx context.Context, report asc.Resource[asc.AnalyticsReportAttributes], instance asc.Resource[asc.AnalyticsReportInstanceAttributes], date string) error {
+	segmentsCtx, cancel := shared.ContextWithTimeout(ctx)
+	segments, err := fetchAnalyticsReportSegments(segmentsCtx, m.client, instance.ID)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to fetch segments for instance %s: %w", instance.ID, err)
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].ID < segments[j].ID })
+
+	item := m.manifest.Instances[instance.ID]
+	if item == nil {
+		item = &analyticsMirrorManifestItem{Segments: map[string]analyticsMirrorManifestSegment{}}
+	}
+	item.ReportID = report.ID
+	item.ReportName = report.Attributes.Name
+	item.Category = report.Attributes.Category
+	item.Date = date
+	item.Granularity = instance.Attributes.Granularity
+
+	complete := len(segments) > 0
+	for index, segment := range segments {
+		relPath := analyticsMirrorRelativePath(report.Attributes, instance.Attributes, date, index, len(segments))
+		file := AnalyticsMirrorFile{
+			Category:    report.Attributes.Category,
+			Report:      report.Attributes.Name,
+			Date:        date,
+			Granularity: instance.Attributes.Granularity,
+			InstanceID:  instance.ID,
+			SegmentID:   segment.ID,
+			Path:        filepath.Join(m.dir, relPath),
+		}
+
+		if known, ok := item.Segments[segment.ID]; ok && known.Checksum != "" && strings.EqualFold(known.Checksum, segment.Attributes.Checksum) {
+			m.result.Summary.UnchangedSegment++
+			continue
+		}
+		if m.dryRun {
+			file.Status = analyticsMirrorStatusPlanned
+			m.result.Summary.Planned++
+			m.result.Files = append(m.result.Files, file)
+			complete = false
+			continue
+		}
+
+		downloadCtx, cancel := shared.ContextWithTimeout(ctx)
+		size, err := downloadAnalyticsMirrorSegment(downloadCtx, m.client, segment, file.Path)
+		cancel()
+		if err != nil {
+			file.Status = analyticsMirrorStatusFailed
+			file.Error = err.Error()
+			m.result.Summary.Failed++
+			m.result.Files = append(m.result.Files, file)
+			complete = false
+			continue
+		}
+		file.Status = analyticsMirrorStatusDownloaded
+		file.Size = size
+		m.result.Summary.Downloaded++
+		m.result.Files = append(m.result.Files, file)
+		item.Segments[segment.ID] = analyticsMirrorManifestSegment{
+			Checksum:    segment.Attributes.Checksum,
+			SizeInBytes: segment.Attributes.SizeInBytes,
+			File:        filepath.ToSlash(relPath),
+		}
+	}
+
+	if m.dryRun {
+		return nil
+	}
+	item.Complete = complete
+	m.manifest.Instances[instance.ID] = item
+	m.manifest.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	if err := m.manifest.save(m.dir); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// downloadAnalyticsMirrorSegment downloads a gzip segment to a temp file,
+// verifies its MD5 checksum, and decompresses it over destPath.
+func downloadAnalyticsMirrorSegment(ctx context.Context, client *asc.Client, segment asc.Resource[asc.AnalyticsReportSegmentAttributes], destPath string) (int64, error) {
+	downloadURL := strings.TrimSpace(segment.Attributes.URL)
+	if downloadURL == "" {
+		return 0, fmt.Errorf("segment download URL is empty")
+	}
+	download, err := client.DownloadAnalyticsReport(ctx, downloadURL)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = download.Body.Close() }()
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+		return 0, err
+	}
+	temp, err := os.CreateTemp(filepath.Dir(destPath), ".segment-*.gz")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = temp.Close()
+		_ = os.Remove(temp.Name())
+	}()
+
+	hash := md5.New()
+	if _, err := io.Copy(io.MultiWriter(temp, hash), download.Body); err != nil {
+		return 0, fmt.Errorf("failed to download segment: %w", err)
+	}
+	if expected := strings.TrimSpace(segment.Attributes.Checksum); expected != "" {
+		if actual := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(actual, expected) {
+			return 0, fmt.Errorf("checksum mismatch: expected %s, got %s", expected, actual)
+		}
+	}
+
+	if _, err := temp.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	reader, err := gzip.NewReader(temp)
+	if err != nil {
+		return 0, fmt.Errorf("segment is not gzip data: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	return shared.WriteFileNoSymlinkOverwrite(destPath, reader, 0o600, ".analytics-*.tmp", ".analytics-*.bak")
+}
+
+func analyticsMirrorInstanceDate(attrs asc.AnalyticsReportInstanceAttributes) string {
+	date := strings.TrimSpace(attrs.ReportDate)
+	if date == "" {
+		date = strings.TrimSpace(attrs.ProcessingDate)
+	}
+	if len(date) > len("2006-01-02") {
+		date = date[:len("2006-01-02")]
+	}
+	return date
+}
+
+func analyticsMirrorRelativePath(report asc.AnalyticsReportAttributes, instance asc.AnalyticsReportInstanceAttributes, date string, index, total int) string {
+	name := date
+	if granularity := strings.ToLower(strings.TrimSpace(instance.Granularity)); granularity != "" && granularity != "daily" {
+		name += "-" + granularity
+	}
+	if total > 1 {
+		name += "-part" + strconv.Itoa(index+1)
+	}
+	return filepath.Join(
+		analyticsMirrorPathComponent(report.Category, "UNCATEGORIZED"),
+		analyticsMirrorPathComponent(report.Name, "report"),
+		name+".csv",
+	)
+}
+
+// analyticsMirrorPathComponent turns an API-provided name into a single safe
+// path component: runs of anything but letters, digits, '.', '_' and '-'
+// become one '-'.
+func analyticsMirrorPathComponent(value, fallback string) string {
+	var b strings.Builder
+	pendingDash := false
+	for _, r := range strings.TrimSpace(value) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-', r == '.':
+			if pendingDash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingDash = false
+			b.WriteRune(r)
+		default:
+			pendingDash = true
+		}
+	}
+	component := strings.Trim(b.String(), ".")
+	if component == "" {
+		return fallback
+	}
+	return component
+}
+
+func renderAnalyticsMirrorResult(result *AnalyticsMirrorResult, markdown bool) error {
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	summary := result.Summary
+	render(
+		[]string{"App", "Dir", "Reports", "Instances", "Already Mirrored", "Downloaded", "Planned", "Failed"},
+		[][]string{{
+			result.AppID,
+			result.Dir,
+			strconv.Itoa(summary.Reports),
+			strconv.Itoa(summary.Instances),
+			strconv.Itoa(summary.AlreadyMirrored),
+			strconv.Itoa(summary.Downloaded),
+			strconv.Itoa(summary.Planned),
+			strconv.Itoa(summary.Failed),
+		}},
+	)
+	if len(result.Files) == 0 {
+		return nil
+	}
+
+	rows := make([][]string, 0, len(result.Files))
+	for _, file := range result.Files {
+		detail := file.Path
+		if file.Error != "" {
+			detail = file.Error
+		}
+		rows = append(rows, []string{file.Category, file.Report, file.Date, file.Status, detail})
+	}
+	fmt.Println()
+	render([]string{"Category", "Report", "Date", "Status", "Path"}, rows)
+	return nil
+}
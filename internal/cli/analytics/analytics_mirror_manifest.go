@@ -0,0 +1,82 @@
+package analytics
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	analyticsMirrorManifestName    = ".asc-analytics-manifest.json"
+	analyticsMirrorManifestVersion = 1
+)
+
+// analyticsMirrorManifest records which report instances and segments a
+// mirror directory already holds, so later runs only download new data.
+type analyticsMirrorManifest struct {
+	Version   int                                     `json:"version"`
+	AppID     string                                  `json:"appId"`
+	UpdatedAt string                                  `json:"updatedAt,omitempty"`
+	Instances map[string]*analyticsMirrorManifestItem `json:"instances"`
+}
+
+type analyticsMirrorManifestItem struct {
+	ReportID    string                                    `json:"reportId"`
+	ReportName  string                                    `json:"reportName"`
+	Category    string                                    `json:"category"`
+	Date        string                                    `json:"date"`
+	Granularity string                                    `json:"granularity,omitempty"`
+	Complete    bool                                      `json:"complete"`
+	Segments    map[string]analyticsMirrorManifestSegment `json:"segments"`
+}
+
+type analyticsMirrorManifestSegment struct {
+	Checksum    string `json:"checksum,omitempty"`
+	SizeInBytes int64  `json:"sizeInBytes,omitempty"`
+	File        string `json:"file"`
+}
+
+func analyticsMirrorManifestPath(dir string) string {
+	return filepath.Join(dir, analyticsMirrorManifestName)
+}
+
+func loadAnalyticsMirrorManifest(dir, appID string) (*analyticsMirrorManifest, error) {
+	manifest := &analyticsMirrorManifest{
+		Version:   analyticsMirrorManifestVersion,
+		AppID:     appID,
+		Instances: map[string]*analyticsMirrorManifestItem{},
+	}
+	data, err := os.ReadFile(analyticsMirrorManifestPath(dir))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return manifest, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", analyticsMirrorManifestPath(dir), err)
+	}
+	if manifest.AppID != "" && manifest.AppID != appID {
+		return nil, fmt.Errorf("%s mirrors app %s, not %s; use a separate --dir per app", dir, manifest.AppID, appID)
+	}
+	manifest.AppID = appID
+	if manifest.Instances == nil {
+		manifest.Instances = map[string]*analyticsMirrorManifestItem{}
+	}
+	return manifest, nil
+}
+
+func (m *analyticsMirrorManifest) save(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	_, err = shared.WriteFileNoSymlinkOverwrite(analyticsMirrorManifestPath(dir), bytes.NewReader(data), 0o600, ".asc-analytics-manifest-*.tmp", ".asc-analytics-manifest-*.bak")
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/reports"
 )
 
 // AnalyticsSalesCommand downloads sales and trends reports.
@@ -23,7 +25,7 @@ func AnalyticsSalesCommand() *ffcli.Command {
 	frequency := fs.String("frequency", "", "Frequency: DAILY, WEEKLY, MONTHLY, YEARLY")
 	date := fs.String("date", "", "Report date: daily YYYY-MM-DD, weekly Monday(start) or Sunday(end) YYYY-MM-DD, monthly YYYY-MM, yearly YYYY")
 	version := fs.String("version", "1_0", "Report format version: 1_0 (default), 1_1, 1_3")
-	output := fs.String("output", "", "Output file path (default: sales_report_{date}_{type}.tsv.gz)")
+	output := fs.String("output", "", "Output file path (default: sales_report_{date}_{type}.tsv.gz), or json/csv to print parsed rows")
 	decompress := fs.Bool("decompress", false, "Decompress gzip output to .tsv")
 	outputFlags := shared.BindMetadataOutputFlags(fs)
 
@@ -33,12 +35,20 @@ func AnalyticsSalesCommand() *ffcli.Command {
 		ShortHelp:  "Download sales and trends reports.",
 		LongHelp: `Download sales and trends reports.
 
+By default the gzipped TSV report is saved to a file. Pass --output json or
+--output csv to print normalized rows to stdout instead: dates become
+YYYY-MM-DD, units and amounts become numbers, and product type codes are
+decoded. Parsed output supports SALES, SUBSCRIPTION, and SUBSCRIPTION_EVENT
+reports. To save a file literally named json or csv, pass ./json or ./csv.
+
 Examples:
   asc analytics sales --vendor "12345678" --type SALES --subtype SUMMARY --frequency DAILY --date "2024-01-20"
   asc analytics sales --vendor "12345678" --type SALES --subtype SUMMARY --frequency WEEKLY --date "2024-01-15" # Monday start accepted
   asc analytics sales --vendor "12345678" --type SUBSCRIPTION --subtype DETAILED --frequency MONTHLY --date "2024-01"
   asc analytics sales --vendor "12345678" --type SALES --subtype SUMMARY --frequency DAILY --date "2024-01-20" --decompress
-  asc analytics sales --vendor "12345678" --type SALES --subtype SUMMARY --frequency DAILY --date "2024-01-20" --output "reports/daily_sales.tsv.gz"`,
+  asc analytics sales --vendor "12345678" --type SALES --subtype SUMMARY --frequency DAILY --date "2024-01-20" --output "reports/daily_sales.tsv.gz"
+  asc analytics sales --vendor "12345678" --type SALES --subtype SUMMARY --frequency DAILY --date "2024-01-20" --output json
+  asc analytics sales --vendor "12345678" --type SUBSCRIPTION_EVENT --subtype SUMMARY --frequency DAILY --date "2024-01-20" --output csv > events.csv`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -85,6 +95,18 @@ Examples:
 				return fmt.Errorf("analytics sales: %w", err)
 			}
 
+			rowsFormat := shared.ReportRowsFormat(*output)
+			if rowsFormat != "" {
+				if *decompress {
+					return shared.UsageErrorf("--decompress cannot be used with --output %s", rowsFormat)
+				}
+				switch salesType {
+				case asc.SalesReportTypeSales, asc.SalesReportTypeSubscription, asc.SalesReportTypeSubscriptionEvent:
+				default:
+					return shared.UsageErrorf("--output %s supports SALES, SUBSCRIPTION, and SUBSCRIPTION_EVENT reports", rowsFormat)
+				}
+			}
+
 			defaultOutput := fmt.Sprintf("sales_report_%s_%s.tsv.gz", reportDate, string(salesType))
 			compressedPath, decompressedPath := shared.ResolveReportOutputPaths(*output, defaultOutput, ".tsv", *decompress)
 
@@ -109,6 +131,13 @@ Examples:
 			}
 			defer func() { _ = download.Body.Close() }()
 
+			if rowsFormat != "" {
+				if err := printParsedSalesReport(download.Body, salesType, rowsFormat, *outputFlags.Pretty); err != nil {
+					return fmt.Errorf("analytics sales: %w", err)
+				}
+				return nil
+			}
+
 			compressedSize, err := shared.WriteStreamToFile(compressedPath, download.Body)
 			if err != nil {
 				return fmt.Errorf("analytics sales: failed to write report: %w", err)
@@ -140,3 +169,26 @@ Examples:
 		},
 	}
 }
+
+func printParsedSalesReport(reader io.Reader, reportType asc.SalesReportType, format string, pretty bool) error {
+	switch reportType {
+	case asc.SalesReportTypeSubscription:
+		report, err := reports.ParseSubscription(reader)
+		if err != nil {
+			return err
+		}
+		return shared.PrintReportRows(report.Rows, format, pretty)
+	case asc.SalesReportTypeSubscriptionEvent:
+		report, err := reports.ParseSubscriptionEvent(reader)
+		if err != nil {
+			return err
+		}
+		return shared.PrintReportRows(report.Rows, format, pretty)
+	default:
+		report, err := reports.ParseSales(reader)
+		if err != nil {
+			return err
+		}
+		return shared.PrintReportRows(report.Rows, format, pretty)
+	}
+}
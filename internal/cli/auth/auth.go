@@ -22,6 +22,8 @@ var (
 	loginJWTGenerator        = asc.GenerateJWT
 	loginNetworkValidate     = validateLoginNetwork
 	statusValidateCredential = validateStoredCredential
+
+	statusValidateCredentialProcess = validateCredentialProcess
 )
 
 // Auth command factory
@@ -45,7 +47,8 @@ Credential resolution order:
   2) Environment variables (fallback for missing fields)
 
 Use --strict-auth or ASC_STRICT_AUTH=true (also: 1, yes, y, on) to fail when sources are mixed.
-Set ASC_BYPASS_KEYCHAIN to 1/true/yes/on to bypass keychain.`,
+Set ASC_BYPASS_KEYCHAIN to 1/true/yes/on to bypass keychain.
+Set ASC_SCOPED_TOKENS to 1/true/yes/on to sign GET requests with request-scoped tokens.`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
@@ -55,6 +58,8 @@ Set ASC_BYPASS_KEYCHAIN to 1/true/yes/on to bypass keychain.`,
 			AuthLogoutCommand(),
 			AuthDoctorCommand(),
 			AuthStatusCommand(),
+			AuthTokenCommand(),
+			AuthRotateCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			if len(args) == 0 {
@@ -150,7 +155,8 @@ func AuthDoctorCommand() *ffcli.Command {
 		LongHelp: `Diagnose authentication configuration issues.
 
 Runs a comprehensive health check across keychain availability, config files,
-stored profiles, private key files, and environment variables.
+stored profiles, credential_process, private key files, and environment
+variables.
 
 Examples:
   asc auth doctor
@@ -339,6 +345,24 @@ func validateStoredCredential(ctx context.Context, cred authsvc.Credential) erro
 	return nil
 }
 
+func validateCredentialProcess(ctx context.Context, command string) error {
+	creds, err := authsvc.ResolveProcessCredentials(ctx, command)
+	if err != nil {
+		return err
+	}
+	client, err := asc.NewClientWithPrivateKey(creds.KeyID, creds.IssuerID, creds.PrivateKey)
+	if err != nil {
+		return err
+	}
+	if _, err := client.GetApps(ctx, asc.WithAppsLimit(1)); err != nil {
+		if errors.Is(err, asc.ErrForbidden) {
+			return &permissionWarning{err: err}
+		}
+		return err
+	}
+	return nil
+}
+
 func validateLoginCredentials(ctx context.Context, keyID, issuerID, keyPath string, network bool) error {
 	privateKey, err := authsvc.LoadPrivateKey(keyPath)
 	if err != nil {
@@ -613,6 +637,7 @@ func AuthStatusCommand() *ffcli.Command {
 		LongHelp: `Show current authentication status.
 
 Displays information about stored API keys and which one is currently active.
+A credential_process configured in the config file is listed as well.
 Add --validate to perform a network validation for each stored credential.
 
 Examples:
@@ -716,6 +741,28 @@ Examples:
 			}
 
 			profile := shared.ResolveProfileName()
+			processCommand, processConfigPath, processErr := authsvc.CredentialProcessCommand()
+			if processErr == nil && processCommand != "" {
+				fmt.Println()
+				if profile != "" {
+					fmt.Printf("Credential process: configured in %s (ignored while profile %q is selected)\n", processConfigPath, profile)
+				} else {
+					fmt.Printf("Credential process: configured in %s (active)\n", processConfigPath)
+				}
+				if *validate {
+					if err := statusValidateCredentialProcess(ctx, processCommand); err != nil {
+						if _, ok := errors.AsType[*permissionWarning](err); ok {
+							fmt.Println("    credential_process: works (insufficient permissions for apps list)")
+						} else {
+							validationFailures++
+							fmt.Printf("    credential_process: failed (%v)\n", err)
+						}
+					} else {
+						fmt.Println("    credential_process: works")
+					}
+				}
+			}
+
 			envKeyID := strings.TrimSpace(os.Getenv("ASC_KEY_ID"))
 			envIssuerID := strings.TrimSpace(os.Getenv("ASC_ISSUER_ID"))
 			hasKeyEnv := strings.TrimSpace(os.Getenv("ASC_PRIVATE_KEY_PATH")) != "" ||
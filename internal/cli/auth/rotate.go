@@ -0,0 +1,293 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	authsvc "github.com/rudrankriyam/App-Store-Connect-CLI/internal/auth"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	rotateReferenceEnv     = "env"
+	rotateReferenceProfile = "profile"
+	rotateReferenceFile    = "file"
+
+	rotateScanMaxFileSize = 1 << 20
+	rotateScanMaxFiles    = 10000
+)
+
+// rotateScanSkipDirs are directories that never hold hand-written config.
+var rotateScanSkipDirs = map[string]bool{
+	".git":         true,
+	".asc":         true,
+	"node_modules": true,
+	"vendor":       true,
+	"Pods":         true,
+	".build":       true,
+	"DerivedData":  true,
+}
+
+// AuthRotateReference is a place that still mentions the old key ID.
+type AuthRotateReference struct {
+	Kind     string `json:"kind"`
+	Location string `json:"location"`
+}
+
+// AuthRotateResult is the output of auth rotate.
+type AuthRotateResult struct {
+	Profile         string                `json:"profile"`
+	PreviousProfile string                `json:"previousProfile"`
+	OldKeyID        string                `json:"oldKeyId"`
+	NewKeyID        string                `json:"newKeyId,omitempty"`
+	Storage         string                `json:"storage,omitempty"`
+	Finalized       bool                  `json:"finalized"`
+	References      []AuthRotateReference `json:"references"`
+}
+
+// AuthRotateCommand rotates the API key behind a stored profile.
+func AuthRotateCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("auth rotate", flag.ExitOnError)
+
+	profile := fs.String("profile", "", "Profile to rotate (defaults to --profile/ASC_PROFILE on the root command)")
+	newKey := fs.String("new-key", "", "Path to the new private key (.p8) file")
+	keyID := fs.String("key-id", "", "Key ID of the new API key")
+	issuerID := fs.String("issuer-id", "", "Issuer ID of the new API key (defaults to the profile's issuer)")
+	finalize := fs.Bool("finalize", false, "Remove the previous key kept by an earlier rotation")
+	scanDir := fs.String("scan-dir", ".", "Directory to scan for files that still reference the old key ID")
+	output := shared.BindOutputFlagsWith(fs, "output", "text", "Output format: text (default), json")
+
+	return &ffcli.Command{
+		Name:       "rotate",
+		ShortUsage: "asc auth rotate --profile NAME (--new-key PATH --key-id ID | --finalize) [flags]",
+		ShortHelp:  "Rotate the API key behind a stored profile.",
+		LongHelp: `Rotate the API key behind a stored profile.
+
+Generate the new key in App Store Connect first, then run rotate with it. The
+new key is validated with a lightweight API request before anything is stored.
+The profile is updated in place (keychain or config, wherever it lives) and the
+old key is kept as "<profile>.previous" so you can switch back while CI secrets
+and teammates catch up. Run again with --finalize to drop the previous key;
+a profile cannot be rotated again until its previous key is finalized.
+
+Both modes list environment variables, other profiles, and files under
+--scan-dir that still reference the old key ID. Only names and paths are
+printed, never values.
+
+Examples:
+  asc auth rotate --profile ci --new-key ./AuthKey_NEWKEY.p8 --key-id NEWKEY
+  asc auth rotate --profile ci --new-key ./AuthKey_NEWKEY.p8 --key-id NEWKEY --scan-dir ~/src/app --output json
+  asc auth rotate --profile ci --finalize`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			normalizedOutput, err := shared.ValidateOutputFormatAllowed(*output.Output, *output.Pretty, "text", "json")
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+			name := strings.TrimSpace(*profile)
+			if name == "" {
+				name = shared.ResolveProfileName()
+			}
+			if name == "" {
+				fmt.Fprintln(os.Stderr, "Error: --profile is required")
+				return flag.ErrHelp
+			}
+			if strings.HasSuffix(name, authsvc.PreviousProfileSuffix) {
+				return shared.UsageErrorf("--profile must name the active profile, not %q", name)
+			}
+
+			var result AuthRotateResult
+			if *finalize {
+				if strings.TrimSpace(*newKey) != "" || strings.TrimSpace(*keyID) != "" || strings.TrimSpace(*issuerID) != "" {
+					return shared.UsageError("--finalize cannot be combined with --new-key, --key-id, or --issuer-id")
+				}
+				previous, err := authsvc.FinalizeRotation(name)
+				if err != nil {
+					return fmt.Errorf("auth rotate: %w", err)
+				}
+				result = AuthRotateResult{
+					Profile:         name,
+					PreviousProfile: previous.Name,
+					OldKeyID:        previous.KeyID,
+					Finalized:       true,
+				}
+			} else {
+				if strings.TrimSpace(*newKey) == "" {
+					fmt.Fprintln(os.Stderr, "Error: --new-key is required")
+					return flag.ErrHelp
+				}
+				if strings.TrimSpace(*keyID) == "" {
+					fmt.Fprintln(os.Stderr, "Error: --key-id is required")
+					return flag.ErrHelp
+				}
+
+				existing, err := authsvc.FindCredential(name)
+				if err != nil {
+					return fmt.Errorf("auth rotate: %w", err)
+				}
+				if err := authsvc.CheckNoPendingRotation(name); err != nil {
+					return fmt.Errorf("auth rotate: %w; run `asc auth rotate --profile %s --finalize` first", err, name)
+				}
+				issuer := strings.TrimSpace(*issuerID)
+				if issuer == "" {
+					issuer = existing.IssuerID
+				}
+				if err := authsvc.ValidateKeyFile(*newKey); err != nil {
+					return fmt.Errorf("auth rotate: invalid private key: %w", err)
+				}
+				if err := validateLoginCredentials(ctx, strings.TrimSpace(*keyID), issuer, *newKey, true); err != nil {
+					return fmt.Errorf("auth rotate: %w", err)
+				}
+
+				previous, err := authsvc.RotateCredentials(name, strings.TrimSpace(*keyID), issuer, *newKey)
+				if err != nil {
+					return fmt.Errorf("auth rotate: %w", err)
+				}
+				result = AuthRotateResult{
+					Profile:         name,
+					PreviousProfile: authsvc.PreviousProfileName(name),
+					OldKeyID:        previous.KeyID,
+					NewKeyID:        strings.TrimSpace(*keyID),
+					Storage:         previous.Source,
+				}
+			}
+
+			references, err := findKeyIDReferences(result.OldKeyID, result.PreviousProfile, *scanDir)
+			if err != nil {
+				return fmt.Errorf("auth rotate: %w", err)
+			}
+			result.References = references
+
+			if normalizedOutput == "json" {
+				return shared.PrintOutput(result, "json", *output.Pretty)
+			}
+			printAuthRotateResult(result, *scanDir)
+			return nil
+		},
+	}
+}
+
+func printAuthRotateResult(result AuthRotateResult, scanDir string) {
+	if result.Finalized {
+		fmt.Printf("Removed previous key %s (%s) for profile '%s'\n", result.OldKeyID, result.PreviousProfile, result.Profile)
+	} else {
+		fmt.Printf("Rotated profile '%s' from key %s to %s (%s)\n", result.Profile, result.OldKeyID, result.NewKeyID, result.Storage)
+		fmt.Printf("Previous key kept as '%s'; run `asc auth rotate --profile %s --finalize` once nothing uses it.\n", result.PreviousProfile, result.Profile)
+	}
+
+	if len(result.References) == 0 {
+		fmt.Printf("No environment variables, profiles, or files under %s reference %s.\n", scanDir, result.OldKeyID)
+		return
+	}
+	fmt.Printf("\nStill referencing %s:\n", result.OldKeyID)
+	for _, ref := range result.References {
+		fmt.Printf("  %-8s %s\n", ref.Kind, ref.Location)
+	}
+}
+
+// findKeyIDReferences lists environment variables, stored profiles (other
+// than the rotation's own previous profile), and files under dir that mention
+// keyID.
+func findKeyIDReferences(keyID, previousProfile, dir string) ([]AuthRotateReference, error) {
+	keyID = strings.TrimSpace(keyID)
+	if keyID == "" {
+		return []AuthRotateReference{}, nil
+	}
+
+	references := []AuthRotateReference{}
+	var envNames []string
+	for _, entry := range os.Environ() {
+		name, value, ok := strings.Cut(entry, "=")
+		if ok && strings.Contains(value, keyID) {
+			envNames = append(envNames, name)
+		}
+	}
+	sort.Strings(envNames)
+	for _, name := range envNames {
+		references = append(references, AuthRotateReference{Kind: rotateReferenceEnv, Location: name})
+	}
+
+	credentials, err := authsvc.ListCredentials()
+	if err != nil {
+		if _, ok := errors.AsType[*authsvc.CredentialsWarning](err); !ok {
+			return nil, err
+		}
+	}
+	for _, cred := range credentials {
+		if cred.KeyID == keyID && cred.Name != previousProfile {
+			references = append(references, AuthRotateReference{Kind: rotateReferenceProfile, Location: cred.Name})
+		}
+	}
+
+	files, err := scanFilesForKeyID(dir, keyID)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range files {
+		references = append(references, AuthRotateReference{Kind: rotateReferenceFile, Location: path})
+	}
+	return references, nil
+}
+
+func scanFilesForKeyID(dir, keyID string) ([]string, error) {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return nil, nil
+	}
+	needle := []byte(keyID)
+	var matches []string
+	scanned := 0
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir {
+				return err
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			if path != dir && rotateScanSkipDirs[entry.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		if scanned >= rotateScanMaxFiles {
+			return filepath.SkipAll
+		}
+		scanned++
+
+		if strings.Contains(entry.Name(), keyID) {
+			matches = append(matches, path)
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil || info.Size() > rotateScanMaxFileSize {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil || bytes.IndexByte(data, 0) >= 0 {
+			return nil
+		}
+		if bytes.Contains(data, needle) {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan %s: %w", dir, err)
+	}
+	return matches, nil
+}
@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/config"
+)
+
+func TestAuthRotateCommand(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv("ASC_CONFIG_PATH", configPath)
+	t.Setenv("ASC_BYPASS_KEYCHAIN", "1")
+	t.Setenv("ASC_PROFILE", "")
+	t.Setenv("LEGACY_ASC_KEY", "OLDKEY1234")
+	if err := config.SaveAt(configPath, &config.Config{
+		DefaultKeyName: "ci",
+		Keys: []config.Credential{
+			{Name: "ci", KeyID: "OLDKEY1234", IssuerID: "ISS", PrivateKeyPath: "/tmp/old.p8"},
+			{Name: "laptop", KeyID: "OLDKEY1234", IssuerID: "ISS", PrivateKeyPath: "/tmp/old.p8"},
+		},
+	}); err != nil {
+		t.Fatalf("SaveAt() error: %v", err)
+	}
+
+	scanDir := t.TempDir()
+	workflow := filepath.Join(scanDir, ".github", "workflows", "release.yml")
+	if err := os.MkdirAll(filepath.Dir(workflow), 0o755); err != nil {
+		t.Fatalf("MkdirAll() error: %v", err)
+	}
+	if err := os.WriteFile(workflow, []byte("env:\n  ASC_KEY_ID: OLDKEY1234\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(scanDir, "README.md"), []byte("nothing here"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	var validated []string
+	prevNetwork := loginNetworkValidate
+	loginNetworkValidate = func(_ context.Context, keyID, issuerID, _ string) error {
+		validated = append(validated, keyID+"/"+issuerID)
+		return nil
+	}
+	t.Cleanup(func() { loginNetworkValidate = prevNetwork })
+
+	keyPath := writeTempECDSAKeyFile(t)
+	cmd := AuthRotateCommand()
+	if err := cmd.FlagSet.Parse([]string{"--profile", "ci", "--new-key", keyPath, "--key-id", "NEWKEY5678", "--scan-dir", scanDir, "--output", "json"}); err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	stdout, _ := captureAuthOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("Exec() error: %v", err)
+		}
+	})
+
+	if !reflect.DeepEqual(validated, []string{"NEWKEY5678/ISS"}) {
+		t.Fatalf("expected the new key to be validated with the profile issuer, got %v", validated)
+	}
+	var result AuthRotateResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse output %q: %v", stdout, err)
+	}
+	if result.OldKeyID != "OLDKEY1234" || result.NewKeyID != "NEWKEY5678" || result.PreviousProfile != "ci.previous" || result.Storage != "config" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	wantRefs := []AuthRotateReference{
+		{Kind: rotateReferenceEnv, Location: "LEGACY_ASC_KEY"},
+		{Kind: rotateReferenceProfile, Location: "laptop"},
+		{Kind: rotateReferenceFile, Location: workflow},
+	}
+	if !reflect.DeepEqual(result.References, wantRefs) {
+		t.Fatalf("references = %+v, want %+v", result.References, wantRefs)
+	}
+
+	cfg, err := config.LoadAt(configPath)
+	if err != nil {
+		t.Fatalf("LoadAt() error: %v", err)
+	}
+	keys := map[string]string{}
+	for _, cred := range cfg.Keys {
+		keys[cred.Name] = cred.KeyID
+	}
+	if keys["ci"] != "NEWKEY5678" || keys["ci.previous"] != "OLDKEY1234" {
+		t.Fatalf("unexpected stored keys %v", keys)
+	}
+
+	finalize := AuthRotateCommand()
+	if err := finalize.FlagSet.Parse([]string{"--profile", "ci", "--finalize", "--scan-dir", scanDir}); err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	stdout, _ = captureAuthOutput(t, func() {
+		if err := finalize.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("Exec() error: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, "Removed previous key OLDKEY1234 (ci.previous)") || !strings.Contains(stdout, "laptop") {
+		t.Fatalf("unexpected finalize output %q", stdout)
+	}
+	cfg, _ = config.LoadAt(configPath)
+	for _, cred := range cfg.Keys {
+		if cred.Name == "ci.previous" {
+			t.Fatal("expected ci.previous to be removed")
+		}
+	}
+}
+
+func TestAuthRotateCommandValidation(t *testing.T) {
+	t.Setenv("ASC_PROFILE", "")
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "missing profile", args: []string{"--new-key", "k.p8", "--key-id", "K"}, want: "--profile is required"},
+		{name: "missing key", args: []string{"--profile", "ci", "--key-id", "K"}, want: "--new-key is required"},
+		{name: "finalize with key", args: []string{"--profile", "ci", "--finalize", "--key-id", "K"}, want: "--finalize cannot be combined"},
+		{name: "previous profile", args: []string{"--profile", "ci.previous", "--finalize"}, want: "must name the active profile"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := AuthRotateCommand()
+			if err := cmd.FlagSet.Parse(test.args); err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+			_, stderr := captureAuthOutput(t, func() {
+				if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected flag.ErrHelp, got %v", err)
+				}
+			})
+			if !strings.Contains(stderr, test.want) {
+				t.Fatalf("expected %q in stderr, got %q", test.want, stderr)
+			}
+		})
+	}
+}
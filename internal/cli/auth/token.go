@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// tokenScopes collects repeated --scope flags.
+type tokenScopes []string
+
+func (s *tokenScopes) String() string {
+	return strings.Join(*s, ", ")
+}
+
+func (s *tokenScopes) Set(value string) error {
+	scope, err := asc.NormalizeTokenScope(value)
+	if err != nil {
+		return err
+	}
+	*s = append(*s, scope)
+	return nil
+}
+
+// AuthTokenResult is the JSON output of auth token.
+type AuthTokenResult struct {
+	Token     string   `json:"token"`
+	ExpiresAt string   `json:"expiresAt"`
+	Scope     []string `json:"scope,omitempty"`
+}
+
+// AuthTokenCommand prints a short-lived, optionally scoped API token.
+func AuthTokenCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("auth token", flag.ExitOnError)
+
+	var scopes tokenScopes
+	fs.Var(&scopes, "scope", `Restrict the token to a GET request, e.g. "GET /v1/apps?filter[platform]=IOS" (repeatable)`)
+	lifetime := fs.Duration("lifetime", asc.DefaultTokenLifetime, "Token lifetime (max 20m)")
+	output := shared.BindOutputFlagsWith(fs, "output", "text", "Output format: text (default), json")
+
+	return &ffcli.Command{
+		Name:       "token",
+		ShortUsage: "asc auth token [flags]",
+		ShortHelp:  "Print a short-lived API token for the active credentials.",
+		LongHelp: `Print a short-lived API token for the active credentials.
+
+Each --scope limits the token to one GET request, including its query string,
+which makes it suitable for read-only dashboards. Without --scope the token
+has the full access of the API key.
+
+Set ASC_SCOPED_TOKENS=1 (also: true, yes, y, on) to make every command sign
+its GET requests with a token scoped to that request.
+
+Examples:
+  asc auth token
+  asc auth token --scope "GET /v1/apps?filter[platform]=IOS" --lifetime 5m
+  asc auth token --scope "GET /v1/apps" --scope "GET /v1/builds" --output json`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			normalizedOutput, err := shared.ValidateOutputFormatAllowed(*output.Output, *output.Pretty, "text", "json")
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+			if *lifetime <= 0 || *lifetime > asc.MaxTokenLifetime {
+				return shared.UsageErrorf("--lifetime must be between 1s and %s", asc.MaxTokenLifetime)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("auth token: %w", err)
+			}
+			token, expiresAt, err := client.GenerateToken(scopes, *lifetime)
+			if err != nil {
+				return fmt.Errorf("auth token: %w", err)
+			}
+
+			if normalizedOutput == "json" {
+				return shared.PrintOutput(AuthTokenResult{
+					Token:     token,
+					ExpiresAt: expiresAt.UTC().Format(time.RFC3339),
+					Scope:     scopes,
+				}, "json", *output.Pretty)
+			}
+			fmt.Println(token)
+			return nil
+		},
+	}
+}
@@ -31,6 +31,7 @@ Examples:
   asc certificates list --certificate-type IOS_DISTRIBUTION
   asc certificates get --id "CERT_ID" --include passTypeId
   asc certificates create --certificate-type IOS_DISTRIBUTION --csr "./cert.csr"
+  asc certificates export-p12 --key "./cert.key" --cert-id "CERT_ID" --password-env P12_PASSWORD
   asc certificates update --id "CERT_ID" --activated true
   asc certificates update --id "CERT_ID" --activated false
   asc certificates revoke --id "CERT_ID" --confirm
@@ -42,6 +43,7 @@ Examples:
 			CertificatesGetCommand(),
 			CertificatesCSRCommand(),
 			CertificatesCreateCommand(),
+			CertificatesExportP12Command(),
 			CertificatesUpdateCommand(),
 			CertificatesRevokeCommand(),
 			CertificatesRelationshipsCommand(),
@@ -0,0 +1,231 @@
+package certificates
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/pkcs12"
+)
+
+type exportP12Result struct {
+	CertificateID   string `json:"certificateId"`
+	CertificateType string `json:"certificateType,omitempty"`
+	SerialNumber    string `json:"serialNumber,omitempty"`
+	CommonName      string `json:"commonName"`
+	ExpirationDate  string `json:"expirationDate,omitempty"`
+	Output          string `json:"output"`
+}
+
+// CertificatesExportP12Command returns the certificates export-p12 subcommand.
+func CertificatesExportP12Command() *ffcli.Command {
+	fs := flag.NewFlagSet("export-p12", flag.ExitOnError)
+
+	keyPath := fs.String("key", "", "Private key path (PEM) used to create the certificate's CSR")
+	certID := fs.String("cert-id", "", "Certificate ID")
+	passwordEnv := fs.String("password-env", "", "Environment variable holding the .p12 password")
+	outputPath := fs.String("output", "", "PKCS#12 output path (default: <serial number>.p12)")
+	force := fs.Bool("force", false, "Overwrite an existing output file")
+	output := shared.BindOutputFlagsWith(fs, "format", "json", "Output format for metadata: json (default), table, markdown")
+
+	return &ffcli.Command{
+		Name:       "export-p12",
+		ShortUsage: "asc certificates export-p12 --key ./cert.key --cert-id \"CERT_ID\" --password-env VAR [flags]",
+		ShortHelp:  "Export a certificate and its private key as a PKCS#12 file.",
+		LongHelp: `Export a certificate and its private key as a PKCS#12 file.
+
+Downloads the certificate, verifies that it was issued for the given private
+key, and writes a password-protected .p12 that can be imported into a
+keychain. The password is read from an environment variable so it never
+appears in shell history or process listings.
+
+Examples:
+  asc certificates export-p12 --key "./signing/cert.key" --cert-id "CERT_ID" --password-env P12_PASSWORD
+  asc certificates export-p12 --key "./signing/cert.key" --cert-id "CERT_ID" --password-env P12_PASSWORD --output "./signing/dist.p12"
+  asc certificates export-p12 --key "./signing/cert.key" --cert-id "CERT_ID" --password-env P12_PASSWORD --output "./signing/dist.p12" --force`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			keyValue := strings.TrimSpace(*keyPath)
+			if keyValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --key is required")
+				return flag.ErrHelp
+			}
+			idValue := strings.TrimSpace(*certID)
+			if idValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --cert-id is required")
+				return flag.ErrHelp
+			}
+			envName := strings.TrimSpace(*passwordEnv)
+			if envName == "" {
+				fmt.Fprintln(os.Stderr, "Error: --password-env is required")
+				return flag.ErrHelp
+			}
+			password, ok := os.LookupEnv(envName)
+			if !ok {
+				return shared.UsageErrorf("environment variable %s is not set", envName)
+			}
+
+			privateKey, err := readPrivateKey(keyValue)
+			if err != nil {
+				return fmt.Errorf("certificates export-p12: read --key: %w", err)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("certificates export-p12: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			resp, err := client.GetCertificate(requestCtx, idValue)
+			if err != nil {
+				return fmt.Errorf("certificates export-p12: failed to fetch: %w", err)
+			}
+			attrs := resp.Data.Attributes
+
+			cert, err := parseCertificateContent(attrs.CertificateContent)
+			if err != nil {
+				return fmt.Errorf("certificates export-p12: certificate %s: %w", idValue, err)
+			}
+			if err := verifyCertificateKey(cert, privateKey); err != nil {
+				return fmt.Errorf("certificates export-p12: %w", err)
+			}
+
+			data, err := pkcs12.Encode(privateKey, cert, password, cert.Subject.CommonName)
+			if err != nil {
+				return fmt.Errorf("certificates export-p12: %w", err)
+			}
+
+			outputValue := strings.TrimSpace(*outputPath)
+			if outputValue == "" {
+				name := strings.TrimSpace(attrs.SerialNumber)
+				if name == "" {
+					name = idValue
+				}
+				outputValue = name + ".p12"
+			}
+			if err := writeFileBytesNoSymlink(outputValue, data, 0o600, *force); err != nil {
+				return fmt.Errorf("certificates export-p12: write --output: %w", err)
+			}
+
+			result := &exportP12Result{
+				CertificateID:   idValue,
+				CertificateType: attrs.CertificateType,
+				SerialNumber:    attrs.SerialNumber,
+				CommonName:      cert.Subject.CommonName,
+				ExpirationDate:  attrs.ExpirationDate,
+				Output:          outputValue,
+			}
+
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderExportP12Result(result, false) },
+				func() error { return renderExportP12Result(result, true) },
+			)
+		},
+	}
+}
+
+// readPrivateKey loads a PKCS#8, PKCS#1, or SEC 1 private key from a PEM file.
+func readPrivateKey(path string) (crypto.Signer, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no private key found in PEM file")
+		}
+		switch block.Type {
+		case "PRIVATE KEY":
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			signer, ok := key.(crypto.Signer)
+			if !ok {
+				return nil, fmt.Errorf("unsupported private key type %T", key)
+			}
+			return signer, nil
+		case "RSA PRIVATE KEY":
+			return x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			return x509.ParseECPrivateKey(block.Bytes)
+		case "ENCRYPTED PRIVATE KEY":
+			return nil, fmt.Errorf("encrypted private keys are not supported; decrypt the key first")
+		}
+	}
+}
+
+func parseCertificateContent(content string) (*x509.Certificate, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, fmt.Errorf("response did not include certificate content")
+	}
+	der, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		return nil, fmt.Errorf("decode certificate content: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse certificate: %w", err)
+	}
+	return cert, nil
+}
+
+// verifyCertificateKey checks that cert was issued for the public half of key.
+func verifyCertificateKey(cert *x509.Certificate, key crypto.Signer) error {
+	public, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !public.Equal(cert.PublicKey) {
+		return fmt.Errorf("private key does not match certificate %q", cert.Subject.CommonName)
+	}
+	return nil
+}
+
+func renderExportP12Result(result *exportP12Result, markdown bool) error {
+	if result == nil {
+		return fmt.Errorf("result is nil")
+	}
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	render(
+		[]string{"Certificate ID", "Type", "Serial Number", "Common Name", "Expires", "Output"},
+		[][]string{{
+			result.CertificateID,
+			result.CertificateType,
+			result.SerialNumber,
+			result.CommonName,
+			result.ExpirationDate,
+			result.Output,
+		}},
+	)
+	return nil
+}
@@ -0,0 +1,143 @@
+package cmdtest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAnalyticsMirrorDownloadsNewSegmentsOnly(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	var gz bytes.Buffer
+	writer := gzip.NewWriter(&gz)
+	_, _ = writer.Write([]byte("Date,Installs\n2026-01-02,5\n"))
+	_ = writer.Close()
+	sum := md5.Sum(gz.Bytes())
+	checksum := hex.EncodeToString(sum[:])
+
+	downloads := 0
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() { http.DefaultTransport = originalTransport })
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.URL.Path == "/v1/apps/app-1/analyticsReportRequests":
+			return jsonResponse(http.StatusOK, `{"data":[
+				{"type":"analyticsReportRequests","id":"req-1","attributes":{"accessType":"ONGOING"}},
+				{"type":"analyticsReportRequests","id":"req-2","attributes":{"accessType":"ONE_TIME_SNAPSHOT"}}
+			],"links":{}}`)
+		case req.URL.Path == "/v1/analyticsReportRequests/req-1/reports":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"analyticsReports","id":"rep-1","attributes":{"name":"App Store Installs: Standard","category":"APP_STORE_ENGAGEMENT"}}],"links":{}}`)
+		case req.URL.Path == "/v1/analyticsReports/rep-1/instances":
+			return jsonResponse(http.StatusOK, `{"data":[
+				{"type":"analyticsReportInstances","id":"inst-old","attributes":{"reportDate":"2025-12-31","granularity":"DAILY"}},
+				{"type":"analyticsReportInstances","id":"inst-1","attributes":{"reportDate":"2026-01-02","granularity":"DAILY"}}
+			],"links":{}}`)
+		case req.URL.Path == "/v1/analyticsReportInstances/inst-1/segments":
+			return jsonResponse(http.StatusOK, fmt.Sprintf(`{"data":[{"type":"analyticsReportSegments","id":"seg-1","attributes":{"url":"https://example.apple.com/seg-1","checksum":%q,"sizeInBytes":%d}}],"links":{}}`, checksum, gz.Len()))
+		case req.URL.Host == "example.apple.com":
+			downloads++
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader(gz.Bytes())),
+				Header:     http.Header{"Content-Type": []string{"application/a-gzip"}},
+			}, nil
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	dir := t.TempDir()
+	run := func() map[string]any {
+		root := RootCommand("1.2.3")
+		root.FlagSet.SetOutput(io.Discard)
+		stdout, stderr := captureOutput(t, func() {
+			if err := root.Parse([]string{"analytics", "mirror", "--app", "app-1", "--dir", dir, "--since", "2026-01-01"}); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			if err := root.Run(context.Background()); err != nil {
+				t.Fatalf("run error: %v", err)
+			}
+		})
+		if stderr != "" {
+			t.Fatalf("expected empty stderr, got %q", stderr)
+		}
+		var payload map[string]any
+		if err := json.Unmarshal([]byte(stdout), &payload); err != nil {
+			t.Fatalf("failed to parse output %q: %v", stdout, err)
+		}
+		return payload["summary"].(map[string]any)
+	}
+
+	summary := run()
+	if summary["downloaded"] != float64(1) || summary["instances"] != float64(1) || summary["failed"] != float64(0) {
+		t.Fatalf("unexpected first summary %v", summary)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "APP_STORE_ENGAGEMENT", "App-Store-Installs-Standard", "2026-01-02.csv"))
+	if err != nil {
+		t.Fatalf("expected mirrored CSV: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "Date,Installs\n") {
+		t.Fatalf("unexpected CSV contents %q", data)
+	}
+	manifest, err := os.ReadFile(filepath.Join(dir, ".asc-analytics-manifest.json"))
+	if err != nil {
+		t.Fatalf("expected manifest: %v", err)
+	}
+	if !strings.Contains(string(manifest), `"inst-1"`) || !strings.Contains(string(manifest), checksum) {
+		t.Fatalf("manifest missing instance or checksum: %s", manifest)
+	}
+
+	summary = run()
+	if summary["downloaded"] != float64(0) || summary["alreadyMirrored"] != float64(1) {
+		t.Fatalf("unexpected second summary %v", summary)
+	}
+	if downloads != 1 {
+		t.Fatalf("expected one segment download across runs, got %d", downloads)
+	}
+}
+
+func TestAnalyticsMirrorValidationErrors(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "missing app", args: []string{"analytics", "mirror", "--dir", "out"}, wantErr: "--app is required"},
+		{name: "missing dir", args: []string{"analytics", "mirror", "--app", "app-1"}, wantErr: "--dir is required"},
+		{name: "bad since", args: []string{"analytics", "mirror", "--app", "app-1", "--dir", "out", "--since", "01/02/2026"}, wantErr: "--since must be in YYYY-MM-DD format"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+			_, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected flag.ErrHelp, got %v", err)
+				}
+			})
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected %q in stderr, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuthTokenPrintsScopedToken(t *testing.T) {
+	setupAuth(t)
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"auth", "token", "--scope", "GET /v1/apps?filter[platform]=IOS", "--lifetime", "5m", "--output", "json"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		Token     string   `json:"token"`
+		ExpiresAt string   `json:"expiresAt"`
+		Scope     []string `json:"scope"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse output %q: %v", stdout, err)
+	}
+	if !reflect.DeepEqual(result.Scope, []string{"GET /v1/apps?filter[platform]=IOS"}) {
+		t.Fatalf("unexpected scope %v", result.Scope)
+	}
+	if strings.Count(result.Token, ".") != 2 {
+		t.Fatalf("expected a JWT, got %q", result.Token)
+	}
+	expiresAt, err := time.Parse(time.RFC3339, result.ExpiresAt)
+	if err != nil {
+		t.Fatalf("invalid expiresAt %q: %v", result.ExpiresAt, err)
+	}
+	if remaining := time.Until(expiresAt); remaining > 5*time.Minute || remaining < 4*time.Minute {
+		t.Fatalf("unexpected expiry %s", result.ExpiresAt)
+	}
+}
+
+func TestAuthTokenRejectsLongLifetime(t *testing.T) {
+	setupAuth(t)
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	_, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"auth", "token", "--lifetime", "30m"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--lifetime must be between 1s and 20m0s") {
+		t.Fatalf("unexpected stderr %q", stderr)
+	}
+}
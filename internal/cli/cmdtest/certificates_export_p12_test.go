@@ -0,0 +1,166 @@
+package cmdtest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCertificatesExportP12_MissingPasswordEnv(t *testing.T) {
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	_, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"certificates", "export-p12", "--key", "cert.key", "--cert-id", "CERT_1"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		err := root.Run(context.Background())
+		if !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+
+	if !strings.Contains(stderr, "Error: --password-env is required") {
+		t.Fatalf("expected missing password-env error, got %q", stderr)
+	}
+}
+
+func TestCertificatesExportP12_WritesIdentity(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("P12_PASSWORD", "ci secret")
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "cert.key")
+	certContent := writeExportP12Identity(t, keyPath)
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet || req.URL.Path != "/v1/certificates/CERT_1" {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		}
+		return jsonResponse(http.StatusOK, `{"data":{"type":"certificates","id":"CERT_1","attributes":{"certificateType":"DISTRIBUTION","serialNumber":"SERIAL1","certificateContent":"`+certContent+`"}}}`)
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	outputPath := filepath.Join(dir, "dist.p12")
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"certificates", "export-p12", "--key", keyPath, "--cert-id", "CERT_1", "--password-env", "P12_PASSWORD", "--output", outputPath}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		CertificateID string `json:"certificateId"`
+		CommonName    string `json:"commonName"`
+		Output        string `json:"output"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode output: %v (%q)", err, stdout)
+	}
+	if result.CertificateID != "CERT_1" || result.CommonName != "Apple Distribution: Example" || result.Output != outputPath {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	info, err := os.Stat(outputPath)
+	if err != nil {
+		t.Fatalf("stat output: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected 0600 permissions, got %v", info.Mode().Perm())
+	}
+	data, err := os.ReadFile(outputPath)
+	if err != nil || len(data) == 0 || data[0] != 0x30 {
+		t.Fatalf("expected DER PKCS#12 output, got %d bytes, %v", len(data), err)
+	}
+}
+
+func TestCertificatesExportP12_RejectsMismatchedKey(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("P12_PASSWORD", "ci secret")
+
+	dir := t.TempDir()
+	certContent := writeExportP12Identity(t, filepath.Join(dir, "issued.key"))
+	otherKeyPath := filepath.Join(dir, "other.key")
+	writeExportP12Identity(t, otherKeyPath)
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, `{"data":{"type":"certificates","id":"CERT_1","attributes":{"certificateContent":"`+certContent+`"}}}`)
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	outputPath := filepath.Join(dir, "dist.p12")
+	var runErr error
+	captureOutput(t, func() {
+		if err := root.Parse([]string{"certificates", "export-p12", "--key", otherKeyPath, "--cert-id", "CERT_1", "--password-env", "P12_PASSWORD", "--output", outputPath}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+
+	if runErr == nil || !strings.Contains(runErr.Error(), "private key does not match certificate") {
+		t.Fatalf("expected key mismatch error, got %v", runErr)
+	}
+	if _, err := os.Stat(outputPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected no output file, got %v", err)
+	}
+}
+
+// writeExportP12Identity writes a PKCS#8 key to keyPath and returns a
+// self-signed certificate for it as base64 DER, like App Store Connect does.
+func writeExportP12Identity(t *testing.T, keyPath string) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Apple Distribution: Example"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(certDER)
+}
@@ -0,0 +1,115 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDevicesImportRegistersAndRefreshesProfiles(t *testing.T) {
+	setupAuth(t)
+
+	file := filepath.Join(t.TempDir(), "devices.txt")
+	content := "Device ID\tDevice Name\tDevice Platform\n" +
+		"existing-udid\tOld Phone\tios\n" +
+		"NEW-UDID\tNew Phone\tios\n" +
+		"new-udid\tNew Phone Again\tios\n"
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("write devices file: %v", err)
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	var requests []string
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/devices":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"devices","id":"DEV_OLD","attributes":{"name":"Old Phone","udid":"EXISTING-UDID","platform":"IOS","status":"ENABLED"}}],"links":{}}`)
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/devices":
+			body, _ := io.ReadAll(req.Body)
+			if !strings.Contains(string(body), `"udid":"NEW-UDID"`) || !strings.Contains(string(body), `"platform":"IOS"`) {
+				t.Fatalf("unexpected device create body: %s", body)
+			}
+			return jsonResponse(http.StatusCreated, `{"data":{"type":"devices","id":"DEV_NEW","attributes":{"name":"New Phone","udid":"NEW-UDID","platform":"IOS"}}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/bundleIds":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"bundleIds","id":"BUNDLE_1","attributes":{"identifier":"com.example.app"}}],"links":{}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/bundleIds/BUNDLE_1/profiles":
+			return jsonResponse(http.StatusOK, `{"data":[
+				{"type":"profiles","id":"P_DEV","attributes":{"name":"Example Dev","profileType":"IOS_APP_DEVELOPMENT","profileState":"ACTIVE"}},
+				{"type":"profiles","id":"P_STORE","attributes":{"name":"Example Store","profileType":"IOS_APP_STORE","profileState":"ACTIVE"}}
+			],"links":{}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/profiles/P_DEV/relationships/certificates":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"certificates","id":"CERT_1"}],"links":{}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/profiles/P_DEV/relationships/devices":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"devices","id":"DEV_OLD"}],"links":{}}`)
+		case req.Method == http.MethodDelete && req.URL.Path == "/v1/profiles/P_DEV":
+			return jsonResponse(http.StatusNoContent, "")
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/profiles":
+			body, _ := io.ReadAll(req.Body)
+			for _, want := range []string{`"Example Dev"`, `"IOS_APP_DEVELOPMENT"`, `"BUNDLE_1"`, `"CERT_1"`, `"DEV_OLD"`, `"DEV_NEW"`} {
+				if !strings.Contains(string(body), want) {
+					t.Fatalf("expected profile create body to contain %s, got %s", want, body)
+				}
+			}
+			return jsonResponse(http.StatusCreated, `{"data":{"type":"profiles","id":"P_DEV_2","attributes":{"name":"Example Dev","profileType":"IOS_APP_DEVELOPMENT"}}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"devices", "import", "--file", file, "--refresh-profiles", "--bundle-id", "com.example.app"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		Summary struct {
+			Registered  int `json:"registered"`
+			Existing    int `json:"existing"`
+			Duplicates  int `json:"duplicates"`
+			Regenerated int `json:"regeneratedProfiles"`
+		} `json:"summary"`
+		Rows []struct {
+			Line     int    `json:"line"`
+			Status   string `json:"status"`
+			DeviceID string `json:"deviceId"`
+		} `json:"rows"`
+		Profiles []struct {
+			ProfileID    string `json:"profileId"`
+			NewProfileID string `json:"newProfileId"`
+			Status       string `json:"status"`
+		} `json:"profiles"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode output: %v (%q)", err, stdout)
+	}
+	if result.Summary.Registered != 1 || result.Summary.Existing != 1 || result.Summary.Duplicates != 1 || result.Summary.Regenerated != 1 {
+		t.Fatalf("unexpected summary: %+v", result.Summary)
+	}
+	if len(result.Rows) != 3 || result.Rows[0].DeviceID != "DEV_OLD" || result.Rows[1].DeviceID != "DEV_NEW" || result.Rows[2].Status != "duplicate" || result.Rows[2].Line != 4 {
+		t.Fatalf("unexpected rows: %+v", result.Rows)
+	}
+	if len(result.Profiles) != 1 || result.Profiles[0].ProfileID != "P_DEV" || result.Profiles[0].NewProfileID != "P_DEV_2" || result.Profiles[0].Status != "regenerated" {
+		t.Fatalf("unexpected profiles: %+v", result.Profiles)
+	}
+	if !strings.Contains(strings.Join(requests, ","), "DELETE /v1/profiles/P_DEV,POST /v1/profiles") {
+		t.Fatalf("expected delete before recreate, got %v", requests)
+	}
+}
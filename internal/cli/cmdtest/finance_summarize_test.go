@@ -0,0 +1,94 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFinanceSummarizeConvertsRegionsAndAttributesParentApps(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	financialHeader := "Start Date\tEnd Date\tVendor Identifier\tQuantity\tPartner Share\tExtended Partner Share\tPartner Share Currency\tSales or Return\tApple Identifier\tTitle\tProduct Type Identifier\tCountry Of Sale"
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() { http.DefaultTransport = originalTransport })
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		switch req.URL.Path {
+		case "/v1/financeReports":
+			switch query.Get("filter[regionCode]") {
+			case "US":
+				return insightsGzipResponse(strings.Join([]string{
+					financialHeader,
+					"08/31/2026\t10/03/2026\tapp.sku\t2\t0.70\t1.40\tUSD\tS\t100\tMy App\t1F\tUS",
+					"08/31/2026\t10/03/2026\tpro.monthly\t1\t6.99\t6.99\tUSD\tS\t200\tPro\tIAY\tUS",
+					"Total_Amount\t8.39",
+				}, "\n")), nil
+			case "EU":
+				return insightsGzipResponse(strings.Join([]string{
+					financialHeader,
+					"08/31/2026\t10/03/2026\tpro.monthly\t2\t5.00\t10.00\tEUR\tS\t200\tPro\tIAY\tDE",
+					"Total_Amount\t10.00",
+				}, "\n")), nil
+			case "Z1":
+				return insightsGzipResponse("Partner Share Currency\tPayment Currency\tExchange Rate\nEUR\tUSD\t1.1\n"), nil
+			default:
+				return jsonResponse(http.StatusNotFound, `{"errors":[{"status":"404","code":"NOT_FOUND","title":"Not Found","detail":"no report"}]}`)
+			}
+		case "/v1/salesReports":
+			if query.Get("filter[frequency]") != "MONTHLY" || query.Get("filter[reportDate]") != "2026-09" {
+				t.Fatalf("unexpected sales report query %v", query)
+			}
+			return insightsGzipResponse(strings.Join([]string{
+				"SKU\tTitle\tApple Identifier\tParent Identifier",
+				"app.sku\tMy App\t100\t",
+				"pro.monthly\tPro\t200\tapp.sku",
+			}, "\n")), nil
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"finance", "summarize", "--vendor", "12345678", "--date", "2026-09", "--group-by", "app,territory"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+
+	var result struct {
+		Rows []struct {
+			App       string  `json:"app"`
+			Territory string  `json:"territory"`
+			Proceeds  float64 `json:"proceeds"`
+		} `json:"rows"`
+		Regions       []map[string]any `json:"regions"`
+		TotalProceeds float64          `json:"totalProceeds"`
+		Reconciled    bool             `json:"reconciled"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse output %q: %v", stdout, err)
+	}
+	if len(result.Regions) != 2 || !result.Reconciled || result.TotalProceeds != 19.39 {
+		t.Fatalf("unexpected summary %+v", result)
+	}
+	if len(result.Rows) != 2 || result.Rows[0].App != "My App" || result.Rows[0].Territory != "DE" || result.Rows[0].Proceeds != 11 {
+		t.Fatalf("unexpected rows %+v", result.Rows)
+	}
+	if result.Rows[1].App != "My App" || result.Rows[1].Territory != "US" || result.Rows[1].Proceeds != 8.39 {
+		t.Fatalf("unexpected rows %+v", result.Rows)
+	}
+}
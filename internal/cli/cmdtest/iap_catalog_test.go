@@ -0,0 +1,226 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const iapCatalogNotFoundBody = `{"errors":[{"status":"404","code":"NOT_FOUND","title":"Not Found","detail":"missing"}]}`
+
+func TestIAPCatalogValidationErrors(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "export missing app",
+			args:    []string{"iap", "catalog", "export", "--file", "catalog.yaml"},
+			wantErr: "Error: --app is required (or set ASC_APP_ID)",
+		},
+		{
+			name:    "export missing file",
+			args:    []string{"iap", "catalog", "export", "--app", "APP_ID"},
+			wantErr: "Error: --file is required",
+		},
+		{
+			name:    "apply missing file",
+			args:    []string{"iap", "catalog", "apply", "--app", "APP_ID"},
+			wantErr: "Error: --file is required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			stdout, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				err := root.Run(context.Background())
+				if !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			})
+
+			if stdout != "" {
+				t.Fatalf("expected empty stdout, got %q", stdout)
+			}
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected error %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
+
+func TestIAPCatalogApplyRejectsUnknownFields(t *testing.T) {
+	catalogPath := filepath.Join(t.TempDir(), "catalog.yaml")
+	if err := os.WriteFile(catalogPath, []byte("products:\n  - productId: a\n    tier: 3\n"), 0o600); err != nil {
+		t.Fatalf("write catalog: %v", err)
+	}
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	_, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"iap", "catalog", "apply", "--app", "APP_ID", "--file", catalogPath}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		err := root.Run(context.Background())
+		if !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected ErrHelp, got %v", err)
+		}
+	})
+
+	if !strings.Contains(stderr, "field tier not found") {
+		t.Fatalf("expected unknown field error, got %q", stderr)
+	}
+}
+
+func TestIAPCatalogApply(t *testing.T) {
+	catalog := `products:
+  - productId: com.example.coins
+    type: CONSUMABLE
+    referenceName: Coin Pack
+    localizations:
+      - locale: en-US
+        name: Coins
+  - productId: com.example.gems
+    type: CONSUMABLE
+    referenceName: Gems
+    localizations:
+      - locale: en-US
+        name: Gems
+        description: Shiny gems
+`
+
+	tests := []struct {
+		name        string
+		dryRun      bool
+		wantStatus  map[string]string
+		wantMethods []string
+	}{
+		{
+			name:       "dry run",
+			dryRun:     true,
+			wantStatus: map[string]string{"com.example.coins": "planned", "com.example.gems": "planned"},
+		},
+		{
+			name:       "apply",
+			wantStatus: map[string]string{"com.example.coins": "applied", "com.example.gems": "applied"},
+			wantMethods: []string{
+				"PATCH /v2/inAppPurchases/iap-1",
+				"POST /v2/inAppPurchases",
+				"POST /v1/inAppPurchaseLocalizations",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setupAuth(t)
+
+			catalogPath := filepath.Join(t.TempDir(), "catalog.yaml")
+			if err := os.WriteFile(catalogPath, []byte(catalog), 0o600); err != nil {
+				t.Fatalf("write catalog: %v", err)
+			}
+
+			originalTransport := http.DefaultTransport
+			t.Cleanup(func() {
+				http.DefaultTransport = originalTransport
+			})
+
+			var mutations []string
+			http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				if req.Method != http.MethodGet {
+					mutations = append(mutations, req.Method+" "+req.URL.Path)
+				}
+				switch {
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/apps/APP_ID/inAppPurchasesV2":
+					return jsonResponse(http.StatusOK, `{"data":[{"type":"inAppPurchases","id":"iap-1","attributes":{"name":"Coins","productId":"com.example.coins","inAppPurchaseType":"CONSUMABLE"}}],"links":{}}`)
+				case req.Method == http.MethodGet && req.URL.Path == "/v2/inAppPurchases/iap-1/inAppPurchaseLocalizations":
+					return jsonResponse(http.StatusOK, `{"data":[{"type":"inAppPurchaseLocalizations","id":"loc-1","attributes":{"locale":"en-US","name":"Coins"}}],"links":{}}`)
+				case req.Method == http.MethodGet && strings.HasPrefix(req.URL.Path, "/v2/inAppPurchases/iap-1/"):
+					return jsonResponse(http.StatusNotFound, iapCatalogNotFoundBody)
+				case req.Method == http.MethodPatch && req.URL.Path == "/v2/inAppPurchases/iap-1":
+					return jsonResponse(http.StatusOK, `{"data":{"type":"inAppPurchases","id":"iap-1","attributes":{"name":"Coin Pack","productId":"com.example.coins","inAppPurchaseType":"CONSUMABLE"}}}`)
+				case req.Method == http.MethodPost && req.URL.Path == "/v2/inAppPurchases":
+					return jsonResponse(http.StatusCreated, `{"data":{"type":"inAppPurchases","id":"iap-2","attributes":{"name":"Gems","productId":"com.example.gems","inAppPurchaseType":"CONSUMABLE"}}}`)
+				case req.Method == http.MethodPost && req.URL.Path == "/v1/inAppPurchaseLocalizations":
+					body, _ := io.ReadAll(req.Body)
+					if !strings.Contains(string(body), `"id":"iap-2"`) {
+						t.Fatalf("expected localization for created IAP, got %s", body)
+					}
+					return jsonResponse(http.StatusCreated, `{"data":{"type":"inAppPurchaseLocalizations","id":"loc-2","attributes":{"locale":"en-US","name":"Gems"}}}`)
+				default:
+					t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+					return nil, nil
+				}
+			})
+
+			args := []string{"iap", "catalog", "apply", "--app", "APP_ID", "--file", catalogPath}
+			if test.dryRun {
+				args = append(args, "--dry-run")
+			}
+
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			stdout, _ := captureOutput(t, func() {
+				if err := root.Parse(args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); err != nil {
+					t.Fatalf("run error: %v", err)
+				}
+			})
+
+			var result struct {
+				DryRun  bool `json:"dryRun"`
+				Changes []struct {
+					ProductID string `json:"productId"`
+					Action    string `json:"action"`
+				} `json:"changes"`
+				Items []struct {
+					ProductID string `json:"productId"`
+					IAPID     string `json:"iapId"`
+					Status    string `json:"status"`
+				} `json:"items"`
+				Failed int `json:"failed"`
+			}
+			if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+				t.Fatalf("decode output: %v (stdout=%q)", err, stdout)
+			}
+
+			if result.DryRun != test.dryRun {
+				t.Fatalf("expected dryRun=%t, got %t", test.dryRun, result.DryRun)
+			}
+			if result.Failed != 0 {
+				t.Fatalf("expected no failures, got %d", result.Failed)
+			}
+			if len(result.Changes) != 3 {
+				t.Fatalf("expected 3 changes, got %+v", result.Changes)
+			}
+			for _, item := range result.Items {
+				if item.Status != test.wantStatus[item.ProductID] {
+					t.Fatalf("expected %s status %q, got %q", item.ProductID, test.wantStatus[item.ProductID], item.Status)
+				}
+			}
+			if strings.Join(mutations, ",") != strings.Join(test.wantMethods, ",") {
+				t.Fatalf("expected mutations %v, got %v", test.wantMethods, mutations)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestInsightsSubscriptionsValidationErrors(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+	t.Setenv("ASC_VENDOR_NUMBER", "")
+	t.Setenv("ASC_ANALYTICS_VENDOR_NUMBER", "")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "missing app",
+			args:    []string{"insights", "subscriptions", "--vendor", "12345678", "--from", "2026-02-09", "--to", "2026-02-15"},
+			wantErr: "--app is required",
+		},
+		{
+			name:    "to before from",
+			args:    []string{"insights", "subscriptions", "--app", "app-1", "--vendor", "12345678", "--from", "2026-02-09", "--to", "2026-02-01"},
+			wantErr: "--to must not be before --from",
+		},
+		{
+			name:    "range too long",
+			args:    []string{"insights", "subscriptions", "--app", "app-1", "--vendor", "12345678", "--from", "2026-01-01", "--to", "2026-06-01"},
+			wantErr: "range must be at most 92 days",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			_, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			})
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected error %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
+
+func TestInsightsSubscriptionsJSON(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	t.Setenv("ASC_APP_ID", "")
+
+	eventReports := map[string]string{
+		"2026-02-03": "Event Date\tEvent\tApp Apple ID\tSubscription Name\tSubscription Apple ID\tCountry\tQuantity\n" +
+			"2026-02-03\tCancel\tapp-1\tPro\tsub-1\tUS\t5\n",
+		"2026-02-10": "Event Date\tEvent\tApp Apple ID\tSubscription Name\tSubscription Apple ID\tCountry\tQuantity\n" +
+			"2026-02-10\tCancel\tapp-1\tPro\tsub-1\tUS\t11\n" +
+			"2026-02-10\tStart Introductory Price\tapp-1\tPro\tsub-1\tDE\t4\n" +
+			"2026-02-10\tCancel\tapp-999\tOther\tsub-9\tUS\t50\n",
+	}
+	snapshotReports := map[string]string{
+		"2026-02-01": "App Apple ID\tSubscription Name\tSubscription Apple ID\tCountry\tActive Standard Price Subscriptions\n" +
+			"app-1\tPro\tsub-1\tUS\t100\n",
+		"2026-02-08": "App Apple ID\tSubscription Name\tSubscription Apple ID\tCountry\tActive Standard Price Subscriptions\n" +
+			"app-1\tPro\tsub-1\tUS\t110\n",
+		"2026-02-15": "App Apple ID\tSubscription Name\tSubscription Apple ID\tCountry\tActive Standard Price Subscriptions\n" +
+			"app-1\tPro\tsub-1\tUS\t105\n",
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/v1/salesReports" {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		}
+		query := req.URL.Query()
+		if query.Get("filter[frequency]") != "DAILY" || query.Get("filter[version]") != "1_3" {
+			t.Fatalf("unexpected report filters %v", query)
+		}
+		reportsByDate := eventReports
+		if query.Get("filter[reportType]") == "SUBSCRIPTION" {
+			reportsByDate = snapshotReports
+		}
+		if report, ok := reportsByDate[query.Get("filter[reportDate]")]; ok {
+			return insightsGzipResponse(report), nil
+		}
+		return jsonResponse(http.StatusNotFound, `{"errors":[{"status":"404","code":"NOT_FOUND","title":"Not Found","detail":"no report"}]}`)
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"insights", "subscriptions", "--app", "app-1", "--vendor", "12345678", "--from", "2026-02-09", "--to", "2026-02-15"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+
+	var payload struct {
+		Source struct {
+			MissingDates []string `json:"missingDates"`
+		} `json:"source"`
+		Groups []struct {
+			Subscription string `json:"subscription"`
+			Territory    string `json:"territory"`
+			Weeks        []struct {
+				Metrics []any `json:"metrics"`
+			} `json:"weeks"`
+		} `json:"groups"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &payload); err != nil {
+		t.Fatalf("unmarshal output: %v\nstdout=%s", err, stdout)
+	}
+
+	if !slices.Contains(payload.Source.MissingDates, "2026-02-02") || slices.Contains(payload.Source.MissingDates, "2026-02-10") {
+		t.Fatalf("unexpected missing dates %v", payload.Source.MissingDates)
+	}
+
+	var labels []string
+	for _, group := range payload.Groups {
+		labels = append(labels, group.Subscription+"/"+group.Territory)
+	}
+	if strings.Join(labels, ",") != "(all)/(all),Pro/(all),Pro/DE,Pro/US" {
+		t.Fatalf("unexpected groups %v", labels)
+	}
+
+	all := payload.Groups[0].Weeks[0].Metrics
+	churn := findMetric(t, all, "churn_rate")
+	if churn["thisWeek"] != 10.0 || churn["lastWeek"] != 5.0 || churn["status"] != "ok" {
+		t.Fatalf("unexpected churn rate: %v", churn)
+	}
+	trialStarts := findMetric(t, all, "trial_starts")
+	if trialStarts["thisWeek"] != 4.0 || trialStarts["lastWeek"] != 0.0 {
+		t.Fatalf("unexpected trial starts: %v", trialStarts)
+	}
+	cancellations := findMetric(t, payload.Groups[3].Weeks[0].Metrics, "cancellations")
+	if cancellations["thisWeek"] != 11.0 || cancellations["lastWeek"] != 5.0 {
+		t.Fatalf("unexpected US cancellations: %v", cancellations)
+	}
+}
@@ -0,0 +1,199 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInsightsTrendValidationErrors(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+	t.Setenv("ASC_VENDOR_NUMBER", "")
+	t.Setenv("ASC_ANALYTICS_VENDOR_NUMBER", "")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "missing app",
+			args:    []string{"insights", "trend", "--vendor", "12345678"},
+			wantErr: "--app is required",
+		},
+		{
+			name:    "missing vendor for sales",
+			args:    []string{"insights", "trend", "--app", "app-1"},
+			wantErr: "--vendor is required for --source sales",
+		},
+		{
+			name:    "weeks out of range",
+			args:    []string{"insights", "trend", "--app", "app-1", "--vendor", "12345678", "--weeks", "0"},
+			wantErr: "--weeks must be between 1 and 52",
+		},
+		{
+			name:    "invalid threshold",
+			args:    []string{"insights", "trend", "--app", "app-1", "--vendor", "12345678", "--threshold", "-1"},
+			wantErr: "--threshold must be greater than 0",
+		},
+		{
+			name:    "unknown metric",
+			args:    []string{"insights", "trend", "--app", "app-1", "--vendor", "12345678", "--metrics", "downloads,installs"},
+			wantErr: `unknown metric "installs" for --source sales`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			_, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			})
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected error %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
+
+func runInsightsTrendSales(t *testing.T, extraArgs ...string) string {
+	t.Helper()
+
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	t.Setenv("ASC_APP_ID", "")
+
+	downloadsByWeekEnd := map[string]int{
+		"2026-01-18": 100,
+		"2026-01-25": 100,
+		"2026-02-01": 104,
+		"2026-02-08": 96,
+		"2026-02-15": 101,
+		"2026-02-22": 40,
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/v1/apps/app-1":
+			return insightsJSONResponse(`{"data":{"type":"apps","id":"app-1","attributes":{"name":"Example App","bundleId":"com.example.app","sku":"example-sku-1"}}}`), nil
+		case "/v1/salesReports":
+			query := req.URL.Query()
+			if query.Get("filter[frequency]") != "WEEKLY" {
+				t.Fatalf("expected filter[frequency]=WEEKLY, got %q", query.Get("filter[frequency]"))
+			}
+			units, ok := downloadsByWeekEnd[query.Get("filter[reportDate]")]
+			if !ok {
+				t.Fatalf("unexpected report date filter %q", query.Get("filter[reportDate]"))
+			}
+			return insightsGzipResponse(strings.Join([]string{
+				"Provider\tSKU\tApple Identifier\tParent Identifier\tUnits\tDeveloper Proceeds\tCustomer Price",
+				fmt.Sprintf("foo\texample-sku-1\tapp-1\t\t%d\t0.00\t0.00", units),
+				"",
+			}, "\n")), nil
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	args := append([]string{
+		"insights", "trend", "--app", "app-1", "--vendor", "12345678",
+		"--week", "2026-02-16", "--weeks", "5", "--metrics", "downloads,active_devices,sessions",
+	}, extraArgs...)
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse(args); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+	return stdout
+}
+
+func TestInsightsTrendSalesJSON(t *testing.T) {
+	stdout := runInsightsTrendSales(t)
+
+	var payload struct {
+		Weeks  []map[string]string `json:"weeks"`
+		Series []struct {
+			Name      string `json:"name"`
+			Status    string `json:"status"`
+			Sparkline string `json:"sparkline"`
+			Points    []struct {
+				Week  string   `json:"week"`
+				Value *float64 `json:"value"`
+			} `json:"points"`
+			Anomalies []struct {
+				Week      string `json:"week"`
+				Direction string `json:"direction"`
+			} `json:"anomalies"`
+		} `json:"series"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &payload); err != nil {
+		t.Fatalf("unmarshal output: %v\nstdout=%s", err, stdout)
+	}
+
+	if len(payload.Weeks) != 5 || payload.Weeks[0]["start"] != "2026-01-19" || payload.Weeks[4]["start"] != "2026-02-16" {
+		t.Fatalf("unexpected weeks %v", payload.Weeks)
+	}
+	if len(payload.Series) != 3 {
+		t.Fatalf("expected three series, got %d", len(payload.Series))
+	}
+
+	downloads := payload.Series[0]
+	if downloads.Name != "download_units" || downloads.Status != "ok" || len(downloads.Points) != 5 {
+		t.Fatalf("unexpected downloads series %+v", downloads)
+	}
+	if value := downloads.Points[4].Value; value == nil || *value != 40 {
+		t.Fatalf("unexpected latest downloads point %v", value)
+	}
+	if len(downloads.Anomalies) != 1 || downloads.Anomalies[0].Week != "2026-02-16" || downloads.Anomalies[0].Direction != "drop" {
+		t.Fatalf("expected a drop in the last week, got %+v", downloads.Anomalies)
+	}
+	if downloads.Sparkline != "^^^^_" {
+		t.Fatalf("unexpected sparkline %q", downloads.Sparkline)
+	}
+
+	if devices := payload.Series[1]; devices.Name != "active_devices" || devices.Status != "unavailable" {
+		t.Fatalf("expected active_devices to be unavailable for sales, got %+v", devices)
+	}
+	if sessions := payload.Series[2]; sessions.Name != "sessions" || sessions.Status != "unavailable" || len(sessions.Points) != 5 {
+		t.Fatalf("expected sessions to be an unavailable series, got %+v", sessions)
+	}
+}
+
+func TestInsightsTrendTableOutput(t *testing.T) {
+	stdout := runInsightsTrendSales(t, "--output", "table")
+
+	for _, want := range []string{"download_units", "^^^^_", "ANOMALIES", "drop"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in table output, got:\n%s", want, stdout)
+		}
+	}
+}
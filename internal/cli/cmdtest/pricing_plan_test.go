@@ -0,0 +1,265 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const pricingPlanManualPricesBody = `{"data":[{"type":"appPrices","id":"price-1","attributes":{"startDate":"2024-01-01","manual":true},"relationships":{"appPricePoint":{"data":{"type":"appPricePoints","id":"pp-usa-499"}},"territory":{"data":{"type":"territories","id":"USA"}}}}],"included":[{"type":"appPricePoints","id":"pp-usa-499","attributes":{"customerPrice":"4.99"}},{"type":"territories","id":"USA"}],"links":{}}`
+
+func pricingPlanScheduleTransport(t *testing.T, onPost func(body []byte)) roundTripFunc {
+	t.Helper()
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/apps/APP_ID/appPriceSchedule":
+			return jsonResponse(http.StatusOK, `{"data":{"type":"appPriceSchedules","id":"schedule-1"}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/appPriceSchedules/schedule-1/baseTerritory":
+			return jsonResponse(http.StatusOK, `{"data":{"type":"territories","id":"USA","attributes":{"currency":"USD"}}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/appPriceSchedules/schedule-1/manualPrices":
+			if got := req.URL.Query().Get("include"); got != "appPricePoint,territory" {
+				t.Fatalf("expected include appPricePoint,territory, got %q", got)
+			}
+			return jsonResponse(http.StatusOK, pricingPlanManualPricesBody)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/apps/APP_ID/appPricePoints":
+			if got := req.URL.Query().Get("filter[territory]"); got != "USA" {
+				t.Fatalf("expected USA price points lookup, got %q", got)
+			}
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"appPricePoints","id":"pp-usa-299","attributes":{"customerPrice":"2.99"}},{"type":"appPricePoints","id":"pp-usa-499","attributes":{"customerPrice":"4.99"}}],"links":{}}`)
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/appPriceSchedules":
+			body, _ := io.ReadAll(req.Body)
+			onPost(body)
+			return jsonResponse(http.StatusCreated, `{"data":{"type":"appPriceSchedules","id":"schedule-2"}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+}
+
+func TestPricingPlanApplyValidationErrors(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+
+	planPath := filepath.Join(t.TempDir(), "sale.yaml")
+	if err := os.WriteFile(planPath, []byte("prices:\n  - territory: USA\n    price: \"2.99\"\n    startDate: \"2099-11-20\"\n    discount: 40\n"), 0o600); err != nil {
+		t.Fatalf("write plan: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "missing target",
+			args:    []string{"pricing", "plan", "apply", "--file", planPath},
+			wantErr: "Error: --app or --iap is required",
+		},
+		{
+			name:    "missing file",
+			args:    []string{"pricing", "plan", "apply", "--app", "APP_ID"},
+			wantErr: "Error: --file is required",
+		},
+		{
+			name:    "unknown field",
+			args:    []string{"pricing", "plan", "apply", "--app", "APP_ID", "--file", planPath},
+			wantErr: "field discount not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			_, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				err := root.Run(context.Background())
+				if !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			})
+
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected error %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
+
+func TestPricingPlanApply(t *testing.T) {
+	setupAuth(t)
+
+	planPath := filepath.Join(t.TempDir(), "sale.yaml")
+	plan := "prices:\n  - territory: usa\n    price: \"2.99\"\n    startDate: \"2099-11-20\"\n    endDate: \"2099-12-04\"\n"
+	if err := os.WriteFile(planPath, []byte(plan), 0o600); err != nil {
+		t.Fatalf("write plan: %v", err)
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	var posted struct {
+		Data struct {
+			Relationships struct {
+				BaseTerritory struct {
+					Data struct {
+						ID string `json:"id"`
+					} `json:"data"`
+				} `json:"baseTerritory"`
+			} `json:"relationships"`
+		} `json:"data"`
+		Included []struct {
+			Attributes struct {
+				StartDate string `json:"startDate"`
+				EndDate   string `json:"endDate"`
+			} `json:"attributes"`
+			Relationships struct {
+				AppPricePoint struct {
+					Data struct {
+						ID string `json:"id"`
+					} `json:"data"`
+				} `json:"appPricePoint"`
+			} `json:"relationships"`
+		} `json:"included"`
+	}
+	http.DefaultTransport = pricingPlanScheduleTransport(t, func(body []byte) {
+		if err := json.Unmarshal(body, &posted); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"pricing", "plan", "apply", "--app", "APP_ID", "--file", planPath}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		DryRun     bool   `json:"dryRun"`
+		ScheduleID string `json:"scheduleId"`
+		Prices     []struct {
+			Source string `json:"source"`
+		} `json:"prices"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode output: %v (stdout=%q)", err, stdout)
+	}
+	if result.DryRun || result.ScheduleID != "schedule-2" || len(result.Prices) != 3 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	if posted.Data.Relationships.BaseTerritory.Data.ID != "USA" {
+		t.Fatalf("expected base territory USA, got %q", posted.Data.Relationships.BaseTerritory.Data.ID)
+	}
+	var got []string
+	for _, item := range posted.Included {
+		got = append(got, item.Relationships.AppPricePoint.Data.ID+"|"+item.Attributes.StartDate+"|"+item.Attributes.EndDate)
+	}
+	want := "pp-usa-499||2099-11-20,pp-usa-299|2099-11-20|2099-12-04,pp-usa-499|2099-12-04|"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("expected posted prices %s, got %s", want, strings.Join(got, ","))
+	}
+}
+
+func TestPricingPlanApplyDryRunRejectsConflicts(t *testing.T) {
+	setupAuth(t)
+
+	planPath := filepath.Join(t.TempDir(), "sale.yaml")
+	plan := "prices:\n  - territory: USA\n    price: \"2.99\"\n    startDate: \"2099-11-20\"\n"
+	if err := os.WriteFile(planPath, []byte(plan), 0o600); err != nil {
+		t.Fatalf("write plan: %v", err)
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/v1/apps/APP_ID/appPriceSchedule":
+			return jsonResponse(http.StatusOK, `{"data":{"type":"appPriceSchedules","id":"schedule-1"}}`)
+		case "/v1/appPriceSchedules/schedule-1/baseTerritory":
+			return jsonResponse(http.StatusOK, `{"data":{"type":"territories","id":"USA"}}`)
+		case "/v1/appPriceSchedules/schedule-1/manualPrices":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"appPrices","id":"price-2","attributes":{"startDate":"2099-12-01"},"relationships":{"appPricePoint":{"data":{"type":"appPricePoints","id":"pp-usa-599"}},"territory":{"data":{"type":"territories","id":"USA"}}}}],"included":[{"type":"appPricePoints","id":"pp-usa-599","attributes":{"customerPrice":"5.99"}}],"links":{}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	var runErr error
+	captureOutput(t, func() {
+		if err := root.Parse([]string{"pricing", "plan", "apply", "--app", "APP_ID", "--file", planPath, "--dry-run"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+
+	if runErr == nil || !strings.Contains(runErr.Error(), "overlaps scheduled price 5.99 from 2099-12-01") {
+		t.Fatalf("expected overlap error, got %v", runErr)
+	}
+}
+
+func TestPricingScheduleCalendar(t *testing.T) {
+	setupAuth(t)
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = pricingPlanScheduleTransport(t, func(body []byte) {
+		t.Fatalf("unexpected schedule create: %s", body)
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"pricing", "schedule", "calendar", "--app", "APP_ID"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		ScheduleID string `json:"scheduleId"`
+		Events     []struct {
+			Territory string `json:"territory"`
+			Price     string `json:"price"`
+			Current   bool   `json:"current"`
+		} `json:"events"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode output: %v (stdout=%q)", err, stdout)
+	}
+	if result.ScheduleID != "schedule-1" || len(result.Events) != 1 {
+		t.Fatalf("unexpected calendar: %+v", result)
+	}
+	if event := result.Events[0]; event.Territory != "USA" || event.Price != "4.99" || !event.Current {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+}
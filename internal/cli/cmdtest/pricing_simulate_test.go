@@ -0,0 +1,131 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestPricingSimulateApp(t *testing.T) {
+	setupAuth(t)
+
+	encode := func(payload string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(payload))
+	}
+	usaCurrent := encode(`{"s":"APP_ID","t":"USA","p":"10003"}`)
+	usaNew := encode(`{"s":"APP_ID","t":"USA","p":"10005"}`)
+	gbrCurrent := encode(`{"s":"APP_ID","t":"GBR","p":"10003"}`)
+	gbrNew := encode(`{"s":"APP_ID","t":"GBR","p":"10005"}`)
+	manualPrice := encode(`{"s":"APP_ID","t":"USA","p":"10003","sd":0,"ed":0}`)
+	gbrManualPrice := encode(`{"s":"APP_ID","t":"GBR","p":"10001","sd":0,"ed":0}`)
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet {
+			t.Fatalf("unexpected mutation: %s %s", req.Method, req.URL.Path)
+		}
+		switch req.URL.Path {
+		case "/v1/apps/APP_ID/appPricePoints":
+			if got := req.URL.Query().Get("filter[territory]"); got != "USA" {
+				t.Fatalf("expected USA price points lookup, got %q", got)
+			}
+			return jsonResponse(http.StatusOK, fmt.Sprintf(`{"data":[{"type":"appPricePoints","id":%q,"attributes":{"customerPrice":"2.99","proceeds":"2.09"}},{"type":"appPricePoints","id":%q,"attributes":{"customerPrice":"4.99","proceeds":"3.49"}}],"links":{}}`, usaCurrent, usaNew))
+		case "/v3/appPricePoints/" + usaNew + "/equalizations":
+			return jsonResponse(http.StatusOK, fmt.Sprintf(`{"data":[{"type":"appPricePoints","id":%q,"attributes":{"customerPrice":"4.99","proceeds":"3.33"}}],"links":{}}`, gbrNew))
+		case "/v3/appPricePoints/" + usaCurrent + "/equalizations":
+			return jsonResponse(http.StatusOK, fmt.Sprintf(`{"data":[{"type":"appPricePoints","id":%q,"attributes":{"customerPrice":"2.99","proceeds":"2.00"}}],"links":{}}`, gbrCurrent))
+		case "/v1/apps/APP_ID/appPriceSchedule":
+			return jsonResponse(http.StatusOK, `{"data":{"type":"appPriceSchedules","id":"schedule-1"}}`)
+		case "/v1/appPriceSchedules/schedule-1/manualPrices":
+			// The USA price is only on the second page.
+			if req.URL.Query().Get("cursor") == "" {
+				return jsonResponse(http.StatusOK, fmt.Sprintf(`{"data":[{"type":"appPrices","id":%q,"attributes":{"manual":true}}],"links":{"next":"https://api.appstoreconnect.apple.com/v1/appPriceSchedules/schedule-1/manualPrices?cursor=2"}}`, gbrManualPrice))
+			}
+			return jsonResponse(http.StatusOK, fmt.Sprintf(`{"data":[{"type":"appPrices","id":%q,"attributes":{"manual":true}}],"links":{}}`, manualPrice))
+		case "/v1/territories":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"territories","id":"USA","attributes":{"currency":"USD"}},{"type":"territories","id":"GBR","attributes":{"currency":"GBP"}}],"links":{}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"pricing", "simulate", "--app", "APP_ID", "--base-territory", "usa", "--price", "4.99"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		PricePointID        string `json:"pricePointId"`
+		CurrentPricePointID string `json:"currentPricePointId"`
+		Territories         []struct {
+			Territory    string `json:"territory"`
+			Currency     string `json:"currency"`
+			CurrentPrice string `json:"currentPrice"`
+			NewPrice     string `json:"newPrice"`
+			NewProceeds  string `json:"newProceeds"`
+			Changed      bool   `json:"changed"`
+		} `json:"territories"`
+		Changed int `json:"changed"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode output: %v (stdout=%q)", err, stdout)
+	}
+
+	if result.PricePointID != usaNew || result.CurrentPricePointID != usaCurrent {
+		t.Fatalf("unexpected price point IDs: %+v", result)
+	}
+	if result.Changed != 2 || len(result.Territories) != 2 {
+		t.Fatalf("expected 2 changed territories, got %+v", result)
+	}
+	gbr := result.Territories[0]
+	if gbr.Territory != "GBR" || gbr.Currency != "GBP" || gbr.CurrentPrice != "2.99" || gbr.NewPrice != "4.99" || gbr.NewProceeds != "3.33" || !gbr.Changed {
+		t.Fatalf("unexpected GBR row: %+v", gbr)
+	}
+}
+
+func TestPricingSimulatePriceNotFound(t *testing.T) {
+	setupAuth(t)
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/v1/subscriptions/SUB_ID/pricePoints" {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"subscriptionPricePoints","id":"pp-1","attributes":{"customerPrice":"9.99"}}],"links":{}}`)
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	var runErr error
+	captureOutput(t, func() {
+		if err := root.Parse([]string{"pricing", "simulate", "--subscription", "SUB_ID", "--base-territory", "USA", "--price", "4.99"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+
+	if runErr == nil {
+		t.Fatal("expected error for unknown price, got nil")
+	}
+}
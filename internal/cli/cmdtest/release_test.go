@@ -0,0 +1,228 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/release"
+)
+
+func TestReleaseRunValidationErrors(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+
+	configPath := filepath.Join(t.TempDir(), "release.yaml")
+	if err := os.WriteFile(configPath, []byte("app: \"1\"\nversion: \"1.0\"\nsubmit: true\nrollout: 10\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "missing config",
+			args:    []string{"release", "run"},
+			wantErr: "Error: --config is required",
+		},
+		{
+			name:    "unknown field",
+			args:    []string{"release", "run", "--config", configPath},
+			wantErr: "field rollout not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			_, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				err := root.Run(context.Background())
+				if !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			})
+
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected error %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
+
+func TestReleaseRunWaitsForReviewAndResumes(t *testing.T) {
+	setupAuth(t)
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "release.yaml")
+	config := `app: "APP_ID"
+version: "2.1.0"
+publish:
+  ipa: ./App.ipa
+submit: true
+waitForReview:
+  pollInterval: 1ms
+notify:
+  slack:
+    message: "2.1.0 approved"
+`
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	states := []string{"WAITING_FOR_REVIEW", "IN_REVIEW", "PENDING_DEVELOPER_RELEASE"}
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet || req.URL.Path != "/v1/appStoreVersions/VERSION_ID" {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		}
+		state := states[0]
+		if len(states) > 1 {
+			states = states[1:]
+		}
+		return jsonResponse(http.StatusOK, `{"data":{"type":"appStoreVersions","id":"VERSION_ID","attributes":{"versionString":"2.1.0","appVersionState":"`+state+`"}}}`)
+	})
+
+	var calls []string
+	failNotify := true
+	restore := release.SetStageRunner(func(ctx context.Context, args []string) ([]byte, error) {
+		calls = append(calls, strings.Join(args, " "))
+		switch args[0] {
+		case "publish":
+			return []byte(`{"buildId":"BUILD_ID","versionId":"VERSION_ID","uploaded":true,"attached":true}`), nil
+		case "submit":
+			return []byte(`{"submissionId":"SUB_ID","versionId":"VERSION_ID","buildId":"BUILD_ID"}`), nil
+		case "notify":
+			if failNotify {
+				return nil, errors.New("webhook unreachable")
+			}
+			return nil, nil
+		}
+		t.Fatalf("unexpected stage command: %v", args)
+		return nil, nil
+	})
+	defer restore()
+
+	run := func() (string, error) {
+		root := RootCommand("1.2.3")
+		root.FlagSet.SetOutput(io.Discard)
+
+		var runErr error
+		stdout, _ := captureOutput(t, func() {
+			if err := root.Parse([]string{"release", "run", "--config", configPath}); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			runErr = root.Run(context.Background())
+		})
+		return stdout, runErr
+	}
+
+	stdout, err := run()
+	if err == nil || !strings.Contains(err.Error(), "stage notify failed") {
+		t.Fatalf("expected notify failure, got %v", err)
+	}
+
+	var result struct {
+		Status       string `json:"status"`
+		StatePath    string `json:"statePath"`
+		SubmissionID string `json:"submissionId"`
+		Stages       []struct {
+			Name   string `json:"name"`
+			Status string `json:"status"`
+			Detail string `json:"detail"`
+		} `json:"stages"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode output: %v (stdout=%q)", err, stdout)
+	}
+	if result.Status != "failed" || result.SubmissionID != "SUB_ID" || result.StatePath != filepath.Join(dir, "release.state.json") {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(result.Stages) != 4 || result.Stages[2].Detail != "PENDING_DEVELOPER_RELEASE" || result.Stages[3].Status != "failed" {
+		t.Fatalf("unexpected stages: %+v", result.Stages)
+	}
+	if !strings.Contains(calls[0], "--ipa "+filepath.Join(dir, "App.ipa")) {
+		t.Fatalf("expected IPA path relative to config, got %q", calls[0])
+	}
+	if !strings.Contains(calls[1], "--version-id VERSION_ID --build BUILD_ID") {
+		t.Fatalf("expected submit to use published IDs, got %q", calls[1])
+	}
+
+	failNotify = false
+	calls = nil
+	stdout, err = run()
+	if err != nil {
+		t.Fatalf("resume error: %v", err)
+	}
+	if len(calls) != 1 || !strings.HasPrefix(calls[0], "notify slack") {
+		t.Fatalf("expected only notify to run on resume, got %v", calls)
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode output: %v (stdout=%q)", err, stdout)
+	}
+	if result.Status != "completed" {
+		t.Fatalf("expected completed run, got %+v", result)
+	}
+}
+
+func TestReleaseRunForwardsRootFlagsToStages(t *testing.T) {
+	setupAuth(t)
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "release.yaml")
+	config := `app: "APP_ID"
+version: "2.1.0"
+notify:
+  slack:
+    message: "2.1.0 shipped"
+`
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	var calls [][]string
+	restore := release.SetStageRunner(func(ctx context.Context, args []string) ([]byte, error) {
+		calls = append(calls, args)
+		return nil, nil
+	})
+	defer restore()
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	captureOutput(t, func() {
+		args := []string{"--profile", "staging", "--strict-auth", "--api-debug", "release", "run", "--config", configPath}
+		if err := root.Parse(args); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	if len(calls) != 1 {
+		t.Fatalf("expected one stage command, got %v", calls)
+	}
+	got := strings.Join(calls[0], " ")
+	if !strings.HasPrefix(got, "--profile=staging --strict-auth=true --api-debug=true notify slack") {
+		t.Fatalf("expected root flags before the stage command, got %q", got)
+	}
+	if strings.Contains(got, "--debug") {
+		t.Fatalf("expected unset root flags to be left out, got %q", got)
+	}
+}
@@ -0,0 +1,130 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runReportRowsCommand(t *testing.T, args []string, report string) (string, string) {
+	t.Helper()
+
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	t.Chdir(t.TempDir())
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() { http.DefaultTransport = originalTransport })
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/v1/salesReports", "/v1/financeReports":
+			return insightsGzipResponse(report), nil
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	return captureOutput(t, func() {
+		if err := root.Parse(args); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+}
+
+func TestAnalyticsSalesOutputJSONPrintsParsedRows(t *testing.T) {
+	stdout, _ := runReportRowsCommand(t, []string{
+		"analytics", "sales", "--vendor", "12345678", "--type", "SALES", "--subtype", "SUMMARY",
+		"--frequency", "DAILY", "--date", "2026-01-02", "--output", "json",
+	}, strings.Join([]string{
+		"Provider\tSKU\tProduct Type Identifier\tUnits\tDeveloper Proceeds\tBegin Date\tApple Identifier",
+		"APPLE\tapp.sku\t1F\t12\t0\t01/02/2026\t123",
+		"",
+	}, "\n"))
+
+	var rows []map[string]any
+	if err := json.Unmarshal([]byte(stdout), &rows); err != nil {
+		t.Fatalf("failed to parse output %q: %v", stdout, err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected one row, got %d", len(rows))
+	}
+	row := rows[0]
+	if row["units"] != float64(12) || row["beginDate"] != "2026-01-02" || row["productCategory"] != "app" || row["sku"] != "app.sku" {
+		t.Fatalf("unexpected row %v", row)
+	}
+	if entries, _ := os.ReadDir("."); len(entries) != 0 {
+		t.Fatalf("expected no report file to be written, found %d entries", len(entries))
+	}
+}
+
+func TestFinanceReportsOutputCSVPrintsParsedRows(t *testing.T) {
+	stdout, _ := runReportRowsCommand(t, []string{
+		"finance", "reports", "--vendor", "12345678", "--report-type", "FINANCIAL", "--region", "ZZ",
+		"--date", "2025-12", "--output", "csv",
+	}, strings.Join([]string{
+		"Start Date\tEnd Date\tVendor Identifier\tQuantity\tPartner Share\tExtended Partner Share\tPartner Share Currency\tSales or Return",
+		"11/30/2025\t01/03/2026\tpro.monthly\t3\t6.99\t20.97\tUSD\tS",
+		"",
+		"Total_Rows\t1",
+	}, "\n"))
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected header and one row, got %q", stdout)
+	}
+	if !strings.HasPrefix(lines[0], "startDate,endDate,") {
+		t.Fatalf("unexpected header %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "2025-11-30,2026-01-03,") || !strings.Contains(lines[1], ",3,6.99,20.97,USD,S,") {
+		t.Fatalf("unexpected row %q", lines[1])
+	}
+}
+
+func TestReportRowsOutputValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "unsupported sales type",
+			args:    []string{"analytics", "sales", "--vendor", "1", "--type", "PRE_ORDER", "--subtype", "SUMMARY", "--frequency", "DAILY", "--date", "2026-01-02", "--output", "csv"},
+			wantErr: "--output csv supports SALES, SUBSCRIPTION, and SUBSCRIPTION_EVENT reports",
+		},
+		{
+			name:    "finance decompress",
+			args:    []string{"finance", "reports", "--vendor", "1", "--report-type", "FINANCIAL", "--region", "ZZ", "--date", "2025-12", "--output", "json", "--decompress"},
+			wantErr: "--decompress cannot be used with --output json",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+			_, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected flag.ErrHelp, got %v", err)
+				}
+			})
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected %q in stderr, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
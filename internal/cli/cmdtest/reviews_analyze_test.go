@@ -0,0 +1,217 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func reviewsAnalyzeTransport(t *testing.T) (*[]string, func()) {
+	t.Helper()
+
+	var requests []string
+	originalTransport := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.URL.Path)
+		switch req.URL.Path {
+		case "/v1/apps/app-1/customerReviews":
+			if req.URL.Query().Get("cursor") == "" {
+				if req.URL.Query().Get("sort") != "-createdDate" {
+					t.Fatalf("expected newest-first sort, got %q", req.URL.RawQuery)
+				}
+				return jsonResponse(http.StatusOK, `{"data":[
+					{"type":"customerReviews","id":"r1","attributes":{"rating":1,"title":"Login broken","body":"Login broken since the update.","territory":"USA","createdDate":"2026-02-10T00:00:00Z"}},
+					{"type":"customerReviews","id":"r2","attributes":{"rating":2,"title":"Meh","body":"login broken again","territory":"GBR","createdDate":"2026-02-05T00:00:00Z"}}
+				],"links":{"next":"https://api.appstoreconnect.apple.com/v1/apps/app-1/customerReviews?cursor=2"}}`)
+			}
+			return jsonResponse(http.StatusOK, `{"data":[
+				{"type":"customerReviews","id":"r3","attributes":{"rating":5,"title":"Wunderbar","body":"Sehr schnell","territory":"DEU","createdDate":"2026-01-20T00:00:00Z"}},
+				{"type":"customerReviews","id":"r4","attributes":{"rating":4,"title":"Old","body":"Older review","territory":"USA","createdDate":"2025-12-20T00:00:00Z"}}
+			],"links":{}}`)
+		case "/v1/apps/app-1/appStoreVersions":
+			if req.URL.Query().Get("filter[platform]") != "IOS" {
+				t.Fatalf("expected IOS platform filter, got %q", req.URL.RawQuery)
+			}
+			return jsonResponse(http.StatusOK, `{"data":[
+				{"type":"appStoreVersions","id":"v3","attributes":{"versionString":"1.2","appStoreState":"PREPARE_FOR_SUBMISSION","createdDate":"2026-02-08T00:00:00Z"}},
+				{"type":"appStoreVersions","id":"v2","attributes":{"versionString":"1.1","appStoreState":"READY_FOR_SALE","createdDate":"2026-01-15T00:00:00Z","earliestReleaseDate":"2026-02-01T00:00:00Z"}},
+				{"type":"appStoreVersions","id":"v1","attributes":{"versionString":"1.0","appStoreState":"REPLACED_WITH_NEW_VERSION","createdDate":"2025-12-01T00:00:00Z"}}
+			],"links":{}}`)
+		case "/v1/apps/app-1/reviewSubmissions":
+			query := req.URL.Query()
+			if query.Get("filter[state]") != "COMPLETE" || query.Get("include") != "appStoreVersionForReview" {
+				t.Fatalf("expected completed submissions with their versions, got %q", req.URL.RawQuery)
+			}
+			return jsonResponse(http.StatusOK, `{"data":[
+				{"type":"reviewSubmissions","id":"rs3","attributes":{"platform":"IOS","state":"COMPLETE","submittedDate":"2026-01-25T00:00:00Z"},"relationships":{"appStoreVersionForReview":{"data":{"type":"appStoreVersions","id":"v2"}}}},
+				{"type":"reviewSubmissions","id":"rs2","attributes":{"platform":"IOS","state":"COMPLETE","submittedDate":"2026-01-10T00:00:00Z"},"relationships":{"appStoreVersionForReview":{"data":{"type":"appStoreVersions","id":"v1"}}}},
+				{"type":"reviewSubmissions","id":"rs1","attributes":{"platform":"IOS","state":"COMPLETE","submittedDate":"2026-01-05T00:00:00Z"},"relationships":{"appStoreVersionForReview":{"data":{"type":"appStoreVersions","id":"v1"}}}}
+			],"links":{}}`)
+		case "/v1/apps/app-1/customerReviewSummarizations":
+			return jsonResponse(http.StatusOK, `{"data":[
+				{"type":"customerReviewSummarizations","id":"s1","attributes":{"platform":"IOS","locale":"en-US","createdDate":"2026-02-11T00:00:00Z","text":"People report login problems."},"relationships":{"territory":{"data":{"type":"territories","id":"USA"}}}}
+			],"links":{}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+	return &requests, func() { http.DefaultTransport = originalTransport }
+}
+
+func TestReviewsAnalyzeJSON(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	_, restore := reviewsAnalyzeTransport(t)
+	t.Cleanup(restore)
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"reviews", "analyze", "--app", "app-1", "--since", "2026-01-01"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		Reviews            int     `json:"reviews"`
+		AverageRating      float64 `json:"averageRating"`
+		VersionAttribution string  `json:"versionAttribution"`
+		Versions           []struct {
+			Version string `json:"version"`
+			Reviews int    `json:"reviews"`
+		} `json:"versions"`
+		Groups []struct {
+			Version   string `json:"version"`
+			Territory string `json:"territory"`
+		} `json:"groups"`
+		Keywords []struct {
+			Bucket   string `json:"bucket"`
+			Keywords []struct {
+				Term    string `json:"term"`
+				Reviews int    `json:"reviews"`
+			} `json:"keywords"`
+			Bigrams []struct {
+				Term string `json:"term"`
+			} `json:"bigrams"`
+		} `json:"keywords"`
+		Summarizations []struct {
+			Territory string `json:"territory"`
+			Text      string `json:"text"`
+		} `json:"summarizations"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse output %q: %v", stdout, err)
+	}
+	if result.Reviews != 3 {
+		t.Fatalf("expected reviews before --since to be dropped, got %d", result.Reviews)
+	}
+	if len(result.Versions) != 2 || result.Versions[0].Version != "1.1" || result.Versions[0].Reviews != 2 || result.Versions[1].Version != "1.0" {
+		t.Fatalf("expected versions grouped by release date, got %+v", result.Versions)
+	}
+	if !strings.HasPrefix(result.VersionAttribution, "approximate") {
+		t.Fatalf("expected version grouping to be labeled approximate, got %q", result.VersionAttribution)
+	}
+	if len(result.Groups) != 3 {
+		t.Fatalf("unexpected groups %+v", result.Groups)
+	}
+	negative := result.Keywords[0]
+	if negative.Bucket != "negative" || len(negative.Keywords) == 0 || negative.Keywords[0].Term != "broken" || negative.Keywords[0].Reviews != 2 {
+		t.Fatalf("unexpected negative keywords %+v", negative)
+	}
+	if len(negative.Bigrams) != 1 || negative.Bigrams[0].Term != "login broken" {
+		t.Fatalf("unexpected negative bigrams %+v", negative.Bigrams)
+	}
+	if len(result.Summarizations) != 1 || result.Summarizations[0].Territory != "USA" {
+		t.Fatalf("unexpected summarizations %+v", result.Summarizations)
+	}
+}
+
+func TestReviewsAnalyzeMarkdownWithoutSummarizations(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	requests, restore := reviewsAnalyzeTransport(t)
+	t.Cleanup(restore)
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"reviews", "analyze", "--app", "app-1", "--summarizations=false", "--output", "markdown"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	for _, want := range []string{"### Overview", "### By Version (approximate)", "### By Version and Territory", "### Top Keywords", "login broken (2)", "| unknown |"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+	if strings.Contains(stdout, "Review Summarizations") {
+		t.Fatalf("expected no summarizations section:\n%s", stdout)
+	}
+	for _, path := range *requests {
+		if strings.HasSuffix(path, "/customerReviewSummarizations") {
+			t.Fatal("expected summarizations not to be fetched")
+		}
+	}
+}
+
+func TestReviewsAnalyzeValidationErrors(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "missing app",
+			args:    []string{"reviews", "analyze"},
+			wantErr: "--app is required",
+		},
+		{
+			name:    "invalid since",
+			args:    []string{"reviews", "analyze", "--app", "app-1", "--since", "01/02/2026"},
+			wantErr: "--since must be in YYYY-MM-DD format",
+		},
+		{
+			name:    "invalid top",
+			args:    []string{"reviews", "analyze", "--app", "app-1", "--top", "0"},
+			wantErr: "--top must be between 1 and 100",
+		},
+		{
+			name:    "invalid platform",
+			args:    []string{"reviews", "analyze", "--app", "app-1", "--platform", "ANDROID"},
+			wantErr: "--platform must be one of",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+			_, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected flag.ErrHelp, got %v", err)
+				}
+			})
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected %q in stderr, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReviewsRatingsAllWritesSnapshotForHistory(t *testing.T) {
+	historyDir := t.TempDir()
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host != "itunes.apple.com" {
+			t.Fatalf("unexpected request: %s", req.URL.String())
+		}
+		if req.URL.Path != "/lookup" {
+			return jsonResponse(http.StatusNotFound, "")
+		}
+		switch req.URL.Query().Get("country") {
+		case "us":
+			return jsonResponse(http.StatusOK, `{"resultCount":1,"results":[{"trackId":123,"trackName":"Demo","averageUserRating":4.4,"userRatingCount":1100,"version":"1.1"}]}`)
+		case "gb":
+			return jsonResponse(http.StatusOK, `{"resultCount":1,"results":[{"trackId":123,"trackName":"Demo","averageUserRating":4.6,"userRatingCount":210,"version":"1.1"}]}`)
+		default:
+			return jsonResponse(http.StatusOK, `{"resultCount":1,"results":[{"trackId":123,"trackName":"Demo","averageUserRating":0,"userRatingCount":0,"version":"1.1"}]}`)
+		}
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	captureOutput(t, func() {
+		if err := root.Parse([]string{"reviews", "ratings", "--app", "123", "--all", "--history-dir", historyDir}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	entries, err := os.ReadDir(filepath.Join(historyDir, "123"))
+	if err != nil || len(entries) != 1 || !strings.HasSuffix(entries[0].Name(), "Z.json") {
+		t.Fatalf("expected one snapshot, got %v (%v)", entries, err)
+	}
+
+	// An older snapshot from before the 1.1 release.
+	older := `{"capturedAt":"2020-01-01T00:00:00Z","ratings":{"appId":123,"appName":"Demo","averageRating":4.7,"totalCount":1200,"countryCount":2,"version":"1.0","byCountry":[
+		{"appId":123,"appName":"Demo","country":"US","averageRating":4.6,"ratingCount":1000,"version":"1.0"},
+		{"appId":123,"appName":"Demo","country":"GB","averageRating":4.58,"ratingCount":200,"version":"1.0"}
+	]}}`
+	if err := os.WriteFile(filepath.Join(historyDir, "123", "2020-01-01T000000Z.json"), []byte(older), 0o600); err != nil {
+		t.Fatalf("write snapshot: %v", err)
+	}
+
+	root = RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"reviews", "ratings", "history", "--app", "123", "--history-dir", historyDir}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		AppName   string `json:"appName"`
+		Snapshots []struct {
+			Version    string `json:"version"`
+			CountDelta int64  `json:"countDelta"`
+		} `json:"snapshots"`
+		Countries []struct {
+			Country    string `json:"country"`
+			CountDelta int64  `json:"countDelta"`
+			Dropped    bool   `json:"droppedAfterRelease"`
+		} `json:"countries"`
+		Drops []struct {
+			Country      string  `json:"country"`
+			FromVersion  string  `json:"fromVersion"`
+			ToVersion    string  `json:"toVersion"`
+			AverageDelta float64 `json:"averageDelta"`
+			NewAverage   float64 `json:"newRatingsAverage"`
+		} `json:"drops"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse output %q: %v", stdout, err)
+	}
+	if result.AppName != "Demo" || len(result.Snapshots) != 2 || result.Snapshots[1].Version != "1.1" || result.Snapshots[1].CountDelta != 110 {
+		t.Fatalf("unexpected snapshots %+v", result)
+	}
+	if len(result.Countries) != 2 || result.Countries[0].Country != "US" || result.Countries[0].CountDelta != 100 || !result.Countries[0].Dropped || result.Countries[1].Dropped {
+		t.Fatalf("unexpected countries %+v", result.Countries)
+	}
+	if len(result.Drops) != 1 || result.Drops[0].Country != "US" || result.Drops[0].FromVersion != "1.0" || result.Drops[0].ToVersion != "1.1" || result.Drops[0].AverageDelta != -0.2 || result.Drops[0].NewAverage != 2.4 {
+		t.Fatalf("unexpected drops %+v", result.Drops)
+	}
+
+	root = RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, _ = captureOutput(t, func() {
+		if err := root.Parse([]string{"reviews", "ratings", "history", "--app", "123", "--history-dir", historyDir, "--output", "table"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, "DROPS AFTER RELEASE") || !strings.Contains(stdout, "1.0 -> 1.1") {
+		t.Fatalf("expected drops section in table output:\n%s", stdout)
+	}
+}
+
+func TestReviewsRatingsHistoryWithoutSnapshots(t *testing.T) {
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	captureOutput(t, func() {
+		if err := root.Parse([]string{"reviews", "ratings", "history", "--app", "123", "--history-dir", t.TempDir()}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		err := root.Run(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "no snapshots for app 123") {
+			t.Fatalf("expected missing snapshots error, got %v", err)
+		}
+	})
+}
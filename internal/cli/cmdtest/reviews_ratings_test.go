@@ -25,6 +25,26 @@ func TestReviewsRatingsValidationErrors(t *testing.T) {
 			args:    []string{"reviews", "ratings", "--app", "123", "--workers", "0"},
 			wantErr: "--workers must be at least 1",
 		},
+		{
+			name:    "reviews ratings history-dir without all",
+			args:    []string{"reviews", "ratings", "--app", "123", "--history-dir", "history"},
+			wantErr: "--history-dir requires --all",
+		},
+		{
+			name:    "reviews ratings history missing app",
+			args:    []string{"reviews", "ratings", "history", "--history-dir", "history"},
+			wantErr: "--app is required",
+		},
+		{
+			name:    "reviews ratings history missing dir",
+			args:    []string{"reviews", "ratings", "history", "--app", "123"},
+			wantErr: "--history-dir is required",
+		},
+		{
+			name:    "reviews ratings history negative min-drop",
+			args:    []string{"reviews", "ratings", "history", "--app", "123", "--history-dir", "history", "--min-drop", "-1"},
+			wantErr: "--min-drop must be zero or greater",
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,251 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const reviewsTriageTestRules = `rules:
+  - name: crash
+    stars: [1, 2]
+    keywords: [crash]
+    response: "Sorry {{.Nickname}}, please email support@example.com."
+    responses:
+      de: "Entschuldigung {{.Nickname}}!"
+  - name: praise
+    stars: [5]
+    skip: true
+`
+
+type reviewsTriageTestResult struct {
+	NextCursor string `json:"nextCursor"`
+	Applied    bool   `json:"applied"`
+	Summary    struct {
+		Reviews         int `json:"reviews"`
+		Respond         int `json:"respond"`
+		Skipped         int `json:"skipped"`
+		Unmatched       int `json:"unmatched"`
+		AlreadyAnswered int `json:"alreadyAnswered"`
+		Failed          int `json:"failed"`
+	} `json:"summary"`
+	Items []struct {
+		ReviewID   string `json:"reviewId"`
+		Language   string `json:"language"`
+		Rule       string `json:"rule"`
+		Action     string `json:"action"`
+		Response   string `json:"response"`
+		ResponseID string `json:"responseId"`
+	} `json:"items"`
+}
+
+func runReviewsTriage(t *testing.T, args []string) reviewsTriageTestResult {
+	t.Helper()
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse(args); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result reviewsTriageTestResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse output %q: %v", stdout, err)
+	}
+	return result
+}
+
+func TestReviewsTriagePlanApplyAndRerun(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	dir := t.TempDir()
+	rulesPath := filepath.Join(dir, "rules.yaml")
+	statePath := filepath.Join(dir, "state", "triage.json")
+	if err := os.WriteFile(rulesPath, []byte(reviewsTriageTestRules), 0o600); err != nil {
+		t.Fatalf("write rules: %v", err)
+	}
+
+	var posted []string
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/apps/app-1/customerReviews":
+			query := req.URL.Query()
+			if query.Get("exists[publishedResponse]") != "false" || query.Get("sort") != "-createdDate" {
+				t.Fatalf("unexpected review query %v", query)
+			}
+			// Responses stay pending publication, so answered reviews keep coming back.
+			return jsonResponse(http.StatusOK, `{"data":[
+				{"type":"customerReviews","id":"r4","attributes":{"rating":3,"title":"Meh","body":"It is fine","reviewerNickname":"kim","territory":"USA","createdDate":"2026-01-04T00:00:00Z"}},
+				{"type":"customerReviews","id":"r3","attributes":{"rating":5,"title":"Great","body":"Love it","reviewerNickname":"lee","territory":"USA","createdDate":"2026-01-03T00:00:00Z"}},
+				{"type":"customerReviews","id":"r2","attributes":{"rating":1,"title":"Absturz","body":"Crash beim Start","reviewerNickname":"max","territory":"DEU","createdDate":"2026-01-02T00:00:00Z"}},
+				{"type":"customerReviews","id":"r1","attributes":{"rating":2,"title":"Crashes","body":"Crashes on launch","reviewerNickname":"sam","territory":"USA","createdDate":"2026-01-01T00:00:00Z"}}
+			],"links":{}}`)
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/customerReviewResponses":
+			body, _ := io.ReadAll(req.Body)
+			var payload struct {
+				Data struct {
+					Attributes struct {
+						ResponseBody string `json:"responseBody"`
+					} `json:"attributes"`
+					Relationships struct {
+						Review struct {
+							Data struct {
+								ID string `json:"id"`
+							} `json:"data"`
+						} `json:"review"`
+					} `json:"relationships"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Fatalf("invalid payload %s: %v", body, err)
+			}
+			reviewID := payload.Data.Relationships.Review.Data.ID
+			if len(posted) > 0 {
+				saved, err := os.ReadFile(statePath)
+				if err != nil || !strings.Contains(string(saved), `"resp-r1"`) {
+					t.Fatalf("expected state to record r1 before the next post, got %s (%v)", saved, err)
+				}
+			}
+			posted = append(posted, reviewID+":"+payload.Data.Attributes.ResponseBody)
+			return jsonResponse(http.StatusCreated, `{"data":{"type":"customerReviewResponses","id":"resp-`+reviewID+`","attributes":{"responseBody":"ok","state":"PENDING_PUBLISH"}}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	args := []string{"reviews", "triage", "--app", "app-1", "--rules", rulesPath, "--state", statePath}
+
+	plan := runReviewsTriage(t, args)
+	if plan.Applied || len(posted) != 0 {
+		t.Fatalf("expected plan without posting, got applied=%v posted=%v", plan.Applied, posted)
+	}
+	if _, err := os.Stat(statePath); !os.IsNotExist(err) {
+		t.Fatalf("expected no state file after planning, got %v", err)
+	}
+	if plan.Summary.Reviews != 4 || plan.Summary.Respond != 2 || plan.Summary.Skipped != 1 || plan.Summary.Unmatched != 1 {
+		t.Fatalf("unexpected plan summary %+v", plan.Summary)
+	}
+	if plan.Items[0].ReviewID != "r1" || plan.Items[0].Response != "Sorry sam, please email support@example.com." {
+		t.Fatalf("unexpected first item %+v", plan.Items[0])
+	}
+	if plan.Items[1].ReviewID != "r2" || plan.Items[1].Language != "de" || plan.Items[1].Response != "Entschuldigung max!" {
+		t.Fatalf("unexpected localized item %+v", plan.Items[1])
+	}
+
+	applied := runReviewsTriage(t, append(args, "--apply"))
+	if !applied.Applied || len(posted) != 2 || posted[0] != "r1:Sorry sam, please email support@example.com." {
+		t.Fatalf("unexpected posted responses %v", posted)
+	}
+	if applied.Items[0].Action != "responded" || applied.Items[0].ResponseID != "resp-r1" {
+		t.Fatalf("unexpected applied item %+v", applied.Items[0])
+	}
+	if applied.NextCursor != "2026-01-04T00:00:00Z" {
+		t.Fatalf("unexpected next cursor %q", applied.NextCursor)
+	}
+
+	// Re-reading from an earlier date must not answer the same reviews again.
+	rerun := runReviewsTriage(t, append(args, "--apply", "--since", "2025-12-31"))
+	if len(posted) != 2 {
+		t.Fatalf("expected no new responses, got %v", posted)
+	}
+	if rerun.Summary.AlreadyAnswered != 2 || rerun.Summary.Respond != 0 {
+		t.Fatalf("unexpected rerun summary %+v", rerun.Summary)
+	}
+	if rerun.NextCursor != "2026-01-04T00:00:00Z" {
+		t.Fatalf("expected cursor to stay at the newest review, got %q", rerun.NextCursor)
+	}
+
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatalf("read state: %v", err)
+	}
+	var state struct {
+		AppID    string                     `json:"appId"`
+		Cursor   string                     `json:"cursor"`
+		Answered map[string]json.RawMessage `json:"answered"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("invalid state %s: %v", data, err)
+	}
+	if state.AppID != "app-1" || state.Cursor != "2026-01-04T00:00:00Z" || len(state.Answered) != 2 {
+		t.Fatalf("unexpected state %s", data)
+	}
+
+	// Without --since, the stored cursor hides every review already read.
+	cursorRun := runReviewsTriage(t, args)
+	if cursorRun.Summary.Reviews != 0 {
+		t.Fatalf("expected no reviews after the cursor, got %+v", cursorRun.Summary)
+	}
+}
+
+func TestReviewsTriageValidationErrors(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+
+	dir := t.TempDir()
+	invalidRules := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalidRules, []byte("rules:\n  - name: empty\n"), 0o600); err != nil {
+		t.Fatalf("write rules: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "missing app",
+			args:    []string{"reviews", "triage", "--rules", invalidRules},
+			wantErr: "--app is required",
+		},
+		{
+			name:    "missing rules",
+			args:    []string{"reviews", "triage", "--app", "app-1"},
+			wantErr: "--rules is required",
+		},
+		{
+			name:    "invalid since",
+			args:    []string{"reviews", "triage", "--app", "app-1", "--rules", invalidRules, "--since", "yesterday"},
+			wantErr: "--since must be YYYY-MM-DD or RFC3339",
+		},
+		{
+			name:    "rule without response",
+			args:    []string{"reviews", "triage", "--app", "app-1", "--rules", invalidRules},
+			wantErr: "response or responses is required unless skip is set",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+			_, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected flag.ErrHelp, got %v", err)
+				}
+			})
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected %q in stderr, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
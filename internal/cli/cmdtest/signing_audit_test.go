@@ -0,0 +1,140 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"howett.net/plist"
+)
+
+func TestSigningAuditReportsFindingsAndFails(t *testing.T) {
+	setupAuth(t)
+
+	cert, _ := selfSignedCert(t)
+	revoked, _ := selfSignedCert(t)
+	certContent := base64.StdEncoding.EncodeToString(cert.Raw)
+	serial := strings.ToUpper(cert.SerialNumber.Text(16))
+	now := time.Now().UTC()
+
+	installDir := t.TempDir()
+	writeAuditProfile(t, filepath.Join(installDir, "ok.mobileprovision"), "11111111-1111-1111-1111-111111111111", now.Add(300*24*time.Hour), cert.Raw, nil)
+	writeAuditProfile(t, filepath.Join(installDir, "stale.mobileprovision"), "22222222-2222-2222-2222-222222222222", now.Add(5*24*time.Hour), revoked.Raw, []string{"00008030-DISABLED"})
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/certificates":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"certificates","id":"CERT_1","attributes":{"name":"Dist","certificateType":"DISTRIBUTION","serialNumber":"`+serial+`","expirationDate":"`+now.Add(400*24*time.Hour).Format(time.RFC3339)+`","certificateContent":"`+certContent+`"}}],"links":{}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/devices":
+			if req.URL.Query().Get("filter[status]") != "DISABLED" {
+				t.Fatalf("expected disabled device filter, got %s", req.URL.RawQuery)
+			}
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"devices","id":"DEV_OFF","attributes":{"name":"Old iPhone","udid":"00008030-DISABLED","status":"DISABLED"}}],"links":{}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/profiles":
+			if req.URL.Query().Get("include") != "certificates,devices" {
+				t.Fatalf("expected relationship include, got %s", req.URL.RawQuery)
+			}
+			return jsonResponse(http.StatusOK, `{"data":[
+				{"type":"profiles","id":"P_OK","attributes":{"name":"Good","profileState":"ACTIVE","expirationDate":"`+now.Add(300*24*time.Hour).Format(time.RFC3339)+`"},"relationships":{"certificates":{"data":[{"type":"certificates","id":"CERT_1"}],"meta":{"paging":{"total":1}}},"devices":{"data":[],"meta":{"paging":{"total":0}}}}},
+				{"type":"profiles","id":"P_BAD","attributes":{"name":"Bad","profileState":"INVALID","expirationDate":"`+now.Add(300*24*time.Hour).Format(time.RFC3339)+`"},"relationships":{"certificates":{"data":[{"type":"certificates","id":"CERT_GONE"}],"meta":{"paging":{"total":1}}},"devices":{"data":[],"meta":{"paging":{"total":2}}}}}
+			],"links":{}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/profiles/P_BAD/relationships/devices":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"devices","id":"DEV_ON"},{"type":"devices","id":"DEV_OFF"}],"links":{}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"signing", "audit", "--within", "30d", "--install-dir", installDir}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "signing audit: found") {
+		t.Fatalf("expected non-zero exit with findings, got %v", runErr)
+	}
+
+	var result struct {
+		Summary struct {
+			Certificates  int `json:"certificates"`
+			Profiles      int `json:"profiles"`
+			LocalProfiles int `json:"localProfiles"`
+			Errors        int `json:"errors"`
+			Warnings      int `json:"warnings"`
+		} `json:"summary"`
+		Findings []struct {
+			Source string `json:"source"`
+			ID     string `json:"id"`
+			Issue  string `json:"issue"`
+		} `json:"findings"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode output: %v (%q)", err, stdout)
+	}
+	if result.Summary.Certificates != 1 || result.Summary.Profiles != 2 || result.Summary.LocalProfiles != 2 {
+		t.Fatalf("unexpected summary: %+v", result.Summary)
+	}
+
+	var issues []string
+	for _, finding := range result.Findings {
+		issues = append(issues, finding.Source+":"+finding.ID+":"+finding.Issue)
+	}
+	for _, want := range []string{
+		"remote:P_BAD:invalid",
+		"remote:P_BAD:revoked-certificate",
+		"remote:P_BAD:disabled-device",
+		"local:22222222-2222-2222-2222-222222222222:expiring",
+		"local:22222222-2222-2222-2222-222222222222:revoked-certificate",
+		"local:22222222-2222-2222-2222-222222222222:disabled-device",
+	} {
+		if !strings.Contains(strings.Join(issues, ","), want) {
+			t.Fatalf("expected finding %q, got %v", want, issues)
+		}
+	}
+	if len(issues) != 6 {
+		t.Fatalf("expected 6 findings, got %v", issues)
+	}
+}
+
+func writeAuditProfile(t *testing.T, path, uuid string, expires time.Time, certDER []byte, devices []string) {
+	t.Helper()
+
+	payload := map[string]any{
+		"UUID":                  uuid,
+		"Name":                  "Audit " + uuid[:4],
+		"TeamIdentifier":        []string{"TEAM12345"},
+		"CreationDate":          time.Now().UTC().Add(-time.Hour),
+		"ExpirationDate":        expires.UTC(),
+		"DeveloperCertificates": [][]byte{certDER},
+		"Entitlements": map[string]any{
+			"application-identifier": "TEAM12345.com.example.app",
+		},
+	}
+	if len(devices) > 0 {
+		payload["ProvisionedDevices"] = devices
+	}
+	data, err := plist.Marshal(payload, plist.XMLFormat)
+	if err != nil {
+		t.Fatalf("plist.Marshal() error: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write profile: %v", err)
+	}
+}
@@ -0,0 +1,241 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runSigningCommand(t *testing.T, args []string) string {
+	t.Helper()
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse(args); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+	return stdout
+}
+
+func TestSigningSyncValidationErrors(t *testing.T) {
+	t.Setenv("ASC_SIGNING_PASSPHRASE", "")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "missing store",
+			args:    []string{"signing", "sync", "--bundle-id", "com.example.app", "--profile-type", "IOS_APP_STORE"},
+			wantErr: "Error: --store is required",
+		},
+		{
+			name:    "missing passphrase",
+			args:    []string{"signing", "sync", "--store", t.TempDir(), "--bundle-id", "com.example.app", "--profile-type", "IOS_APP_STORE"},
+			wantErr: "ASC_SIGNING_PASSPHRASE is not set",
+		},
+		{
+			name:    "nuke without confirm",
+			args:    []string{"signing", "nuke", "--store", t.TempDir()},
+			wantErr: "Error: --confirm is required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			_, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				err := root.Run(context.Background())
+				if !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			})
+
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected error %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
+
+func TestSigningSyncCreatesReusesAndNukes(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_SIGNING_PASSPHRASE", "team secret")
+
+	storeDir := filepath.Join(t.TempDir(), "store")
+	certContent := base64.StdEncoding.EncodeToString([]byte("certificate-der"))
+	profileContent := base64.StdEncoding.EncodeToString([]byte("<plist>com.example.app</plist>"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	certCreated := false
+	var requests []string
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/bundleIds":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"bundleIds","id":"BUNDLE_RES","attributes":{"identifier":"com.example.app"}}],"links":{}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/certificates":
+			if !certCreated {
+				return jsonResponse(http.StatusOK, `{"data":[],"links":{}}`)
+			}
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"certificates","id":"CERT_1","attributes":{"certificateType":"IOS_DISTRIBUTION","expirationDate":"2099-01-01T00:00:00.000+00:00"}}],"links":{}}`)
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/certificates":
+			certCreated = true
+			return jsonResponse(http.StatusCreated, `{"data":{"type":"certificates","id":"CERT_1","attributes":{"certificateType":"IOS_DISTRIBUTION","serialNumber":"SERIAL1","certificateContent":"`+certContent+`"}}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/profiles":
+			return jsonResponse(http.StatusOK, `{"data":[],"links":{}}`)
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/profiles":
+			body, _ := io.ReadAll(req.Body)
+			if !strings.Contains(string(body), `"CERT_1"`) {
+				t.Fatalf("expected profile to use stored certificate, got %s", body)
+			}
+			return jsonResponse(http.StatusCreated, `{"data":{"type":"profiles","id":"PROFILE_1","attributes":{"name":"App Store","profileType":"IOS_APP_STORE","profileState":"ACTIVE","profileContent":"`+profileContent+`"}}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/profiles/PROFILE_1":
+			return jsonResponse(http.StatusOK, `{"data":{"type":"profiles","id":"PROFILE_1","attributes":{"name":"App Store","profileType":"IOS_APP_STORE","profileState":"ACTIVE"}}}`)
+		case req.Method == http.MethodDelete && (req.URL.Path == "/v1/profiles/PROFILE_1" || req.URL.Path == "/v1/certificates/CERT_1"):
+			return jsonResponse(http.StatusNoContent, "")
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	type syncResult struct {
+		CertificateID      string   `json:"certificateId"`
+		CertificateCreated bool     `json:"certificateCreated"`
+		ProfileID          string   `json:"profileId"`
+		ProfileCreated     bool     `json:"profileCreated"`
+		OutputFiles        []string `json:"outputFiles"`
+	}
+	syncArgs := []string{"signing", "sync", "--store", storeDir, "--bundle-id", "com.example.app", "--profile-type", "IOS_APP_STORE"}
+
+	var first syncResult
+	if err := json.Unmarshal([]byte(runSigningCommand(t, syncArgs)), &first); err != nil {
+		t.Fatalf("decode first sync: %v", err)
+	}
+	if !first.CertificateCreated || !first.ProfileCreated || first.CertificateID != "CERT_1" || first.ProfileID != "PROFILE_1" {
+		t.Fatalf("expected assets to be created, got %+v", first)
+	}
+
+	var second syncResult
+	if err := json.Unmarshal([]byte(runSigningCommand(t, syncArgs)), &second); err != nil {
+		t.Fatalf("decode second sync: %v", err)
+	}
+	if second.CertificateCreated || second.ProfileCreated || second.CertificateID != "CERT_1" {
+		t.Fatalf("expected stored assets to be reused, got %+v", second)
+	}
+
+	requests = nil
+	outputDir := filepath.Join(t.TempDir(), "signing")
+	var readonly syncResult
+	if err := json.Unmarshal([]byte(runSigningCommand(t, append(syncArgs, "--readonly", "--output", outputDir))), &readonly); err != nil {
+		t.Fatalf("decode readonly sync: %v", err)
+	}
+	if len(requests) != 0 {
+		t.Fatalf("expected readonly sync to skip App Store Connect, got %v", requests)
+	}
+	if len(readonly.OutputFiles) != 3 {
+		t.Fatalf("expected certificate, key, and profile output, got %v", readonly.OutputFiles)
+	}
+	if data, err := os.ReadFile(filepath.Join(outputDir, "SERIAL1.cer")); err != nil || string(data) != "certificate-der" {
+		t.Fatalf("unexpected certificate output: %q, %v", data, err)
+	}
+	if data, err := os.ReadFile(filepath.Join(outputDir, "SERIAL1.key")); err != nil || !strings.Contains(string(data), "PRIVATE KEY") {
+		t.Fatalf("unexpected key output: %v", err)
+	}
+
+	var nuked struct {
+		RevokedCertificates []string `json:"revokedCertificates"`
+		DeletedProfiles     []string `json:"deletedProfiles"`
+	}
+	if err := json.Unmarshal([]byte(runSigningCommand(t, []string{"signing", "nuke", "--store", storeDir, "--confirm"})), &nuked); err != nil {
+		t.Fatalf("decode nuke: %v", err)
+	}
+	if strings.Join(nuked.RevokedCertificates, ",") != "CERT_1" || strings.Join(nuked.DeletedProfiles, ",") != "PROFILE_1" {
+		t.Fatalf("unexpected nuke result: %+v", nuked)
+	}
+	if strings.Join(requests, ",") != "DELETE /v1/profiles/PROFILE_1,DELETE /v1/certificates/CERT_1" {
+		t.Fatalf("unexpected nuke requests: %v", requests)
+	}
+
+	index, err := os.ReadFile(filepath.Join(storeDir, "index.json"))
+	if err != nil {
+		t.Fatalf("read index: %v", err)
+	}
+	if strings.Contains(string(index), "CERT_1") {
+		t.Fatalf("expected nuked certificate to leave the index, got %s", index)
+	}
+}
+
+func TestSigningSyncKeepsNewCertificateWhenProfileFails(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_SIGNING_PASSPHRASE", "team secret")
+
+	storeDir := filepath.Join(t.TempDir(), "store")
+	certContent := base64.StdEncoding.EncodeToString([]byte("certificate-der"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/bundleIds":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"bundleIds","id":"BUNDLE_RES","attributes":{"identifier":"com.example.app"}}],"links":{}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/certificates":
+			return jsonResponse(http.StatusOK, `{"data":[],"links":{}}`)
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/certificates":
+			return jsonResponse(http.StatusCreated, `{"data":{"type":"certificates","id":"CERT_1","attributes":{"certificateType":"IOS_DISTRIBUTION","serialNumber":"SERIAL1","certificateContent":"`+certContent+`"}}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/profiles":
+			return jsonResponse(http.StatusOK, `{"data":[],"links":{}}`)
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/profiles":
+			return jsonResponse(http.StatusConflict, `{"errors":[{"status":"409","code":"ENTITY_ERROR","title":"Profile failed"}]}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	_, _ = captureOutput(t, func() {
+		if err := root.Parse([]string{"signing", "sync", "--store", storeDir, "--bundle-id", "com.example.app", "--profile-type", "IOS_APP_STORE"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err == nil {
+			t.Fatal("expected profile creation failure")
+		}
+	})
+
+	index, err := os.ReadFile(filepath.Join(storeDir, "index.json"))
+	if err != nil {
+		t.Fatalf("read index: %v", err)
+	}
+	if !strings.Contains(string(index), "CERT_1") {
+		t.Fatalf("expected new certificate to be saved before the profile step, got %s", index)
+	}
+}
@@ -0,0 +1,386 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSubscriptionsCatalogValidationErrors(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "export missing app",
+			args:    []string{"subscriptions", "catalog", "export", "--file", "subscriptions.yaml"},
+			wantErr: "Error: --app is required (or set ASC_APP_ID)",
+		},
+		{
+			name:    "export missing file",
+			args:    []string{"subscriptions", "catalog", "export", "--app", "APP_ID"},
+			wantErr: "Error: --file is required",
+		},
+		{
+			name:    "apply missing file",
+			args:    []string{"subscriptions", "catalog", "apply", "--app", "APP_ID"},
+			wantErr: "Error: --file is required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			stdout, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				err := root.Run(context.Background())
+				if !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			})
+
+			if stdout != "" {
+				t.Fatalf("expected empty stdout, got %q", stdout)
+			}
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected error %q, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
+
+func TestSubscriptionsCatalogApplyRejectsUnknownFields(t *testing.T) {
+	catalogPath := filepath.Join(t.TempDir(), "subscriptions.yaml")
+	if err := os.WriteFile(catalogPath, []byte("groups:\n  - referenceName: Premium\n    discounts: []\n"), 0o600); err != nil {
+		t.Fatalf("write catalog: %v", err)
+	}
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	_, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"subscriptions", "catalog", "apply", "--app", "APP_ID", "--file", catalogPath}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		err := root.Run(context.Background())
+		if !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected ErrHelp, got %v", err)
+		}
+	})
+
+	if !strings.Contains(stderr, "field discounts not found") {
+		t.Fatalf("expected unknown field error, got %q", stderr)
+	}
+}
+
+func TestSubscriptionsCatalogApply(t *testing.T) {
+	catalog := `groups:
+  - referenceName: Premium
+    localizations:
+      - locale: en-US
+        name: Premium
+    subscriptions:
+      - productId: com.example.monthly
+        referenceName: Monthly
+        period: ONE_MONTH
+        localizations:
+          - locale: en-US
+            name: Monthly
+        prices:
+          - territory: United States
+            price: "9.99"
+          - territory: CA
+            price: "12.99"
+        introductoryOffers:
+          - territory: USA
+            offerMode: FREE_TRIAL
+            duration: ONE_WEEK
+            numberOfPeriods: 1
+            endDate: "2026-12-31"
+        promotionalOffers:
+          - offerCode: WINBACK
+            name: Win back
+            offerMode: PAY_UP_FRONT
+            duration: THREE_MONTHS
+            numberOfPeriods: 1
+            prices:
+              - territory: CA
+                price: "12.99"
+`
+
+	tests := []struct {
+		name        string
+		dryRun      bool
+		wantStatus  map[string]string
+		wantMethods []string
+	}{
+		{
+			name:       "dry run",
+			dryRun:     true,
+			wantStatus: map[string]string{"group": "planned", "subscription": "planned"},
+		},
+		{
+			name:       "apply",
+			wantStatus: map[string]string{"group": "applied", "subscription": "applied"},
+			wantMethods: []string{
+				"POST /v1/subscriptionGroupLocalizations",
+				"POST /v1/subscriptionPrices",
+				"PATCH /v1/subscriptionIntroductoryOffers/intro-1",
+				"POST /v1/subscriptionPromotionalOffers",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setupAuth(t)
+
+			catalogPath := filepath.Join(t.TempDir(), "subscriptions.yaml")
+			if err := os.WriteFile(catalogPath, []byte(catalog), 0o600); err != nil {
+				t.Fatalf("write catalog: %v", err)
+			}
+
+			originalTransport := http.DefaultTransport
+			t.Cleanup(func() {
+				http.DefaultTransport = originalTransport
+			})
+
+			var mutations []string
+			http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				if req.Method != http.MethodGet {
+					mutations = append(mutations, req.Method+" "+req.URL.Path)
+				}
+				switch {
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/apps/APP_ID/subscriptionGracePeriod":
+					return jsonResponse(http.StatusNotFound, iapCatalogNotFoundBody)
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/apps/APP_ID/subscriptionGroups":
+					return jsonResponse(http.StatusOK, `{"data":[{"type":"subscriptionGroups","id":"group-1","attributes":{"referenceName":"Premium"}}],"links":{}}`)
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/subscriptionGroups/group-1/subscriptionGroupLocalizations":
+					return jsonResponse(http.StatusOK, `{"data":[],"links":{}}`)
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/subscriptionGroups/group-1/subscriptions":
+					return jsonResponse(http.StatusOK, `{"data":[{"type":"subscriptions","id":"sub-1","attributes":{"name":"Monthly","productId":"com.example.monthly","subscriptionPeriod":"ONE_MONTH"}}],"links":{}}`)
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/subscriptions/sub-1/subscriptionLocalizations":
+					return jsonResponse(http.StatusOK, `{"data":[{"type":"subscriptionLocalizations","id":"loc-1","attributes":{"locale":"en-US","name":"Monthly"}}],"links":{}}`)
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/subscriptions/sub-1/prices":
+					return jsonResponse(http.StatusOK, `{"data":[{"type":"subscriptionPrices","id":"price-1","attributes":{"startDate":"2024-01-01"},"relationships":{"subscriptionPricePoint":{"data":{"type":"subscriptionPricePoints","id":"pp-usa"}},"territory":{"data":{"type":"territories","id":"USA"}}}}],"included":[{"type":"subscriptionPricePoints","id":"pp-usa","attributes":{"customerPrice":"9.99"}}],"links":{}}`)
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/subscriptions/sub-1/introductoryOffers":
+					if got := req.URL.Query().Get("include"); got != "territory,subscriptionPricePoint" {
+						t.Fatalf("expected offer relationships to be included, got %q", got)
+					}
+					return jsonResponse(http.StatusOK, `{"data":[{"type":"subscriptionIntroductoryOffers","id":"intro-1","attributes":{"offerMode":"FREE_TRIAL","duration":"ONE_WEEK","numberOfPeriods":1},"relationships":{"territory":{"data":{"type":"territories","id":"USA"}}}}],"links":{}}`)
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/subscriptions/sub-1/promotionalOffers":
+					return jsonResponse(http.StatusOK, `{"data":[],"links":{}}`)
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/subscriptions/sub-1/pricePoints":
+					if got := req.URL.Query().Get("filter[territory]"); got != "CAN" {
+						t.Fatalf("expected CAN price points lookup, got %q", got)
+					}
+					return jsonResponse(http.StatusOK, `{"data":[{"type":"subscriptionPricePoints","id":"pp-can","attributes":{"customerPrice":"12.99"}}],"links":{}}`)
+				case req.Method == http.MethodPost && req.URL.Path == "/v1/subscriptionGroupLocalizations":
+					return jsonResponse(http.StatusCreated, `{"data":{"type":"subscriptionGroupLocalizations","id":"gloc-1","attributes":{"locale":"en-US","name":"Premium"}}}`)
+				case req.Method == http.MethodPost && req.URL.Path == "/v1/subscriptionPrices":
+					body, _ := io.ReadAll(req.Body)
+					if !strings.Contains(string(body), `"id":"pp-can"`) {
+						t.Fatalf("expected CAN price point in request, got %s", body)
+					}
+					return jsonResponse(http.StatusCreated, `{"data":{"type":"subscriptionPrices","id":"price-2","attributes":{}}}`)
+				case req.Method == http.MethodPatch && req.URL.Path == "/v1/subscriptionIntroductoryOffers/intro-1":
+					body, _ := io.ReadAll(req.Body)
+					if !strings.Contains(string(body), `"endDate":"2026-12-31"`) {
+						t.Fatalf("expected endDate update, got %s", body)
+					}
+					return jsonResponse(http.StatusOK, `{"data":{"type":"subscriptionIntroductoryOffers","id":"intro-1","attributes":{}}}`)
+				case req.Method == http.MethodPost && req.URL.Path == "/v1/subscriptionPromotionalOffers":
+					body, _ := io.ReadAll(req.Body)
+					if !strings.Contains(string(body), `"offerCode":"WINBACK"`) || !strings.Contains(string(body), `"id":"pp-can"`) {
+						t.Fatalf("expected WINBACK offer with CAN price point, got %s", body)
+					}
+					return jsonResponse(http.StatusCreated, `{"data":{"type":"subscriptionPromotionalOffers","id":"promo-1","attributes":{}}}`)
+				default:
+					t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+					return nil, nil
+				}
+			})
+
+			args := []string{"subscriptions", "catalog", "apply", "--app", "APP_ID", "--file", catalogPath}
+			if test.dryRun {
+				args = append(args, "--dry-run")
+			}
+
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			stdout, _ := captureOutput(t, func() {
+				if err := root.Parse(args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); err != nil {
+					t.Fatalf("run error: %v", err)
+				}
+			})
+
+			var result struct {
+				DryRun  bool `json:"dryRun"`
+				Changes []struct {
+					Action string `json:"action"`
+					Key    string `json:"key"`
+				} `json:"changes"`
+				Items []struct {
+					Kind   string `json:"kind"`
+					Status string `json:"status"`
+				} `json:"items"`
+				Failed int `json:"failed"`
+			}
+			if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+				t.Fatalf("decode output: %v (stdout=%q)", err, stdout)
+			}
+
+			if result.DryRun != test.dryRun {
+				t.Fatalf("expected dryRun=%t, got %t", test.dryRun, result.DryRun)
+			}
+			if result.Failed != 0 {
+				t.Fatalf("expected no failures, got %d", result.Failed)
+			}
+			wantChanges := []string{
+				"create-group-localization en-US",
+				"set-price CAN",
+				"update-introductory-offer USA",
+				"create-promotional-offer WINBACK",
+			}
+			gotChanges := make([]string, 0, len(result.Changes))
+			for _, change := range result.Changes {
+				gotChanges = append(gotChanges, change.Action+" "+change.Key)
+			}
+			if strings.Join(gotChanges, ",") != strings.Join(wantChanges, ",") {
+				t.Fatalf("expected changes %v, got %v", wantChanges, gotChanges)
+			}
+			for _, item := range result.Items {
+				if item.Status != test.wantStatus[item.Kind] {
+					t.Fatalf("expected %s status %q, got %q", item.Kind, test.wantStatus[item.Kind], item.Status)
+				}
+			}
+			if strings.Join(mutations, ",") != strings.Join(test.wantMethods, ",") {
+				t.Fatalf("expected mutations %v, got %v", test.wantMethods, mutations)
+			}
+		})
+	}
+}
+
+func TestSubscriptionsCatalogApplyReplacesIntroOfferCreateFirst(t *testing.T) {
+	catalog := `groups:
+  - referenceName: Premium
+    subscriptions:
+      - productId: com.example.monthly
+        referenceName: Monthly
+        introductoryOffers:
+          - territory: USA
+            offerMode: FREE_TRIAL
+            duration: ONE_WEEK
+            numberOfPeriods: 2
+`
+
+	tests := []struct {
+		name          string
+		createStatus  int
+		wantMutations []string
+		wantFailed    int
+	}{
+		{
+			name:          "create succeeds",
+			createStatus:  http.StatusCreated,
+			wantMutations: []string{"POST /v1/subscriptionIntroductoryOffers", "DELETE /v1/subscriptionIntroductoryOffers/intro-1"},
+		},
+		{
+			name:          "create fails",
+			createStatus:  http.StatusConflict,
+			wantMutations: []string{"POST /v1/subscriptionIntroductoryOffers"},
+			wantFailed:    1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setupAuth(t)
+
+			catalogPath := filepath.Join(t.TempDir(), "subscriptions.yaml")
+			if err := os.WriteFile(catalogPath, []byte(catalog), 0o600); err != nil {
+				t.Fatalf("write catalog: %v", err)
+			}
+
+			originalTransport := http.DefaultTransport
+			t.Cleanup(func() {
+				http.DefaultTransport = originalTransport
+			})
+
+			var mutations []string
+			http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				if req.Method != http.MethodGet {
+					mutations = append(mutations, req.Method+" "+req.URL.Path)
+				}
+				switch {
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/apps/APP_ID/subscriptionGracePeriod":
+					return jsonResponse(http.StatusNotFound, iapCatalogNotFoundBody)
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/apps/APP_ID/subscriptionGroups":
+					return jsonResponse(http.StatusOK, `{"data":[{"type":"subscriptionGroups","id":"group-1","attributes":{"referenceName":"Premium"}}],"links":{}}`)
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/subscriptionGroups/group-1/subscriptionGroupLocalizations":
+					return jsonResponse(http.StatusOK, `{"data":[],"links":{}}`)
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/subscriptionGroups/group-1/subscriptions":
+					return jsonResponse(http.StatusOK, `{"data":[{"type":"subscriptions","id":"sub-1","attributes":{"name":"Monthly","productId":"com.example.monthly"}}],"links":{}}`)
+				case req.Method == http.MethodGet && (req.URL.Path == "/v1/subscriptions/sub-1/subscriptionLocalizations" || req.URL.Path == "/v1/subscriptions/sub-1/prices" || req.URL.Path == "/v1/subscriptions/sub-1/promotionalOffers"):
+					return jsonResponse(http.StatusOK, `{"data":[],"links":{}}`)
+				case req.Method == http.MethodGet && req.URL.Path == "/v1/subscriptions/sub-1/introductoryOffers":
+					return jsonResponse(http.StatusOK, `{"data":[{"type":"subscriptionIntroductoryOffers","id":"intro-1","attributes":{"offerMode":"FREE_TRIAL","duration":"ONE_WEEK","numberOfPeriods":1},"relationships":{"territory":{"data":{"type":"territories","id":"USA"}}}}],"links":{}}`)
+				case req.Method == http.MethodPost && req.URL.Path == "/v1/subscriptionIntroductoryOffers":
+					if test.createStatus != http.StatusCreated {
+						return jsonResponse(test.createStatus, `{"errors":[{"status":"409","code":"ENTITY_ERROR","title":"Offer rejected"}]}`)
+					}
+					return jsonResponse(http.StatusCreated, `{"data":{"type":"subscriptionIntroductoryOffers","id":"intro-2","attributes":{}}}`)
+				case req.Method == http.MethodDelete && req.URL.Path == "/v1/subscriptionIntroductoryOffers/intro-1":
+					return jsonResponse(http.StatusNoContent, "")
+				default:
+					t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+					return nil, nil
+				}
+			})
+
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			stdout, _ := captureOutput(t, func() {
+				if err := root.Parse([]string{"subscriptions", "catalog", "apply", "--app", "APP_ID", "--file", catalogPath}); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				err := root.Run(context.Background())
+				if (err != nil) != (test.wantFailed > 0) {
+					t.Fatalf("unexpected run error: %v", err)
+				}
+			})
+
+			var result struct {
+				Failed int `json:"failed"`
+			}
+			if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+				t.Fatalf("decode output: %v (stdout=%q)", err, stdout)
+			}
+			if result.Failed != test.wantFailed {
+				t.Fatalf("expected %d failures, got %d", test.wantFailed, result.Failed)
+			}
+			if strings.Join(mutations, ",") != strings.Join(test.wantMutations, ",") {
+				t.Fatalf("expected mutations %v, got %v", test.wantMutations, mutations)
+			}
+		})
+	}
+}
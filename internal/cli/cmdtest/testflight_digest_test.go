@@ -0,0 +1,244 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testFlightDigestCrashLog = `Exception Type:  EXC_CRASH (SIGABRT)
+
+Thread 0 Crashed:
+0   libsystem_kernel.dylib 0x00000001e4a1e42c __pthread_kill + 8
+1   MyApp                  0x0000000104d8c123 ViewModel.load() + 120 (ViewModel.swift:42)
+`
+
+func testFlightDigestTransport(t *testing.T) (*[]string, func()) {
+	t.Helper()
+
+	var requests []string
+	originalTransport := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.URL.Host+req.URL.Path)
+		switch req.URL.Path {
+		case "/v1/apps/app-1/betaFeedbackScreenshotSubmissions":
+			query := req.URL.Query()
+			if query.Get("sort") != "-createdDate" || query.Get("include") != "build" {
+				t.Fatalf("unexpected feedback query %q", req.URL.RawQuery)
+			}
+			return jsonResponse(http.StatusOK, `{"data":[
+				{"type":"betaFeedbackScreenshotSubmissions","id":"f1","attributes":{"createdDate":"2026-03-10T09:00:00Z","comment":"Button | overlaps","deviceModel":"iPhone15,3","osVersion":"18.3","screenshots":[{"url":"https://cdn.example.com/shot.jpg?sig=1"}]},"relationships":{"build":{"data":{"type":"builds","id":"b2"}}}},
+				{"type":"betaFeedbackScreenshotSubmissions","id":"f0","attributes":{"createdDate":"2026-02-01T09:00:00Z","comment":"Old"}}
+			],"included":[{"type":"builds","id":"b2","attributes":{"version":"42"}}],"links":{}}`)
+		case "/v1/apps/app-1/betaFeedbackCrashSubmissions":
+			if req.URL.Query().Get("include") != "build" {
+				t.Fatalf("unexpected crash query %q", req.URL.RawQuery)
+			}
+			return jsonResponse(http.StatusOK, `{"data":[
+				{"type":"betaFeedbackCrashSubmissions","id":"c1","attributes":{"createdDate":"2026-03-10T10:00:00Z","deviceModel":"iPhone15,3","osVersion":"18.3"},"relationships":{"build":{"data":{"type":"builds","id":"b2"}}}},
+				{"type":"betaFeedbackCrashSubmissions","id":"c2","attributes":{"createdDate":"2026-03-10T08:00:00Z","deviceModel":"iPad13,1","osVersion":"17.6"},"relationships":{"build":{"data":{"type":"builds","id":"b1"}}}}
+			],"included":[{"type":"builds","id":"b2","attributes":{"version":"42"}},{"type":"builds","id":"b1","attributes":{"version":"41"}}],"links":{}}`)
+		case "/v1/betaFeedbackCrashSubmissions/c1/crashLog", "/v1/betaFeedbackCrashSubmissions/c2/crashLog":
+			body, _ := json.Marshal(map[string]any{"data": map[string]any{
+				"type":       "betaCrashLogs",
+				"id":         "log",
+				"attributes": map[string]string{"logText": testFlightDigestCrashLog},
+			}})
+			return jsonResponse(http.StatusOK, string(body))
+		case "/shot.jpg":
+			if req.Header.Get("Authorization") != "" {
+				t.Fatal("expected screenshot download without credentials")
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("jpeg-bytes")),
+				Header:     http.Header{"Content-Type": []string{"image/jpeg"}},
+			}, nil
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+	return &requests, func() { http.DefaultTransport = originalTransport }
+}
+
+func TestTestFlightDigestDownloadsAndDeduplicates(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	requests, restore := testFlightDigestTransport(t)
+	t.Cleanup(restore)
+	dir := t.TempDir()
+
+	run := func(args ...string) string {
+		root := RootCommand("1.2.3")
+		root.FlagSet.SetOutput(io.Discard)
+		stdout, _ := captureOutput(t, func() {
+			if err := root.Parse(args); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			if err := root.Run(context.Background()); err != nil {
+				t.Fatalf("run error: %v", err)
+			}
+		})
+		return stdout
+	}
+
+	stdout := run("testflight", "digest", "--app", "app-1", "--since", "2026-03-01", "--dir", dir)
+	var result struct {
+		Feedback      int `json:"feedback"`
+		Crashes       int `json:"crashes"`
+		UniqueCrashes int `json:"uniqueCrashes"`
+		Builds        []struct {
+			Name     string `json:"name"`
+			Feedback int    `json:"feedback"`
+			Crashes  int    `json:"crashes"`
+		} `json:"builds"`
+		CrashGroups []struct {
+			Count     int      `json:"count"`
+			Exception string   `json:"exception"`
+			Frames    []string `json:"frames"`
+			Builds    []string `json:"builds"`
+			Logs      []string `json:"logs"`
+		} `json:"crashGroups"`
+		FeedbackItems []struct {
+			Build       string   `json:"build"`
+			Screenshots []string `json:"screenshots"`
+		} `json:"feedbackItems"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse output %q: %v", stdout, err)
+	}
+	if result.Feedback != 1 || result.Crashes != 2 || result.UniqueCrashes != 1 {
+		t.Fatalf("unexpected totals %+v", result)
+	}
+	if len(result.Builds) != 2 || result.Builds[0].Name != "42" || result.Builds[0].Feedback != 1 || result.Builds[0].Crashes != 1 {
+		t.Fatalf("unexpected builds %+v", result.Builds)
+	}
+	group := result.CrashGroups[0]
+	if group.Count != 2 || group.Exception != "EXC_CRASH (SIGABRT)" || len(group.Frames) != 2 || len(group.Logs) != 2 {
+		t.Fatalf("unexpected crash group %+v", group)
+	}
+	logText, err := os.ReadFile(filepath.Join(dir, "crash-logs", "c1.crash"))
+	if err != nil || string(logText) != testFlightDigestCrashLog {
+		t.Fatalf("expected saved crash log, got %q (%v)", logText, err)
+	}
+	shotPath := filepath.Join(dir, "screenshots", "f1-1.jpg")
+	if shot, err := os.ReadFile(shotPath); err != nil || string(shot) != "jpeg-bytes" {
+		t.Fatalf("expected saved screenshot, got %q (%v)", shot, err)
+	}
+	if item := result.FeedbackItems[0]; item.Build != "42" || len(item.Screenshots) != 1 || item.Screenshots[0] != shotPath {
+		t.Fatalf("unexpected feedback item %+v", item)
+	}
+
+	// A second run reuses the saved crash logs and screenshots.
+	*requests = nil
+	markdown := run("testflight", "digest", "--app", "app-1", "--since", "2026-03-01", "--dir", dir, "--output", "markdown")
+	for _, path := range *requests {
+		if strings.HasSuffix(path, "/crashLog") || strings.HasSuffix(path, "/shot.jpg") {
+			t.Fatalf("expected cached files to be reused, got request %s", path)
+		}
+	}
+	for _, want := range []string{"### TestFlight Digest", "### By Build", "### By Device Model", "### By OS Version", "### Crashes", "MyApp ViewModel.load()", "### Feedback", "Button \\| overlaps"} {
+		if !strings.Contains(markdown, want) {
+			t.Fatalf("expected %q in output:\n%s", want, markdown)
+		}
+	}
+}
+
+func TestTestFlightDigestWritesNothingWithoutDir(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	requests, restore := testFlightDigestTransport(t)
+	t.Cleanup(restore)
+
+	workDir := t.TempDir()
+	oldwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldwd) })
+	if err := os.Chdir(workDir); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"testflight", "digest", "--app", "app-1", "--since", "2026-03-01", "--output", "markdown"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, "MyApp ViewModel.load()") {
+		t.Fatalf("expected crashes to be deduplicated from fetched logs, got:\n%s", stdout)
+	}
+	for _, path := range *requests {
+		if strings.HasSuffix(path, "/shot.jpg") {
+			t.Fatalf("expected no screenshot download without --dir, got request %s", path)
+		}
+	}
+	entries, err := os.ReadDir(workDir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no files written without --dir, got %d entries", len(entries))
+	}
+}
+
+func TestTestFlightDigestValidationErrors(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "missing app",
+			args:    []string{"testflight", "digest"},
+			wantErr: "--app is required",
+		},
+		{
+			name:    "invalid since",
+			args:    []string{"testflight", "digest", "--app", "app-1", "--since", "yesterday"},
+			wantErr: "--since must be a duration like 24h",
+		},
+		{
+			name:    "invalid frames",
+			args:    []string{"testflight", "digest", "--app", "app-1", "--frames", "0"},
+			wantErr: "--frames must be between 1 and 20",
+		},
+		{
+			name:    "blank dir",
+			args:    []string{"testflight", "digest", "--app", "app-1", "--dir", " "},
+			wantErr: "--dir must not be blank",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+			_, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected flag.ErrHelp, got %v", err)
+				}
+			})
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected %q in stderr, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
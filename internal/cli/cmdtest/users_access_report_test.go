@@ -0,0 +1,54 @@
+package cmdtest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUsersAccessReportCSV(t *testing.T) {
+	setupAuth(t)
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/users":
+			return jsonResponse(http.StatusOK, `{"data":[
+				{"type":"users","id":"U_DEV","attributes":{"username":"dev@example.com","firstName":"Dev","lastName":"One","roles":["DEVELOPER"],"allAppsVisible":false}},
+				{"type":"users","id":"U_ADMIN","attributes":{"username":"admin@example.com","firstName":"Ada","lastName":"Admin","roles":["ADMIN"],"allAppsVisible":true,"provisioningAllowed":true}}
+			],"links":{}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/userInvitations":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"userInvitations","id":"INV_1","attributes":{"email":"new@example.com","roles":["SALES"],"allAppsVisible":true,"expirationDate":"2026-11-01T00:00:00Z"}}],"links":{}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/users/U_DEV/visibleApps":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"apps","id":"APP_1","attributes":{"name":"Example","bundleId":"com.example.app"}}],"links":{}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"users", "access-report", "--output", "csv"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	want := "kind,email,firstName,lastName,roles,allApps,visibleApps,provisioningAllowed,invitationExpires,highlights\n" +
+		"user,admin@example.com,Ada,Admin,ADMIN,true,all,true,,admin;all-apps\n" +
+		"user,dev@example.com,Dev,One,DEVELOPER,false,com.example.app,false,,\n" +
+		"invitation,new@example.com,,,SALES,true,all,false,2026-11-01T00:00:00Z,all-apps\n"
+	if strings.TrimSpace(stdout) != strings.TrimSpace(want) {
+		t.Fatalf("unexpected csv:\n%s\nwant:\n%s", stdout, want)
+	}
+}
@@ -0,0 +1,133 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUsersApplyInvitesUpdatesAndPrunes(t *testing.T) {
+	setupAuth(t)
+
+	file := filepath.Join(t.TempDir(), "team.yaml")
+	content := `users:
+  - email: dev@example.com
+    roles: [DEVELOPER]
+    visibleApps: ["APP_2"]
+  - email: new@example.com
+    firstName: New
+    lastName: Person
+    roles: [APP_MANAGER]
+    allApps: true
+`
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("write team file: %v", err)
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	var requests []string
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/users":
+			return jsonResponse(http.StatusOK, `{"data":[
+				{"type":"users","id":"U_DEV","attributes":{"username":"dev@example.com","roles":["DEVELOPER"],"allAppsVisible":false}},
+				{"type":"users","id":"U_GONE","attributes":{"username":"gone@example.com","roles":["SALES"],"allAppsVisible":true}},
+				{"type":"users","id":"U_OWNER","attributes":{"username":"owner@example.com","roles":["ACCOUNT_HOLDER"],"allAppsVisible":true}}
+			],"links":{}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/userInvitations":
+			return jsonResponse(http.StatusOK, `{"data":[],"links":{}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/users/U_DEV/relationships/visibleApps":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"apps","id":"APP_1"}],"links":{}}`)
+		case req.Method == http.MethodPatch && req.URL.Path == "/v1/users/U_DEV/relationships/visibleApps":
+			body, _ := io.ReadAll(req.Body)
+			if !strings.Contains(string(body), `"APP_2"`) || strings.Contains(string(body), `"APP_1"`) {
+				t.Fatalf("unexpected visible apps body: %s", body)
+			}
+			return jsonResponse(http.StatusNoContent, "")
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/userInvitations":
+			body, _ := io.ReadAll(req.Body)
+			for _, want := range []string{`"email":"new@example.com"`, `"APP_MANAGER"`, `"allAppsVisible":true`} {
+				if !strings.Contains(string(body), want) {
+					t.Fatalf("expected invitation body to contain %s, got %s", want, body)
+				}
+			}
+			return jsonResponse(http.StatusCreated, `{"data":{"type":"userInvitations","id":"INV_NEW","attributes":{"email":"new@example.com","expirationDate":"2026-11-18T00:00:00Z"}}}`)
+		case req.Method == http.MethodDelete && req.URL.Path == "/v1/users/U_GONE":
+			return jsonResponse(http.StatusNoContent, "")
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"users", "apply", "--file", file, "--prune", "--confirm"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		Applied bool `json:"applied"`
+		Summary struct {
+			Invited int `json:"invited"`
+			Updated int `json:"updated"`
+			Removed int `json:"removed"`
+			Failed  int `json:"failed"`
+		} `json:"summary"`
+		Invitations []struct {
+			Email          string `json:"email"`
+			ID             string `json:"id"`
+			ExpirationDate string `json:"expirationDate"`
+			Status         string `json:"status"`
+		} `json:"invitations"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode output: %v (%q)", err, stdout)
+	}
+	if !result.Applied || result.Summary.Invited != 1 || result.Summary.Updated != 1 || result.Summary.Removed != 1 || result.Summary.Failed != 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(result.Invitations) != 1 || result.Invitations[0].ID != "INV_NEW" || result.Invitations[0].Status != "applied" || result.Invitations[0].ExpirationDate == "" {
+		t.Fatalf("unexpected invitations: %+v", result.Invitations)
+	}
+	if strings.Contains(strings.Join(requests, ","), "U_OWNER") {
+		t.Fatalf("expected account holder to be left alone, got %v", requests)
+	}
+}
+
+func TestUsersApplyPruneRequiresConfirm(t *testing.T) {
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	var runErr error
+	_, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"users", "apply", "--file", "team.yaml", "--prune"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+	if !errors.Is(runErr, flag.ErrHelp) {
+		t.Fatalf("expected flag.ErrHelp, got %v", runErr)
+	}
+	if !strings.Contains(stderr, "--confirm is required with --prune") {
+		t.Fatalf("expected confirm error, got %q", stderr)
+	}
+}
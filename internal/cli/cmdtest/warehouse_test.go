@@ -0,0 +1,221 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runWarehouseCommand(t *testing.T, args []string) (string, string) {
+	t.Helper()
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+	return captureOutput(t, func() {
+		if err := root.Parse(args); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+}
+
+func TestWarehouseSyncAndQuery(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	t.Setenv("ASC_APP_ID", "")
+	t.Setenv("ASC_VENDOR_NUMBER", "")
+	warehouseDir := t.TempDir()
+
+	analyticsDir := t.TempDir()
+	reportDir := filepath.Join(analyticsDir, "commerce", "app-downloads-standard")
+	if err := os.MkdirAll(reportDir, 0o755); err != nil {
+		t.Fatalf("mkdir error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(reportDir, "2026-01-01-daily.csv"), []byte("Date\tTerritory\tCounts\n2026-01-01\tUS\t12\n2026-01-01\tDE\t3\n"), 0o600); err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+
+	salesRequests := 0
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/v1/salesReports":
+			salesRequests++
+			query := req.URL.Query()
+			if query.Get("filter[reportType]") != "SALES" || query.Get("filter[frequency]") != "DAILY" {
+				t.Fatalf("unexpected sales filters %v", query)
+			}
+			if query.Get("filter[reportDate]") != "2026-01-01" {
+				return jsonResponse(http.StatusNotFound, `{"errors":[{"status":"404","code":"NOT_FOUND","title":"Not Found","detail":"no report"}]}`)
+			}
+			return insightsGzipResponse(strings.Join([]string{
+				"Provider\tSKU\tProduct Type Identifier\tUnits\tDeveloper Proceeds\tBegin Date\tCountry Code",
+				"APPLE\tapp.sku\t1F\t10\t0\t01/01/2026\tUS",
+				"APPLE\tapp.sku\t1F\t4\t0\t01/01/2026\tDE",
+				"APPLE\tpro.monthly\tIAY\t2\t6.99\t01/01/2026\tUS",
+				"",
+			}, "\n")), nil
+		case "/v1/apps/app-1/customerReviews":
+			return jsonResponse(http.StatusOK, `{"data":[
+				{"type":"customerReviews","id":"r1","attributes":{"rating":1,"title":"Crashes","body":"It crashes on launch","territory":"USA","createdDate":"2026-01-02T00:00:00Z"}},
+				{"type":"customerReviews","id":"r2","attributes":{"rating":5,"title":"Great","body":"Love it","territory":"DEU","createdDate":"2026-01-03T00:00:00Z"}}
+			],"links":{}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	syncArgs := []string{
+		"warehouse", "sync", "--dir", warehouseDir, "--app", "app-1", "--vendor", "12345678",
+		"--sources", "sales,reviews,analytics", "--analytics-dir", analyticsDir,
+		"--from", "2026-01-01", "--to", "2026-01-02",
+	}
+	stdout, _ := runWarehouseCommand(t, syncArgs)
+
+	var syncResult struct {
+		Sources []struct {
+			Source  string   `json:"source"`
+			Tables  []string `json:"tables"`
+			Loaded  int      `json:"loaded"`
+			Skipped int      `json:"skipped"`
+			Missing []string `json:"missing"`
+			Rows    int      `json:"rows"`
+		} `json:"sources"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &syncResult); err != nil {
+		t.Fatalf("failed to parse sync output %q: %v", stdout, err)
+	}
+	if len(syncResult.Sources) != 3 {
+		t.Fatalf("expected three sources, got %+v", syncResult.Sources)
+	}
+	if sales := syncResult.Sources[0]; sales.Source != "sales" || sales.Loaded != 1 || sales.Rows != 3 || strings.Join(sales.Missing, ",") != "2026-01-02" {
+		t.Fatalf("unexpected sales stats %+v", sales)
+	}
+	if reviews := syncResult.Sources[1]; reviews.Source != "reviews" || reviews.Rows != 2 {
+		t.Fatalf("unexpected reviews stats %+v", reviews)
+	}
+	if analytics := syncResult.Sources[2]; analytics.Source != "analytics" || strings.Join(analytics.Tables, ",") != "analytics_app_downloads_standard" || analytics.Rows != 2 {
+		t.Fatalf("unexpected analytics stats %+v", analytics)
+	}
+
+	// A second sync skips the day already stored and only retries the missing one.
+	salesRequests = 0
+	stdout, _ = runWarehouseCommand(t, syncArgs)
+	if err := json.Unmarshal([]byte(stdout), &syncResult); err != nil {
+		t.Fatalf("failed to parse sync output %q: %v", stdout, err)
+	}
+	if salesRequests != 1 || syncResult.Sources[0].Skipped != 1 {
+		t.Fatalf("expected one skipped day and one request, got %d requests and %+v", salesRequests, syncResult.Sources[0])
+	}
+
+	stdout, _ = runWarehouseCommand(t, []string{
+		"warehouse", "query", "--dir", warehouseDir,
+		"SELECT territory, sum(units) AS units FROM sales GROUP BY territory ORDER BY units DESC",
+	})
+	var queryResult struct {
+		Columns []string         `json:"columns"`
+		Rows    []map[string]any `json:"rows"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &queryResult); err != nil {
+		t.Fatalf("failed to parse query output %q: %v", stdout, err)
+	}
+	if strings.Join(queryResult.Columns, ",") != "territory,units" || len(queryResult.Rows) != 2 {
+		t.Fatalf("unexpected query result %+v", queryResult)
+	}
+	if queryResult.Rows[0]["territory"] != "US" || queryResult.Rows[0]["units"] != 12.0 {
+		t.Fatalf("unexpected first row %v", queryResult.Rows[0])
+	}
+
+	stdout, _ = runWarehouseCommand(t, []string{
+		"warehouse", "query", "--dir", warehouseDir, "--output", "table",
+		"SELECT territory, counts FROM analytics_app_downloads_standard WHERE counts > 5",
+	})
+	if !strings.Contains(stdout, "US") || strings.Contains(stdout, "DE") {
+		t.Fatalf("unexpected analytics query output:\n%s", stdout)
+	}
+
+	stdout, _ = runWarehouseCommand(t, []string{
+		"warehouse", "query", "--dir", warehouseDir, "--output", "table",
+		"SELECT title, rating FROM reviews WHERE body LIKE '%crash%'",
+	})
+	if !strings.Contains(stdout, "Crashes") || strings.Contains(stdout, "Great") {
+		t.Fatalf("unexpected reviews query output:\n%s", stdout)
+	}
+
+	stdout, _ = runWarehouseCommand(t, []string{"warehouse", "tables", "--dir", warehouseDir})
+	var tables struct {
+		Stored []struct {
+			Name string `json:"name"`
+			Rows int    `json:"rows"`
+		} `json:"stored"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &tables); err != nil {
+		t.Fatalf("failed to parse tables output %q: %v", stdout, err)
+	}
+	if len(tables.Stored) != 3 {
+		t.Fatalf("expected three stored tables, got %+v", tables.Stored)
+	}
+}
+
+func TestWarehouseValidationErrors(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+	t.Setenv("ASC_VENDOR_NUMBER", "")
+	t.Setenv("ASC_ANALYTICS_VENDOR_NUMBER", "")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "nothing to sync",
+			args:    []string{"warehouse", "sync", "--dir", t.TempDir()},
+			wantErr: "nothing to sync",
+		},
+		{
+			name:    "unknown source",
+			args:    []string{"warehouse", "sync", "--dir", t.TempDir(), "--sources", "crashes"},
+			wantErr: "--sources must be a comma-separated list of",
+		},
+		{
+			name:    "sales without vendor",
+			args:    []string{"warehouse", "sync", "--dir", t.TempDir(), "--sources", "sales"},
+			wantErr: "--vendor is required for sales and finance",
+		},
+		{
+			name:    "missing query",
+			args:    []string{"warehouse", "query", "--dir", t.TempDir()},
+			wantErr: "a query is required",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+			_, stderr := captureOutput(t, func() {
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected flag.ErrHelp, got %v", err)
+				}
+			})
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected %q in stderr, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
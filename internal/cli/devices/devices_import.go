@@ -0,0 +1,634 @@
+package devices
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	deviceImportRegistered    = "registered"
+	deviceImportWouldRegister = "would-register"
+	deviceImportExists        = "exists"
+	deviceImportDuplicate     = "duplicate"
+	deviceImportInvalid       = "invalid"
+	deviceImportFailed        = "failed"
+
+	profileRefreshRegenerated     = "regenerated"
+	profileRefreshWouldRegenerate = "would-regenerate"
+	profileRefreshUnchanged       = "unchanged"
+	profileRefreshFailed          = "failed"
+)
+
+const (
+	deviceImportDefaultPlatform = "IOS"
+	deviceImportMaxFileBytes    = 10 << 20
+)
+
+type deviceImportRow struct {
+	Line     int    `json:"line"`
+	UDID     string `json:"udid"`
+	Name     string `json:"name,omitempty"`
+	Platform string `json:"platform,omitempty"`
+	Status   string `json:"status"`
+	DeviceID string `json:"deviceId,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+type deviceImportProfile struct {
+	BundleID     string `json:"bundleId"`
+	ProfileID    string `json:"profileId"`
+	Name         string `json:"name"`
+	ProfileType  string `json:"profileType"`
+	NewProfileID string `json:"newProfileId,omitempty"`
+	Devices      int    `json:"devices"`
+	AddedDevices int    `json:"addedDevices"`
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+}
+
+type deviceImportSummary struct {
+	Rows        int `json:"rows"`
+	Registered  int `json:"registered"`
+	Existing    int `json:"existing"`
+	Duplicates  int `json:"duplicates"`
+	Failed      int `json:"failed"`
+	Regenerated int `json:"regeneratedProfiles"`
+}
+
+type deviceImportResult struct {
+	File     string                `json:"file"`
+	DryRun   bool                  `json:"dryRun"`
+	Summary  deviceImportSummary   `json:"summary"`
+	Rows     []deviceImportRow     `json:"rows"`
+	Profiles []deviceImportProfile `json:"profiles,omitempty"`
+}
+
+// DevicesImportCommand returns the devices import subcommand.
+func DevicesImportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+
+	file := fs.String("file", "", "Device list in Apple's upload format (.txt/.tsv or .csv)")
+	platform := fs.String("platform", deviceImportDefaultPlatform, "Platform for rows without one: "+strings.Join(devicePlatformList(), ", "))
+	refreshProfiles := fs.Bool("refresh-profiles", false, "Regenerate development and ad hoc profiles so they include the imported devices")
+	bundleIDs := fs.String("bundle-id", "", "Bundle identifier(s) whose profiles to refresh, comma-separated (required with --refresh-profiles)")
+	dryRun := fs.Bool("dry-run", false, "Report what would be registered and regenerated without changing anything")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "import",
+		ShortUsage: "asc devices import --file devices.txt [flags]",
+		ShortHelp:  "Register devices in bulk from a device list file.",
+		LongHelp: `Register devices in bulk from a device list file.
+
+Accepts Apple's multiple device upload format: a tab-separated file with
+Device ID, Device Name, and Device Platform columns and an optional header
+row. Comma-separated files are accepted too. Rows whose UDID is already
+registered, or repeated in the file, are skipped.
+
+With --refresh-profiles, the development and ad hoc profiles of each
+--bundle-id are deleted and recreated with the same name, certificates, and
+devices plus the newly registered devices of a matching platform.
+
+Examples:
+  asc devices import --file "./devices.txt"
+  asc devices import --file "./devices.csv" --dry-run
+  asc devices import --file "./devices.txt" --refresh-profiles --bundle-id "com.example.app,com.example.app.widget"
+
+File format:
+  Device ID	Device Name	Device Platform
+  00008030-001A2B3C4D5E6F70	Ada's iPhone	ios
+  A1B2C3D4-E5F6-7890-ABCD-EF1234567890	Build Mac	mac`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			filePath := strings.TrimSpace(*file)
+			if filePath == "" {
+				fmt.Fprintln(os.Stderr, "Error: --file is required")
+				return flag.ErrHelp
+			}
+			defaultPlatform, err := normalizeDevicePlatform(*platform)
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+			bundleIdentifiers := shared.SplitCSV(*bundleIDs)
+			if *refreshProfiles && len(bundleIdentifiers) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --bundle-id is required with --refresh-profiles")
+				return flag.ErrHelp
+			}
+			if !*refreshProfiles && len(bundleIdentifiers) > 0 {
+				return shared.UsageError("--bundle-id requires --refresh-profiles")
+			}
+
+			rows, err := readDeviceImportFile(filePath, defaultPlatform)
+			if err != nil {
+				return fmt.Errorf("devices import: %w", err)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("devices import: %w", err)
+			}
+
+			existing, err := listRegisteredDeviceIDs(ctx, client)
+			if err != nil {
+				return fmt.Errorf("devices import: list devices: %w", err)
+			}
+
+			result := &deviceImportResult{File: filePath, DryRun: *dryRun}
+			result.Rows = registerImportedDevices(ctx, client, rows, existing, *dryRun)
+
+			if *refreshProfiles {
+				added := make(map[string][]string)
+				for _, row := range result.Rows {
+					if row.Status == deviceImportRegistered || row.Status == deviceImportWouldRegister {
+						added[row.Platform] = append(added[row.Platform], row.DeviceID)
+					}
+				}
+				for _, bundleIdentifier := range bundleIdentifiers {
+					profiles, err := refreshBundleProfiles(ctx, client, bundleIdentifier, added, *dryRun)
+					if err != nil {
+						return fmt.Errorf("devices import: refresh profiles for %s: %w", bundleIdentifier, err)
+					}
+					result.Profiles = append(result.Profiles, profiles...)
+				}
+			}
+
+			result.Summary = summarizeDeviceImport(result)
+
+			if err := shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderDeviceImportResult(result, false) },
+				func() error { return renderDeviceImportResult(result, true) },
+			); err != nil {
+				return err
+			}
+			if result.Summary.Failed > 0 {
+				return shared.NewReportedError(fmt.Errorf("devices import: %d row(s) or profile(s) failed", result.Summary.Failed))
+			}
+			return nil
+		},
+	}
+}
+
+// readDeviceImportFile parses a device upload file. Rows with missing or
+// invalid values are returned with status invalid rather than failing the
+// whole import.
+func readDeviceImportFile(path, defaultPlatform string) ([]deviceImportRow, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(file, deviceImportMaxFileBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > deviceImportMaxFileBytes {
+		return nil, fmt.Errorf("%s is larger than %d bytes", path, deviceImportMaxFileBytes)
+	}
+	return parseDeviceImport(string(data), detectDeviceImportDelimiter(path, string(data)), defaultPlatform)
+}
+
+func detectDeviceImportDelimiter(path, content string) rune {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return ','
+	}
+	for line := range strings.SplitSeq(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.Contains(line, "\t") {
+			return '\t'
+		}
+		return ','
+	}
+	return '\t'
+}
+
+func parseDeviceImport(content string, delimiter rune, defaultPlatform string) ([]deviceImportRow, error) {
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(content, "\ufeff")))
+	reader.Comma = delimiter
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	columns := map[string]int{"udid": 0, "name": 1, "platform": 2}
+	var rows []deviceImportRow
+	first := true
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if isBlankDeviceRecord(record) {
+			continue
+		}
+		if first {
+			first = false
+			if header, ok := deviceImportHeader(record); ok {
+				columns = header
+				continue
+			}
+		}
+
+		row := deviceImportRow{
+			Line:     line,
+			UDID:     deviceImportField(record, columns["udid"]),
+			Name:     deviceImportField(record, columns["name"]),
+			Platform: deviceImportField(record, columns["platform"]),
+		}
+		row.Platform, err = normalizeDeviceImportPlatform(row.Platform, defaultPlatform)
+		switch {
+		case row.UDID == "":
+			row.Status, row.Error = deviceImportInvalid, "device ID is required"
+		case row.Name == "":
+			row.Status, row.Error = deviceImportInvalid, "device name is required"
+		case err != nil:
+			row.Status, row.Error = deviceImportInvalid, err.Error()
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// deviceImportHeader maps header names to column indexes when record is a
+// header row.
+func deviceImportHeader(record []string) (map[string]int, bool) {
+	columns := map[string]int{"udid": -1, "name": -1, "platform": -1}
+	for i, field := range record {
+		switch strings.ToLower(strings.TrimSpace(field)) {
+		case "device id", "udid", "device udid":
+			columns["udid"] = i
+		case "device name", "name":
+			columns["name"] = i
+		case "device platform", "platform":
+			columns["platform"] = i
+		}
+	}
+	if columns["udid"] < 0 {
+		return nil, false
+	}
+	return columns, true
+}
+
+func deviceImportField(record []string, index int) string {
+	if index < 0 || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+func isBlankDeviceRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeDeviceImportPlatform accepts Apple's upload file values (ios, mac)
+// as well as API platform names.
+func normalizeDeviceImportPlatform(value, defaultPlatform string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "":
+		return defaultPlatform, nil
+	case "ios", "ipados", "watchos":
+		return "IOS", nil
+	case "mac", "macos", "mac_os", "osx":
+		return "MAC_OS", nil
+	case "tvos", "tv_os":
+		return "TV_OS", nil
+	case "visionos", "vision_os", "xros":
+		return "VISION_OS", nil
+	}
+	return "", fmt.Errorf("unsupported device platform %q", value)
+}
+
+// listRegisteredDeviceIDs maps registered UDIDs to device IDs. Each API call
+// in this file gets its own timeout so large imports are not cut short.
+func listRegisteredDeviceIDs(ctx context.Context, client *asc.Client) (map[string]string, error) {
+	existing := make(map[string]string)
+	next := ""
+	for {
+		pageCtx, cancel := shared.ContextWithTimeout(ctx)
+		resp, err := client.GetDevices(pageCtx, asc.WithDevicesLimit(200), asc.WithDevicesNextURL(next))
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		for _, device := range resp.Data {
+			existing[strings.ToUpper(strings.TrimSpace(device.Attributes.UDID))] = device.ID
+		}
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return existing, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+func registerImportedDevices(ctx context.Context, client *asc.Client, rows []deviceImportRow, existing map[string]string, dryRun bool) []deviceImportRow {
+	seen := make(map[string]int)
+	for i := range rows {
+		row := &rows[i]
+		if row.Status == deviceImportInvalid {
+			continue
+		}
+		key := strings.ToUpper(row.UDID)
+		if line, ok := seen[key]; ok {
+			row.Status = deviceImportDuplicate
+			row.Error = "duplicate of line " + strconv.Itoa(line)
+			continue
+		}
+		seen[key] = row.Line
+
+		if id, ok := existing[key]; ok {
+			row.Status = deviceImportExists
+			row.DeviceID = id
+			continue
+		}
+		if dryRun {
+			row.Status = deviceImportWouldRegister
+			continue
+		}
+
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		resp, err := client.CreateDevice(requestCtx, asc.DeviceCreateAttributes{
+			Name:     row.Name,
+			UDID:     row.UDID,
+			Platform: asc.DevicePlatform(row.Platform),
+		})
+		cancel()
+		if err != nil {
+			row.Status = deviceImportFailed
+			row.Error = err.Error()
+			continue
+		}
+		row.Status = deviceImportRegistered
+		row.DeviceID = resp.Data.ID
+	}
+	return rows
+}
+
+// refreshBundleProfiles recreates the development and ad hoc profiles of a
+// bundle ID with the added devices of a matching platform. The API cannot add
+// devices to an existing profile, so each profile is deleted and recreated
+// under the same name.
+func refreshBundleProfiles(ctx context.Context, client *asc.Client, bundleIdentifier string, added map[string][]string, dryRun bool) ([]deviceImportProfile, error) {
+	bundleResourceID, err := findBundleIDResource(ctx, client, bundleIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles []asc.Resource[asc.ProfileAttributes]
+	next := ""
+	for {
+		pageCtx, cancel := shared.ContextWithTimeout(ctx)
+		resp, err := client.GetBundleIDProfiles(pageCtx, bundleResourceID, asc.WithBundleIDProfilesLimit(200), asc.WithBundleIDProfilesNextURL(next))
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("list profiles: %w", err)
+		}
+		profiles = append(profiles, resp.Data...)
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			break
+		}
+		next = resp.Links.Next
+	}
+
+	var results []deviceImportProfile
+	for _, profile := range profiles {
+		profileType := profile.Attributes.ProfileType
+		if !isDeviceProfileType(profileType) {
+			continue
+		}
+		result := deviceImportProfile{
+			BundleID:    bundleIdentifier,
+			ProfileID:   profile.ID,
+			Name:        profile.Attributes.Name,
+			ProfileType: profileType,
+		}
+
+		certIDs, deviceIDs, err := profileLinkageIDs(ctx, client, profile.ID)
+		if err != nil {
+			result.Status, result.Error = profileRefreshFailed, err.Error()
+			results = append(results, result)
+			continue
+		}
+		merged, addedCount := mergeDeviceIDs(deviceIDs, added[profileDevicePlatform(profileType)])
+		result.Devices = len(merged)
+		result.AddedDevices = addedCount
+
+		switch {
+		case addedCount == 0:
+			result.Status = profileRefreshUnchanged
+		case dryRun:
+			result.Status = profileRefreshWouldRegenerate
+		default:
+			deleteCtx, deleteCancel := shared.ContextWithTimeout(ctx)
+			err := client.DeleteProfile(deleteCtx, profile.ID)
+			deleteCancel()
+			if err != nil {
+				result.Status, result.Error = profileRefreshFailed, fmt.Sprintf("delete profile: %v", err)
+				break
+			}
+			createCtx, createCancel := shared.ContextWithTimeout(ctx)
+			created, err := client.CreateProfile(createCtx, asc.ProfileCreateAttributes{
+				Name:        profile.Attributes.Name,
+				ProfileType: profileType,
+			}, bundleResourceID, certIDs, merged)
+			createCancel()
+			if err != nil {
+				result.Status, result.Error = profileRefreshFailed, fmt.Sprintf("profile was deleted but could not be recreated: %v", err)
+				break
+			}
+			result.Status = profileRefreshRegenerated
+			result.NewProfileID = created.Data.ID
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func findBundleIDResource(ctx context.Context, client *asc.Client, identifier string) (string, error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	resp, err := client.GetBundleIDs(requestCtx, asc.WithBundleIDsFilterIdentifier(identifier))
+	if err != nil {
+		return "", fmt.Errorf("find bundle ID: %w", err)
+	}
+	for _, item := range resp.Data {
+		if item.Attributes.Identifier == identifier {
+			return item.ID, nil
+		}
+	}
+	return "", fmt.Errorf("bundle ID %q not found", identifier)
+}
+
+func profileLinkageIDs(ctx context.Context, client *asc.Client, profileID string) ([]string, []string, error) {
+	certIDs, err := collectLinkageIDs(ctx, func(ctx context.Context, next string) (*asc.LinkagesResponse, error) {
+		return client.GetProfileCertificatesRelationships(ctx, profileID, asc.WithLinkagesLimit(200), asc.WithLinkagesNextURL(next))
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list certificates: %w", err)
+	}
+	deviceIDs, err := collectLinkageIDs(ctx, func(ctx context.Context, next string) (*asc.LinkagesResponse, error) {
+		return client.GetProfileDevicesRelationships(ctx, profileID, asc.WithLinkagesLimit(200), asc.WithLinkagesNextURL(next))
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list devices: %w", err)
+	}
+	return certIDs, deviceIDs, nil
+}
+
+func collectLinkageIDs(ctx context.Context, fetch func(ctx context.Context, next string) (*asc.LinkagesResponse, error)) ([]string, error) {
+	var ids []string
+	next := ""
+	for {
+		pageCtx, cancel := shared.ContextWithTimeout(ctx)
+		resp, err := fetch(pageCtx, next)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range resp.Data {
+			ids = append(ids, item.ID)
+		}
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return ids, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+// mergeDeviceIDs appends added to current, skipping IDs already present, and
+// returns how many were new. Dry runs pass empty IDs, which count as new.
+func mergeDeviceIDs(current, added []string) ([]string, int) {
+	merged := append([]string{}, current...)
+	present := make(map[string]bool, len(current))
+	for _, id := range current {
+		present[id] = true
+	}
+	count := 0
+	for _, id := range added {
+		if id != "" && present[id] {
+			continue
+		}
+		if id != "" {
+			present[id] = true
+			merged = append(merged, id)
+		}
+		count++
+	}
+	return merged, count
+}
+
+// isDeviceProfileType reports whether profiles of this type list devices.
+func isDeviceProfileType(profileType string) bool {
+	normalized := strings.ToUpper(profileType)
+	return strings.HasSuffix(normalized, "_DEVELOPMENT") || strings.HasSuffix(normalized, "_ADHOC")
+}
+
+// profileDevicePlatform returns the device platform a profile type targets.
+// Mac and Mac Catalyst profiles take Macs, tvOS profiles take Apple TVs, and
+// every other device profile takes IOS devices.
+func profileDevicePlatform(profileType string) string {
+	normalized := strings.ToUpper(profileType)
+	switch {
+	case strings.HasPrefix(normalized, "MAC_"):
+		return "MAC_OS"
+	case strings.HasPrefix(normalized, "TVOS_"):
+		return "TV_OS"
+	default:
+		return "IOS"
+	}
+}
+
+func summarizeDeviceImport(result *deviceImportResult) deviceImportSummary {
+	summary := deviceImportSummary{Rows: len(result.Rows)}
+	for _, row := range result.Rows {
+		switch row.Status {
+		case deviceImportRegistered, deviceImportWouldRegister:
+			summary.Registered++
+		case deviceImportExists:
+			summary.Existing++
+		case deviceImportDuplicate:
+			summary.Duplicates++
+		case deviceImportInvalid, deviceImportFailed:
+			summary.Failed++
+		}
+	}
+	for _, profile := range result.Profiles {
+		switch profile.Status {
+		case profileRefreshRegenerated, profileRefreshWouldRegenerate:
+			summary.Regenerated++
+		case profileRefreshFailed:
+			summary.Failed++
+		}
+	}
+	return summary
+}
+
+func renderDeviceImportResult(result *deviceImportResult, markdown bool) error {
+	if result == nil {
+		return fmt.Errorf("result is nil")
+	}
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	rows := make([][]string, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		rows = append(rows, []string{
+			strconv.Itoa(row.Line),
+			row.UDID,
+			row.Name,
+			row.Platform,
+			row.Status,
+			row.DeviceID,
+			row.Error,
+		})
+	}
+	render([]string{"Line", "UDID", "Name", "Platform", "Status", "Device ID", "Error"}, rows)
+
+	if len(result.Profiles) > 0 {
+		profileRows := make([][]string, 0, len(result.Profiles))
+		for _, profile := range result.Profiles {
+			profileRows = append(profileRows, []string{
+				profile.BundleID,
+				profile.Name,
+				profile.ProfileType,
+				profile.Status,
+				strconv.Itoa(profile.AddedDevices),
+				profile.NewProfileID,
+				profile.Error,
+			})
+		}
+		render([]string{"Bundle ID", "Profile", "Type", "Status", "Added Devices", "New Profile ID", "Error"}, profileRows)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package devices
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDeviceImport_AppleTSVWithHeader(t *testing.T) {
+	content := "\ufeffDevice ID\tDevice Name\tDevice Platform\n" +
+		"00008030-AAA\tAda's iPhone\tios\n" +
+		"\n" +
+		"# comment\n" +
+		"MAC-UDID\tBuild Mac\tmac\n" +
+		"NO-NAME\t\tios\n" +
+		"BAD-PLATFORM\tWatch\tpebble\n"
+
+	rows, err := parseDeviceImport(content, detectDeviceImportDelimiter("devices.txt", content), "IOS")
+	if err != nil {
+		t.Fatalf("parseDeviceImport() error: %v", err)
+	}
+
+	got := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		got = append(got, []string{row.UDID, row.Name, row.Platform, row.Status})
+	}
+	want := [][]string{
+		{"00008030-AAA", "Ada's iPhone", "IOS", ""},
+		{"MAC-UDID", "Build Mac", "MAC_OS", ""},
+		{"NO-NAME", "", "IOS", deviceImportInvalid},
+		{"BAD-PLATFORM", "Watch", "", deviceImportInvalid},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("rows = %v, want %v", got, want)
+	}
+	if rows[1].Line != 5 {
+		t.Fatalf("expected source line numbers, got %d", rows[1].Line)
+	}
+}
+
+func TestParseDeviceImport_CSVWithoutHeaderUsesDefaultPlatform(t *testing.T) {
+	content := "UDID-1,\"Lab iPad, 3rd floor\"\nUDID-2,Apple TV,tvos\n"
+
+	rows, err := parseDeviceImport(content, detectDeviceImportDelimiter("devices.csv", content), "IOS")
+	if err != nil {
+		t.Fatalf("parseDeviceImport() error: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Name != "Lab iPad, 3rd floor" || rows[0].Platform != "IOS" || rows[1].Platform != "TV_OS" {
+		t.Fatalf("unexpected rows: %+v", rows)
+	}
+}
+
+func TestMergeDeviceIDs(t *testing.T) {
+	merged, added := mergeDeviceIDs([]string{"A", "B"}, []string{"B", "C"})
+	if !reflect.DeepEqual(merged, []string{"A", "B", "C"}) || added != 1 {
+		t.Fatalf("mergeDeviceIDs() = %v, %d", merged, added)
+	}
+
+	// Dry runs have no device IDs yet but still count as additions.
+	merged, added = mergeDeviceIDs([]string{"A"}, []string{"", ""})
+	if !reflect.DeepEqual(merged, []string{"A"}) || added != 2 {
+		t.Fatalf("mergeDeviceIDs() dry run = %v, %d", merged, added)
+	}
+}
+
+func TestProfileDevicePlatform(t *testing.T) {
+	tests := map[string]string{
+		"IOS_APP_DEVELOPMENT":          "IOS",
+		"IOS_APP_ADHOC":                "IOS",
+		"MAC_APP_DEVELOPMENT":          "MAC_OS",
+		"MAC_CATALYST_APP_DEVELOPMENT": "MAC_OS",
+		"TVOS_APP_ADHOC":               "TV_OS",
+	}
+	for profileType, want := range tests {
+		if got := profileDevicePlatform(profileType); got != want {
+			t.Fatalf("profileDevicePlatform(%q) = %q, want %q", profileType, got, want)
+		}
+	}
+	if isDeviceProfileType("IOS_APP_STORE") || !isDeviceProfileType("IOS_APP_ADHOC") {
+		t.Fatal("expected only development and ad hoc profiles to carry devices")
+	}
+}
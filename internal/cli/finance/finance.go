@@ -11,6 +11,7 @@ import (
 
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/reports"
 )
 
 // FinanceCommand returns the finance command with subcommands.
@@ -28,12 +29,14 @@ Requires Account Holder, Admin, or Finance role.
 
 Examples:
   asc finance reports --vendor "12345678" --report-type FINANCIAL --region "US" --date "2025-12"
-  asc finance regions --output table`,
+  asc finance regions --output table
+  asc finance summarize --vendor "12345678" --date "2025-12" --group-by app,territory --currency USD`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
 			FinanceReportsCommand(),
 			FinanceRegionsCommand(),
+			FinanceSummarizeCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
@@ -49,7 +52,7 @@ func FinanceReportsCommand() *ffcli.Command {
 	reportType := fs.String("report-type", "", "Report type: FINANCIAL or FINANCE_DETAIL (see help for UI mapping)")
 	region := fs.String("region", "", "Region code (e.g., US, ZZ, Z1; see 'asc finance regions')")
 	date := fs.String("date", "", "Report date (YYYY-MM, Apple fiscal month)")
-	output := fs.String("output", "", "Output file path (default: finance_report_{date}_{type}_{region}.tsv.gz)")
+	output := fs.String("output", "", "Output file path (default: finance_report_{date}_{type}_{region}.tsv.gz), or json/csv to print parsed rows")
 	decompress := fs.Bool("decompress", false, "Decompress gzip output to .tsv")
 	outputFlags := shared.BindMetadataOutputFlags(fs)
 
@@ -88,6 +91,13 @@ REGION CODES:
 
   Run 'asc finance regions' for the complete list.
 
+PARSED OUTPUT:
+
+  Pass --output json or --output csv to print normalized rows to stdout instead
+  of saving the file: dates become YYYY-MM-DD, quantities and amounts become
+  numbers, product type codes are decoded, and Total_* summary lines are
+  dropped. To save a file literally named json or csv, pass ./json or ./csv.
+
 Examples:
   # Download single consolidated report (all regions)
   asc finance reports --vendor "12345678" --report-type FINANCIAL --region "ZZ" --date "2025-12"
@@ -99,7 +109,10 @@ Examples:
   asc finance reports --vendor "12345678" --report-type FINANCE_DETAIL --region "Z1" --date "2025-12" --decompress
 
   # Save to custom path
-  asc finance reports --vendor "12345678" --report-type FINANCIAL --region "US" --date "2025-12" --output "reports/finance.tsv.gz"`,
+  asc finance reports --vendor "12345678" --report-type FINANCIAL --region "US" --date "2025-12" --output "reports/finance.tsv.gz"
+
+  # Print normalized rows
+  asc finance reports --vendor "12345678" --report-type FINANCIAL --region "ZZ" --date "2025-12" --output csv > finance.csv`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -133,6 +146,10 @@ Examples:
 			if err != nil {
 				return fmt.Errorf("finance reports: %w", err)
 			}
+			rowsFormat := shared.ReportRowsFormat(*output)
+			if rowsFormat != "" && *decompress {
+				return shared.UsageErrorf("--decompress cannot be used with --output %s", rowsFormat)
+			}
 			defaultOutput := fmt.Sprintf("finance_report_%s_%s_%s.tsv.gz", reportDate, string(normalizedReportType), regionCode)
 			compressedPath, decompressedPath := shared.ResolveReportOutputPaths(*output, defaultOutput, ".tsv", *decompress)
 
@@ -155,6 +172,14 @@ Examples:
 			}
 			defer func() { _ = download.Body.Close() }()
 
+			if rowsFormat != "" {
+				report, err := reports.ParseFinancial(download.Body)
+				if err != nil {
+					return fmt.Errorf("finance reports: %w", err)
+				}
+				return shared.PrintReportRows(report.Rows, rowsFormat, *outputFlags.Pretty)
+			}
+
 			compressedSize, err := shared.WriteStreamToFile(compressedPath, download.Body)
 			if err != nil {
 				return fmt.Errorf("finance reports: failed to write report: %w", err)
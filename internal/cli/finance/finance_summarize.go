@@ -0,0 +1,552 @@
+package finance
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/reports"
+)
+
+const (
+	summaryGroupApp       = "app"
+	summaryGroupTerritory = "territory"
+	summaryGroupProduct   = "product"
+	summaryGroupCategory  = "category"
+
+	unattributedAppName = "(unattributed)"
+)
+
+var summaryGroupDimensions = []string{summaryGroupApp, summaryGroupTerritory, summaryGroupProduct, summaryGroupCategory}
+
+// FinanceSummaryRow is one group of a finance summary. Only the grouped
+// dimensions are set.
+type FinanceSummaryRow struct {
+	App        string  `json:"app,omitempty"`
+	AppAppleID string  `json:"appAppleId,omitempty"`
+	Territory  string  `json:"territory,omitempty"`
+	Product    string  `json:"product,omitempty"`
+	Category   string  `json:"category,omitempty"`
+	Units      float64 `json:"units"`
+	Proceeds   float64 `json:"proceeds"`
+}
+
+// FinanceSummaryRegion reconciles one regional FINANCIAL report.
+type FinanceSummaryRegion struct {
+	Region            string   `json:"region"`
+	Currency          string   `json:"currency"`
+	Rows              int      `json:"rows"`
+	Units             float64  `json:"units"`
+	Proceeds          float64  `json:"proceeds"`
+	ReportedTotal     *float64 `json:"reportedTotal,omitempty"`
+	Reconciled        bool     `json:"reconciled"`
+	ExchangeRate      float64  `json:"exchangeRate"`
+	ConvertedProceeds float64  `json:"convertedProceeds"`
+}
+
+// FinanceSummaryResult is the output of finance summarize.
+type FinanceSummaryResult struct {
+	VendorNumber  string                 `json:"vendorNumber"`
+	ReportDate    string                 `json:"reportDate"`
+	Currency      string                 `json:"currency"`
+	GroupBy       []string               `json:"groupBy"`
+	Rows          []FinanceSummaryRow    `json:"rows"`
+	Regions       []FinanceSummaryRegion `json:"regions"`
+	TotalUnits    float64                `json:"totalUnits"`
+	TotalProceeds float64                `json:"totalProceeds"`
+	Unattributed  int                    `json:"unattributedRows"`
+	Reconciled    bool                   `json:"reconciled"`
+}
+
+// FinanceSummarizeCommand rolls FINANCIAL reports up into one currency.
+func FinanceSummarizeCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("summarize", flag.ExitOnError)
+
+	vendor := fs.String("vendor", "", "Vendor number (or ASC_VENDOR_NUMBER env)")
+	date := fs.String("date", "", "Report date (YYYY-MM, Apple fiscal month)")
+	groupBy := fs.String("group-by", summaryGroupApp, "Comma-separated grouping: app, territory, product, category")
+	currency := fs.String("currency", "USD", "Currency to convert proceeds into")
+	rates := fs.String("rates", "", "Comma-separated exchange rate overrides as CUR=RATE (1 CUR = RATE target currency)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "summarize",
+		ShortUsage: "asc finance summarize --date YYYY-MM [flags]",
+		ShortHelp:  "Roll up monthly proceeds per app and territory in one currency.",
+		LongHelp: `Roll up monthly proceeds per app and territory in one currency.
+
+Downloads the FINANCIAL report of every region for the fiscal month, converts
+each region's proceeds (Extended Partner Share) with the exchange rates from
+the month's FINANCE_DETAIL report, and groups them by the requested
+dimensions. In-app purchase and subscription proceeds are attributed to their
+parent app through the Parent Identifier of the month's sales report, the
+same way 'asc insights' does.
+
+The regions table reconciles each report against Apple's Total_Amount line so
+the converted total can be checked against the payment. Rates missing from the
+FINANCE_DETAIL report can be supplied with --rates.
+
+Requires Account Holder, Admin, or Finance role.
+
+Examples:
+  asc finance summarize --vendor "12345678" --date 2026-09
+  asc finance summarize --vendor "12345678" --date 2026-09 --group-by app,territory --currency USD --output table
+  asc finance summarize --vendor "12345678" --date 2026-09 --currency EUR --rates "USD=0.92,GBP=1.17"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			vendorNumber := shared.ResolveVendorNumber(*vendor)
+			if vendorNumber == "" {
+				fmt.Fprintln(os.Stderr, "Error: --vendor is required (or set ASC_VENDOR_NUMBER)")
+				return flag.ErrHelp
+			}
+			if strings.TrimSpace(*date) == "" {
+				fmt.Fprintln(os.Stderr, "Error: --date is required")
+				return flag.ErrHelp
+			}
+			reportDate, err := normalizeFinanceReportDate(*date)
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+			dimensions, err := parseSummaryGroupBy(*groupBy)
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+			targetCurrency := strings.ToUpper(strings.TrimSpace(*currency))
+			if len(targetCurrency) != 3 {
+				return shared.UsageError("--currency must be a three-letter currency code")
+			}
+			overrides, err := parseSummaryRates(*rates)
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("finance summarize: %w", err)
+			}
+
+			regionReports, err := fetchRegionalFinancialReports(ctx, client, vendorNumber, reportDate)
+			if err != nil {
+				return fmt.Errorf("finance summarize: %w", err)
+			}
+			if len(regionReports) == 0 {
+				return fmt.Errorf("finance summarize: no FINANCIAL reports found for %s", reportDate)
+			}
+
+			converter := newCurrencyConverter(targetCurrency, overrides)
+			detail, err := downloadFinancialReport(ctx, client, vendorNumber, asc.FinanceReportTypeFinanceDetail, "Z1", reportDate)
+			switch {
+			case err == nil:
+				converter.addDetailRates(detail.Rows)
+			case asc.IsNotFound(err):
+			default:
+				return fmt.Errorf("finance summarize: failed to download FINANCE_DETAIL report: %w", err)
+			}
+
+			apps, err := fetchSummaryAppIndex(ctx, client, vendorNumber, reportDate)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not load the %s sales report for parent app attribution: %v\n", reportDate, err)
+			}
+
+			result, err := summarizeFinancialReports(regionReports, converter, apps, dimensions)
+			if err != nil {
+				return fmt.Errorf("finance summarize: %w", err)
+			}
+			result.VendorNumber = vendorNumber
+			result.ReportDate = reportDate
+
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderFinanceSummary(result, false) },
+				func() error { return renderFinanceSummary(result, true) },
+			)
+		},
+	}
+}
+
+type regionalFinancialReport struct {
+	region asc.FinanceRegion
+	report *reports.Report[reports.FinancialRow]
+}
+
+// fetchRegionalFinancialReports downloads the FINANCIAL report of every
+// single-currency region. Regions without sales have no report and are
+// skipped.
+func fetchRegionalFinancialReports(ctx context.Context, client *asc.Client, vendorNumber, reportDate string) ([]regionalFinancialReport, error) {
+	var results []regionalFinancialReport
+	for _, region := range asc.FinanceRegions() {
+		if region.RegionCode == "ZZ" || region.RegionCode == "Z1" {
+			continue
+		}
+		report, err := downloadFinancialReport(ctx, client, vendorNumber, asc.FinanceReportTypeFinancial, region.RegionCode, reportDate)
+		if err != nil {
+			if asc.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to download %s report: %w", region.RegionCode, err)
+		}
+		results = append(results, regionalFinancialReport{region: region, report: report})
+	}
+	return results, nil
+}
+
+func downloadFinancialReport(ctx context.Context, client *asc.Client, vendorNumber string, reportType asc.FinanceReportType, regionCode, reportDate string) (*reports.Report[reports.FinancialRow], error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	download, err := client.DownloadFinanceReport(requestCtx, asc.FinanceReportParams{
+		VendorNumber: vendorNumber,
+		ReportType:   reportType,
+		RegionCode:   regionCode,
+		ReportDate:   reportDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = download.Body.Close() }()
+
+	return reports.ParseFinancial(download.Body)
+}
+
+type summaryApp struct {
+	sku     string
+	name    string
+	appleID string
+}
+
+// summaryAppIndex maps product SKUs to their parent apps.
+type summaryAppIndex struct {
+	apps    map[string]summaryApp
+	parents map[string]string
+}
+
+func fetchSummaryAppIndex(ctx context.Context, client *asc.Client, vendorNumber, reportDate string) (*summaryAppIndex, error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	download, err := client.GetSalesReport(requestCtx, asc.SalesReportParams{
+		VendorNumber:  vendorNumber,
+		ReportType:    asc.SalesReportTypeSales,
+		ReportSubType: asc.SalesReportSubTypeSummary,
+		Frequency:     asc.SalesReportFrequencyMonthly,
+		ReportDate:    reportDate,
+		Version:       asc.SalesReportVersion1_0,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = download.Body.Close() }()
+
+	report, err := reports.ParseSales(download.Body)
+	if err != nil {
+		return nil, err
+	}
+	return newSummaryAppIndex(report.Rows), nil
+}
+
+func newSummaryAppIndex(rows []reports.SalesRow) *summaryAppIndex {
+	index := &summaryAppIndex{apps: map[string]summaryApp{}, parents: map[string]string{}}
+	for _, row := range rows {
+		if row.SKU == "" {
+			continue
+		}
+		if row.ParentIdentifier != "" {
+			index.parents[row.SKU] = row.ParentIdentifier
+			continue
+		}
+		if _, ok := index.apps[row.SKU]; !ok {
+			index.apps[row.SKU] = summaryApp{sku: row.SKU, name: row.Title, appleID: row.AppleIdentifier}
+		}
+	}
+	return index
+}
+
+// resolve returns the app a finance row's proceeds belong to, and false when
+// an in-app product could not be tied to a parent app.
+func (idx *summaryAppIndex) resolve(row reports.FinancialRow) (summaryApp, bool) {
+	sku := row.VendorIdentifier
+	isInApp := row.ProductCategory == reports.ProductCategoryInAppPurchase || row.ProductCategory == reports.ProductCategorySubscription
+	parent := row.ParentIdentifier
+	if parent == "" && idx != nil {
+		parent = idx.parents[sku]
+	}
+	if parent != "" {
+		isInApp = true
+		sku = parent
+	}
+	if idx != nil {
+		if app, ok := idx.apps[sku]; ok {
+			return app, true
+		}
+	}
+	if isInApp {
+		if parent == "" {
+			return summaryApp{name: unattributedAppName}, false
+		}
+		return summaryApp{sku: parent, name: parent}, true
+	}
+	return summaryApp{sku: sku, name: row.Title, appleID: row.AppleIdentifier}, true
+}
+
+// currencyConverter converts amounts into the target currency using rate
+// overrides first, then FINANCE_DETAIL rates (directly, or through the
+// payment currency they are quoted in).
+type currencyConverter struct {
+	target    string
+	overrides map[string]float64
+	rates     map[string]map[string]float64
+}
+
+func newCurrencyConverter(target string, overrides map[string]float64) *currencyConverter {
+	return &currencyConverter{target: target, overrides: overrides, rates: map[string]map[string]float64{}}
+}
+
+func (c *currencyConverter) addDetailRates(rows []reports.FinancialRow) {
+	for _, row := range rows {
+		from := strings.ToUpper(row.PartnerShareCurrency)
+		to := strings.ToUpper(row.PaymentCurrency)
+		if from == "" || to == "" || row.ExchangeRate <= 0 {
+			continue
+		}
+		if c.rates[from] == nil {
+			c.rates[from] = map[string]float64{}
+		}
+		if _, ok := c.rates[from][to]; !ok {
+			c.rates[from][to] = row.ExchangeRate
+		}
+	}
+}
+
+func (c *currencyConverter) rate(currency string) (float64, bool) {
+	currency = strings.ToUpper(currency)
+	if currency == c.target {
+		return 1, true
+	}
+	if rate, ok := c.overrides[currency]; ok {
+		return rate, true
+	}
+	if rate, ok := c.rates[currency][c.target]; ok {
+		return rate, true
+	}
+	for via, rate := range c.rates[currency] {
+		if targetRate, ok := c.rates[c.target][via]; ok && targetRate > 0 {
+			return rate / targetRate, true
+		}
+	}
+	return 0, false
+}
+
+func summarizeFinancialReports(regionReports []regionalFinancialReport, converter *currencyConverter, apps *summaryAppIndex, dimensions []string) (*FinanceSummaryResult, error) {
+	result := &FinanceSummaryResult{
+		Currency:   converter.target,
+		GroupBy:    dimensions,
+		Rows:       []FinanceSummaryRow{},
+		Regions:    []FinanceSummaryRegion{},
+		Reconciled: true,
+	}
+
+	var missing []string
+	groups := map[string]*FinanceSummaryRow{}
+	for _, regional := range regionReports {
+		currency := regional.region.ReportCurrency
+		for _, row := range regional.report.Rows {
+			if row.PartnerShareCurrency != "" {
+				currency = strings.ToUpper(row.PartnerShareCurrency)
+				break
+			}
+		}
+		rate, ok := converter.rate(currency)
+		if !ok {
+			missing = append(missing, currency)
+			continue
+		}
+
+		region := FinanceSummaryRegion{
+			Region:       regional.region.RegionCode,
+			Currency:     currency,
+			Rows:         len(regional.report.Rows),
+			ExchangeRate: rate,
+		}
+		for _, row := range regional.report.Rows {
+			region.Units += row.Quantity
+			region.Proceeds += row.ExtendedPartnerShare
+
+			app, attributed := apps.resolve(row)
+			if !attributed {
+				result.Unattributed++
+			}
+			group := FinanceSummaryRow{}
+			for _, dimension := range dimensions {
+				switch dimension {
+				case summaryGroupApp:
+					group.App = app.name
+					group.AppAppleID = app.appleID
+				case summaryGroupTerritory:
+					group.Territory = row.CountryOfSale
+				case summaryGroupProduct:
+					group.Product = row.VendorIdentifier
+				case summaryGroupCategory:
+					group.Category = row.ProductCategory
+				}
+			}
+			key := strings.Join([]string{group.App, group.AppAppleID, group.Territory, group.Product, group.Category}, "\x00")
+			existing, ok := groups[key]
+			if !ok {
+				existing = &group
+				groups[key] = existing
+			}
+			existing.Units += row.Quantity
+			existing.Proceeds += row.ExtendedPartnerShare * rate
+		}
+
+		region.ConvertedProceeds = roundCurrency(region.Proceeds * rate)
+		region.Proceeds = roundCurrency(region.Proceeds)
+		region.Reconciled = true
+		if reported, ok := regional.report.Totals["Total_Amount"]; ok {
+			region.ReportedTotal = &reported
+			region.Reconciled = math.Abs(reported-region.Proceeds) < 0.005
+		}
+		if !region.Reconciled {
+			result.Reconciled = false
+		}
+		result.TotalUnits += region.Units
+		result.TotalProceeds += region.ConvertedProceeds
+		result.Regions = append(result.Regions, region)
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		missing = slices.Compact(missing)
+		return nil, fmt.Errorf("no exchange rate from %s to %s in the FINANCE_DETAIL report; pass --rates %s=<rate>", strings.Join(missing, ", "), converter.target, missing[0])
+	}
+
+	for _, group := range groups {
+		group.Proceeds = roundCurrency(group.Proceeds)
+		result.Rows = append(result.Rows, *group)
+	}
+	sort.Slice(result.Rows, func(i, j int) bool {
+		left, right := result.Rows[i], result.Rows[j]
+		if left.Proceeds != right.Proceeds {
+			return left.Proceeds > right.Proceeds
+		}
+		return summaryRowLabel(left) < summaryRowLabel(right)
+	})
+	result.TotalProceeds = roundCurrency(result.TotalProceeds)
+	return result, nil
+}
+
+func parseSummaryGroupBy(value string) ([]string, error) {
+	dimensions := shared.SplitCSV(strings.ToLower(value))
+	if len(dimensions) == 0 {
+		return nil, fmt.Errorf("--group-by must include at least one of: %s", strings.Join(summaryGroupDimensions, ", "))
+	}
+	seen := map[string]bool{}
+	var result []string
+	for _, dimension := range dimensions {
+		if !slices.Contains(summaryGroupDimensions, dimension) {
+			return nil, fmt.Errorf("--group-by must be one or more of: %s", strings.Join(summaryGroupDimensions, ", "))
+		}
+		if !seen[dimension] {
+			seen[dimension] = true
+			result = append(result, dimension)
+		}
+	}
+	return result, nil
+}
+
+func parseSummaryRates(value string) (map[string]float64, error) {
+	rates := map[string]float64{}
+	for _, entry := range shared.SplitCSV(value) {
+		currency, raw, ok := strings.Cut(entry, "=")
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		rate, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if !ok || len(currency) != 3 || err != nil || rate <= 0 {
+			return nil, fmt.Errorf("--rates entries must look like EUR=1.08")
+		}
+		rates[currency] = rate
+	}
+	return rates, nil
+}
+
+func roundCurrency(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func summaryRowLabel(row FinanceSummaryRow) string {
+	return strings.Join([]string{row.App, row.Territory, row.Product, row.Category}, "/")
+}
+
+func formatAmount(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+func renderFinanceSummary(result *FinanceSummaryResult, markdown bool) error {
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	headers := []string{}
+	for _, dimension := range result.GroupBy {
+		headers = append(headers, strings.ToUpper(dimension[:1])+dimension[1:])
+	}
+	headers = append(headers, "Units", "Proceeds ("+result.Currency+")")
+	rows := make([][]string, 0, len(result.Rows)+1)
+	for _, row := range result.Rows {
+		var cells []string
+		for _, dimension := range result.GroupBy {
+			switch dimension {
+			case summaryGroupApp:
+				cells = append(cells, row.App)
+			case summaryGroupTerritory:
+				cells = append(cells, row.Territory)
+			case summaryGroupProduct:
+				cells = append(cells, row.Product)
+			case summaryGroupCategory:
+				cells = append(cells, row.Category)
+			}
+		}
+		cells = append(cells, strconv.FormatFloat(row.Units, 'f', -1, 64), formatAmount(row.Proceeds))
+		rows = append(rows, cells)
+	}
+	total := make([]string, len(result.GroupBy))
+	total[0] = "Total"
+	total = append(total, strconv.FormatFloat(result.TotalUnits, 'f', -1, 64), formatAmount(result.TotalProceeds))
+	rows = append(rows, total)
+	render(headers, rows)
+
+	regionRows := make([][]string, 0, len(result.Regions))
+	for _, region := range result.Regions {
+		reported := "n/a"
+		if region.ReportedTotal != nil {
+			reported = formatAmount(*region.ReportedTotal)
+		}
+		regionRows = append(regionRows, []string{
+			region.Region,
+			region.Currency,
+			formatAmount(region.Proceeds),
+			reported,
+			strconv.FormatBool(region.Reconciled),
+			strconv.FormatFloat(region.ExchangeRate, 'f', -1, 64),
+			formatAmount(region.ConvertedProceeds),
+		})
+	}
+	fmt.Println()
+	render([]string{"Region", "Currency", "Proceeds", "Reported Total", "Reconciled", "Rate", "Proceeds (" + result.Currency + ")"}, regionRows)
+	if result.Unattributed > 0 {
+		fmt.Printf("\n%d row(s) could not be attributed to a parent app.\n", result.Unattributed)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package finance
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/reports"
+)
+
+func TestSummarizeFinancialReports(t *testing.T) {
+	usTotal := 20.97
+	us := &reports.Report[reports.FinancialRow]{
+		Rows: []reports.FinancialRow{
+			{VendorIdentifier: "app.sku", Title: "My App", AppleIdentifier: "100", ProductCategory: reports.ProductCategoryApp, CountryOfSale: "US", Quantity: 1, ExtendedPartnerShare: 0.99, PartnerShareCurrency: "USD"},
+			{VendorIdentifier: "pro.monthly", Title: "Pro", ProductCategory: reports.ProductCategorySubscription, CountryOfSale: "US", Quantity: 3, ExtendedPartnerShare: 19.98, PartnerShareCurrency: "USD"},
+		},
+		Totals: map[string]float64{"Total_Amount": usTotal},
+	}
+	eu := &reports.Report[reports.FinancialRow]{
+		Rows: []reports.FinancialRow{
+			{VendorIdentifier: "pro.monthly", Title: "Pro", ProductCategory: reports.ProductCategorySubscription, CountryOfSale: "DE", Quantity: 2, ExtendedPartnerShare: 10, PartnerShareCurrency: "EUR"},
+			{VendorIdentifier: "orphan.iap", Title: "Orphan", ProductCategory: reports.ProductCategoryInAppPurchase, CountryOfSale: "FR", Quantity: 1, ExtendedPartnerShare: 5, PartnerShareCurrency: "EUR"},
+		},
+		Totals: map[string]float64{"Total_Amount": 16},
+	}
+
+	converter := newCurrencyConverter("USD", map[string]float64{})
+	converter.addDetailRates([]reports.FinancialRow{{PartnerShareCurrency: "EUR", PaymentCurrency: "USD", ExchangeRate: 1.1}})
+	apps := newSummaryAppIndex([]reports.SalesRow{
+		{SKU: "app.sku", Title: "My App", AppleIdentifier: "100"},
+		{SKU: "pro.monthly", ParentIdentifier: "app.sku"},
+	})
+
+	result, err := summarizeFinancialReports([]regionalFinancialReport{
+		{region: asc.FinanceRegion{RegionCode: "US", ReportCurrency: "USD"}, report: us},
+		{region: asc.FinanceRegion{RegionCode: "EU", ReportCurrency: "EUR"}, report: eu},
+	}, converter, apps, []string{"app"})
+	if err != nil {
+		t.Fatalf("summarizeFinancialReports() error: %v", err)
+	}
+
+	if len(result.Rows) != 2 {
+		t.Fatalf("expected app and unattributed rows, got %+v", result.Rows)
+	}
+	if row := result.Rows[0]; row.App != "My App" || row.AppAppleID != "100" || row.Proceeds != 31.97 || row.Units != 6 {
+		t.Fatalf("unexpected app row %+v", row)
+	}
+	if row := result.Rows[1]; row.App != unattributedAppName || row.Proceeds != 5.5 {
+		t.Fatalf("unexpected unattributed row %+v", row)
+	}
+	if result.Unattributed != 1 || result.TotalProceeds != 37.47 {
+		t.Fatalf("unexpected totals %+v", result)
+	}
+	if result.Reconciled {
+		t.Fatal("expected EU region to fail reconciliation against its Total_Amount")
+	}
+	if region := result.Regions[0]; !region.Reconciled || region.ConvertedProceeds != 20.97 {
+		t.Fatalf("unexpected US region %+v", region)
+	}
+	if region := result.Regions[1]; region.Reconciled || region.ExchangeRate != 1.1 || region.ConvertedProceeds != 16.5 {
+		t.Fatalf("unexpected EU region %+v", region)
+	}
+}
+
+func TestSummarizeFinancialReportsMissingRate(t *testing.T) {
+	jp := &reports.Report[reports.FinancialRow]{
+		Rows: []reports.FinancialRow{{VendorIdentifier: "app.sku", ExtendedPartnerShare: 100, PartnerShareCurrency: "JPY"}},
+	}
+	_, err := summarizeFinancialReports([]regionalFinancialReport{
+		{region: asc.FinanceRegion{RegionCode: "JP", ReportCurrency: "JPY"}, report: jp},
+	}, newCurrencyConverter("USD", nil), nil, []string{"territory"})
+	if err == nil || !strings.Contains(err.Error(), "pass --rates JPY=<rate>") {
+		t.Fatalf("expected missing rate error, got %v", err)
+	}
+}
+
+func TestCurrencyConverterCrossRate(t *testing.T) {
+	converter := newCurrencyConverter("EUR", map[string]float64{"GBP": 1.2})
+	converter.addDetailRates([]reports.FinancialRow{
+		{PartnerShareCurrency: "JPY", PaymentCurrency: "USD", ExchangeRate: 0.007},
+		{PartnerShareCurrency: "EUR", PaymentCurrency: "USD", ExchangeRate: 1.1},
+	})
+
+	if rate, ok := converter.rate("GBP"); !ok || rate != 1.2 {
+		t.Fatalf("expected override rate, got %v %v", rate, ok)
+	}
+	if rate, ok := converter.rate("JPY"); !ok || math.Abs(rate-0.007/1.1) > 1e-12 {
+		t.Fatalf("expected JPY->USD->EUR cross rate, got %v %v", rate, ok)
+	}
+	if _, ok := converter.rate("CHF"); ok {
+		t.Fatal("expected no CHF rate")
+	}
+}
+
+func TestParseSummaryGroupBy(t *testing.T) {
+	dimensions, err := parseSummaryGroupBy("App, territory,app")
+	if err != nil || strings.Join(dimensions, ",") != "app,territory" {
+		t.Fatalf("parseSummaryGroupBy() = %v, %v", dimensions, err)
+	}
+	if _, err := parseSummaryGroupBy("device"); err == nil {
+		t.Fatal("expected invalid dimension error")
+	}
+}
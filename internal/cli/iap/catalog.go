@@ -0,0 +1,384 @@
+package iap
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// IAPCatalog is the YAML schema for an in-app purchase catalog.
+type IAPCatalog struct {
+	Products []IAPCatalogProduct `yaml:"products"`
+}
+
+// IAPCatalogProduct describes one in-app purchase in a catalog file.
+type IAPCatalogProduct struct {
+	ProductID        string                   `yaml:"productId"`
+	Type             string                   `yaml:"type"`
+	ReferenceName    string                   `yaml:"referenceName"`
+	Localizations    []IAPCatalogLocalization `yaml:"localizations,omitempty"`
+	Price            *IAPCatalogPrice         `yaml:"price,omitempty"`
+	Availability     *IAPCatalogAvailability  `yaml:"availability,omitempty"`
+	ReviewScreenshot string                   `yaml:"reviewScreenshot,omitempty"`
+}
+
+// IAPCatalogLocalization describes a display name and description for a locale.
+type IAPCatalogLocalization struct {
+	Locale      string `yaml:"locale"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// IAPCatalogPrice describes the base territory price of an in-app purchase.
+// Other territories follow Apple's automatic equalization.
+type IAPCatalogPrice struct {
+	BaseTerritory string `yaml:"baseTerritory"`
+	CustomerPrice string `yaml:"customerPrice"`
+}
+
+// IAPCatalogAvailability describes the territories an in-app purchase is sold in.
+type IAPCatalogAvailability struct {
+	AvailableInNewTerritories bool     `yaml:"availableInNewTerritories"`
+	Territories               []string `yaml:"territories"`
+}
+
+type iapCatalogExportSummary struct {
+	AppID    string `json:"appId"`
+	File     string `json:"file"`
+	Products int    `json:"products"`
+}
+
+// IAPCatalogCommand returns the iap catalog command group.
+func IAPCatalogCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("catalog", flag.ExitOnError)
+
+	return &ffcli.Command{
+		Name:       "catalog",
+		ShortUsage: "asc iap catalog <subcommand> [flags]",
+		ShortHelp:  "Export and apply in-app purchase catalogs as YAML.",
+		LongHelp: `Export and apply in-app purchase catalogs as YAML.
+
+A catalog covers product type, reference name, product ID, localizations,
+base territory price, availability, and the review screenshot path for
+every in-app purchase of an app.
+
+Examples:
+  asc iap catalog export --app "APP_ID" --file "./catalog.yaml"
+  asc iap catalog apply --app "APP_ID" --file "./catalog.yaml" --dry-run
+  asc iap catalog apply --app "APP_ID" --file "./catalog.yaml"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			IAPCatalogExportCommand(),
+			IAPCatalogApplyCommand(),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+// IAPCatalogExportCommand returns the iap catalog export subcommand.
+func IAPCatalogExportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("catalog export", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	file := fs.String("file", "", "Output catalog YAML path (required)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "export",
+		ShortUsage: "asc iap catalog export --app \"APP_ID\" --file \"./catalog.yaml\"",
+		ShortHelp:  "Export all in-app purchases of an app to a catalog file.",
+		LongHelp: `Export all in-app purchases of an app to a catalog file.
+
+Review screenshots are exported as their uploaded file name. Place the
+image next to the catalog (or edit the path) before applying it elsewhere.
+
+Examples:
+  asc iap catalog export --app "APP_ID" --file "./catalog.yaml"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
+				return flag.ErrHelp
+			}
+			fileValue := strings.TrimSpace(*file)
+			if fileValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --file is required")
+				return flag.ErrHelp
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("iap catalog export: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			remote, err := fetchIAPCatalogRemote(requestCtx, client, resolvedAppID)
+			if err != nil {
+				return fmt.Errorf("iap catalog export: %w", err)
+			}
+
+			catalog := IAPCatalog{Products: make([]IAPCatalogProduct, 0, len(remote))}
+			for _, item := range remote {
+				catalog.Products = append(catalog.Products, item.Product)
+			}
+
+			if err := writeIAPCatalogFile(fileValue, catalog); err != nil {
+				return fmt.Errorf("iap catalog export: %w", err)
+			}
+
+			summary := iapCatalogExportSummary{
+				AppID:    resolvedAppID,
+				File:     filepath.Clean(fileValue),
+				Products: len(catalog.Products),
+			}
+			return shared.PrintOutput(summary, *output.Output, *output.Pretty)
+		},
+	}
+}
+
+// IAPCatalogApplyCommand returns the iap catalog apply subcommand.
+func IAPCatalogApplyCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("catalog apply", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	file := fs.String("file", "", "Catalog YAML path (required)")
+	dryRun := fs.Bool("dry-run", false, "Print the plan without mutating App Store Connect")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "apply",
+		ShortUsage: "asc iap catalog apply --app \"APP_ID\" --file \"./catalog.yaml\" [--dry-run]",
+		ShortHelp:  "Reconcile in-app purchases with a catalog file.",
+		LongHelp: `Reconcile in-app purchases with a catalog file.
+
+The catalog is diffed against App Store Connect and only the differences
+are applied, so re-running an applied catalog is a no-op. A failure for one
+product is reported in the summary and does not stop the others.
+
+Notes:
+  - products and localizations missing from the file are left untouched.
+  - price changes replace the price schedule, effective immediately.
+  - reviewScreenshot paths are relative to the catalog file.
+
+Examples:
+  asc iap catalog apply --app "APP_ID" --file "./catalog.yaml" --dry-run
+  asc iap catalog apply --app "APP_ID" --file "./catalog.yaml" --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
+				return flag.ErrHelp
+			}
+			fileValue := strings.TrimSpace(*file)
+			if fileValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --file is required")
+				return flag.ErrHelp
+			}
+
+			catalog, err := readIAPCatalogFile(fileValue)
+			if err != nil {
+				return err
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("iap catalog apply: %w", err)
+			}
+
+			fetchCtx, fetchCancel := shared.ContextWithTimeout(ctx)
+			remote, err := fetchIAPCatalogRemote(fetchCtx, client, resolvedAppID)
+			fetchCancel()
+			if err != nil {
+				return fmt.Errorf("iap catalog apply: %w", err)
+			}
+
+			plans := buildIAPCatalogPlan(catalog, remote, filepath.Dir(fileValue))
+			result := &IAPCatalogApplyResult{
+				AppID:  resolvedAppID,
+				File:   filepath.Clean(fileValue),
+				DryRun: *dryRun,
+			}
+
+			if !*dryRun {
+				for idx := range plans {
+					if plans[idx].err != nil || len(plans[idx].changes) == 0 {
+						continue
+					}
+					plans[idx].err = applyIAPCatalogProductPlan(ctx, client, resolvedAppID, &plans[idx])
+					plans[idx].applied = plans[idx].err == nil
+				}
+				result.Applied = true
+			}
+			result.collect(plans)
+
+			if err := shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderIAPCatalogApplyResult(result, false) },
+				func() error { return renderIAPCatalogApplyResult(result, true) },
+			); err != nil {
+				return err
+			}
+
+			if result.Failed > 0 {
+				return shared.NewReportedError(fmt.Errorf("iap catalog apply: %d product(s) failed", result.Failed))
+			}
+			return nil
+		},
+	}
+}
+
+func readIAPCatalogFile(path string) (IAPCatalog, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return IAPCatalog{}, fmt.Errorf("iap catalog apply: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var catalog IAPCatalog
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&catalog); err != nil {
+		return IAPCatalog{}, shared.UsageErrorf("invalid catalog %s: %v", path, err)
+	}
+	if err := normalizeIAPCatalog(&catalog); err != nil {
+		return IAPCatalog{}, shared.UsageErrorf("invalid catalog %s: %v", path, err)
+	}
+	return catalog, nil
+}
+
+func normalizeIAPCatalog(catalog *IAPCatalog) error {
+	if len(catalog.Products) == 0 {
+		return fmt.Errorf("at least one product is required")
+	}
+
+	seenProducts := make(map[string]struct{}, len(catalog.Products))
+	for idx := range catalog.Products {
+		product := &catalog.Products[idx]
+		product.ProductID = strings.TrimSpace(product.ProductID)
+		if product.ProductID == "" {
+			return fmt.Errorf("products[%d]: productId is required", idx)
+		}
+		if _, ok := seenProducts[product.ProductID]; ok {
+			return fmt.Errorf("products[%d]: duplicate productId %q", idx, product.ProductID)
+		}
+		seenProducts[product.ProductID] = struct{}{}
+
+		normalizedType, err := normalizeIAPType(product.Type)
+		if err != nil {
+			return fmt.Errorf("%s: %s", product.ProductID, strings.TrimPrefix(err.Error(), "--"))
+		}
+		product.Type = normalizedType
+
+		product.ReferenceName = strings.TrimSpace(product.ReferenceName)
+		if product.ReferenceName == "" {
+			return fmt.Errorf("%s: referenceName is required", product.ProductID)
+		}
+
+		seenLocales := make(map[string]struct{}, len(product.Localizations))
+		for locIdx := range product.Localizations {
+			loc := &product.Localizations[locIdx]
+			loc.Locale = strings.TrimSpace(loc.Locale)
+			loc.Name = strings.TrimSpace(loc.Name)
+			loc.Description = strings.TrimSpace(loc.Description)
+			if loc.Locale == "" {
+				return fmt.Errorf("%s: localizations[%d]: locale is required", product.ProductID, locIdx)
+			}
+			if loc.Name == "" {
+				return fmt.Errorf("%s: localization %q: name is required", product.ProductID, loc.Locale)
+			}
+			if _, ok := seenLocales[loc.Locale]; ok {
+				return fmt.Errorf("%s: duplicate localization %q", product.ProductID, loc.Locale)
+			}
+			seenLocales[loc.Locale] = struct{}{}
+		}
+
+		if product.Price != nil {
+			product.Price.BaseTerritory = strings.ToUpper(strings.TrimSpace(product.Price.BaseTerritory))
+			product.Price.CustomerPrice = strings.TrimSpace(product.Price.CustomerPrice)
+			if product.Price.BaseTerritory == "" {
+				return fmt.Errorf("%s: price.baseTerritory is required", product.ProductID)
+			}
+			if _, err := normalizeIAPCatalogPrice(product.Price.CustomerPrice); err != nil {
+				return fmt.Errorf("%s: price.customerPrice: %w", product.ProductID, err)
+			}
+		}
+
+		if product.Availability != nil {
+			territories := make([]string, 0, len(product.Availability.Territories))
+			for _, territory := range product.Availability.Territories {
+				value := strings.ToUpper(strings.TrimSpace(territory))
+				if value == "" {
+					return fmt.Errorf("%s: availability.territories must not contain empty values", product.ProductID)
+				}
+				territories = append(territories, value)
+			}
+			if len(territories) == 0 {
+				return fmt.Errorf("%s: availability.territories is required", product.ProductID)
+			}
+			product.Availability.Territories = uniqueSortedIAPCatalogStrings(territories)
+		}
+
+		product.ReviewScreenshot = strings.TrimSpace(product.ReviewScreenshot)
+	}
+	return nil
+}
+
+func writeIAPCatalogFile(path string, catalog IAPCatalog) error {
+	data, err := yaml.Marshal(catalog)
+	if err != nil {
+		return err
+	}
+	_, err = shared.WriteFileNoSymlinkOverwrite(path, bytes.NewReader(data), 0o644, ".asc-iap-catalog-*", ".asc-iap-catalog-backup-*")
+	return err
+}
+
+func normalizeIAPCatalogPrice(value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return "", fmt.Errorf("price is required")
+	}
+	rat := new(big.Rat)
+	if _, ok := rat.SetString(trimmed); !ok {
+		return "", fmt.Errorf("price %q is not a valid numeric value", trimmed)
+	}
+	if rat.Sign() < 0 {
+		return "", fmt.Errorf("price %q must not be negative", trimmed)
+	}
+	return rat.RatString(), nil
+}
+
+func uniqueSortedIAPCatalogStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		unique = append(unique, value)
+	}
+	sort.Strings(unique)
+	return unique
+}
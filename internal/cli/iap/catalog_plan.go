@@ -0,0 +1,613 @@
+package iap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	iapCatalogActionCreateProduct      = "create-product"
+	iapCatalogActionUpdateName         = "update-reference-name"
+	iapCatalogActionCreateLocalization = "create-localization"
+	iapCatalogActionUpdateLocalization = "update-localization"
+	iapCatalogActionSetPrice           = "set-price"
+	iapCatalogActionSetAvailability    = "set-availability"
+	iapCatalogActionUploadScreenshot   = "upload-review-screenshot"
+
+	iapCatalogStatusUnchanged = "unchanged"
+	iapCatalogStatusPlanned   = "planned"
+	iapCatalogStatusApplied   = "applied"
+	iapCatalogStatusFailed    = "failed"
+)
+
+// IAPCatalogChange is one planned catalog mutation.
+type IAPCatalogChange struct {
+	ProductID string `json:"productId"`
+	Action    string `json:"action"`
+	Locale    string `json:"locale,omitempty"`
+	From      string `json:"from,omitempty"`
+	To        string `json:"to,omitempty"`
+}
+
+// IAPCatalogItemResult reports the outcome for one catalog product.
+type IAPCatalogItemResult struct {
+	ProductID string `json:"productId"`
+	IAPID     string `json:"iapId,omitempty"`
+	Status    string `json:"status"`
+	Changes   int    `json:"changes"`
+	Error     string `json:"error,omitempty"`
+}
+
+// IAPCatalogApplyResult is the output of iap catalog apply.
+type IAPCatalogApplyResult struct {
+	AppID   string                 `json:"appId"`
+	File    string                 `json:"file"`
+	DryRun  bool                   `json:"dryRun"`
+	Applied bool                   `json:"applied,omitempty"`
+	Changes []IAPCatalogChange     `json:"changes"`
+	Items   []IAPCatalogItemResult `json:"items"`
+	Failed  int                    `json:"failed"`
+}
+
+type iapCatalogRemoteProduct struct {
+	ID                 string
+	Product            IAPCatalogProduct
+	LocalizationIDs    map[string]string
+	ScreenshotID       string
+	ScreenshotFileSize int64
+}
+
+type iapCatalogProductPlan struct {
+	local          IAPCatalogProduct
+	remote         *iapCatalogRemoteProduct
+	screenshotPath string
+	changes        []IAPCatalogChange
+	iapID          string
+	applied        bool
+	err            error
+}
+
+func fetchIAPCatalogRemote(ctx context.Context, client *asc.Client, appID string) ([]iapCatalogRemoteProduct, error) {
+	firstPage, err := client.GetInAppPurchasesV2(ctx, appID, asc.WithIAPLimit(200))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch IAP list: %w", err)
+	}
+	paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetInAppPurchasesV2(ctx, appID, asc.WithIAPNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("paginate IAP list: %w", err)
+	}
+	resp, ok := paginated.(*asc.InAppPurchasesV2Response)
+	if !ok {
+		return nil, fmt.Errorf("unexpected pagination response type %T", paginated)
+	}
+
+	iaps := resp.Data
+	results := make([]iapCatalogRemoteProduct, len(iaps))
+	if len(iaps) == 0 {
+		return results, nil
+	}
+
+	workers := max(min(len(iaps), defaultIAPPricesWorkers), 1)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, workers)
+	errs := make(chan error, len(iaps))
+	var once sync.Once
+	var wg sync.WaitGroup
+	now := time.Now().UTC()
+
+	for idx := range iaps {
+		wg.Go(func() {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+
+			product, err := fetchIAPCatalogRemoteProduct(ctx, client, iaps[idx], now)
+			if err != nil {
+				once.Do(cancel)
+				errs <- fmt.Errorf("%s: %w", iaps[idx].Attributes.ProductID, err)
+				return
+			}
+			results[idx] = product
+		})
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context cancelled: %w", err)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Product.ProductID < results[j].Product.ProductID
+	})
+	return results, nil
+}
+
+func fetchIAPCatalogRemoteProduct(
+	ctx context.Context,
+	client *asc.Client,
+	iap asc.Resource[asc.InAppPurchaseV2Attributes],
+	now time.Time,
+) (iapCatalogRemoteProduct, error) {
+	remote := iapCatalogRemoteProduct{
+		ID: iap.ID,
+		Product: IAPCatalogProduct{
+			ProductID:     iap.Attributes.ProductID,
+			Type:          iap.Attributes.InAppPurchaseType,
+			ReferenceName: iap.Attributes.Name,
+		},
+		LocalizationIDs: make(map[string]string),
+	}
+
+	locFirstPage, err := client.GetInAppPurchaseLocalizations(ctx, iap.ID, asc.WithIAPLocalizationsLimit(200))
+	if err != nil {
+		return remote, fmt.Errorf("fetch localizations: %w", err)
+	}
+	locPaginated, err := asc.PaginateAll(ctx, locFirstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetInAppPurchaseLocalizations(ctx, iap.ID, asc.WithIAPLocalizationsNextURL(nextURL))
+	})
+	if err != nil {
+		return remote, fmt.Errorf("paginate localizations: %w", err)
+	}
+	if locResp, ok := locPaginated.(*asc.InAppPurchaseLocalizationsResponse); ok {
+		for _, item := range locResp.Data {
+			locale := strings.TrimSpace(item.Attributes.Locale)
+			if locale == "" {
+				continue
+			}
+			remote.LocalizationIDs[locale] = item.ID
+			remote.Product.Localizations = append(remote.Product.Localizations, IAPCatalogLocalization{
+				Locale:      locale,
+				Name:        strings.TrimSpace(item.Attributes.Name),
+				Description: strings.TrimSpace(item.Attributes.Description),
+			})
+		}
+		sort.Slice(remote.Product.Localizations, func(i, j int) bool {
+			return remote.Product.Localizations[i].Locale < remote.Product.Localizations[j].Locale
+		})
+	}
+
+	summary, err := resolveIAPPriceSummary(ctx, client, iap, "", now)
+	switch {
+	case err != nil && !asc.IsNotFound(err):
+		return remote, err
+	case err == nil && summary.BaseTerritory != "" && summary.CurrentPrice != nil:
+		remote.Product.Price = &IAPCatalogPrice{
+			BaseTerritory: summary.BaseTerritory,
+			CustomerPrice: summary.CurrentPrice.Amount,
+		}
+	}
+
+	availability, err := client.GetInAppPurchaseAvailability(ctx, iap.ID)
+	switch {
+	case err != nil && !asc.IsNotFound(err):
+		return remote, fmt.Errorf("fetch availability: %w", err)
+	case err == nil && availability != nil && strings.TrimSpace(availability.Data.ID) != "":
+		territories, err := fetchIAPCatalogAvailableTerritories(ctx, client, availability.Data.ID)
+		if err != nil {
+			return remote, err
+		}
+		remote.Product.Availability = &IAPCatalogAvailability{
+			AvailableInNewTerritories: availability.Data.Attributes.AvailableInNewTerritories,
+			Territories:               territories,
+		}
+	}
+
+	screenshot, err := client.GetInAppPurchaseAppStoreReviewScreenshotForIAP(ctx, iap.ID)
+	switch {
+	case err != nil && !asc.IsNotFound(err):
+		return remote, fmt.Errorf("fetch review screenshot: %w", err)
+	case err == nil && screenshot != nil && strings.TrimSpace(screenshot.Data.ID) != "":
+		remote.ScreenshotID = screenshot.Data.ID
+		remote.ScreenshotFileSize = screenshot.Data.Attributes.FileSize
+		remote.Product.ReviewScreenshot = screenshot.Data.Attributes.FileName
+	}
+
+	return remote, nil
+}
+
+func fetchIAPCatalogAvailableTerritories(ctx context.Context, client *asc.Client, availabilityID string) ([]string, error) {
+	firstPage, err := client.GetInAppPurchaseAvailabilityAvailableTerritories(ctx, availabilityID, asc.WithIAPAvailabilityTerritoriesLimit(200))
+	if err != nil {
+		return nil, fmt.Errorf("fetch available territories: %w", err)
+	}
+	paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetInAppPurchaseAvailabilityAvailableTerritories(ctx, availabilityID, asc.WithIAPAvailabilityTerritoriesNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("paginate available territories: %w", err)
+	}
+	resp, ok := paginated.(*asc.TerritoriesResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected territories response type %T", paginated)
+	}
+	territories := make([]string, 0, len(resp.Data))
+	for _, item := range resp.Data {
+		territories = append(territories, strings.ToUpper(strings.TrimSpace(item.ID)))
+	}
+	return uniqueSortedIAPCatalogStrings(territories), nil
+}
+
+// buildIAPCatalogPlan diffs catalog products against remote state. Products are
+// planned independently so one invalid entry does not block the others.
+func buildIAPCatalogPlan(catalog IAPCatalog, remote []iapCatalogRemoteProduct, baseDir string) []iapCatalogProductPlan {
+	remoteByProductID := make(map[string]*iapCatalogRemoteProduct, len(remote))
+	for idx := range remote {
+		remoteByProductID[remote[idx].Product.ProductID] = &remote[idx]
+	}
+
+	plans := make([]iapCatalogProductPlan, 0, len(catalog.Products))
+	for _, local := range catalog.Products {
+		plan := iapCatalogProductPlan{local: local, remote: remoteByProductID[local.ProductID]}
+		if plan.remote != nil {
+			plan.iapID = plan.remote.ID
+		}
+		plan.changes, plan.screenshotPath, plan.err = diffIAPCatalogProduct(local, plan.remote, baseDir)
+		plans = append(plans, plan)
+	}
+	return plans
+}
+
+func diffIAPCatalogProduct(local IAPCatalogProduct, remote *iapCatalogRemoteProduct, baseDir string) ([]IAPCatalogChange, string, error) {
+	changes := make([]IAPCatalogChange, 0)
+	add := func(action, locale, from, to string) {
+		changes = append(changes, IAPCatalogChange{
+			ProductID: local.ProductID,
+			Action:    action,
+			Locale:    locale,
+			From:      from,
+			To:        to,
+		})
+	}
+
+	current := IAPCatalogProduct{}
+	if remote == nil {
+		add(iapCatalogActionCreateProduct, "", "", local.Type)
+	} else {
+		current = remote.Product
+		if current.Type != local.Type {
+			return nil, "", fmt.Errorf("type is %s in App Store Connect and cannot be changed to %s", current.Type, local.Type)
+		}
+		if current.ReferenceName != local.ReferenceName {
+			add(iapCatalogActionUpdateName, "", current.ReferenceName, local.ReferenceName)
+		}
+	}
+
+	currentLocs := make(map[string]IAPCatalogLocalization, len(current.Localizations))
+	for _, loc := range current.Localizations {
+		currentLocs[loc.Locale] = loc
+	}
+	for _, loc := range local.Localizations {
+		existing, ok := currentLocs[loc.Locale]
+		switch {
+		case !ok:
+			add(iapCatalogActionCreateLocalization, loc.Locale, "", loc.Name)
+		case existing.Name != loc.Name || existing.Description != loc.Description:
+			add(iapCatalogActionUpdateLocalization, loc.Locale, formatIAPCatalogLocalization(existing), formatIAPCatalogLocalization(loc))
+		}
+	}
+
+	if local.Price != nil && !iapCatalogPricesEqual(current.Price, local.Price) {
+		add(iapCatalogActionSetPrice, "", formatIAPCatalogPrice(current.Price), formatIAPCatalogPrice(local.Price))
+	}
+
+	if local.Availability != nil && !iapCatalogAvailabilityEqual(current.Availability, local.Availability) {
+		add(iapCatalogActionSetAvailability, "", formatIAPCatalogAvailability(current.Availability), formatIAPCatalogAvailability(local.Availability))
+	}
+
+	screenshotPath := ""
+	if local.ReviewScreenshot != "" {
+		screenshotPath = local.ReviewScreenshot
+		if !filepath.IsAbs(screenshotPath) {
+			screenshotPath = filepath.Join(baseDir, screenshotPath)
+		}
+		info, err := os.Stat(screenshotPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("review screenshot: %w", err)
+		}
+		if info.IsDir() {
+			return nil, "", fmt.Errorf("review screenshot %q is a directory", screenshotPath)
+		}
+		unchanged := remote != nil &&
+			remote.ScreenshotID != "" &&
+			current.ReviewScreenshot == info.Name() &&
+			remote.ScreenshotFileSize == info.Size()
+		if !unchanged {
+			add(iapCatalogActionUploadScreenshot, "", current.ReviewScreenshot, info.Name())
+		}
+	}
+
+	return changes, screenshotPath, nil
+}
+
+func iapCatalogPricesEqual(current, desired *IAPCatalogPrice) bool {
+	if current == nil || desired == nil {
+		return current == desired
+	}
+	if current.BaseTerritory != desired.BaseTerritory {
+		return false
+	}
+	currentValue, err := normalizeIAPCatalogPrice(current.CustomerPrice)
+	if err != nil {
+		return false
+	}
+	desiredValue, err := normalizeIAPCatalogPrice(desired.CustomerPrice)
+	if err != nil {
+		return false
+	}
+	return currentValue == desiredValue
+}
+
+func iapCatalogAvailabilityEqual(current, desired *IAPCatalogAvailability) bool {
+	if current == nil || desired == nil {
+		return current == desired
+	}
+	return current.AvailableInNewTerritories == desired.AvailableInNewTerritories &&
+		slices.Equal(current.Territories, desired.Territories)
+}
+
+func formatIAPCatalogLocalization(loc IAPCatalogLocalization) string {
+	if loc.Description == "" {
+		return loc.Name
+	}
+	return loc.Name + " / " + loc.Description
+}
+
+func formatIAPCatalogPrice(price *IAPCatalogPrice) string {
+	if price == nil {
+		return ""
+	}
+	return price.BaseTerritory + " " + price.CustomerPrice
+}
+
+func formatIAPCatalogAvailability(availability *IAPCatalogAvailability) string {
+	if availability == nil {
+		return ""
+	}
+	value := fmt.Sprintf("%d territories", len(availability.Territories))
+	if availability.AvailableInNewTerritories {
+		value += " +new"
+	}
+	return value
+}
+
+// applyIAPCatalogProductPlan executes planned changes for one product in order,
+// stopping at the first failure for that product.
+func applyIAPCatalogProductPlan(ctx context.Context, client *asc.Client, appID string, plan *iapCatalogProductPlan) error {
+	for _, change := range plan.changes {
+		if err := applyIAPCatalogChange(ctx, client, appID, plan, change); err != nil {
+			return fmt.Errorf("%s: %w", change.Action, err)
+		}
+	}
+	return nil
+}
+
+func applyIAPCatalogChange(ctx context.Context, client *asc.Client, appID string, plan *iapCatalogProductPlan, change IAPCatalogChange) error {
+	local := plan.local
+
+	if change.Action == iapCatalogActionUploadScreenshot {
+		return replaceIAPCatalogReviewScreenshot(ctx, client, plan)
+	}
+
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	switch change.Action {
+	case iapCatalogActionCreateProduct:
+		resp, err := client.CreateInAppPurchaseV2(requestCtx, appID, asc.InAppPurchaseV2CreateAttributes{
+			Name:              local.ReferenceName,
+			ProductID:         local.ProductID,
+			InAppPurchaseType: local.Type,
+		})
+		if err != nil {
+			return err
+		}
+		plan.iapID = resp.Data.ID
+		return nil
+	case iapCatalogActionUpdateName:
+		name := local.ReferenceName
+		_, err := client.UpdateInAppPurchaseV2(requestCtx, plan.iapID, asc.InAppPurchaseV2UpdateAttributes{Name: &name})
+		return err
+	case iapCatalogActionCreateLocalization, iapCatalogActionUpdateLocalization:
+		idx := slices.IndexFunc(local.Localizations, func(loc IAPCatalogLocalization) bool {
+			return loc.Locale == change.Locale
+		})
+		if idx < 0 {
+			return fmt.Errorf("localization %q not found in catalog", change.Locale)
+		}
+		loc := local.Localizations[idx]
+		if change.Action == iapCatalogActionCreateLocalization {
+			_, err := client.CreateInAppPurchaseLocalization(requestCtx, plan.iapID, asc.InAppPurchaseLocalizationCreateAttributes{
+				Name:        loc.Name,
+				Locale:      loc.Locale,
+				Description: loc.Description,
+			})
+			return err
+		}
+		_, err := client.UpdateInAppPurchaseLocalization(requestCtx, plan.remote.LocalizationIDs[loc.Locale], asc.InAppPurchaseLocalizationUpdateAttributes{
+			Name:        &loc.Name,
+			Description: &loc.Description,
+		})
+		return err
+	case iapCatalogActionSetPrice:
+		pricePointID, err := lookupIAPCatalogPricePointID(requestCtx, client, plan.iapID, local.Price)
+		if err != nil {
+			return err
+		}
+		_, err = client.CreateInAppPurchasePriceSchedule(requestCtx, plan.iapID, asc.InAppPurchasePriceScheduleCreateAttributes{
+			BaseTerritoryID: local.Price.BaseTerritory,
+			Prices:          []asc.InAppPurchasePriceSchedulePrice{{PricePointID: pricePointID}},
+		})
+		return err
+	case iapCatalogActionSetAvailability:
+		_, err := client.CreateInAppPurchaseAvailability(
+			requestCtx,
+			plan.iapID,
+			local.Availability.AvailableInNewTerritories,
+			local.Availability.Territories,
+		)
+		return err
+	default:
+		return fmt.Errorf("unsupported action %q", change.Action)
+	}
+}
+
+func replaceIAPCatalogReviewScreenshot(ctx context.Context, client *asc.Client, plan *iapCatalogProductPlan) error {
+	file, info, err := openImageFile(plan.screenshotPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	checksum, err := asc.ComputeChecksumFromReader(file, asc.ChecksumAlgorithmMD5)
+	if err != nil {
+		return err
+	}
+
+	requestCtx, cancel := contextWithAssetUploadTimeout(ctx)
+	defer cancel()
+
+	if plan.remote != nil && plan.remote.ScreenshotID != "" {
+		if err := client.DeleteInAppPurchaseAppStoreReviewScreenshot(requestCtx, plan.remote.ScreenshotID); err != nil {
+			return fmt.Errorf("failed to delete previous screenshot: %w", err)
+		}
+	}
+
+	_, err = uploadIAPReviewScreenshot(requestCtx, client, plan.iapID, file, info, checksum.Hash)
+	return err
+}
+
+// lookupIAPCatalogPricePointID finds the price point whose customer price in
+// the base territory matches the catalog price.
+func lookupIAPCatalogPricePointID(ctx context.Context, client *asc.Client, iapID string, price *IAPCatalogPrice) (string, error) {
+	want, err := normalizeIAPCatalogPrice(price.CustomerPrice)
+	if err != nil {
+		return "", err
+	}
+
+	firstPage, err := client.GetInAppPurchasePricePoints(
+		ctx,
+		iapID,
+		asc.WithIAPPricePointsTerritory(price.BaseTerritory),
+		asc.WithIAPPricePointsFields([]string{"customerPrice", "proceeds", "territory"}),
+		asc.WithIAPPricePointsLimit(8000),
+	)
+	if err != nil {
+		return "", fmt.Errorf("fetch price points: %w", err)
+	}
+	paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetInAppPurchasePricePoints(ctx, iapID, asc.WithIAPPricePointsNextURL(nextURL))
+	})
+	if err != nil {
+		return "", fmt.Errorf("paginate price points: %w", err)
+	}
+	resp, ok := paginated.(*asc.InAppPurchasePricePointsResponse)
+	if !ok {
+		return "", fmt.Errorf("unexpected price points response type %T", paginated)
+	}
+
+	matches := make([]string, 0, 1)
+	for _, item := range resp.Data {
+		value, err := normalizeIAPCatalogPrice(item.Attributes.CustomerPrice)
+		if err != nil || value != want {
+			continue
+		}
+		matches = append(matches, item.ID)
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("price %q was not found in price points for territory %q", price.CustomerPrice, price.BaseTerritory)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("price %q matched multiple price points in territory %q", price.CustomerPrice, price.BaseTerritory)
+	}
+}
+
+func (r *IAPCatalogApplyResult) collect(plans []iapCatalogProductPlan) {
+	r.Changes = make([]IAPCatalogChange, 0)
+	r.Items = make([]IAPCatalogItemResult, 0, len(plans))
+	r.Failed = 0
+	for _, plan := range plans {
+		item := IAPCatalogItemResult{
+			ProductID: plan.local.ProductID,
+			IAPID:     plan.iapID,
+			Changes:   len(plan.changes),
+		}
+		switch {
+		case plan.err != nil:
+			item.Status = iapCatalogStatusFailed
+			item.Error = plan.err.Error()
+			r.Failed++
+		case len(plan.changes) == 0:
+			item.Status = iapCatalogStatusUnchanged
+		case plan.applied:
+			item.Status = iapCatalogStatusApplied
+		default:
+			item.Status = iapCatalogStatusPlanned
+		}
+		r.Changes = append(r.Changes, plan.changes...)
+		r.Items = append(r.Items, item)
+	}
+}
+
+func renderIAPCatalogApplyResult(result *IAPCatalogApplyResult, markdown bool) error {
+	if result == nil {
+		return fmt.Errorf("result is nil")
+	}
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	changeRows := make([][]string, 0, len(result.Changes))
+	for _, change := range result.Changes {
+		changeRows = append(changeRows, []string{
+			change.ProductID,
+			change.Action,
+			change.Locale,
+			compactIAPText(change.From),
+			compactIAPText(change.To),
+		})
+	}
+	if len(changeRows) == 0 {
+		changeRows = append(changeRows, []string{"", "none", "", "", ""})
+	}
+	render([]string{"Product ID", "Action", "Locale", "From", "To"}, changeRows)
+
+	itemRows := make([][]string, 0, len(result.Items))
+	for _, item := range result.Items {
+		itemRows = append(itemRows, []string{
+			item.ProductID,
+			item.IAPID,
+			item.Status,
+			fmt.Sprintf("%d", item.Changes),
+			item.Error,
+		})
+	}
+	render([]string{"Product ID", "IAP ID", "Status", "Changes", "Error"}, itemRows)
+	return nil
+}
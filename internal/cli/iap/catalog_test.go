@@ -0,0 +1,208 @@
+package iap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalizeIAPCatalog_NormalizesFields(t *testing.T) {
+	catalog := IAPCatalog{Products: []IAPCatalogProduct{{
+		ProductID:     " com.example.coins100 ",
+		Type:          "consumable",
+		ReferenceName: " 100 Coins ",
+		Localizations: []IAPCatalogLocalization{{Locale: "en-US", Name: " 100 Coins "}},
+		Price:         &IAPCatalogPrice{BaseTerritory: "usa", CustomerPrice: "0.99"},
+		Availability:  &IAPCatalogAvailability{Territories: []string{"can", "USA", "CAN"}},
+	}}}
+
+	if err := normalizeIAPCatalog(&catalog); err != nil {
+		t.Fatalf("normalizeIAPCatalog() error: %v", err)
+	}
+
+	product := catalog.Products[0]
+	if product.ProductID != "com.example.coins100" {
+		t.Fatalf("expected trimmed product ID, got %q", product.ProductID)
+	}
+	if product.Type != "CONSUMABLE" {
+		t.Fatalf("expected CONSUMABLE type, got %q", product.Type)
+	}
+	if product.Localizations[0].Name != "100 Coins" {
+		t.Fatalf("expected trimmed localization name, got %q", product.Localizations[0].Name)
+	}
+	if product.Price.BaseTerritory != "USA" {
+		t.Fatalf("expected USA base territory, got %q", product.Price.BaseTerritory)
+	}
+	if got := product.Availability.Territories; len(got) != 2 || got[0] != "CAN" || got[1] != "USA" {
+		t.Fatalf("expected sorted unique territories, got %v", got)
+	}
+}
+
+func TestNormalizeIAPCatalog_RejectsInvalidProducts(t *testing.T) {
+	tests := []struct {
+		name    string
+		catalog IAPCatalog
+	}{
+		{name: "empty", catalog: IAPCatalog{}},
+		{
+			name:    "missing product ID",
+			catalog: IAPCatalog{Products: []IAPCatalogProduct{{Type: "CONSUMABLE", ReferenceName: "Coins"}}},
+		},
+		{
+			name:    "invalid type",
+			catalog: IAPCatalog{Products: []IAPCatalogProduct{{ProductID: "a", Type: "BOGUS", ReferenceName: "Coins"}}},
+		},
+		{
+			name: "duplicate product ID",
+			catalog: IAPCatalog{Products: []IAPCatalogProduct{
+				{ProductID: "a", Type: "CONSUMABLE", ReferenceName: "Coins"},
+				{ProductID: "a", Type: "CONSUMABLE", ReferenceName: "Coins"},
+			}},
+		},
+		{
+			name: "duplicate locale",
+			catalog: IAPCatalog{Products: []IAPCatalogProduct{{
+				ProductID: "a", Type: "CONSUMABLE", ReferenceName: "Coins",
+				Localizations: []IAPCatalogLocalization{{Locale: "en-US", Name: "A"}, {Locale: "en-US", Name: "B"}},
+			}}},
+		},
+		{
+			name: "invalid price",
+			catalog: IAPCatalog{Products: []IAPCatalogProduct{{
+				ProductID: "a", Type: "CONSUMABLE", ReferenceName: "Coins",
+				Price: &IAPCatalogPrice{BaseTerritory: "USA", CustomerPrice: "cheap"},
+			}}},
+		},
+		{
+			name: "empty availability",
+			catalog: IAPCatalog{Products: []IAPCatalogProduct{{
+				ProductID: "a", Type: "CONSUMABLE", ReferenceName: "Coins",
+				Availability: &IAPCatalogAvailability{},
+			}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := normalizeIAPCatalog(&test.catalog); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestBuildIAPCatalogPlan_NewProductPlansAllChanges(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "review.png"), []byte("png"), 0o600); err != nil {
+		t.Fatalf("write screenshot: %v", err)
+	}
+
+	catalog := IAPCatalog{Products: []IAPCatalogProduct{{
+		ProductID:        "com.example.coins100",
+		Type:             "CONSUMABLE",
+		ReferenceName:    "100 Coins",
+		Localizations:    []IAPCatalogLocalization{{Locale: "en-US", Name: "100 Coins"}},
+		Price:            &IAPCatalogPrice{BaseTerritory: "USA", CustomerPrice: "0.99"},
+		Availability:     &IAPCatalogAvailability{Territories: []string{"USA"}},
+		ReviewScreenshot: "review.png",
+	}}}
+
+	plans := buildIAPCatalogPlan(catalog, nil, dir)
+	if len(plans) != 1 {
+		t.Fatalf("expected 1 plan, got %d", len(plans))
+	}
+	if plans[0].err != nil {
+		t.Fatalf("unexpected plan error: %v", plans[0].err)
+	}
+
+	want := []string{
+		iapCatalogActionCreateProduct,
+		iapCatalogActionCreateLocalization,
+		iapCatalogActionSetPrice,
+		iapCatalogActionSetAvailability,
+		iapCatalogActionUploadScreenshot,
+	}
+	if len(plans[0].changes) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), plans[0].changes)
+	}
+	for idx, action := range want {
+		if plans[0].changes[idx].Action != action {
+			t.Fatalf("change %d: expected %s, got %s", idx, action, plans[0].changes[idx].Action)
+		}
+	}
+	if plans[0].screenshotPath != filepath.Join(dir, "review.png") {
+		t.Fatalf("expected screenshot path relative to catalog, got %q", plans[0].screenshotPath)
+	}
+}
+
+func TestBuildIAPCatalogPlan_MatchingRemoteIsUnchanged(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "review.png"), []byte("png"), 0o600); err != nil {
+		t.Fatalf("write screenshot: %v", err)
+	}
+
+	product := IAPCatalogProduct{
+		ProductID:        "com.example.coins100",
+		Type:             "CONSUMABLE",
+		ReferenceName:    "100 Coins",
+		Localizations:    []IAPCatalogLocalization{{Locale: "en-US", Name: "100 Coins", Description: "A pile"}},
+		Price:            &IAPCatalogPrice{BaseTerritory: "USA", CustomerPrice: "0.99"},
+		Availability:     &IAPCatalogAvailability{AvailableInNewTerritories: true, Territories: []string{"CAN", "USA"}},
+		ReviewScreenshot: "review.png",
+	}
+	remoteProduct := product
+	remoteProduct.Price = &IAPCatalogPrice{BaseTerritory: "USA", CustomerPrice: "0.990"}
+	remote := []iapCatalogRemoteProduct{{
+		ID:                 "iap-1",
+		Product:            remoteProduct,
+		LocalizationIDs:    map[string]string{"en-US": "loc-1"},
+		ScreenshotID:       "shot-1",
+		ScreenshotFileSize: 3,
+	}}
+
+	plans := buildIAPCatalogPlan(IAPCatalog{Products: []IAPCatalogProduct{product}}, remote, dir)
+	if plans[0].err != nil {
+		t.Fatalf("unexpected plan error: %v", plans[0].err)
+	}
+	if len(plans[0].changes) != 0 {
+		t.Fatalf("expected no changes, got %+v", plans[0].changes)
+	}
+	if plans[0].iapID != "iap-1" {
+		t.Fatalf("expected remote IAP ID, got %q", plans[0].iapID)
+	}
+}
+
+func TestBuildIAPCatalogPlan_ReportsPerItemErrors(t *testing.T) {
+	catalog := IAPCatalog{Products: []IAPCatalogProduct{
+		{ProductID: "com.example.typed", Type: "NON_CONSUMABLE", ReferenceName: "Typed"},
+		{ProductID: "com.example.missing", Type: "CONSUMABLE", ReferenceName: "Missing", ReviewScreenshot: "nope.png"},
+		{ProductID: "com.example.renamed", Type: "CONSUMABLE", ReferenceName: "New Name"},
+	}}
+	remote := []iapCatalogRemoteProduct{
+		{ID: "iap-1", Product: IAPCatalogProduct{ProductID: "com.example.typed", Type: "CONSUMABLE", ReferenceName: "Typed"}},
+		{ID: "iap-2", Product: IAPCatalogProduct{ProductID: "com.example.renamed", Type: "CONSUMABLE", ReferenceName: "Old Name"}},
+	}
+
+	plans := buildIAPCatalogPlan(catalog, remote, t.TempDir())
+	if plans[0].err == nil {
+		t.Fatal("expected type change error")
+	}
+	if plans[1].err == nil {
+		t.Fatal("expected missing screenshot error")
+	}
+	if plans[2].err != nil {
+		t.Fatalf("unexpected error for valid product: %v", plans[2].err)
+	}
+	if len(plans[2].changes) != 1 || plans[2].changes[0].Action != iapCatalogActionUpdateName {
+		t.Fatalf("expected single rename change, got %+v", plans[2].changes)
+	}
+
+	result := &IAPCatalogApplyResult{}
+	result.collect(plans)
+	if result.Failed != 2 {
+		t.Fatalf("expected 2 failed items, got %d", result.Failed)
+	}
+	if result.Items[2].Status != iapCatalogStatusPlanned {
+		t.Fatalf("expected planned status, got %q", result.Items[2].Status)
+	}
+}
@@ -2,7 +2,6 @@ package iap
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -858,32 +857,14 @@ type iapPriceResourceMetadata struct {
 }
 
 func decodeIAPPriceResourceMetadata(resourceID string) (iapPriceResourceMetadata, bool) {
-	resourceID = strings.TrimSpace(resourceID)
-	if resourceID == "" {
-		return iapPriceResourceMetadata{}, false
-	}
-
-	decoded, err := base64.RawURLEncoding.DecodeString(resourceID)
-	if err != nil {
-		decoded, err = base64.URLEncoding.DecodeString(resourceID)
-		if err != nil {
-			return iapPriceResourceMetadata{}, false
-		}
-	}
-
-	var payload struct {
-		TerritoryID      string  `json:"t"`
-		PricePointID     string  `json:"p"`
-		StartDateSeconds float64 `json:"sd"`
-		EndDateSeconds   float64 `json:"ed"`
-	}
-	if err := json.Unmarshal(decoded, &payload); err != nil {
+	payload, ok := shared.DecodePriceResourceID(resourceID)
+	if !ok {
 		return iapPriceResourceMetadata{}, false
 	}
 
 	return iapPriceResourceMetadata{
-		TerritoryID:  strings.ToUpper(strings.TrimSpace(payload.TerritoryID)),
-		PricePointID: strings.TrimSpace(payload.PricePointID),
+		TerritoryID:  payload.TerritoryID,
+		PricePointID: payload.PricePointID,
 		StartDate:    scheduleDateFromUnixSeconds(payload.StartDateSeconds),
 		EndDate:      scheduleDateFromUnixSeconds(payload.EndDateSeconds),
 	}, true
@@ -144,27 +144,12 @@ Examples:
 			requestCtx, cancel := contextWithAssetUploadTimeout(ctx)
 			defer cancel()
 
-			resp, err := client.CreateInAppPurchaseAppStoreReviewScreenshot(requestCtx, iapValue, info.Name(), info.Size())
+			screenshotID, err := uploadIAPReviewScreenshot(requestCtx, client, iapValue, file, info, checksum.Hash)
 			if err != nil {
-				return fmt.Errorf("iap review-screenshots create: failed to create: %w", err)
-			}
-			if resp == nil || len(resp.Data.Attributes.UploadOperations) == 0 {
-				return fmt.Errorf("iap review-screenshots create: no upload operations returned")
-			}
-
-			if err := asc.UploadAssetFromFile(requestCtx, file, info.Size(), resp.Data.Attributes.UploadOperations); err != nil {
-				return fmt.Errorf("iap review-screenshots create: upload failed: %w", err)
-			}
-
-			uploaded := true
-			if _, err := client.UpdateInAppPurchaseAppStoreReviewScreenshot(requestCtx, resp.Data.ID, asc.InAppPurchaseAppStoreReviewScreenshotUpdateAttributes{
-				Uploaded:           &uploaded,
-				SourceFileChecksum: &checksum.Hash,
-			}); err != nil {
-				return fmt.Errorf("iap review-screenshots create: failed to commit upload: %w", err)
+				return fmt.Errorf("iap review-screenshots create: %w", err)
 			}
 
-			finalResp, err := client.GetInAppPurchaseAppStoreReviewScreenshot(requestCtx, resp.Data.ID)
+			finalResp, err := client.GetInAppPurchaseAppStoreReviewScreenshot(requestCtx, screenshotID)
 			if err != nil {
 				return fmt.Errorf("iap review-screenshots create: failed to fetch: %w", err)
 			}
@@ -327,3 +312,29 @@ Examples:
 		},
 	}
 }
+
+// uploadIAPReviewScreenshot reserves, uploads, and commits a review screenshot
+// for an in-app purchase, returning the new screenshot ID.
+func uploadIAPReviewScreenshot(ctx context.Context, client *asc.Client, iapID string, file *os.File, info os.FileInfo, checksum string) (string, error) {
+	resp, err := client.CreateInAppPurchaseAppStoreReviewScreenshot(ctx, iapID, info.Name(), info.Size())
+	if err != nil {
+		return "", fmt.Errorf("failed to create: %w", err)
+	}
+	if resp == nil || len(resp.Data.Attributes.UploadOperations) == 0 {
+		return "", fmt.Errorf("no upload operations returned")
+	}
+
+	if err := asc.UploadAssetFromFile(ctx, file, info.Size(), resp.Data.Attributes.UploadOperations); err != nil {
+		return "", fmt.Errorf("upload failed: %w", err)
+	}
+
+	uploaded := true
+	if _, err := client.UpdateInAppPurchaseAppStoreReviewScreenshot(ctx, resp.Data.ID, asc.InAppPurchaseAppStoreReviewScreenshotUpdateAttributes{
+		Uploaded:           &uploaded,
+		SourceFileChecksum: &checksum,
+	}); err != nil {
+		return "", fmt.Errorf("failed to commit upload: %w", err)
+	}
+
+	return resp.Data.ID, nil
+}
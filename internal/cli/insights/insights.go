@@ -1,9 +1,7 @@
 package insights
 
 import (
-	"compress/gzip"
 	"context"
-	"encoding/csv"
 	"errors"
 	"flag"
 	"fmt"
@@ -18,6 +16,7 @@ import (
 
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/reports"
 )
 
 const (
@@ -38,12 +37,16 @@ func InsightsCommand() *ffcli.Command {
 Examples:
   asc insights weekly --app "123456789" --source analytics --week "2026-02-16"
   asc insights weekly --app "123456789" --source sales --week "2026-02-16" --vendor "12345678"
-  asc insights daily --app "123456789" --vendor "12345678" --date "2026-02-20"`,
+  asc insights daily --app "123456789" --vendor "12345678" --date "2026-02-20"
+  asc insights subscriptions --app "123456789" --vendor "12345678" --from "2026-02-02" --to "2026-03-01"
+  asc insights trend --app "123456789" --vendor "12345678" --weeks 12 --metrics downloads,proceeds`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
 			insightsWeeklyCommand(),
 			insightsDailyCommand(),
+			insightsSubscriptionsCommand(),
+			insightsTrendCommand(),
 		},
 		Exec: func(_ context.Context, _ []string) error {
 			return flag.ErrHelp
@@ -575,58 +578,28 @@ func fetchSalesDayMetrics(ctx context.Context, client *asc.Client, vendor, repor
 }
 
 func parseSalesReportMetrics(reader io.Reader, scope salesScope) (salesWeekMetrics, error) {
-	gzipReader, err := gzip.NewReader(reader)
-	if err != nil {
-		return salesWeekMetrics{}, fmt.Errorf("read gzip report: %w", err)
-	}
-	defer func() { _ = gzipReader.Close() }()
-
-	tsvReader := csv.NewReader(gzipReader)
-	tsvReader.Comma = '\t'
-	tsvReader.FieldsPerRecord = -1
-	tsvReader.LazyQuotes = true
-
-	rows, err := tsvReader.ReadAll()
+	report, err := reports.ParseSales(reader, reports.Lenient())
 	if err != nil {
-		return salesWeekMetrics{}, fmt.Errorf("parse report rows: %w", err)
-	}
-	if len(rows) == 0 {
-		return salesWeekMetrics{}, fmt.Errorf("report is empty")
+		return salesWeekMetrics{}, err
 	}
-
-	headers := rows[0]
-	appleIdentifierIdx := findColumnIndex(headers, "appleidentifier")
-	parentIdentifierIdx := findColumnIndex(headers, "parentidentifier")
-	skuIdx := findColumnIndex(headers, "sku")
-	subscriptionIdx := findColumnIndex(headers, "subscription")
-	unitsIdx := findColumnIndex(headers, "units")
-	developerProceedsIdx := findColumnIndex(headers, "developerproceeds")
-	customerPriceIdx := findColumnIndex(headers, "customerprice")
-	if appleIdentifierIdx < 0 && parentIdentifierIdx < 0 {
+	if !report.HasColumn("Apple Identifier") && !report.HasColumn("Parent Identifier") {
 		return salesWeekMetrics{}, fmt.Errorf("report is missing Apple Identifier and Parent Identifier columns")
 	}
 
-	scope = enrichSalesScopeFromRows(scope, rows[1:], appleIdentifierIdx, skuIdx)
+	scope = enrichSalesScopeFromRows(scope, report.Rows)
 	metrics := salesWeekMetrics{
-		unitsColumnPresent:             unitsIdx >= 0,
-		developerProceedsColumnPresent: developerProceedsIdx >= 0,
-		customerPriceColumnPresent:     customerPriceIdx >= 0,
-		subscriptionColumnPresent:      subscriptionIdx >= 0,
+		unitsColumnPresent:             report.HasColumn("Units"),
+		developerProceedsColumnPresent: report.HasColumn("Developer Proceeds"),
+		customerPriceColumnPresent:     report.HasColumn("Customer Price"),
+		subscriptionColumnPresent:      report.HasColumn("Subscription"),
 	}
-	for _, row := range rows[1:] {
-		if isEmptyRow(row) {
-			continue
-		}
-
-		appleIdentifier := strings.TrimSpace(valueAtIndex(row, appleIdentifierIdx))
-		parentIdentifier := strings.TrimSpace(valueAtIndex(row, parentIdentifierIdx))
-		isAppRow, isMonetizedRow, include := rowMatchesSalesScope(scope, appleIdentifier, parentIdentifier)
+	for _, row := range report.Rows {
+		isAppRow, isMonetizedRow, include := rowMatchesSalesScope(scope, row.AppleIdentifier, row.ParentIdentifier)
 		if !include {
 			continue
 		}
-		subscriptionValue := strings.TrimSpace(valueAtIndex(row, subscriptionIdx))
-		isSubscriptionRow := subscriptionValue != ""
-		isRenewalRow := isRenewalSubscriptionState(subscriptionValue)
+		isSubscriptionRow := row.Subscription != ""
+		isRenewalRow := isRenewalSubscriptionState(row.Subscription)
 
 		metrics.rowCount++
 		if isSubscriptionRow {
@@ -636,68 +609,45 @@ func parseSalesReportMetrics(reader io.Reader, scope salesScope) (salesWeekMetri
 			metrics.renewalRows++
 		}
 
-		if unitsIdx >= 0 {
-			if value, ok := parseNumericValue(valueAtIndex(row, unitsIdx)); ok {
-				metrics.unitsTotal += value
-				if isAppRow {
-					metrics.downloadUnitsTotal += value
-				}
-				if isMonetizedRow {
-					metrics.monetizedUnitsTotal += value
-				}
-				if isSubscriptionRow {
-					metrics.subscriptionUnitsTotal += value
-				}
-				if isRenewalRow {
-					metrics.renewalUnitsTotal += value
-				}
-			}
+		metrics.unitsTotal += row.Units
+		if isAppRow {
+			metrics.downloadUnitsTotal += row.Units
 		}
-		if developerProceedsIdx >= 0 {
-			if value, ok := parseNumericValue(valueAtIndex(row, developerProceedsIdx)); ok {
-				metrics.developerProceedsTotal += value
-				if isSubscriptionRow {
-					metrics.subscriptionDeveloperProceeds += value
-				}
-				if isRenewalRow {
-					metrics.renewalDeveloperProceeds += value
-				}
-			}
+		if isMonetizedRow {
+			metrics.monetizedUnitsTotal += row.Units
 		}
-		if customerPriceIdx >= 0 {
-			if value, ok := parseNumericValue(valueAtIndex(row, customerPriceIdx)); ok {
-				metrics.customerPriceTotal += value
-				if isSubscriptionRow {
-					metrics.subscriptionCustomerPrice += value
-				}
-				if isRenewalRow {
-					metrics.renewalCustomerPrice += value
-				}
-			}
+		if isSubscriptionRow {
+			metrics.subscriptionUnitsTotal += row.Units
+		}
+		if isRenewalRow {
+			metrics.renewalUnitsTotal += row.Units
+		}
+
+		metrics.developerProceedsTotal += row.DeveloperProceeds
+		metrics.customerPriceTotal += row.CustomerPrice
+		if isSubscriptionRow {
+			metrics.subscriptionDeveloperProceeds += row.DeveloperProceeds
+			metrics.subscriptionCustomerPrice += row.CustomerPrice
+		}
+		if isRenewalRow {
+			metrics.renewalDeveloperProceeds += row.DeveloperProceeds
+			metrics.renewalCustomerPrice += row.CustomerPrice
 		}
 	}
 
 	return metrics, nil
 }
 
-func enrichSalesScopeFromRows(scope salesScope, rows [][]string, appleIdentifierIdx, skuIdx int) salesScope {
+func enrichSalesScopeFromRows(scope salesScope, rows []reports.SalesRow) salesScope {
 	if strings.TrimSpace(scope.appSKU) != "" {
 		return scope
 	}
-	if appleIdentifierIdx < 0 || skuIdx < 0 {
-		return scope
-	}
 	for _, row := range rows {
-		if isEmptyRow(row) {
+		if row.AppleIdentifier != strings.TrimSpace(scope.appID) {
 			continue
 		}
-		appleIdentifier := strings.TrimSpace(valueAtIndex(row, appleIdentifierIdx))
-		if appleIdentifier != strings.TrimSpace(scope.appID) {
-			continue
-		}
-		sku := strings.TrimSpace(valueAtIndex(row, skuIdx))
-		if sku != "" {
-			scope.appSKU = sku
+		if row.SKU != "" {
+			scope.appSKU = row.SKU
 			return scope
 		}
 	}
@@ -870,12 +820,7 @@ func isLikelyNotFound(err error) bool {
 	if err == nil {
 		return false
 	}
-	if errors.Is(err, asc.ErrNotFound) || asc.IsNotFound(err) {
-		return true
-	}
-
-	var apiErr *asc.APIError
-	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+	if asc.IsNotFound(err) {
 		return true
 	}
 
@@ -967,68 +912,6 @@ func ptrFloat64(value float64) *float64 {
 	return &value
 }
 
-func parseNumericValue(value string) (float64, bool) {
-	normalized := strings.TrimSpace(value)
-	if normalized == "" {
-		return 0, false
-	}
-
-	negative := false
-	if strings.HasPrefix(normalized, "(") && strings.HasSuffix(normalized, ")") {
-		negative = true
-		normalized = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(normalized, "("), ")"))
-	}
-
-	replacements := []string{",", "$", "€", "£", "¥"}
-	for _, token := range replacements {
-		normalized = strings.ReplaceAll(normalized, token, "")
-	}
-
-	parsed, err := strconv.ParseFloat(normalized, 64)
-	if err != nil {
-		return 0, false
-	}
-	if negative {
-		parsed = -parsed
-	}
-	return parsed, true
-}
-
-func findColumnIndex(headers []string, target string) int {
-	normalizedTarget := normalizeColumnName(target)
-	for index, header := range headers {
-		if normalizeColumnName(header) == normalizedTarget {
-			return index
-		}
-	}
-	return -1
-}
-
-func normalizeColumnName(value string) string {
-	normalized := strings.ToLower(strings.TrimSpace(value))
-	normalized = strings.ReplaceAll(normalized, " ", "")
-	normalized = strings.ReplaceAll(normalized, "_", "")
-	normalized = strings.ReplaceAll(normalized, "-", "")
-	normalized = strings.ReplaceAll(normalized, "/", "")
-	return normalized
-}
-
-func valueAtIndex(values []string, index int) string {
-	if index < 0 || index >= len(values) {
-		return ""
-	}
-	return values[index]
-}
-
-func isEmptyRow(values []string) bool {
-	for _, value := range values {
-		if strings.TrimSpace(value) != "" {
-			return false
-		}
-	}
-	return true
-}
-
 func weekWindowFromStart(start time.Time) reportWeekWindow {
 	startUTC := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
 	return reportWeekWindow{
@@ -3,9 +3,13 @@ package insights
 import (
 	"bytes"
 	"compress/gzip"
+	"fmt"
+	"slices"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/reports"
 )
 
 func TestNormalizeWeekStart(t *testing.T) {
@@ -22,6 +26,26 @@ func TestNormalizeWeekStart(t *testing.T) {
 	}
 }
 
+func TestParseSalesReportMetrics_SkipsNonNumericCells(t *testing.T) {
+	report := strings.Join([]string{
+		"Provider\tSKU\tApple Identifier\tUnits\tDeveloper Proceeds",
+		"foo\tChromism12345\t1500196580\t4\tn/a",
+		"foo\tChromism12345\t1500196580\t-\t1.50",
+		"",
+	}, "\n")
+
+	metrics, err := parseSalesReportMetrics(bytes.NewReader(gzipText(t, report)), salesScope{
+		appID:  "1500196580",
+		appSKU: "Chromism12345",
+	})
+	if err != nil {
+		t.Fatalf("parseSalesReportMetrics error: %v", err)
+	}
+	if metrics.rowCount != 2 || metrics.unitsTotal != 4 || metrics.developerProceedsTotal != 1.5 {
+		t.Fatalf("expected non-numeric cells to be skipped, got %+v", metrics)
+	}
+}
+
 func TestParseSalesReportMetrics(t *testing.T) {
 	report := strings.Join([]string{
 		"Provider\tSKU\tApple Identifier\tParent Identifier\tSubscription\tUnits\tDeveloper Proceeds\tCustomer Price",
@@ -94,3 +118,127 @@ func gzipText(t *testing.T, value string) []byte {
 	}
 	return out.Bytes()
 }
+
+func TestClassifySubscriptionEvent(t *testing.T) {
+	tests := map[string]string{
+		"Start Introductory Price":                  subscriptionEventTrialStart,
+		"Paid Subscription from Introductory Price": subscriptionEventTrialConversion,
+		"Subscribe":  subscriptionEventNew,
+		"Reactivate": subscriptionEventNew,
+		"Renew":      subscriptionEventRenewal,
+		"Cancel":     subscriptionEventCancellation,
+		"Refund":     subscriptionEventRefund,
+		"Upgrade":    "",
+	}
+	for event, want := range tests {
+		if got := classifySubscriptionEvent(event); got != want {
+			t.Fatalf("classifySubscriptionEvent(%q) = %q, want %q", event, got, want)
+		}
+	}
+}
+
+func TestSubscriptionInsightsChurnRate(t *testing.T) {
+	from := time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC)
+	collector := &subscriptionInsightsCollector{
+		base:          from.AddDate(0, 0, -7),
+		groups:        map[subscriptionGroupKey]map[int]*subscriptionWeekCounts{},
+		eventDays:     map[int]int{},
+		snapshotWeeks: map[int]bool{},
+	}
+	snapshot := func(day string, active float64) {
+		date, _ := time.Parse("2006-01-02", day)
+		collector.addSnapshot("app-1", date, []reports.SubscriptionRow{
+			{AppAppleID: "app-1", SubscriptionName: "Pro", Country: "US", ActiveStandardPriceSubscriptions: active},
+		})
+	}
+	events := func(day string, rows ...reports.SubscriptionEventRow) {
+		date, _ := time.Parse("2006-01-02", day)
+		collector.addEvents("app-1", date, rows)
+	}
+	snapshot("2026-02-01", 100)
+	snapshot("2026-02-08", 110)
+	snapshot("2026-02-15", 105)
+	events("2026-02-03", reports.SubscriptionEventRow{AppAppleID: "app-1", SubscriptionName: "Pro", Country: "US", Event: "Cancel", Quantity: 5})
+	events("2026-02-10",
+		reports.SubscriptionEventRow{AppAppleID: "app-1", SubscriptionName: "Pro", Country: "US", Event: "Cancel", Quantity: 11},
+		reports.SubscriptionEventRow{AppAppleID: "app-1", SubscriptionName: "Pro", Country: "US", Event: "Paid Subscription from Introductory Price"},
+		reports.SubscriptionEventRow{AppAppleID: "app-2", SubscriptionName: "Other", Country: "US", Event: "Cancel", Quantity: 50},
+	)
+
+	groups := collector.groupsFor(to)
+	if len(groups) != 3 || groups[0].Subscription != subscriptionsAllLabel || groups[2].Territory != "US" {
+		t.Fatalf("unexpected groups %+v", groups)
+	}
+	if len(groups[0].Weeks) != 1 || groups[0].Weeks[0].Week.Start != "2026-02-09" || groups[0].Weeks[0].PreviousWeek.Start != "2026-02-02" {
+		t.Fatalf("unexpected weeks %+v", groups[0].Weeks)
+	}
+
+	metrics := map[string]weeklyMetric{}
+	for _, metric := range groups[0].Weeks[0].Metrics {
+		metrics[metric.Name] = metric
+	}
+	churn := metrics["churn_rate"]
+	if churn.Status != "ok" || *churn.ThisWeek != 10 || *churn.LastWeek != 5 {
+		t.Fatalf("unexpected churn metric %+v", churn)
+	}
+	if conversions := metrics["trial_conversions"]; *conversions.ThisWeek != 1 || *conversions.LastWeek != 0 {
+		t.Fatalf("unexpected trial conversions %+v", conversions)
+	}
+	if active := metrics["active_subscriptions"]; *active.ThisWeek != 105 || *active.LastWeek != 110 {
+		t.Fatalf("unexpected active subscriptions %+v", active)
+	}
+}
+
+func TestDetectTrendAnomalies(t *testing.T) {
+	points := make([]trendPoint, 0)
+	for index, value := range []float64{100, 104, 96, 101, 99, 40} {
+		points = append(points, trendPoint{Week: fmt.Sprintf("w%d", index), Value: ptrFloat64(value), Status: "ok"})
+	}
+	points = append(points, trendPoint{Week: "w6", Status: "unavailable"})
+
+	anomalies := detectTrendAnomalies(points, 2)
+	if len(anomalies) != 1 {
+		t.Fatalf("expected one anomaly, got %+v", anomalies)
+	}
+	if anomaly := anomalies[0]; anomaly.Week != "w5" || anomaly.Direction != "drop" || anomaly.ZScore >= -2 {
+		t.Fatalf("unexpected anomaly %+v", anomaly)
+	}
+	if got := renderSparkline(points); got != "^^^^^_ " {
+		t.Fatalf("renderSparkline() = %q", got)
+	}
+}
+
+func TestResolveTrendMetrics(t *testing.T) {
+	got, err := resolveTrendMetrics("Downloads, proceeds,download_units,active_devices", sourceSales)
+	if err != nil || strings.Join(got, ",") != "download_units,developer_proceeds,active_devices" {
+		t.Fatalf("resolveTrendMetrics() = %v, %v", got, err)
+	}
+	if _, err := resolveTrendMetrics("downloads,installs", sourceSales); err == nil || !strings.Contains(err.Error(), `unknown metric "installs"`) {
+		t.Fatalf("expected unknown metric error, got %v", err)
+	}
+	for _, sourceName := range []string{sourceSales, sourceAnalytics} {
+		if got, err := resolveTrendMetrics("sessions", sourceName); err != nil || strings.Join(got, ",") != "sessions" {
+			t.Fatalf("expected sessions to be accepted for %s, got %v, %v", sourceName, got, err)
+		}
+	}
+	if _, err := resolveTrendMetrics("downloads", sourceAnalytics); err == nil {
+		t.Fatal("expected sales metric to be rejected for analytics")
+	}
+
+	var analytics []string
+	for _, metric := range analyticsUnavailableMetrics("") {
+		analytics = append(analytics, metric.Name)
+	}
+	if !slices.Equal(analytics, trendMetricNames[sourceAnalytics]) {
+		t.Fatalf("analytics trend metrics %v do not match weekly metrics %v", trendMetricNames[sourceAnalytics], analytics)
+	}
+}
+
+func TestDefaultTrendWeekStart(t *testing.T) {
+	// Sunday 2026-10-18 -> Monday 2026-10-05, the start of the last full week.
+	got := defaultTrendWeekStart(time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC))
+	if got.Format("2006-01-02") != "2026-10-05" {
+		t.Fatalf("defaultTrendWeekStart() = %s", got.Format("2006-01-02"))
+	}
+}
@@ -0,0 +1,504 @@
+package insights
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/reports"
+)
+
+const (
+	subscriptionInsightsMaxDays = 92
+	subscriptionsAllLabel       = "(all)"
+
+	subscriptionEventTrialStart      = "trial_starts"
+	subscriptionEventTrialConversion = "trial_conversions"
+	subscriptionEventNew             = "new_subscriptions"
+	subscriptionEventRenewal         = "renewals"
+	subscriptionEventCancellation    = "cancellations"
+	subscriptionEventRefund          = "refunds"
+)
+
+// subscriptionEventMetrics are the event counts reported per week, in output
+// order.
+var subscriptionEventMetrics = []string{
+	subscriptionEventTrialStart,
+	subscriptionEventTrialConversion,
+	subscriptionEventNew,
+	subscriptionEventRenewal,
+	subscriptionEventCancellation,
+	subscriptionEventRefund,
+}
+
+func insightsSubscriptionsCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("insights subscriptions", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (required, or ASC_APP_ID env)")
+	vendor := fs.String("vendor", "", "Vendor number (or ASC_VENDOR_NUMBER)")
+	from := fs.String("from", "", "First day of the range (YYYY-MM-DD)")
+	to := fs.String("to", "", "Last day of the range (YYYY-MM-DD)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "subscriptions",
+		ShortUsage: "asc insights subscriptions --app \"APP_ID\" --vendor \"VENDOR\" --from \"YYYY-MM-DD\" --to \"YYYY-MM-DD\" [flags]",
+		ShortHelp:  "Summarize subscription conversions, renewals, and churn week over week.",
+		LongHelp: `Summarize subscription conversions, renewals, and churn week over week.
+
+Aggregates the daily SUBSCRIPTION_EVENT and SUBSCRIPTION sales reports for the
+app into trial starts, trial conversions, new subscriptions, renewals,
+cancellations, refunds, active subscriptions, and churn rate. Metrics are
+grouped for the whole app, per subscription, and per subscription and
+territory.
+
+The range is split into 7-day weeks starting at --from, and every week is
+compared to the week before it (the week before --from is fetched for the
+first comparison), using the same metric model as 'asc insights weekly'. The
+last week is cut off at --to, so it may be shorter than 7 days.
+Churn rate is cancellations divided by active subscriptions at the end of the
+previous week. Days without a published report are listed in
+source.missingDates. Ranges are limited to 92 days.
+
+Examples:
+  asc insights subscriptions --app "123456789" --vendor "12345678" --from "2026-09-01" --to "2026-09-28"
+  asc insights subscriptions --app "123456789" --vendor "12345678" --from "2026-09-01" --to "2026-09-28" --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) > 0 {
+				return shared.UsageErrorf("unexpected argument(s): %s", strings.Join(args, " "))
+			}
+
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				return shared.UsageError("--app is required (or set ASC_APP_ID)")
+			}
+			resolvedVendor := shared.ResolveVendorNumber(*vendor)
+			if resolvedVendor == "" {
+				return shared.UsageError("--vendor is required (or set ASC_VENDOR_NUMBER)")
+			}
+			fromDate, err := normalizeInsightsDate(*from, "--from")
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+			toDate, err := normalizeInsightsDate(*to, "--to")
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+			if toDate.Before(fromDate) {
+				return shared.UsageError("--to must not be before --from")
+			}
+			if days := int(toDate.Sub(fromDate).Hours()/24) + 1; days > subscriptionInsightsMaxDays {
+				return shared.UsageErrorf("--from/--to range must be at most %d days", subscriptionInsightsMaxDays)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("insights subscriptions: %w", err)
+			}
+
+			resp, err := collectSubscriptionInsights(ctx, client, resolvedAppID, resolvedVendor, fromDate, toDate)
+			if err != nil {
+				return fmt.Errorf("insights subscriptions: %w", err)
+			}
+
+			return shared.PrintOutputWithRenderers(
+				resp,
+				*output.Output,
+				*output.Pretty,
+				func() error { renderSubscriptionInsights(resp, false); return nil },
+				func() error { renderSubscriptionInsights(resp, true); return nil },
+			)
+		},
+	}
+}
+
+type subscriptionInsightsResponse struct {
+	AppID       string                     `json:"appId"`
+	Source      subscriptionInsightsSource `json:"source"`
+	From        string                     `json:"from"`
+	To          string                     `json:"to"`
+	Groups      []subscriptionInsightGroup `json:"groups"`
+	GeneratedAt string                     `json:"generatedAt"`
+}
+
+type subscriptionInsightsSource struct {
+	Name         string   `json:"name"`
+	VendorNumber string   `json:"vendorNumber"`
+	ReportTypes  []string `json:"reportTypes"`
+	Frequency    string   `json:"frequency"`
+	Version      string   `json:"version"`
+	MissingDates []string `json:"missingDates,omitempty"`
+}
+
+type subscriptionInsightGroup struct {
+	Subscription        string                    `json:"subscription"`
+	SubscriptionAppleID string                    `json:"subscriptionAppleId,omitempty"`
+	Territory           string                    `json:"territory"`
+	Weeks               []subscriptionInsightWeek `json:"weeks"`
+}
+
+type subscriptionInsightWeek struct {
+	Week         weekRange      `json:"week"`
+	PreviousWeek weekRange      `json:"previousWeek"`
+	Metrics      []weeklyMetric `json:"metrics"`
+}
+
+type subscriptionGroupKey struct {
+	subscription string
+	appleID      string
+	territory    string
+}
+
+// subscriptionWeekCounts holds one group's totals for one week.
+type subscriptionWeekCounts struct {
+	events map[string]float64
+	active float64
+}
+
+type subscriptionInsightsCollector struct {
+	base          time.Time
+	groups        map[subscriptionGroupKey]map[int]*subscriptionWeekCounts
+	eventDays     map[int]int
+	snapshotWeeks map[int]bool
+}
+
+func collectSubscriptionInsights(ctx context.Context, client *asc.Client, appID, vendor string, from, to time.Time) (*subscriptionInsightsResponse, error) {
+	collector := &subscriptionInsightsCollector{
+		base:          from.AddDate(0, 0, -7),
+		groups:        map[subscriptionGroupKey]map[int]*subscriptionWeekCounts{},
+		eventDays:     map[int]int{},
+		snapshotWeeks: map[int]bool{},
+	}
+	resp := &subscriptionInsightsResponse{
+		AppID: appID,
+		Source: subscriptionInsightsSource{
+			Name:         sourceSales,
+			VendorNumber: vendor,
+			ReportTypes:  []string{string(asc.SalesReportTypeSubscriptionEvent), string(asc.SalesReportTypeSubscription)},
+			Frequency:    string(asc.SalesReportFrequencyDaily),
+			Version:      string(asc.SalesReportVersion1_3),
+		},
+		From:        from.Format("2006-01-02"),
+		To:          to.Format("2006-01-02"),
+		Groups:      []subscriptionInsightGroup{},
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	missing := map[string]bool{}
+
+	// Events for the previous week and the range; active subscription
+	// snapshots on the last day of every week, including the week before the
+	// previous one so the first churn comparison has a denominator.
+	for day := collector.base; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		events, err := fetchSubscriptionEventReport(ctx, client, vendor, date)
+		if err != nil {
+			if !isLikelyNotFound(err) {
+				return nil, fmt.Errorf("SUBSCRIPTION_EVENT report for %s: %w", date, err)
+			}
+			missing[date] = true
+			continue
+		}
+		collector.addEvents(appID, day, events.Rows)
+	}
+	for _, day := range collector.snapshotDays(to) {
+		date := day.Format("2006-01-02")
+		snapshot, err := fetchSubscriptionSnapshotReport(ctx, client, vendor, date)
+		if err != nil {
+			if !isLikelyNotFound(err) {
+				return nil, fmt.Errorf("SUBSCRIPTION report for %s: %w", date, err)
+			}
+			missing[date] = true
+			continue
+		}
+		collector.addSnapshot(appID, day, snapshot.Rows)
+	}
+
+	for date := range missing {
+		resp.Source.MissingDates = append(resp.Source.MissingDates, date)
+	}
+	sort.Strings(resp.Source.MissingDates)
+	resp.Groups = collector.groupsFor(to)
+	return resp, nil
+}
+
+func fetchSubscriptionEventReport(ctx context.Context, client *asc.Client, vendor, date string) (*reports.Report[reports.SubscriptionEventRow], error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	download, err := client.GetSalesReport(requestCtx, subscriptionReportParams(vendor, asc.SalesReportTypeSubscriptionEvent, date))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = download.Body.Close() }()
+	return reports.ParseSubscriptionEvent(download.Body, reports.Lenient())
+}
+
+func fetchSubscriptionSnapshotReport(ctx context.Context, client *asc.Client, vendor, date string) (*reports.Report[reports.SubscriptionRow], error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	download, err := client.GetSalesReport(requestCtx, subscriptionReportParams(vendor, asc.SalesReportTypeSubscription, date))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = download.Body.Close() }()
+	return reports.ParseSubscription(download.Body, reports.Lenient())
+}
+
+func subscriptionReportParams(vendor string, reportType asc.SalesReportType, date string) asc.SalesReportParams {
+	return asc.SalesReportParams{
+		VendorNumber:  vendor,
+		ReportType:    reportType,
+		ReportSubType: asc.SalesReportSubTypeSummary,
+		Frequency:     asc.SalesReportFrequencyDaily,
+		ReportDate:    date,
+		Version:       asc.SalesReportVersion1_3,
+	}
+}
+
+// weekIndex returns the 7-day week of day relative to the week before --from
+// (index 0). Range weeks start at index 1.
+func (c *subscriptionInsightsCollector) weekIndex(day time.Time) int {
+	days := int(day.Sub(c.base).Hours() / 24)
+	if days < 0 {
+		return (days - 6) / 7
+	}
+	return days / 7
+}
+
+func (c *subscriptionInsightsCollector) weekWindow(index int, to time.Time) reportWeekWindow {
+	window := weekWindowFromStart(c.base.AddDate(0, 0, 7*index))
+	if window.end.After(to) {
+		window.end = to
+	}
+	return window
+}
+
+func (c *subscriptionInsightsCollector) lastWeek(to time.Time) int {
+	return c.weekIndex(to)
+}
+
+// snapshotDays returns the last day of weeks -1 through the last range week.
+func (c *subscriptionInsightsCollector) snapshotDays(to time.Time) []time.Time {
+	var days []time.Time
+	for index := -1; index <= c.lastWeek(to); index++ {
+		days = append(days, c.weekWindow(index, to).end)
+	}
+	return days
+}
+
+func (c *subscriptionInsightsCollector) counts(key subscriptionGroupKey, week int) *subscriptionWeekCounts {
+	weeks := c.groups[key]
+	if weeks == nil {
+		weeks = map[int]*subscriptionWeekCounts{}
+		c.groups[key] = weeks
+	}
+	counts := weeks[week]
+	if counts == nil {
+		counts = &subscriptionWeekCounts{events: map[string]float64{}}
+		weeks[week] = counts
+	}
+	return counts
+}
+
+func subscriptionGroupKeys(name, appleID, territory string) []subscriptionGroupKey {
+	if name == "" {
+		name = appleID
+	}
+	return []subscriptionGroupKey{
+		{subscription: subscriptionsAllLabel, territory: subscriptionsAllLabel},
+		{subscription: name, appleID: appleID, territory: subscriptionsAllLabel},
+		{subscription: name, appleID: appleID, territory: shared.OrNA(territory)},
+	}
+}
+
+func (c *subscriptionInsightsCollector) addEvents(appID string, day time.Time, rows []reports.SubscriptionEventRow) {
+	week := c.weekIndex(day)
+	c.eventDays[week]++
+	for _, row := range rows {
+		if row.AppAppleID != "" && row.AppAppleID != appID {
+			continue
+		}
+		metric := classifySubscriptionEvent(row.Event)
+		if metric == "" {
+			continue
+		}
+		quantity := row.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		for _, key := range subscriptionGroupKeys(row.SubscriptionName, row.SubscriptionAppleID, row.Country) {
+			c.counts(key, week).events[metric] += quantity
+		}
+	}
+}
+
+func (c *subscriptionInsightsCollector) addSnapshot(appID string, day time.Time, rows []reports.SubscriptionRow) {
+	week := c.weekIndex(day)
+	c.snapshotWeeks[week] = true
+	for _, row := range rows {
+		if row.AppAppleID != "" && row.AppAppleID != appID {
+			continue
+		}
+		for _, key := range subscriptionGroupKeys(row.SubscriptionName, row.SubscriptionAppleID, row.Country) {
+			c.counts(key, week).active += row.ActiveSubscriptions()
+		}
+	}
+}
+
+func (c *subscriptionInsightsCollector) groupsFor(to time.Time) []subscriptionInsightGroup {
+	keys := make([]subscriptionGroupKey, 0, len(c.groups))
+	for key := range c.groups {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		left, right := keys[i], keys[j]
+		if (left.subscription == subscriptionsAllLabel) != (right.subscription == subscriptionsAllLabel) {
+			return left.subscription == subscriptionsAllLabel
+		}
+		if left.subscription != right.subscription {
+			return left.subscription < right.subscription
+		}
+		if (left.territory == subscriptionsAllLabel) != (right.territory == subscriptionsAllLabel) {
+			return left.territory == subscriptionsAllLabel
+		}
+		return left.territory < right.territory
+	})
+
+	groups := make([]subscriptionInsightGroup, 0, len(keys))
+	for _, key := range keys {
+		group := subscriptionInsightGroup{
+			Subscription:        key.subscription,
+			SubscriptionAppleID: key.appleID,
+			Territory:           key.territory,
+		}
+		for week := 1; week <= c.lastWeek(to); week++ {
+			this := c.weekWindow(week, to)
+			previous := c.weekWindow(week-1, to)
+			group.Weeks = append(group.Weeks, subscriptionInsightWeek{
+				Week:         weekRange{Start: this.start.Format("2006-01-02"), End: this.end.Format("2006-01-02")},
+				PreviousWeek: weekRange{Start: previous.start.Format("2006-01-02"), End: previous.end.Format("2006-01-02")},
+				Metrics:      c.weekMetrics(key, week),
+			})
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+func (c *subscriptionInsightsCollector) weekMetrics(key subscriptionGroupKey, week int) []weeklyMetric {
+	weeks := c.groups[key]
+	empty := &subscriptionWeekCounts{events: map[string]float64{}}
+	get := func(index int) *subscriptionWeekCounts {
+		if counts := weeks[index]; counts != nil {
+			return counts
+		}
+		return empty
+	}
+	this, last := get(week), get(week-1)
+
+	eventsAvailable := c.eventDays[week] > 0 && c.eventDays[week-1] > 0
+	eventsReason := ""
+	if !eventsAvailable {
+		eventsReason = "no SUBSCRIPTION_EVENT reports available for one of the weeks"
+	}
+
+	metrics := make([]weeklyMetric, 0, len(subscriptionEventMetrics)+2)
+	for _, name := range subscriptionEventMetrics {
+		metrics = append(metrics, metricFromOptionalTotals(name, "count", eventsAvailable, this.events[name], last.events[name], eventsReason))
+	}
+	metrics = append(metrics, metricFromOptionalTotals(
+		"active_subscriptions",
+		"count",
+		c.snapshotWeeks[week] && c.snapshotWeeks[week-1],
+		this.active,
+		last.active,
+		"no SUBSCRIPTION report available at the end of one of the weeks",
+	))
+
+	thisChurn, thisOK := churnRate(this, get(week-1), c.snapshotWeeks[week-1])
+	lastChurn, lastOK := churnRate(last, get(week-2), c.snapshotWeeks[week-2])
+	churnReason := "no active subscriptions at the start of one of the weeks"
+	if !eventsAvailable {
+		churnReason = eventsReason
+	}
+	metrics = append(metrics, metricFromOptionalTotals("churn_rate", "percent", eventsAvailable && thisOK && lastOK, thisChurn, lastChurn, churnReason))
+	return metrics
+}
+
+// churnRate is a week's cancellations as a percentage of the subscriptions
+// active at the end of the week before.
+func churnRate(week, previous *subscriptionWeekCounts, previousAvailable bool) (float64, bool) {
+	if !previousAvailable || previous.active <= 0 {
+		return 0, false
+	}
+	return week.events[subscriptionEventCancellation] / previous.active * 100, true
+}
+
+// classifySubscriptionEvent maps a SUBSCRIPTION_EVENT Event value to a
+// metric, or "" for events that are not counted (upgrades, billing retry,
+// grace period, and similar state changes).
+func classifySubscriptionEvent(event string) string {
+	normalized := strings.ToLower(strings.TrimSpace(event))
+	switch {
+	case strings.Contains(normalized, "refund"):
+		return subscriptionEventRefund
+	case strings.HasPrefix(normalized, "cancel"):
+		return subscriptionEventCancellation
+	case strings.HasPrefix(normalized, "paid subscription from introductory price"),
+		strings.HasPrefix(normalized, "paid subscription from free trial"):
+		return subscriptionEventTrialConversion
+	case strings.HasPrefix(normalized, "start introductory price"),
+		strings.HasPrefix(normalized, "start free trial"):
+		return subscriptionEventTrialStart
+	case normalized == "subscribe", strings.HasPrefix(normalized, "reactivate"):
+		return subscriptionEventNew
+	case strings.HasPrefix(normalized, "renew"):
+		return subscriptionEventRenewal
+	default:
+		return ""
+	}
+}
+
+func renderSubscriptionInsights(resp *subscriptionInsightsResponse, markdown bool) {
+	contextRows := [][]string{
+		{"appId", resp.AppID},
+		{"source", resp.Source.Name},
+		{"vendorNumber", resp.Source.VendorNumber},
+		{"range", fmt.Sprintf("%s to %s", resp.From, resp.To)},
+		{"generatedAt", resp.GeneratedAt},
+	}
+	if len(resp.Source.MissingDates) > 0 {
+		contextRows = append(contextRows, []string{"missingDates", strings.Join(resp.Source.MissingDates, ", ")})
+	}
+	shared.RenderSection("Context", []string{"field", "value"}, contextRows, markdown)
+
+	metricRows := make([][]string, 0)
+	for _, group := range resp.Groups {
+		for _, week := range group.Weeks {
+			for _, metric := range week.Metrics {
+				metricRows = append(metricRows, []string{
+					group.Subscription,
+					group.Territory,
+					week.Week.Start,
+					metric.Name,
+					formatOptionalNumber(metric.ThisWeek),
+					formatOptionalNumber(metric.LastWeek),
+					formatOptionalNumber(metric.Delta),
+					formatOptionalNumber(metric.DeltaPercent),
+					metric.Status,
+				})
+			}
+		}
+	}
+	shared.RenderSection("Metrics", []string{"subscription", "territory", "week", "metric", "thisWeek", "lastWeek", "delta", "deltaPercent", "status"}, metricRows, markdown)
+}
@@ -0,0 +1,460 @@
+package insights
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	trendDefaultWeeks     = 12
+	trendMaxWeeks         = 52
+	trendDefaultThreshold = 2.0
+	// trendMinBaseline is the number of earlier points needed before a week
+	// can be flagged as an anomaly.
+	trendMinBaseline = 3
+)
+
+// trendMetricAliases maps short --metrics names to weekly metric names.
+var trendMetricAliases = map[string]string{
+	"downloads": "download_units",
+	"proceeds":  "developer_proceeds",
+	"revenue":   "customer_price",
+}
+
+// trendMetricNames lists the weekly metric names each source reports,
+// including metrics it reports as unavailable.
+var trendMetricNames = map[string][]string{
+	sourceSales: {
+		"download_units",
+		"monetized_units",
+		"units",
+		"developer_proceeds",
+		"customer_price",
+		"report_rows",
+		"active_devices",
+	},
+	sourceAnalytics: {
+		"completed_requests",
+		"reports_available",
+		"instances_available",
+		"business_conversion_rate",
+	},
+}
+
+// trendUnavailableMetrics are metrics that can be requested from either
+// source but that neither weekly collection derives. They are returned as
+// unavailable series with the given reason.
+var trendUnavailableMetrics = map[string]string{
+	"sessions": "sessions come from the App Analytics engagement reports, which weekly insights does not download",
+}
+
+// trendSparkLevels are the ASCII characters used for sparklines, lowest first.
+const trendSparkLevels = "_.-~^"
+
+func insightsTrendCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("insights trend", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (required, or ASC_APP_ID env)")
+	source := fs.String("source", sourceSales, "Insights source: analytics or sales")
+	week := fs.String("week", "", "Start date of the most recent week (YYYY-MM-DD, default: Monday of last week)")
+	weeks := fs.Int("weeks", trendDefaultWeeks, fmt.Sprintf("Number of weeks in the series (1-%d)", trendMaxWeeks))
+	metrics := fs.String("metrics", "", "Comma-separated metric names or aliases (downloads, proceeds, revenue; default: all)")
+	threshold := fs.Float64("threshold", trendDefaultThreshold, "Flag weeks more than this many standard deviations from the preceding weeks")
+	vendor := fs.String("vendor", "", "Vendor number for sales source (or ASC_VENDOR_NUMBER)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "trend",
+		ShortUsage: "asc insights trend --app \"APP_ID\" [--source analytics|sales] [--weeks N] [--metrics LIST] [flags]",
+		ShortHelp:  "Show multi-week metric trends with anomaly detection.",
+		LongHelp: `Show multi-week metric trends with anomaly detection.
+
+Runs the 'asc insights weekly' collection for each of the last --weeks weeks
+ending with --week and returns one time series per metric. A week is flagged
+as an anomaly when its value is more than --threshold standard deviations
+below (drop) or above (spike) the mean of the weeks before it; at least 3
+earlier weeks are needed. Table and markdown output include an ASCII
+sparkline per metric.
+
+Metrics are selected by weekly metric name (for example download_units) or by
+alias: downloads, proceeds, revenue. Names the source does not report are
+rejected; metrics it cannot derive (for example active_devices or sessions)
+are returned as unavailable.
+
+Examples:
+  asc insights trend --app "123456789" --vendor "12345678" --weeks 12 --metrics downloads,proceeds,sessions
+  asc insights trend --app "123456789" --source analytics --weeks 8 --week "2026-02-16"
+  asc insights trend --app "123456789" --vendor "12345678" --threshold 1.5 --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) > 0 {
+				return shared.UsageErrorf("unexpected argument(s): %s", strings.Join(args, " "))
+			}
+
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				return shared.UsageError("--app is required (or set ASC_APP_ID)")
+			}
+
+			sourceName := strings.ToLower(strings.TrimSpace(*source))
+			if !isAllowedSource(sourceName) {
+				return shared.UsageErrorf("--source must be one of: %s, %s", sourceAnalytics, sourceSales)
+			}
+			if *weeks < 1 || *weeks > trendMaxWeeks {
+				return shared.UsageErrorf("--weeks must be between 1 and %d", trendMaxWeeks)
+			}
+			if *threshold <= 0 {
+				return shared.UsageError("--threshold must be greater than 0")
+			}
+
+			metricNames, err := resolveTrendMetrics(*metrics, sourceName)
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+
+			lastWeekStart := defaultTrendWeekStart(time.Now().UTC())
+			if strings.TrimSpace(*week) != "" {
+				parsed, err := normalizeWeekStart(*week)
+				if err != nil {
+					return shared.UsageError(err.Error())
+				}
+				lastWeekStart = parsed
+			}
+
+			resolvedVendor := ""
+			if sourceName == sourceSales {
+				resolvedVendor = shared.ResolveVendorNumber(*vendor)
+				if resolvedVendor == "" {
+					return shared.UsageError("--vendor is required for --source sales (or set ASC_VENDOR_NUMBER)")
+				}
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("insights trend: %w", err)
+			}
+
+			resp, err := collectTrendInsights(ctx, client, resolvedAppID, sourceName, resolvedVendor, lastWeekStart, *weeks, metricNames, *threshold)
+			if err != nil {
+				return fmt.Errorf("insights trend: %w", err)
+			}
+
+			return shared.PrintOutputWithRenderers(
+				resp,
+				*output.Output,
+				*output.Pretty,
+				func() error { renderTrendInsights(resp, false); return nil },
+				func() error { renderTrendInsights(resp, true); return nil },
+			)
+		},
+	}
+}
+
+type trendInsightsResponse struct {
+	AppID       string               `json:"appId"`
+	Source      weeklyInsightsSource `json:"source"`
+	Weeks       []weekRange          `json:"weeks"`
+	Threshold   float64              `json:"threshold"`
+	Series      []trendSeries        `json:"series"`
+	GeneratedAt string               `json:"generatedAt"`
+}
+
+type trendSeries struct {
+	Name      string         `json:"name"`
+	Unit      string         `json:"unit,omitempty"`
+	Points    []trendPoint   `json:"points"`
+	Min       *float64       `json:"min,omitempty"`
+	Max       *float64       `json:"max,omitempty"`
+	Mean      *float64       `json:"mean,omitempty"`
+	Sparkline string         `json:"sparkline"`
+	Anomalies []trendAnomaly `json:"anomalies"`
+	Status    string         `json:"status"`
+	Reason    string         `json:"reason,omitempty"`
+}
+
+type trendPoint struct {
+	Week   string   `json:"week"`
+	Value  *float64 `json:"value,omitempty"`
+	Status string   `json:"status"`
+	Reason string   `json:"reason,omitempty"`
+}
+
+type trendAnomaly struct {
+	Week         string  `json:"week"`
+	Value        float64 `json:"value"`
+	BaselineMean float64 `json:"baselineMean"`
+	BaselineStd  float64 `json:"baselineStdDev"`
+	ZScore       float64 `json:"zScore"`
+	Direction    string  `json:"direction"`
+}
+
+// defaultTrendWeekStart returns the Monday of the last complete week before now.
+func defaultTrendWeekStart(now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	sinceMonday := (int(today.Weekday()) + 6) % 7
+	return today.AddDate(0, 0, -sinceMonday-7)
+}
+
+// resolveTrendMetrics expands aliases and rejects names the source does not
+// report.
+func resolveTrendMetrics(value, sourceName string) ([]string, error) {
+	known := trendMetricNames[sourceName]
+	names := make([]string, 0)
+	for _, name := range shared.SplitCSV(value) {
+		name = strings.ToLower(name)
+		if alias, ok := trendMetricAliases[name]; ok {
+			name = alias
+		}
+		if _, unavailable := trendUnavailableMetrics[name]; !unavailable && !slices.Contains(known, name) {
+			return nil, fmt.Errorf("--metrics: unknown metric %q for --source %s (available: %s, sessions)", name, sourceName, strings.Join(known, ", "))
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func collectTrendInsights(ctx context.Context, client *asc.Client, appID, sourceName, vendor string, lastWeekStart time.Time, weeks int, metricNames []string, threshold float64) (*trendInsightsResponse, error) {
+	resp := &trendInsightsResponse{
+		AppID:       appID,
+		Threshold:   threshold,
+		Series:      []trendSeries{},
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	weekly := make([]*weeklyInsightsResponse, 0, weeks)
+	for index := range weeks {
+		weekStart := lastWeekStart.AddDate(0, 0, -7*(weeks-1-index))
+		weekResp, err := collectTrendWeek(ctx, client, appID, sourceName, vendor, weekStart)
+		if err != nil {
+			return nil, fmt.Errorf("week %s: %w", weekStart.Format("2006-01-02"), err)
+		}
+		weekly = append(weekly, weekResp)
+		resp.Weeks = append(resp.Weeks, weekResp.Week)
+	}
+	resp.Source = weekly[len(weekly)-1].Source
+
+	if len(metricNames) == 0 {
+		for _, metric := range weekly[len(weekly)-1].Metrics {
+			metricNames = append(metricNames, metric.Name)
+		}
+	}
+	for _, name := range metricNames {
+		resp.Series = append(resp.Series, buildTrendSeries(name, sourceName, weekly, threshold))
+	}
+	return resp, nil
+}
+
+func collectTrendWeek(ctx context.Context, client *asc.Client, appID, sourceName, vendor string, weekStart time.Time) (*weeklyInsightsResponse, error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	return collectWeeklyInsights(requestCtx, client, appID, sourceName, vendor, weekStart)
+}
+
+func buildTrendSeries(name, sourceName string, weekly []*weeklyInsightsResponse, threshold float64) trendSeries {
+	series := trendSeries{
+		Name:      name,
+		Points:    make([]trendPoint, 0, len(weekly)),
+		Anomalies: []trendAnomaly{},
+		Status:    "ok",
+	}
+	found := false
+	for _, weekResp := range weekly {
+		point := trendPoint{Week: weekResp.Week.Start, Status: "unavailable"}
+		for _, metric := range weekResp.Metrics {
+			if metric.Name != name {
+				continue
+			}
+			found = true
+			series.Unit = metric.Unit
+			point.Reason = metric.Reason
+			if metric.ThisWeek != nil {
+				point.Value = ptrFloat64(*metric.ThisWeek)
+				point.Status = "ok"
+				point.Reason = ""
+			}
+			break
+		}
+		series.Points = append(series.Points, point)
+	}
+	if !found {
+		series.Status = "unavailable"
+		series.Reason = fmt.Sprintf("metric is not reported by --source %s", sourceName)
+		if reason, ok := trendUnavailableMetrics[name]; ok {
+			series.Reason = reason
+		}
+		series.Sparkline = renderSparkline(series.Points)
+		return series
+	}
+
+	values := make([]float64, 0, len(series.Points))
+	for _, point := range series.Points {
+		if point.Value != nil {
+			values = append(values, *point.Value)
+		}
+	}
+	if len(values) == 0 {
+		series.Status = "unavailable"
+		series.Reason = "no week has a value for this metric"
+	} else {
+		minValue, maxValue := values[0], values[0]
+		for _, value := range values {
+			minValue = math.Min(minValue, value)
+			maxValue = math.Max(maxValue, value)
+		}
+		mean, _ := meanStdDev(values)
+		series.Min, series.Max, series.Mean = ptrFloat64(minValue), ptrFloat64(maxValue), ptrFloat64(mean)
+	}
+	series.Sparkline = renderSparkline(series.Points)
+	series.Anomalies = detectTrendAnomalies(series.Points, threshold)
+	return series
+}
+
+// detectTrendAnomalies flags points that deviate from the mean of all earlier
+// available points by more than threshold standard deviations.
+func detectTrendAnomalies(points []trendPoint, threshold float64) []trendAnomaly {
+	anomalies := []trendAnomaly{}
+	baseline := make([]float64, 0, len(points))
+	for _, point := range points {
+		if point.Value == nil {
+			continue
+		}
+		value := *point.Value
+		if len(baseline) >= trendMinBaseline {
+			mean, stdDev := meanStdDev(baseline)
+			if stdDev > 0 {
+				zScore := (value - mean) / stdDev
+				if math.Abs(zScore) > threshold {
+					direction := "spike"
+					if zScore < 0 {
+						direction = "drop"
+					}
+					anomalies = append(anomalies, trendAnomaly{
+						Week:         point.Week,
+						Value:        value,
+						BaselineMean: mean,
+						BaselineStd:  stdDev,
+						ZScore:       zScore,
+						Direction:    direction,
+					})
+				}
+			}
+		}
+		baseline = append(baseline, value)
+	}
+	return anomalies
+}
+
+// meanStdDev returns the mean and population standard deviation of values.
+func meanStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / float64(len(values))
+	var squares float64
+	for _, value := range values {
+		squares += (value - mean) * (value - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)))
+}
+
+// renderSparkline scales available points onto trendSparkLevels; missing
+// points are rendered as a space.
+func renderSparkline(points []trendPoint) string {
+	minValue, maxValue := math.Inf(1), math.Inf(-1)
+	for _, point := range points {
+		if point.Value != nil {
+			minValue = math.Min(minValue, *point.Value)
+			maxValue = math.Max(maxValue, *point.Value)
+		}
+	}
+
+	var builder strings.Builder
+	top := len(trendSparkLevels) - 1
+	for _, point := range points {
+		switch {
+		case point.Value == nil:
+			builder.WriteByte(' ')
+		case maxValue == minValue:
+			builder.WriteByte(trendSparkLevels[top/2])
+		default:
+			level := int(math.Round((*point.Value - minValue) / (maxValue - minValue) * float64(top)))
+			builder.WriteByte(trendSparkLevels[level])
+		}
+	}
+	return builder.String()
+}
+
+func renderTrendInsights(resp *trendInsightsResponse, markdown bool) {
+	contextRows := [][]string{
+		{"appId", resp.AppID},
+		{"source", resp.Source.Name},
+		{"threshold", strconv.FormatFloat(resp.Threshold, 'f', -1, 64)},
+		{"generatedAt", resp.GeneratedAt},
+	}
+	if len(resp.Weeks) > 0 {
+		contextRows = append(contextRows, []string{"weeks", fmt.Sprintf("%s to %s (%d)", resp.Weeks[0].Start, resp.Weeks[len(resp.Weeks)-1].End, len(resp.Weeks))})
+	}
+	if strings.TrimSpace(resp.Source.VendorNumber) != "" {
+		contextRows = append(contextRows, []string{"vendorNumber", resp.Source.VendorNumber})
+	}
+	shared.RenderSection("Context", []string{"field", "value"}, contextRows, markdown)
+
+	trendRows := make([][]string, 0, len(resp.Series))
+	anomalyRows := make([][]string, 0)
+	for _, series := range resp.Series {
+		var latest *float64
+		if len(series.Points) > 0 {
+			latest = series.Points[len(series.Points)-1].Value
+		}
+		sparkline := series.Sparkline
+		if markdown {
+			sparkline = "`" + sparkline + "`"
+		}
+		trendRows = append(trendRows, []string{
+			series.Name,
+			shared.OrNA(series.Unit),
+			sparkline,
+			formatOptionalNumber(latest),
+			formatOptionalNumber(series.Min),
+			formatOptionalNumber(series.Max),
+			formatOptionalNumber(series.Mean),
+			strconv.Itoa(len(series.Anomalies)),
+			series.Status,
+			shared.OrNA(series.Reason),
+		})
+		for _, anomaly := range series.Anomalies {
+			anomalyRows = append(anomalyRows, []string{
+				series.Name,
+				anomaly.Week,
+				anomaly.Direction,
+				fmt.Sprintf("%.2f", anomaly.Value),
+				fmt.Sprintf("%.2f", anomaly.BaselineMean),
+				fmt.Sprintf("%.2f", anomaly.BaselineStd),
+				fmt.Sprintf("%.2f", anomaly.ZScore),
+			})
+		}
+	}
+	shared.RenderSection("Trends", []string{"metric", "unit", "trend", "latest", "min", "max", "mean", "anomalies", "status", "reason"}, trendRows, markdown)
+	if len(anomalyRows) > 0 {
+		shared.RenderSection("Anomalies", []string{"metric", "week", "direction", "value", "baselineMean", "baselineStdDev", "zScore"}, anomalyRows, markdown)
+	}
+}
@@ -0,0 +1,179 @@
+package pricing
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+type pricingCalendar struct {
+	Target        string                 `json:"target"`
+	ID            string                 `json:"id"`
+	ScheduleID    string                 `json:"scheduleId,omitempty"`
+	BaseTerritory string                 `json:"baseTerritory,omitempty"`
+	Events        []pricingCalendarEvent `json:"events"`
+}
+
+// pricingCalendarEvent is a manual price taking effect in one territory.
+// Current events describe prices already in effect.
+type pricingCalendarEvent struct {
+	Date          string `json:"date,omitempty"`
+	Territory     string `json:"territory"`
+	Price         string `json:"price,omitempty"`
+	PreviousPrice string `json:"previousPrice,omitempty"`
+	Until         string `json:"until,omitempty"`
+	Current       bool   `json:"current"`
+}
+
+// PricingScheduleCalendarCommand returns the schedule calendar subcommand.
+func PricingScheduleCalendarCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("pricing schedule calendar", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID)")
+	iapID := fs.String("iap", "", "In-app purchase ID")
+	territories := fs.String("territory", "", "Filter by territory IDs, comma-separated")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "calendar",
+		ShortUsage: "asc pricing schedule calendar (--app APP_ID | --iap IAP_ID) [flags]",
+		ShortHelp:  "Show current and upcoming manual price changes.",
+		LongHelp: `Show current and upcoming manual price changes.
+
+Lists the manual prices in effect today followed by scheduled changes in date
+order, with the price each change replaces. Expired prices are omitted.
+
+Examples:
+  asc pricing schedule calendar --app "123456789" --output table
+  asc pricing schedule calendar --iap "IAP_ID" --territory "USA,GBR"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			target, id, err := resolvePricingScheduleTarget(*appID, *iapID)
+			if err != nil {
+				return err
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("pricing schedule calendar: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			schedule, err := fetchPricingSchedule(requestCtx, client, target, id)
+			if err != nil {
+				return fmt.Errorf("pricing schedule calendar: %w", err)
+			}
+
+			filter := make(map[string]bool)
+			for _, territory := range shared.SplitCSVUpper(*territories) {
+				filter[territory] = true
+			}
+
+			result := &pricingCalendar{
+				Target:        target,
+				ID:            id,
+				ScheduleID:    schedule.ID,
+				BaseTerritory: schedule.BaseTerritory,
+				Events:        buildPricingCalendarEvents(schedule.Entries, filter, time.Now().UTC().Format("2006-01-02")),
+			}
+
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderPricingCalendar(result, false) },
+				func() error { return renderPricingCalendar(result, true) },
+			)
+		},
+	}
+}
+
+func buildPricingCalendarEvents(entries []pricingScheduleEntry, filter map[string]bool, today string) []pricingCalendarEvent {
+	byTerritory := make(map[string][]pricingScheduleEntry)
+	for _, entry := range entries {
+		if len(filter) > 0 && !filter[entry.Territory] {
+			continue
+		}
+		if pricingScheduleEntryExpired(entry, today) {
+			continue
+		}
+		byTerritory[entry.Territory] = append(byTerritory[entry.Territory], entry)
+	}
+
+	events := make([]pricingCalendarEvent, 0, len(entries))
+	for territory, territoryEntries := range byTerritory {
+		sortPricingScheduleEntries(territoryEntries)
+		for idx, entry := range territoryEntries {
+			event := pricingCalendarEvent{
+				Territory: territory,
+				Price:     entry.CustomerPrice,
+				Until:     entry.EndDate,
+				Current:   entry.StartDate <= today,
+			}
+			if !event.Current {
+				event.Date = entry.StartDate
+				if idx > 0 {
+					previous := territoryEntries[idx-1]
+					if previous.EndDate == "" || previous.EndDate >= entry.StartDate {
+						event.PreviousPrice = previous.CustomerPrice
+					}
+				}
+			}
+			events = append(events, event)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Current != events[j].Current {
+			return events[i].Current
+		}
+		if events[i].Date != events[j].Date {
+			return events[i].Date < events[j].Date
+		}
+		return events[i].Territory < events[j].Territory
+	})
+	return events
+}
+
+func renderPricingCalendar(result *pricingCalendar, markdown bool) error {
+	if result == nil {
+		return fmt.Errorf("result is nil")
+	}
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	rows := make([][]string, 0, len(result.Events))
+	lastDate := ""
+	for _, event := range result.Events {
+		date := event.Date
+		if event.Current {
+			date = "current"
+		}
+		label := date
+		if len(rows) > 0 && date == lastDate {
+			label = ""
+		}
+		lastDate = date
+
+		change := event.Price
+		if event.PreviousPrice != "" {
+			change = event.PreviousPrice + " -> " + event.Price
+		}
+		rows = append(rows, []string{label, event.Territory, change, pricePlanDateLabel(event.Until)})
+	}
+	render([]string{"Date", "Territory", "Price", "Until"}, rows)
+	return nil
+}
@@ -0,0 +1,35 @@
+package pricing
+
+import "testing"
+
+func TestBuildPricingCalendarEvents(t *testing.T) {
+	entries := []pricingScheduleEntry{
+		{Territory: "USA", CustomerPrice: "4.99", EndDate: "2026-11-20"},
+		{Territory: "USA", CustomerPrice: "2.99", StartDate: "2026-11-20", EndDate: "2026-12-04"},
+		{Territory: "USA", CustomerPrice: "4.99", StartDate: "2026-12-04"},
+		{Territory: "GBR", CustomerPrice: "3.99", StartDate: "2026-11-20"},
+		{Territory: "DEU", CustomerPrice: "1.99", StartDate: "2025-01-01", EndDate: "2025-02-01"},
+	}
+
+	events := buildPricingCalendarEvents(entries, nil, "2026-10-18")
+	if len(events) != 4 {
+		t.Fatalf("expected expired DEU price to be omitted, got %+v", events)
+	}
+	if !events[0].Current || events[0].Territory != "USA" || events[0].Until != "2026-11-20" {
+		t.Fatalf("expected current USA price first, got %+v", events[0])
+	}
+	if events[1].Territory != "GBR" || events[1].Date != "2026-11-20" || events[1].PreviousPrice != "" {
+		t.Fatalf("expected GBR change without previous price, got %+v", events[1])
+	}
+	if events[2].Territory != "USA" || events[2].PreviousPrice != "4.99" || events[2].Price != "2.99" {
+		t.Fatalf("expected USA sale start, got %+v", events[2])
+	}
+	if events[3].Date != "2026-12-04" || events[3].PreviousPrice != "2.99" {
+		t.Fatalf("expected USA revert, got %+v", events[3])
+	}
+
+	filtered := buildPricingCalendarEvents(entries, map[string]bool{"GBR": true}, "2026-10-18")
+	if len(filtered) != 1 || filtered[0].Territory != "GBR" {
+		t.Fatalf("expected only GBR events, got %+v", filtered)
+	}
+}
@@ -0,0 +1,460 @@
+package pricing
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	pricePlanSourceExisting = "existing"
+	pricePlanSourcePlan     = "plan"
+)
+
+// PricePlan describes dated manual price windows for an app or IAP.
+type PricePlan struct {
+	BaseTerritory string           `yaml:"baseTerritory,omitempty" json:"baseTerritory,omitempty"`
+	Prices        []PricePlanPrice `yaml:"prices" json:"prices"`
+}
+
+// PricePlanPrice is a customer price for one territory between two dates.
+// An empty end date keeps the price in place indefinitely.
+type PricePlanPrice struct {
+	Territory string `yaml:"territory" json:"territory"`
+	Price     string `yaml:"price" json:"price"`
+	StartDate string `yaml:"startDate" json:"startDate"`
+	EndDate   string `yaml:"endDate,omitempty" json:"endDate,omitempty"`
+}
+
+// PricePlanApplyResult is the schedule produced by merging a plan into the
+// current manual prices.
+type PricePlanApplyResult struct {
+	Target        string                 `json:"target"`
+	ID            string                 `json:"id"`
+	BaseTerritory string                 `json:"baseTerritory"`
+	DryRun        bool                   `json:"dryRun"`
+	ScheduleID    string                 `json:"scheduleId,omitempty"`
+	Prices        []pricingScheduleEntry `json:"prices"`
+}
+
+// PricingPlanCommand returns the pricing plan command group.
+func PricingPlanCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("pricing plan", flag.ExitOnError)
+
+	return &ffcli.Command{
+		Name:       "plan",
+		ShortUsage: "asc pricing plan <subcommand> [flags]",
+		ShortHelp:  "Apply dated price windows from a plan file.",
+		LongHelp: `Apply dated price windows from a plan file.
+
+Examples:
+  asc pricing plan apply --app "123456789" --file "./sale.yaml" --dry-run
+  asc pricing plan apply --iap "IAP_ID" --file "./sale.yaml"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			PricingPlanApplyCommand(),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+// PricingPlanApplyCommand returns the pricing plan apply subcommand.
+func PricingPlanApplyCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("pricing plan apply", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID)")
+	iapID := fs.String("iap", "", "In-app purchase ID")
+	filePath := fs.String("file", "", "Path to price plan YAML file")
+	dryRun := fs.Bool("dry-run", false, "Print the resulting schedule without applying it")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "apply",
+		ShortUsage: "asc pricing plan apply (--app APP_ID | --iap IAP_ID) --file ./sale.yaml [--dry-run]",
+		ShortHelp:  "Schedule dated price windows for an app or IAP.",
+		LongHelp: `Schedule dated price windows for an app or IAP.
+
+Each entry sets a customer price in one territory from startDate until
+endDate. When the window ends, the price that was active before it resumes.
+Omit endDate to keep the new price in place.
+
+App Store Connect replaces the whole manual price schedule on every change, so
+apply merges the plan with the current manual prices and creates one new
+schedule. Windows that overlap each other or an already scheduled future
+price are rejected before anything is changed.
+
+File format:
+  baseTerritory: USA          # optional; defaults to the current schedule
+  prices:
+    - territory: USA
+      price: "2.99"
+      startDate: "2026-11-20"
+      endDate: "2026-12-04"
+    - territory: GBR
+      price: "2.49"
+      startDate: "2026-11-20"
+      endDate: "2026-12-04"
+
+Examples:
+  asc pricing plan apply --app "123456789" --file "./sale.yaml" --dry-run
+  asc pricing plan apply --app "123456789" --file "./sale.yaml" --output table
+  asc pricing plan apply --iap "IAP_ID" --file "./sale.yaml"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			target, id, err := resolvePricingScheduleTarget(*appID, *iapID)
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(*filePath) == "" {
+				fmt.Fprintln(os.Stderr, "Error: --file is required")
+				return flag.ErrHelp
+			}
+
+			today := time.Now().UTC().Format("2006-01-02")
+			plan, err := readPricePlanFile(*filePath, today)
+			if err != nil {
+				return err
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("pricing plan apply: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			schedule, err := fetchPricingSchedule(requestCtx, client, target, id)
+			if err != nil {
+				return fmt.Errorf("pricing plan apply: %w", err)
+			}
+			baseTerritory := plan.BaseTerritory
+			if baseTerritory == "" {
+				baseTerritory = schedule.BaseTerritory
+			}
+			if baseTerritory == "" {
+				return fmt.Errorf("pricing plan apply: no price schedule exists; set baseTerritory in the plan file")
+			}
+
+			if conflicts := findPricePlanConflicts(plan.Prices, schedule.Entries, today); len(conflicts) > 0 {
+				return fmt.Errorf("pricing plan apply: plan overlaps scheduled manual prices:\n  %s", strings.Join(conflicts, "\n  "))
+			}
+
+			var source pricingSource
+			if target == pricingTargetIAP {
+				source = newIAPPricingSource(client, id)
+			} else {
+				source = newAppPricingSource(client, id)
+			}
+			windows, err := resolvePricePlanPricePoints(requestCtx, source, plan.Prices)
+			if err != nil {
+				return fmt.Errorf("pricing plan apply: %w", err)
+			}
+
+			result := &PricePlanApplyResult{
+				Target:        target,
+				ID:            id,
+				BaseTerritory: baseTerritory,
+				DryRun:        *dryRun,
+				Prices:        mergePricePlanSchedule(windows, schedule.Entries, today),
+			}
+
+			if !*dryRun {
+				scheduleID, err := createPricePlanSchedule(requestCtx, client, target, id, baseTerritory, result.Prices)
+				if err != nil {
+					return fmt.Errorf("pricing plan apply: %w", err)
+				}
+				result.ScheduleID = scheduleID
+			}
+
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderPricePlanApplyResult(result, false) },
+				func() error { return renderPricePlanApplyResult(result, true) },
+			)
+		},
+	}
+}
+
+func readPricePlanFile(path, today string) (PricePlan, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return PricePlan{}, fmt.Errorf("pricing plan apply: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var plan PricePlan
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&plan); err != nil {
+		return PricePlan{}, shared.UsageErrorf("invalid plan %s: %v", path, err)
+	}
+	if err := normalizePricePlan(&plan, today); err != nil {
+		return PricePlan{}, shared.UsageErrorf("invalid plan %s: %v", path, err)
+	}
+	return plan, nil
+}
+
+func normalizePricePlan(plan *PricePlan, today string) error {
+	plan.BaseTerritory = strings.ToUpper(strings.TrimSpace(plan.BaseTerritory))
+	if plan.BaseTerritory != "" && len(plan.BaseTerritory) != 3 {
+		return fmt.Errorf("baseTerritory %q must be a 3-letter territory ID", plan.BaseTerritory)
+	}
+	if len(plan.Prices) == 0 {
+		return fmt.Errorf("plan file must contain at least one price")
+	}
+
+	for idx := range plan.Prices {
+		price := &plan.Prices[idx]
+		label := fmt.Sprintf("prices[%d]", idx)
+
+		price.Territory = strings.ToUpper(strings.TrimSpace(price.Territory))
+		if len(price.Territory) != 3 {
+			return fmt.Errorf("%s: territory %q must be a 3-letter territory ID", label, price.Territory)
+		}
+		price.Price = strings.TrimSpace(price.Price)
+		if _, err := normalizePricingPrice(price.Price); err != nil {
+			return fmt.Errorf("%s: %w", label, err)
+		}
+
+		price.StartDate = strings.TrimSpace(price.StartDate)
+		price.EndDate = strings.TrimSpace(price.EndDate)
+		if price.StartDate == "" {
+			return fmt.Errorf("%s: startDate is required", label)
+		}
+		if _, err := time.Parse("2006-01-02", price.StartDate); err != nil {
+			return fmt.Errorf("%s: startDate must be in YYYY-MM-DD format", label)
+		}
+		if price.StartDate <= today {
+			return fmt.Errorf("%s: startDate %s must be after today (%s)", label, price.StartDate, today)
+		}
+		if price.EndDate != "" {
+			if _, err := time.Parse("2006-01-02", price.EndDate); err != nil {
+				return fmt.Errorf("%s: endDate must be in YYYY-MM-DD format", label)
+			}
+			if price.EndDate <= price.StartDate {
+				return fmt.Errorf("%s: endDate must be after startDate", label)
+			}
+		}
+	}
+
+	sort.SliceStable(plan.Prices, func(i, j int) bool {
+		if plan.Prices[i].Territory != plan.Prices[j].Territory {
+			return plan.Prices[i].Territory < plan.Prices[j].Territory
+		}
+		return plan.Prices[i].StartDate < plan.Prices[j].StartDate
+	})
+	for idx := 1; idx < len(plan.Prices); idx++ {
+		prev, cur := plan.Prices[idx-1], plan.Prices[idx]
+		if prev.Territory == cur.Territory && pricingDateRangesOverlap(prev.StartDate, prev.EndDate, cur.StartDate, cur.EndDate) {
+			return fmt.Errorf("territory %s: windows starting %s and %s overlap", cur.Territory, prev.StartDate, cur.StartDate)
+		}
+	}
+	return nil
+}
+
+// findPricePlanConflicts lists plan windows that overlap a manual price
+// scheduled to start after today. Prices already in effect are split around
+// the windows instead.
+func findPricePlanConflicts(windows []PricePlanPrice, entries []pricingScheduleEntry, today string) []string {
+	var conflicts []string
+	for _, window := range windows {
+		for _, entry := range entries {
+			if entry.Territory != window.Territory || entry.StartDate <= today {
+				continue
+			}
+			if pricingDateRangesOverlap(window.StartDate, window.EndDate, entry.StartDate, entry.EndDate) {
+				conflicts = append(conflicts, fmt.Sprintf(
+					"%s %s..%s overlaps scheduled price %s from %s",
+					window.Territory, window.StartDate, pricePlanDateLabel(window.EndDate), entry.CustomerPrice, entry.StartDate,
+				))
+			}
+		}
+	}
+	return conflicts
+}
+
+func resolvePricePlanPricePoints(ctx context.Context, source pricingSource, windows []PricePlanPrice) ([]pricingScheduleEntry, error) {
+	pointsByTerritory := make(map[string][]pricingPoint)
+	resolved := make([]pricingScheduleEntry, 0, len(windows))
+	for _, window := range windows {
+		points, ok := pointsByTerritory[window.Territory]
+		if !ok {
+			var err error
+			points, err = source.basePricePoints(ctx, window.Territory)
+			if err != nil {
+				return nil, fmt.Errorf("fetch %s price points: %w", window.Territory, err)
+			}
+			pointsByTerritory[window.Territory] = points
+		}
+
+		priceKey, _ := normalizePricingPrice(window.Price)
+		pricePointID := ""
+		for _, point := range points {
+			if key, err := normalizePricingPrice(point.CustomerPrice); err == nil && key == priceKey {
+				pricePointID = point.ID
+				break
+			}
+		}
+		if pricePointID == "" {
+			return nil, fmt.Errorf("price %s was not found in price points for territory %s", window.Price, window.Territory)
+		}
+
+		resolved = append(resolved, pricingScheduleEntry{
+			Territory:     window.Territory,
+			CustomerPrice: window.Price,
+			StartDate:     window.StartDate,
+			EndDate:       window.EndDate,
+			PricePointID:  pricePointID,
+			Source:        pricePlanSourcePlan,
+		})
+	}
+	return resolved, nil
+}
+
+// mergePricePlanSchedule builds the full manual price list for a new
+// schedule. Expired prices are dropped, prices in effect are split around
+// the plan windows, and prices that started in the past lose their start date.
+func mergePricePlanSchedule(windows, entries []pricingScheduleEntry, today string) []pricingScheduleEntry {
+	windowsByTerritory := make(map[string][]pricingScheduleEntry)
+	for _, window := range windows {
+		windowsByTerritory[window.Territory] = append(windowsByTerritory[window.Territory], window)
+	}
+
+	merged := make([]pricingScheduleEntry, 0, len(entries)+len(windows))
+	for _, entry := range entries {
+		if pricingScheduleEntryExpired(entry, today) {
+			continue
+		}
+		entry.Source = pricePlanSourceExisting
+		if entry.StartDate <= today {
+			entry.StartDate = ""
+		}
+		if entry.StartDate != "" {
+			merged = append(merged, entry)
+			continue
+		}
+		merged = append(merged, splitPricingScheduleEntry(entry, windowsByTerritory[entry.Territory])...)
+	}
+	merged = append(merged, windows...)
+
+	sortPricingScheduleEntries(merged)
+	return merged
+}
+
+// splitPricingScheduleEntry removes sorted, non-overlapping windows from an
+// entry's date range and returns the remaining segments.
+func splitPricingScheduleEntry(entry pricingScheduleEntry, windows []pricingScheduleEntry) []pricingScheduleEntry {
+	var segments []pricingScheduleEntry
+	cursor := entry.StartDate
+	for _, window := range windows {
+		if !pricingDateRangesOverlap(cursor, entry.EndDate, window.StartDate, window.EndDate) {
+			continue
+		}
+		if cursor < window.StartDate {
+			segment := entry
+			segment.StartDate = cursor
+			segment.EndDate = window.StartDate
+			segments = append(segments, segment)
+		}
+		if window.EndDate == "" {
+			return segments
+		}
+		cursor = window.EndDate
+	}
+	if entry.EndDate == "" || cursor < entry.EndDate {
+		segment := entry
+		segment.StartDate = cursor
+		segments = append(segments, segment)
+	}
+	return segments
+}
+
+func createPricePlanSchedule(ctx context.Context, client *asc.Client, target, id, baseTerritory string, entries []pricingScheduleEntry) (string, error) {
+	if target == pricingTargetIAP {
+		prices := make([]asc.InAppPurchasePriceSchedulePrice, 0, len(entries))
+		for _, entry := range entries {
+			prices = append(prices, asc.InAppPurchasePriceSchedulePrice{
+				PricePointID: entry.PricePointID,
+				StartDate:    entry.StartDate,
+				EndDate:      entry.EndDate,
+			})
+		}
+		resp, err := client.CreateInAppPurchasePriceSchedule(ctx, id, asc.InAppPurchasePriceScheduleCreateAttributes{
+			BaseTerritoryID: baseTerritory,
+			Prices:          prices,
+		})
+		if err != nil {
+			return "", err
+		}
+		return resp.Data.ID, nil
+	}
+
+	prices := make([]asc.AppPriceSchedulePrice, 0, len(entries))
+	for _, entry := range entries {
+		prices = append(prices, asc.AppPriceSchedulePrice{
+			PricePointID: entry.PricePointID,
+			StartDate:    entry.StartDate,
+			EndDate:      entry.EndDate,
+		})
+	}
+	resp, err := client.CreateAppPriceSchedule(ctx, id, asc.AppPriceScheduleCreateAttributes{
+		BaseTerritoryID: baseTerritory,
+		Prices:          prices,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Data.ID, nil
+}
+
+func pricePlanDateLabel(date string) string {
+	if date == "" {
+		return "open"
+	}
+	return date
+}
+
+func renderPricePlanApplyResult(result *PricePlanApplyResult, markdown bool) error {
+	if result == nil {
+		return fmt.Errorf("result is nil")
+	}
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	rows := make([][]string, 0, len(result.Prices))
+	for _, entry := range result.Prices {
+		start := entry.StartDate
+		if start == "" {
+			start = "current"
+		}
+		rows = append(rows, []string{
+			entry.Territory,
+			entry.CustomerPrice,
+			start,
+			pricePlanDateLabel(entry.EndDate),
+			entry.Source,
+		})
+	}
+	render([]string{"Territory", "Price", "Start", "End", "Source"}, rows)
+	return nil
+}
@@ -0,0 +1,115 @@
+package pricing
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizePricePlan_NormalizesAndSorts(t *testing.T) {
+	plan := PricePlan{
+		BaseTerritory: "usa",
+		Prices: []PricePlanPrice{
+			{Territory: "usa", Price: " 2.99 ", StartDate: "2026-12-20", EndDate: "2026-12-27"},
+			{Territory: "gbr", Price: "2.49", StartDate: "2026-11-20"},
+			{Territory: "USA", Price: "3.99", StartDate: "2026-11-20", EndDate: "2026-12-04"},
+		},
+	}
+
+	if err := normalizePricePlan(&plan, "2026-10-18"); err != nil {
+		t.Fatalf("normalizePricePlan() error: %v", err)
+	}
+	if plan.BaseTerritory != "USA" {
+		t.Fatalf("expected USA base territory, got %q", plan.BaseTerritory)
+	}
+	got := make([]string, 0, len(plan.Prices))
+	for _, price := range plan.Prices {
+		got = append(got, price.Territory+"@"+price.StartDate)
+	}
+	if strings.Join(got, ",") != "GBR@2026-11-20,USA@2026-11-20,USA@2026-12-20" {
+		t.Fatalf("unexpected order: %v", got)
+	}
+	if plan.Prices[2].Price != "2.99" {
+		t.Fatalf("expected trimmed price, got %q", plan.Prices[2].Price)
+	}
+}
+
+func TestNormalizePricePlan_RejectsInvalidWindows(t *testing.T) {
+	tests := []struct {
+		name   string
+		prices []PricePlanPrice
+	}{
+		{name: "empty"},
+		{name: "bad territory", prices: []PricePlanPrice{{Territory: "US", Price: "1.99", StartDate: "2026-11-20"}}},
+		{name: "bad price", prices: []PricePlanPrice{{Territory: "USA", Price: "cheap", StartDate: "2026-11-20"}}},
+		{name: "missing start", prices: []PricePlanPrice{{Territory: "USA", Price: "1.99"}}},
+		{name: "past start", prices: []PricePlanPrice{{Territory: "USA", Price: "1.99", StartDate: "2026-10-18"}}},
+		{name: "end before start", prices: []PricePlanPrice{{Territory: "USA", Price: "1.99", StartDate: "2026-11-20", EndDate: "2026-11-19"}}},
+		{
+			name: "overlapping windows",
+			prices: []PricePlanPrice{
+				{Territory: "USA", Price: "1.99", StartDate: "2026-11-20", EndDate: "2026-12-01"},
+				{Territory: "USA", Price: "2.99", StartDate: "2026-11-25"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plan := PricePlan{Prices: test.prices}
+			if err := normalizePricePlan(&plan, "2026-10-18"); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestFindPricePlanConflicts(t *testing.T) {
+	windows := []PricePlanPrice{{Territory: "USA", Price: "2.99", StartDate: "2026-11-20", EndDate: "2026-12-04"}}
+	entries := []pricingScheduleEntry{
+		{Territory: "USA", CustomerPrice: "4.99"},
+		{Territory: "USA", CustomerPrice: "5.99", StartDate: "2026-12-01"},
+		{Territory: "GBR", CustomerPrice: "4.49", StartDate: "2026-11-25"},
+	}
+
+	conflicts := findPricePlanConflicts(windows, entries, "2026-10-18")
+	if len(conflicts) != 1 || !strings.Contains(conflicts[0], "5.99 from 2026-12-01") {
+		t.Fatalf("expected single conflict with scheduled USA price, got %v", conflicts)
+	}
+}
+
+func TestMergePricePlanSchedule_SplitsCurrentPrices(t *testing.T) {
+	windows := []pricingScheduleEntry{
+		{Territory: "USA", CustomerPrice: "2.99", StartDate: "2026-11-20", EndDate: "2026-12-04", PricePointID: "pp-sale", Source: pricePlanSourcePlan},
+	}
+	entries := []pricingScheduleEntry{
+		{Territory: "USA", CustomerPrice: "4.99", StartDate: "2025-01-01", PricePointID: "pp-usa"},
+		{Territory: "USA", CustomerPrice: "1.99", StartDate: "2025-01-01", EndDate: "2025-02-01", PricePointID: "pp-old"},
+		{Territory: "GBR", CustomerPrice: "4.49", StartDate: "2027-01-01", PricePointID: "pp-gbr"},
+	}
+
+	merged := mergePricePlanSchedule(windows, entries, "2026-10-18")
+
+	got := make([]string, 0, len(merged))
+	for _, entry := range merged {
+		got = append(got, strings.Join([]string{entry.Territory, entry.PricePointID, entry.StartDate, entry.EndDate, entry.Source}, "|"))
+	}
+	want := []string{
+		"GBR|pp-gbr|2027-01-01||existing",
+		"USA|pp-usa||2026-11-20|existing",
+		"USA|pp-sale|2026-11-20|2026-12-04|plan",
+		"USA|pp-usa|2026-12-04||existing",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected merged schedule:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestSplitPricingScheduleEntry_OpenEndedWindow(t *testing.T) {
+	entry := pricingScheduleEntry{Territory: "USA", PricePointID: "pp-usa"}
+	windows := []pricingScheduleEntry{{Territory: "USA", StartDate: "2026-11-20"}}
+
+	segments := splitPricingScheduleEntry(entry, windows)
+	if len(segments) != 1 || segments[0].StartDate != "" || segments[0].EndDate != "2026-11-20" {
+		t.Fatalf("expected single segment ending at window start, got %+v", segments)
+	}
+}
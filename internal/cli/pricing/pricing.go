@@ -32,16 +32,21 @@ Examples:
   asc pricing schedule create --app "123456789" --price-point "PRICE_POINT_ID" --base-territory "USA" --start-date "2024-03-01"
   asc pricing schedule manual-prices --schedule "SCHEDULE_ID"
   asc pricing schedule automatic-prices --schedule "SCHEDULE_ID"
+  asc pricing schedule calendar --app "123456789" --output table
+  asc pricing plan apply --app "123456789" --file "./sale.yaml" --dry-run
   asc pricing availability get --app "123456789"
   asc pricing availability get --id "AVAILABILITY_ID"
   asc pricing availability set --app "123456789" --territory "USA,GBR,DEU" --available true
-  asc pricing availability territory-availabilities --availability "AVAILABILITY_ID"`,
+  asc pricing availability territory-availabilities --availability "AVAILABILITY_ID"
+  asc pricing simulate --app "123456789" --base-territory "USA" --price 4.99`,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
 			PricingTerritoriesCommand(),
 			PricingPricePointsCommand(),
 			PricingScheduleCommand(),
 			PricingAvailabilityCommand(),
+			PricingPlanCommand(),
+			PricingSimulateCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
@@ -318,13 +323,15 @@ Examples:
   asc pricing schedule get --id "SCHEDULE_ID"
   asc pricing schedule create --app "123456789" --price-point "PRICE_POINT_ID" --start-date "2024-03-01"
   asc pricing schedule manual-prices --schedule "SCHEDULE_ID"
-  asc pricing schedule automatic-prices --schedule "SCHEDULE_ID"`,
+  asc pricing schedule automatic-prices --schedule "SCHEDULE_ID"
+  asc pricing schedule calendar --app "123456789" --output table`,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
 			PricingScheduleGetCommand(),
 			PricingScheduleCreateCommand(),
 			PricingScheduleManualPricesCommand(),
 			PricingScheduleAutomaticPricesCommand(),
+			PricingScheduleCalendarCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
@@ -0,0 +1,210 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// pricingScheduleEntry is a manual price in an app or IAP price schedule.
+type pricingScheduleEntry struct {
+	Territory     string `json:"territory"`
+	CustomerPrice string `json:"customerPrice,omitempty"`
+	StartDate     string `json:"startDate,omitempty"`
+	EndDate       string `json:"endDate,omitempty"`
+	PricePointID  string `json:"pricePointId"`
+	Source        string `json:"source,omitempty"`
+}
+
+// pricingSchedule is the current price schedule for an app or IAP. ID is
+// empty when no schedule exists yet.
+type pricingSchedule struct {
+	ID            string
+	BaseTerritory string
+	Entries       []pricingScheduleEntry
+}
+
+// resolvePricingScheduleTarget picks the app or IAP a schedule command
+// operates on, falling back to ASC_APP_ID when neither flag is set.
+func resolvePricingScheduleTarget(appID, iapID string) (string, string, error) {
+	appValue := strings.TrimSpace(appID)
+	iapValue := strings.TrimSpace(iapID)
+	if appValue != "" && iapValue != "" {
+		return "", "", shared.UsageError("--app and --iap are mutually exclusive")
+	}
+	if iapValue != "" {
+		return pricingTargetIAP, iapValue, nil
+	}
+	appValue = shared.ResolveAppID(appValue)
+	if appValue == "" {
+		fmt.Fprintln(os.Stderr, "Error: --app or --iap is required (or set ASC_APP_ID)")
+		return "", "", flag.ErrHelp
+	}
+	return pricingTargetApp, appValue, nil
+}
+
+func fetchPricingSchedule(ctx context.Context, client *asc.Client, target, id string) (*pricingSchedule, error) {
+	if target == pricingTargetIAP {
+		return fetchIAPPricingSchedule(ctx, client, id)
+	}
+	return fetchAppPricingSchedule(ctx, client, id)
+}
+
+func fetchAppPricingSchedule(ctx context.Context, client *asc.Client, appID string) (*pricingSchedule, error) {
+	scheduleResp, err := client.GetAppPriceSchedule(ctx, appID)
+	if err != nil {
+		if asc.IsNotFound(err) {
+			return &pricingSchedule{}, nil
+		}
+		return nil, fmt.Errorf("fetch price schedule: %w", err)
+	}
+	schedule := &pricingSchedule{ID: scheduleResp.Data.ID}
+
+	territoryResp, err := client.GetAppPriceScheduleBaseTerritory(ctx, schedule.ID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch base territory: %w", err)
+	}
+	schedule.BaseTerritory = strings.ToUpper(strings.TrimSpace(territoryResp.Data.ID))
+
+	resp, err := client.GetAppPriceScheduleManualPrices(
+		ctx,
+		schedule.ID,
+		asc.WithAppPriceSchedulePricesInclude([]string{"appPricePoint", "territory"}),
+		asc.WithAppPriceSchedulePricesLimit(200),
+	)
+	for {
+		if err != nil {
+			return nil, fmt.Errorf("fetch manual prices: %w", err)
+		}
+		customerPrices := parsePricingScheduleIncluded(resp.Included)
+		for _, price := range resp.Data {
+			schedule.Entries = append(schedule.Entries, newPricingScheduleEntry(
+				price.ID, price.Relationships, "appPricePoint", price.Attributes.StartDate, price.Attributes.EndDate, customerPrices,
+			))
+		}
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			break
+		}
+		resp, err = client.GetAppPriceScheduleManualPrices(ctx, schedule.ID, asc.WithAppPriceSchedulePricesNextURL(resp.Links.Next))
+	}
+
+	sortPricingScheduleEntries(schedule.Entries)
+	return schedule, nil
+}
+
+func fetchIAPPricingSchedule(ctx context.Context, client *asc.Client, iapID string) (*pricingSchedule, error) {
+	scheduleResp, err := client.GetInAppPurchasePriceSchedule(ctx, iapID)
+	if err != nil {
+		if asc.IsNotFound(err) {
+			return &pricingSchedule{}, nil
+		}
+		return nil, fmt.Errorf("fetch price schedule: %w", err)
+	}
+	schedule := &pricingSchedule{ID: scheduleResp.Data.ID}
+
+	territoryResp, err := client.GetInAppPurchasePriceScheduleBaseTerritory(ctx, schedule.ID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch base territory: %w", err)
+	}
+	schedule.BaseTerritory = strings.ToUpper(strings.TrimSpace(territoryResp.Data.ID))
+
+	resp, err := client.GetInAppPurchasePriceScheduleManualPrices(
+		ctx,
+		schedule.ID,
+		asc.WithIAPPriceSchedulePricesInclude([]string{"inAppPurchasePricePoint", "territory"}),
+		asc.WithIAPPriceSchedulePricesLimit(200),
+	)
+	for {
+		if err != nil {
+			return nil, fmt.Errorf("fetch manual prices: %w", err)
+		}
+		customerPrices := parsePricingScheduleIncluded(resp.Included)
+		for _, price := range resp.Data {
+			schedule.Entries = append(schedule.Entries, newPricingScheduleEntry(
+				price.ID, price.Relationships, "inAppPurchasePricePoint", price.Attributes.StartDate, price.Attributes.EndDate, customerPrices,
+			))
+		}
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			break
+		}
+		resp, err = client.GetInAppPurchasePriceScheduleManualPrices(ctx, schedule.ID, asc.WithIAPPriceSchedulePricesNextURL(resp.Links.Next))
+	}
+
+	sortPricingScheduleEntries(schedule.Entries)
+	return schedule, nil
+}
+
+func newPricingScheduleEntry(
+	priceID string,
+	relationships json.RawMessage,
+	pricePointKey string,
+	startDate string,
+	endDate string,
+	customerPrices map[string]string,
+) pricingScheduleEntry {
+	entry := pricingScheduleEntry{
+		Territory:    strings.ToUpper(pricingRelationshipID(relationships, "territory")),
+		StartDate:    strings.TrimSpace(startDate),
+		EndDate:      strings.TrimSpace(endDate),
+		PricePointID: pricingRelationshipID(relationships, pricePointKey),
+	}
+	if entry.Territory == "" {
+		if meta, ok := shared.DecodePriceResourceID(priceID); ok {
+			entry.Territory = meta.TerritoryID
+		}
+	}
+	entry.CustomerPrice = customerPrices[entry.PricePointID]
+	return entry
+}
+
+// parsePricingScheduleIncluded maps included price point IDs to customer prices.
+func parsePricingScheduleIncluded(raw json.RawMessage) map[string]string {
+	customerPrices := make(map[string]string)
+	if len(raw) == 0 {
+		return customerPrices
+	}
+	var included []struct {
+		Type       string `json:"type"`
+		ID         string `json:"id"`
+		Attributes struct {
+			CustomerPrice string `json:"customerPrice"`
+		} `json:"attributes"`
+	}
+	if err := json.Unmarshal(raw, &included); err != nil {
+		return customerPrices
+	}
+	for _, item := range included {
+		if item.Type == "territories" {
+			continue
+		}
+		customerPrices[item.ID] = strings.TrimSpace(item.Attributes.CustomerPrice)
+	}
+	return customerPrices
+}
+
+func sortPricingScheduleEntries(entries []pricingScheduleEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Territory != entries[j].Territory {
+			return entries[i].Territory < entries[j].Territory
+		}
+		return entries[i].StartDate < entries[j].StartDate
+	})
+}
+
+// pricingScheduleEntryExpired reports whether an entry ended on or before today.
+func pricingScheduleEntryExpired(entry pricingScheduleEntry, today string) bool {
+	return entry.EndDate != "" && entry.EndDate <= today
+}
+
+// pricingDateRangesOverlap reports whether two [start, end) date ranges
+// overlap. An empty start is open to the past and an empty end to the future.
+func pricingDateRangesOverlap(aStart, aEnd, bStart, bEnd string) bool {
+	return (aEnd == "" || bStart < aEnd) && (bEnd == "" || aStart < bEnd)
+}
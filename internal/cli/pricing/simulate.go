@@ -0,0 +1,649 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	pricingTargetApp          = "app"
+	pricingTargetIAP          = "iap"
+	pricingTargetSubscription = "subscription"
+)
+
+type pricingSimulation struct {
+	Target              string                       `json:"target"`
+	ID                  string                       `json:"id"`
+	BaseTerritory       string                       `json:"baseTerritory"`
+	Price               string                       `json:"price"`
+	PricePointID        string                       `json:"pricePointId"`
+	CurrentPricePointID string                       `json:"currentPricePointId,omitempty"`
+	Territories         []pricingSimulationTerritory `json:"territories"`
+	Changed             int                          `json:"changed"`
+}
+
+type pricingSimulationTerritory struct {
+	Territory       string `json:"territory"`
+	Currency        string `json:"currency,omitempty"`
+	CurrentPrice    string `json:"currentPrice,omitempty"`
+	CurrentProceeds string `json:"currentProceeds,omitempty"`
+	NewPrice        string `json:"newPrice,omitempty"`
+	NewProceeds     string `json:"newProceeds,omitempty"`
+	Changed         bool   `json:"changed"`
+}
+
+// pricingPoint is a price point normalized across app, IAP, and
+// subscription price point resources.
+type pricingPoint struct {
+	ID            string
+	Territory     string
+	Tier          string
+	CustomerPrice string
+	Proceeds      string
+}
+
+// pricingSource fetches price points for an app, IAP, or subscription.
+type pricingSource struct {
+	target string
+	id     string
+
+	// basePricePoints lists every price point in a territory.
+	basePricePoints func(ctx context.Context, territory string) ([]pricingPoint, error)
+	// equalizations lists the price points equalized from a price point.
+	equalizations func(ctx context.Context, pricePointID string) ([]pricingPoint, error)
+	// currentBase returns the active price point ID or tier in a territory.
+	currentBase func(ctx context.Context, territory string, now time.Time) (pricePointID, tier string, err error)
+}
+
+// PricingSimulateCommand returns the pricing simulate subcommand.
+func PricingSimulateCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("pricing simulate", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID)")
+	iapID := fs.String("iap", "", "In-app purchase ID")
+	subscriptionID := fs.String("subscription", "", "Subscription ID")
+	baseTerritory := fs.String("base-territory", "", "Base territory ID (e.g., USA)")
+	price := fs.String("price", "", "New customer price in the base territory (e.g., 4.99)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "simulate",
+		ShortUsage: "asc pricing simulate (--app APP_ID | --iap IAP_ID | --subscription SUB_ID) --base-territory USA --price 4.99",
+		ShortHelp:  "Preview a price change in every territory.",
+		LongHelp: `Preview a price change in every territory.
+
+Resolves the price point matching --price in the base territory and its
+equalizations, then compares each territory with the equalizations of the
+current base territory price. Nothing is changed in App Store Connect.
+
+Notes:
+  - current prices assume other territories follow the base territory;
+    manual per-territory prices are not reflected.
+  - changed is true when the local customer price differs.
+
+Examples:
+  asc pricing simulate --app "123456789" --base-territory "USA" --price 4.99
+  asc pricing simulate --iap "IAP_ID" --base-territory "USA" --price 1.99 --output table
+  asc pricing simulate --subscription "SUB_ID" --base-territory "USA" --price 9.99 --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			iapValue := strings.TrimSpace(*iapID)
+			subscriptionValue := strings.TrimSpace(*subscriptionID)
+			appValue := strings.TrimSpace(*appID)
+
+			selected := 0
+			for _, value := range []string{appValue, iapValue, subscriptionValue} {
+				if value != "" {
+					selected++
+				}
+			}
+			if selected > 1 {
+				return shared.UsageError("--app, --iap, and --subscription are mutually exclusive")
+			}
+			if selected == 0 {
+				appValue = shared.ResolveAppID("")
+			}
+			if appValue == "" && iapValue == "" && subscriptionValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: one of --app, --iap, or --subscription is required")
+				return flag.ErrHelp
+			}
+
+			territory := strings.ToUpper(strings.TrimSpace(*baseTerritory))
+			if territory == "" {
+				fmt.Fprintln(os.Stderr, "Error: --base-territory is required")
+				return flag.ErrHelp
+			}
+			if strings.TrimSpace(*price) == "" {
+				fmt.Fprintln(os.Stderr, "Error: --price is required")
+				return flag.ErrHelp
+			}
+			priceKey, err := normalizePricingPrice(*price)
+			if err != nil {
+				return shared.UsageErrorf("--price: %v", err)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("pricing simulate: %w", err)
+			}
+
+			var source pricingSource
+			switch {
+			case iapValue != "":
+				source = newIAPPricingSource(client, iapValue)
+			case subscriptionValue != "":
+				source = newSubscriptionPricingSource(client, subscriptionValue)
+			default:
+				source = newAppPricingSource(client, appValue)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			result, err := simulatePricing(requestCtx, client, source, territory, strings.TrimSpace(*price), priceKey, time.Now().UTC())
+			if err != nil {
+				return fmt.Errorf("pricing simulate: %w", err)
+			}
+
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderPricingSimulation(result, false) },
+				func() error { return renderPricingSimulation(result, true) },
+			)
+		},
+	}
+}
+
+func simulatePricing(
+	ctx context.Context,
+	client *asc.Client,
+	source pricingSource,
+	territory string,
+	rawPrice string,
+	priceKey string,
+	now time.Time,
+) (*pricingSimulation, error) {
+	basePoints, err := source.basePricePoints(ctx, territory)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s price points: %w", territory, err)
+	}
+
+	var target *pricingPoint
+	for idx := range basePoints {
+		key, err := normalizePricingPrice(basePoints[idx].CustomerPrice)
+		if err != nil || key != priceKey {
+			continue
+		}
+		if target != nil {
+			return nil, fmt.Errorf("price %q matched multiple price points in territory %q", rawPrice, territory)
+		}
+		target = &basePoints[idx]
+	}
+	if target == nil {
+		return nil, fmt.Errorf("price %q was not found in price points for territory %q", rawPrice, territory)
+	}
+
+	newPoints, err := source.equalizations(ctx, target.ID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch equalizations: %w", err)
+	}
+	newPoints = append(newPoints, *target)
+
+	currentID, currentTier, err := source.currentBase(ctx, territory, now)
+	if err != nil {
+		return nil, fmt.Errorf("fetch current price: %w", err)
+	}
+	var current *pricingPoint
+	for idx := range basePoints {
+		if (currentID != "" && basePoints[idx].ID == currentID) ||
+			(currentTier != "" && basePoints[idx].Tier == currentTier) {
+			current = &basePoints[idx]
+			break
+		}
+	}
+
+	var currentPoints []pricingPoint
+	if current != nil {
+		if current.ID == target.ID {
+			currentPoints = newPoints
+		} else {
+			currentPoints, err = source.equalizations(ctx, current.ID)
+			if err != nil {
+				return nil, fmt.Errorf("fetch current equalizations: %w", err)
+			}
+			currentPoints = append(currentPoints, *current)
+		}
+	}
+
+	currencies, err := fetchPricingSimulateCurrencies(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &pricingSimulation{
+		Target:        source.target,
+		ID:            source.id,
+		BaseTerritory: territory,
+		Price:         rawPrice,
+		PricePointID:  target.ID,
+	}
+	if current != nil {
+		result.CurrentPricePointID = current.ID
+	}
+	result.Territories = comparePricingSimulationPoints(currentPoints, newPoints, currencies)
+	for _, item := range result.Territories {
+		if item.Changed {
+			result.Changed++
+		}
+	}
+	return result, nil
+}
+
+// comparePricingSimulationPoints pairs current and simulated points per
+// territory. A territory is changed when its price tier differs; customer
+// prices are only compared when a tier could not be decoded.
+func comparePricingSimulationPoints(currentPoints, newPoints []pricingPoint, currencies map[string]string) []pricingSimulationTerritory {
+	byTerritory := make(map[string]*pricingSimulationTerritory)
+	currentTiers := make(map[string]string)
+	newTiers := make(map[string]string)
+	entry := func(territory string) *pricingSimulationTerritory {
+		if existing, ok := byTerritory[territory]; ok {
+			return existing
+		}
+		created := &pricingSimulationTerritory{Territory: territory, Currency: currencies[territory]}
+		byTerritory[territory] = created
+		return created
+	}
+	for _, point := range currentPoints {
+		if point.Territory == "" {
+			continue
+		}
+		item := entry(point.Territory)
+		item.CurrentPrice = point.CustomerPrice
+		item.CurrentProceeds = point.Proceeds
+		currentTiers[point.Territory] = point.Tier
+	}
+	for _, point := range newPoints {
+		if point.Territory == "" {
+			continue
+		}
+		item := entry(point.Territory)
+		item.NewPrice = point.CustomerPrice
+		item.NewProceeds = point.Proceeds
+		newTiers[point.Territory] = point.Tier
+	}
+
+	territories := make([]pricingSimulationTerritory, 0, len(byTerritory))
+	for territory, item := range byTerritory {
+		currentTier, newTier := currentTiers[territory], newTiers[territory]
+		if currentTier != "" && newTier != "" {
+			item.Changed = currentTier != newTier
+		} else {
+			currentKey, currentErr := normalizePricingPrice(item.CurrentPrice)
+			newKey, newErr := normalizePricingPrice(item.NewPrice)
+			item.Changed = currentErr != nil || newErr != nil || currentKey != newKey
+		}
+		territories = append(territories, *item)
+	}
+	sort.Slice(territories, func(i, j int) bool {
+		return territories[i].Territory < territories[j].Territory
+	})
+	return territories
+}
+
+func newAppPricingSource(client *asc.Client, appID string) pricingSource {
+	return pricingSource{
+		target: pricingTargetApp,
+		id:     appID,
+		basePricePoints: func(ctx context.Context, territory string) ([]pricingPoint, error) {
+			firstPage, err := client.GetAppPricePoints(ctx, appID, asc.WithPricePointsTerritory(territory), asc.WithPricePointsLimit(200))
+			if err != nil {
+				return nil, err
+			}
+			paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				return client.GetAppPricePoints(ctx, appID, asc.WithPricePointsNextURL(nextURL))
+			})
+			if err != nil {
+				return nil, err
+			}
+			return appPricingPoints(paginated)
+		},
+		equalizations: func(ctx context.Context, pricePointID string) ([]pricingPoint, error) {
+			firstPage, err := client.GetAppPricePointEqualizations(ctx, pricePointID, asc.WithPricePointsLimit(200))
+			if err != nil {
+				return nil, err
+			}
+			paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				return client.GetAppPricePointEqualizations(ctx, pricePointID, asc.WithPricePointsNextURL(nextURL))
+			})
+			if err != nil {
+				return nil, err
+			}
+			return appPricingPoints(paginated)
+		},
+		currentBase: func(ctx context.Context, territory string, now time.Time) (string, string, error) {
+			schedule, err := client.GetAppPriceSchedule(ctx, appID)
+			if err != nil {
+				if asc.IsNotFound(err) {
+					return "", "", nil
+				}
+				return "", "", err
+			}
+			var ids []string
+			prices, err := client.GetAppPriceScheduleManualPrices(ctx, schedule.Data.ID, asc.WithAppPriceSchedulePricesLimit(200))
+			for {
+				if err != nil {
+					return "", "", err
+				}
+				for _, price := range prices.Data {
+					ids = append(ids, price.ID)
+				}
+				if strings.TrimSpace(prices.Links.Next) == "" {
+					break
+				}
+				prices, err = client.GetAppPriceScheduleManualPrices(ctx, schedule.Data.ID, asc.WithAppPriceSchedulePricesNextURL(prices.Links.Next))
+			}
+			return "", activePricingSimulateTier(ids, territory, now), nil
+		},
+	}
+}
+
+func appPricingPoints(paginated asc.PaginatedResponse) ([]pricingPoint, error) {
+	resp, ok := paginated.(*asc.AppPricePointsV3Response)
+	if !ok {
+		return nil, fmt.Errorf("unexpected app price points response type %T", paginated)
+	}
+	points := make([]pricingPoint, 0, len(resp.Data))
+	for _, item := range resp.Data {
+		points = append(points, newPricingPoint(item.ID, item.Attributes.CustomerPrice, item.Attributes.Proceeds))
+	}
+	return points, nil
+}
+
+func newIAPPricingSource(client *asc.Client, iapID string) pricingSource {
+	return pricingSource{
+		target: pricingTargetIAP,
+		id:     iapID,
+		basePricePoints: func(ctx context.Context, territory string) ([]pricingPoint, error) {
+			firstPage, err := client.GetInAppPurchasePricePoints(ctx, iapID, asc.WithIAPPricePointsTerritory(territory), asc.WithIAPPricePointsLimit(200))
+			if err != nil {
+				return nil, err
+			}
+			paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				return client.GetInAppPurchasePricePoints(ctx, iapID, asc.WithIAPPricePointsNextURL(nextURL))
+			})
+			if err != nil {
+				return nil, err
+			}
+			return iapPricingPoints(paginated)
+		},
+		equalizations: func(ctx context.Context, pricePointID string) ([]pricingPoint, error) {
+			firstPage, err := client.GetInAppPurchasePricePointEqualizations(ctx, pricePointID, asc.WithIAPPricePointsLimit(200))
+			if err != nil {
+				return nil, err
+			}
+			paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				return client.GetInAppPurchasePricePointEqualizations(ctx, pricePointID, asc.WithIAPPricePointsNextURL(nextURL))
+			})
+			if err != nil {
+				return nil, err
+			}
+			return iapPricingPoints(paginated)
+		},
+		currentBase: func(ctx context.Context, territory string, now time.Time) (string, string, error) {
+			schedule, err := client.GetInAppPurchasePriceSchedule(ctx, iapID)
+			if err != nil {
+				if asc.IsNotFound(err) {
+					return "", "", nil
+				}
+				return "", "", err
+			}
+			firstPage, err := client.GetInAppPurchasePriceScheduleManualPrices(ctx, schedule.Data.ID, asc.WithIAPPriceSchedulePricesLimit(200))
+			if err != nil {
+				return "", "", err
+			}
+			paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				return client.GetInAppPurchasePriceScheduleManualPrices(ctx, schedule.Data.ID, asc.WithIAPPriceSchedulePricesNextURL(nextURL))
+			})
+			if err != nil {
+				return "", "", err
+			}
+			resp, ok := paginated.(*asc.InAppPurchasePricesResponse)
+			if !ok {
+				return "", "", fmt.Errorf("unexpected manual prices response type %T", paginated)
+			}
+			ids := make([]string, 0, len(resp.Data))
+			for _, price := range resp.Data {
+				ids = append(ids, price.ID)
+			}
+			return "", activePricingSimulateTier(ids, territory, now), nil
+		},
+	}
+}
+
+func iapPricingPoints(paginated asc.PaginatedResponse) ([]pricingPoint, error) {
+	resp, ok := paginated.(*asc.InAppPurchasePricePointsResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected IAP price points response type %T", paginated)
+	}
+	points := make([]pricingPoint, 0, len(resp.Data))
+	for _, item := range resp.Data {
+		points = append(points, newPricingPoint(item.ID, item.Attributes.CustomerPrice, item.Attributes.Proceeds))
+	}
+	return points, nil
+}
+
+func newSubscriptionPricingSource(client *asc.Client, subscriptionID string) pricingSource {
+	return pricingSource{
+		target: pricingTargetSubscription,
+		id:     subscriptionID,
+		basePricePoints: func(ctx context.Context, territory string) ([]pricingPoint, error) {
+			firstPage, err := client.GetSubscriptionPricePoints(ctx, subscriptionID, asc.WithSubscriptionPricePointsTerritory(territory), asc.WithSubscriptionPricePointsLimit(200))
+			if err != nil {
+				return nil, err
+			}
+			paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				return client.GetSubscriptionPricePoints(ctx, subscriptionID, asc.WithSubscriptionPricePointsNextURL(nextURL))
+			})
+			if err != nil {
+				return nil, err
+			}
+			return subscriptionPricingPoints(paginated)
+		},
+		equalizations: func(ctx context.Context, pricePointID string) ([]pricingPoint, error) {
+			firstPage, err := client.GetSubscriptionPricePointEqualizations(ctx, pricePointID, asc.WithSubscriptionPricePointsLimit(200))
+			if err != nil {
+				return nil, err
+			}
+			paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				return client.GetSubscriptionPricePointEqualizations(ctx, pricePointID, asc.WithSubscriptionPricePointsNextURL(nextURL))
+			})
+			if err != nil {
+				return nil, err
+			}
+			return subscriptionPricingPoints(paginated)
+		},
+		currentBase: func(ctx context.Context, territory string, now time.Time) (string, string, error) {
+			resp, err := client.GetSubscriptionPrices(
+				ctx,
+				subscriptionID,
+				asc.WithSubscriptionPricesTerritory(territory),
+				asc.WithSubscriptionPricesInclude([]string{"subscriptionPricePoint"}),
+				asc.WithSubscriptionPricesLimit(200),
+			)
+			today := now.Format("2006-01-02")
+			bestStart := ""
+			bestID := ""
+			for {
+				if err != nil {
+					return "", "", err
+				}
+				for _, price := range resp.Data {
+					start := strings.TrimSpace(price.Attributes.StartDate)
+					if start > today {
+						continue
+					}
+					pricePointID := pricingRelationshipID(price.Relationships, "subscriptionPricePoint")
+					if pricePointID == "" {
+						continue
+					}
+					if bestID == "" || start > bestStart {
+						bestStart = start
+						bestID = pricePointID
+					}
+				}
+				if strings.TrimSpace(resp.Links.Next) == "" {
+					break
+				}
+				resp, err = client.GetSubscriptionPrices(ctx, subscriptionID, asc.WithSubscriptionPricesNextURL(resp.Links.Next))
+			}
+			return bestID, "", nil
+		},
+	}
+}
+
+func subscriptionPricingPoints(paginated asc.PaginatedResponse) ([]pricingPoint, error) {
+	resp, ok := paginated.(*asc.SubscriptionPricePointsResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected subscription price points response type %T", paginated)
+	}
+	points := make([]pricingPoint, 0, len(resp.Data))
+	for _, item := range resp.Data {
+		points = append(points, newPricingPoint(item.ID, item.Attributes.CustomerPrice, item.Attributes.Proceeds))
+	}
+	return points, nil
+}
+
+func fetchPricingSimulateCurrencies(ctx context.Context, client *asc.Client) (map[string]string, error) {
+	firstPage, err := client.GetTerritories(ctx, asc.WithTerritoriesFields([]string{"currency"}), asc.WithTerritoriesLimit(200))
+	if err != nil {
+		return nil, fmt.Errorf("fetch territories: %w", err)
+	}
+	paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetTerritories(ctx, asc.WithTerritoriesNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("paginate territories: %w", err)
+	}
+	resp, ok := paginated.(*asc.TerritoriesResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected territories response type %T", paginated)
+	}
+	currencies := make(map[string]string, len(resp.Data))
+	for _, item := range resp.Data {
+		currencies[strings.ToUpper(strings.TrimSpace(item.ID))] = strings.TrimSpace(item.Attributes.Currency)
+	}
+	return currencies, nil
+}
+
+func newPricingPoint(id, customerPrice, proceeds string) pricingPoint {
+	point := pricingPoint{
+		ID:            id,
+		CustomerPrice: strings.TrimSpace(customerPrice),
+		Proceeds:      strings.TrimSpace(proceeds),
+	}
+	if meta, ok := shared.DecodePriceResourceID(id); ok {
+		point.Territory = meta.TerritoryID
+		point.Tier = meta.PricePointID
+	}
+	return point
+}
+
+// activePricingSimulateTier returns the tier of the price active at now in a
+// territory, picking the latest start date when several overlap.
+func activePricingSimulateTier(priceIDs []string, territory string, now time.Time) string {
+	nowSeconds := float64(now.Unix())
+	bestStart := -1.0
+	tier := ""
+	for _, id := range priceIDs {
+		meta, ok := shared.DecodePriceResourceID(id)
+		if !ok || meta.TerritoryID != territory || meta.PricePointID == "" {
+			continue
+		}
+		if meta.StartDateSeconds > nowSeconds {
+			continue
+		}
+		if meta.EndDateSeconds > 0 && meta.EndDateSeconds <= nowSeconds {
+			continue
+		}
+		if meta.StartDateSeconds > bestStart {
+			bestStart = meta.StartDateSeconds
+			tier = meta.PricePointID
+		}
+	}
+	return tier
+}
+
+func pricingRelationshipID(relationships json.RawMessage, key string) string {
+	if len(relationships) == 0 {
+		return ""
+	}
+	var rels map[string]struct {
+		Data *asc.ResourceData `json:"data"`
+	}
+	if err := json.Unmarshal(relationships, &rels); err != nil {
+		return ""
+	}
+	rel, ok := rels[key]
+	if !ok || rel.Data == nil {
+		return ""
+	}
+	return strings.TrimSpace(rel.Data.ID)
+}
+
+func normalizePricingPrice(value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return "", fmt.Errorf("price is required")
+	}
+	rat := new(big.Rat)
+	if _, ok := rat.SetString(trimmed); !ok {
+		return "", fmt.Errorf("price %q is not a valid numeric value", trimmed)
+	}
+	return rat.RatString(), nil
+}
+
+func renderPricingSimulation(result *pricingSimulation, markdown bool) error {
+	if result == nil {
+		return fmt.Errorf("result is nil")
+	}
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	rows := make([][]string, 0, len(result.Territories))
+	for _, item := range result.Territories {
+		changed := ""
+		if item.Changed {
+			changed = "yes"
+		}
+		rows = append(rows, []string{
+			item.Territory,
+			item.Currency,
+			item.CurrentPrice,
+			item.NewPrice,
+			item.CurrentProceeds,
+			item.NewProceeds,
+			changed,
+		})
+	}
+	render([]string{"Territory", "Currency", "Current Price", "New Price", "Current Proceeds", "New Proceeds", "Changed"}, rows)
+	return nil
+}
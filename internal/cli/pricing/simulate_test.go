@@ -0,0 +1,118 @@
+package pricing
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"testing"
+	"time"
+)
+
+func encodePricingResourceID(payload string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(payload))
+}
+
+func TestPricingSimulateCommand_MissingFlags(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "missing target", args: []string{"--base-territory", "USA", "--price", "4.99"}},
+		{name: "missing base territory", args: []string{"--app", "APP_ID", "--price", "4.99"}},
+		{name: "missing price", args: []string{"--app", "APP_ID", "--base-territory", "USA"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := PricingSimulateCommand()
+			if err := cmd.FlagSet.Parse(test.args); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+			if err := cmd.Exec(context.Background(), []string{}); !errors.Is(err, flag.ErrHelp) {
+				t.Fatalf("expected flag.ErrHelp, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPricingSimulateCommand_RejectsMultipleTargets(t *testing.T) {
+	cmd := PricingSimulateCommand()
+	if err := cmd.FlagSet.Parse([]string{"--app", "APP_ID", "--iap", "IAP_ID", "--base-territory", "USA", "--price", "4.99"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	if err := cmd.Exec(context.Background(), []string{}); !errors.Is(err, flag.ErrHelp) {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
+
+func TestNewPricingPoint_DecodesTerritoryAndTier(t *testing.T) {
+	point := newPricingPoint(encodePricingResourceID(`{"s":"123","t":"usa","p":"10010"}`), " 4.99 ", "3.49")
+	if point.Territory != "USA" || point.Tier != "10010" {
+		t.Fatalf("expected USA tier 10010, got %+v", point)
+	}
+	if point.CustomerPrice != "4.99" {
+		t.Fatalf("expected trimmed customer price, got %q", point.CustomerPrice)
+	}
+
+	opaque := newPricingPoint("not-base64!", "1.00", "0.70")
+	if opaque.Territory != "" || opaque.Tier != "" {
+		t.Fatalf("expected undecodable ID to leave territory empty, got %+v", opaque)
+	}
+}
+
+func TestActivePricingSimulateTier(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	ids := []string{
+		encodePricingResourceID(`{"t":"USA","p":"10001","sd":0,"ed":1735689600}`),
+		encodePricingResourceID(`{"t":"USA","p":"10002","sd":1735689600,"ed":0}`),
+		encodePricingResourceID(`{"t":"USA","p":"10003","sd":1893456000,"ed":0}`),
+		encodePricingResourceID(`{"t":"GBR","p":"10009","sd":1735689600,"ed":0}`),
+	}
+
+	if got := activePricingSimulateTier(ids, "USA", now); got != "10002" {
+		t.Fatalf("expected active tier 10002, got %q", got)
+	}
+	if got := activePricingSimulateTier(ids, "DEU", now); got != "" {
+		t.Fatalf("expected no tier for DEU, got %q", got)
+	}
+}
+
+func TestComparePricingSimulationPoints(t *testing.T) {
+	currentPoints := []pricingPoint{
+		{Territory: "USA", CustomerPrice: "3.99", Proceeds: "2.79"},
+		{Territory: "GBR", CustomerPrice: "4.99", Proceeds: "3.49"},
+		{Territory: "KOR", Tier: "10005", CustomerPrice: "5900", Proceeds: "4130"},
+		{Territory: "MEX", Tier: "10005", CustomerPrice: "89.00", Proceeds: "62.30"},
+	}
+	newPoints := []pricingPoint{
+		{Territory: "USA", CustomerPrice: "4.99", Proceeds: "3.49"},
+		{Territory: "GBR", CustomerPrice: "4.990", Proceeds: "3.49"},
+		{Territory: "JPN", CustomerPrice: "800", Proceeds: "560"},
+		{Territory: "KOR", Tier: "10006", CustomerPrice: "5900", Proceeds: "4130"},
+		{Territory: "MEX", Tier: "10005", CustomerPrice: "99.00", Proceeds: "69.30"},
+	}
+
+	got := comparePricingSimulationPoints(currentPoints, newPoints, map[string]string{"USA": "USD", "GBR": "GBP"})
+	if len(got) != 5 {
+		t.Fatalf("expected 5 territories, got %+v", got)
+	}
+	if got[2].Territory != "KOR" || !got[2].Changed {
+		t.Fatalf("expected KOR with a new tier but equal price to be changed, got %+v", got[2])
+	}
+	if got[3].Territory != "MEX" || got[3].Changed {
+		t.Fatalf("expected MEX on the same tier to be unchanged, got %+v", got[3])
+	}
+	got = append(got[:2], got[4:]...)
+	if got[0].Territory != "GBR" || got[0].Changed || got[0].Currency != "GBP" {
+		t.Fatalf("expected unchanged GBR first, got %+v", got[0])
+	}
+	if got[1].Territory != "JPN" || !got[1].Changed || got[1].CurrentPrice != "" {
+		t.Fatalf("expected JPN without current price to be changed, got %+v", got[1])
+	}
+	if got[2].Territory != "USA" || !got[2].Changed || got[2].CurrentPrice != "3.99" || got[2].NewPrice != "4.99" {
+		t.Fatalf("expected changed USA, got %+v", got[2])
+	}
+}
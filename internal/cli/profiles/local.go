@@ -37,6 +37,9 @@ type localProfile struct {
 	CreatedAt time.Time `json:"createdAt,omitempty"`
 	Path      string    `json:"path"`
 	Expired   bool      `json:"expired"`
+
+	developerCertificates [][]byte
+	provisionedDevices    []string
 }
 
 type localInstallResult struct {
@@ -571,6 +574,9 @@ func scanLocalProfiles(installDir string, now time.Time) ([]localProfile, []loca
 			CreatedAt: parsed.CreationDate,
 			Path:      fullPath,
 			Expired:   isExpired(parsed.ExpirationDate, now),
+
+			developerCertificates: parsed.DeveloperCertificates,
+			provisionedDevices:    parsed.ProvisionedDevices,
 		})
 	}
 
@@ -0,0 +1,75 @@
+package profiles
+
+import (
+	"crypto/x509"
+	"strings"
+	"time"
+)
+
+// InstalledProfile describes a locally installed provisioning profile along
+// with the signing details other commands need to check it.
+type InstalledProfile struct {
+	UUID      string
+	Name      string
+	TeamID    string
+	BundleID  string
+	ExpiresAt time.Time
+	Path      string
+	Expired   bool
+
+	// CertificateSerials are the uppercase hex serial numbers of the
+	// developer certificates embedded in the profile.
+	CertificateSerials []string
+	// DeviceUDIDs are the provisioned devices; empty for distribution profiles.
+	DeviceUDIDs []string
+}
+
+// InstalledProfileSkip records a file that could not be read as a profile.
+type InstalledProfileSkip struct {
+	Path   string
+	Reason string
+}
+
+// ResolveInstallDir returns installDir, or Xcode's Provisioning Profiles
+// directory when it is empty on macOS.
+func ResolveInstallDir(installDir string) (string, error) {
+	return resolveProfilesInstallDir(installDir)
+}
+
+// ScanInstalledProfiles parses the provisioning profiles in installDir with
+// the same safety checks as profiles local list. A missing directory yields
+// no profiles.
+func ScanInstalledProfiles(installDir string, now time.Time) ([]InstalledProfile, []InstalledProfileSkip, error) {
+	scanned, skipped, err := scanLocalProfiles(installDir, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]InstalledProfile, 0, len(scanned))
+	for _, item := range scanned {
+		installed := InstalledProfile{
+			UUID:        item.UUID,
+			Name:        item.Name,
+			TeamID:      item.TeamID,
+			BundleID:    item.BundleID,
+			ExpiresAt:   item.ExpiresAt,
+			Path:        item.Path,
+			Expired:     item.Expired,
+			DeviceUDIDs: item.provisionedDevices,
+		}
+		for _, der := range item.developerCertificates {
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				continue
+			}
+			installed.CertificateSerials = append(installed.CertificateSerials, strings.ToUpper(cert.SerialNumber.Text(16)))
+		}
+		items = append(items, installed)
+	}
+
+	skips := make([]InstalledProfileSkip, 0, len(skipped))
+	for _, item := range skipped {
+		skips = append(skips, InstalledProfileSkip{Path: item.Path, Reason: item.Reason})
+	}
+	return items, skips, nil
+}
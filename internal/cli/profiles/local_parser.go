@@ -17,6 +17,9 @@ type mobileProvision struct {
 	CreationDate   time.Time      `plist:"CreationDate"`
 	ExpirationDate time.Time      `plist:"ExpirationDate"`
 	Entitlements   map[string]any `plist:"Entitlements"`
+
+	DeveloperCertificates [][]byte `plist:"DeveloperCertificates"`
+	ProvisionedDevices    []string `plist:"ProvisionedDevices"`
 }
 
 func parseMobileProvision(data []byte) (*mobileProvision, error) {
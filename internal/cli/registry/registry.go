@@ -59,6 +59,7 @@ import (
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/profiles"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/promotedpurchases"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/publish"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/release"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/releasenotes"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/reviews"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/routingcoverage"
@@ -74,6 +75,7 @@ import (
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/validate"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/versions"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/videopreviews"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/warehouse"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/webhooks"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/winbackoffers"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/workflow"
@@ -114,6 +116,7 @@ func Subcommands(version string) []*ffcli.Command {
 		analytics.AnalyticsCommand(),
 		performance.PerformanceCommand(),
 		finance.FinanceCommand(),
+		warehouse.WarehouseCommand(),
 		apps.AppsCommand(),
 		appclips.AppClipsCommand(),
 		androidiosmapping.AndroidIosMappingCommand(),
@@ -137,6 +140,7 @@ func Subcommands(version string) []*ffcli.Command {
 		builds.BuildsCommand(),
 		buildbundles.BuildBundlesCommand(),
 		publish.PublishCommand(),
+		release.ReleaseCommand(),
 		workflow.WorkflowCommand(),
 		versions.VersionsCommand(),
 		productpages.ProductPagesCommand(),
@@ -0,0 +1,186 @@
+package release
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	defaultReviewPollInterval = 5 * time.Minute
+	defaultReviewTimeout      = 72 * time.Hour
+)
+
+// ReleaseConfig describes the stages of a release run. Only configured
+// stages run; relative paths resolve against the config file directory.
+type ReleaseConfig struct {
+	App           string                      `yaml:"app"`
+	Version       string                      `yaml:"version"`
+	Platform      string                      `yaml:"platform"`
+	Publish       *ReleasePublishConfig       `yaml:"publish"`
+	Metadata      *ReleaseMetadataConfig      `yaml:"metadata"`
+	Screenshots   []ReleaseScreenshotsConfig  `yaml:"screenshots"`
+	Validate      *ReleaseValidateConfig      `yaml:"validate"`
+	Submit        bool                        `yaml:"submit"`
+	WaitForReview *ReleaseWaitForReviewConfig `yaml:"waitForReview"`
+	PhasedRelease bool                        `yaml:"phasedRelease"`
+	Notify        *ReleaseNotifyConfig        `yaml:"notify"`
+}
+
+// ReleasePublishConfig uploads an IPA and attaches it to the version.
+type ReleasePublishConfig struct {
+	IPA         string `yaml:"ipa"`
+	BuildNumber string `yaml:"buildNumber"`
+}
+
+// ReleaseMetadataConfig pushes a canonical metadata directory.
+type ReleaseMetadataConfig struct {
+	Dir string `yaml:"dir"`
+}
+
+// ReleaseScreenshotsConfig uploads one screenshot set for a locale.
+type ReleaseScreenshotsConfig struct {
+	Locale     string `yaml:"locale"`
+	DeviceType string `yaml:"deviceType"`
+	Path       string `yaml:"path"`
+}
+
+// ReleaseValidateConfig gates the release on readiness checks.
+type ReleaseValidateConfig struct {
+	Strict bool `yaml:"strict"`
+}
+
+// ReleaseWaitForReviewConfig controls review state polling.
+type ReleaseWaitForReviewConfig struct {
+	PollInterval string `yaml:"pollInterval"`
+	Timeout      string `yaml:"timeout"`
+
+	pollInterval time.Duration
+	timeout      time.Duration
+}
+
+// ReleaseNotifyConfig sends a notification once every other stage completed.
+type ReleaseNotifyConfig struct {
+	Slack *ReleaseSlackConfig `yaml:"slack"`
+}
+
+// ReleaseSlackConfig mirrors the asc notify slack flags.
+type ReleaseSlackConfig struct {
+	Message string `yaml:"message"`
+	Webhook string `yaml:"webhook"`
+	Channel string `yaml:"channel"`
+}
+
+func readReleaseConfig(path string) (*ReleaseConfig, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return nil, fmt.Errorf("release run: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var cfg ReleaseConfig
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, shared.UsageErrorf("invalid config %s: %v", path, err)
+	}
+	if err := normalizeReleaseConfig(&cfg, filepath.Dir(path)); err != nil {
+		return nil, shared.UsageErrorf("invalid config %s: %v", path, err)
+	}
+	return &cfg, nil
+}
+
+func normalizeReleaseConfig(cfg *ReleaseConfig, baseDir string) error {
+	cfg.App = shared.ResolveAppID(strings.TrimSpace(cfg.App))
+	if cfg.App == "" {
+		return fmt.Errorf("app is required (or set ASC_APP_ID)")
+	}
+	cfg.Version = strings.TrimSpace(cfg.Version)
+	if cfg.Version == "" {
+		return fmt.Errorf("version is required")
+	}
+	if strings.TrimSpace(cfg.Platform) == "" {
+		cfg.Platform = "IOS"
+	}
+	platform, err := shared.NormalizeAppStoreVersionPlatform(cfg.Platform)
+	if err != nil {
+		return err
+	}
+	cfg.Platform = platform
+
+	if cfg.Publish != nil {
+		cfg.Publish.IPA = resolveReleasePath(baseDir, cfg.Publish.IPA)
+		if cfg.Publish.IPA == "" {
+			return fmt.Errorf("publish.ipa is required")
+		}
+		cfg.Publish.BuildNumber = strings.TrimSpace(cfg.Publish.BuildNumber)
+	}
+	if cfg.Metadata != nil {
+		cfg.Metadata.Dir = resolveReleasePath(baseDir, cfg.Metadata.Dir)
+		if cfg.Metadata.Dir == "" {
+			return fmt.Errorf("metadata.dir is required")
+		}
+	}
+	for idx := range cfg.Screenshots {
+		entry := &cfg.Screenshots[idx]
+		entry.Locale = strings.TrimSpace(entry.Locale)
+		entry.DeviceType = strings.ToUpper(strings.TrimSpace(entry.DeviceType))
+		entry.Path = resolveReleasePath(baseDir, entry.Path)
+		if entry.Locale == "" || entry.DeviceType == "" || entry.Path == "" {
+			return fmt.Errorf("screenshots[%d]: locale, deviceType, and path are required", idx)
+		}
+	}
+	if cfg.WaitForReview != nil {
+		wait := cfg.WaitForReview
+		wait.pollInterval, err = parseReleaseDuration(wait.PollInterval, defaultReviewPollInterval)
+		if err != nil {
+			return fmt.Errorf("waitForReview.pollInterval: %w", err)
+		}
+		wait.timeout, err = parseReleaseDuration(wait.Timeout, defaultReviewTimeout)
+		if err != nil {
+			return fmt.Errorf("waitForReview.timeout: %w", err)
+		}
+	}
+	if cfg.Notify != nil {
+		if cfg.Notify.Slack == nil {
+			return fmt.Errorf("notify.slack is required")
+		}
+		cfg.Notify.Slack.Message = strings.TrimSpace(cfg.Notify.Slack.Message)
+		if cfg.Notify.Slack.Message == "" {
+			return fmt.Errorf("notify.slack.message is required")
+		}
+	}
+
+	if len(releaseStages(cfg)) == 0 {
+		return fmt.Errorf("at least one stage must be configured")
+	}
+	return nil
+}
+
+func resolveReleasePath(baseDir, value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" || filepath.IsAbs(value) {
+		return value
+	}
+	return filepath.Join(baseDir, value)
+}
+
+func parseReleaseDuration(value string, fallback time.Duration) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fallback, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("must be greater than zero")
+	}
+	return duration, nil
+}
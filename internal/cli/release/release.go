@@ -0,0 +1,247 @@
+package release
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// ReleaseRunResult is the summary printed after a release run.
+type ReleaseRunResult struct {
+	App          string              `json:"app"`
+	Version      string              `json:"version"`
+	Platform     string              `json:"platform"`
+	StatePath    string              `json:"statePath"`
+	DryRun       bool                `json:"dryRun"`
+	Status       string              `json:"status"`
+	Error        string              `json:"error,omitempty"`
+	BuildID      string              `json:"buildId,omitempty"`
+	VersionID    string              `json:"versionId,omitempty"`
+	SubmissionID string              `json:"submissionId,omitempty"`
+	Stages       []releaseStageState `json:"stages"`
+}
+
+// ReleaseCommand returns the release command group.
+func ReleaseCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("release", flag.ExitOnError)
+
+	return &ffcli.Command{
+		Name:       "release",
+		ShortUsage: "asc release <subcommand> [flags]",
+		ShortHelp:  "Run staged App Store releases with resumable checkpoints.",
+		LongHelp: `Run staged App Store releases with resumable checkpoints.
+
+Examples:
+  asc release run --config release.yaml
+  asc release run --config release.yaml --dry-run`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			ReleaseRunCommand(),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+// ReleaseRunCommand returns the release run subcommand.
+func ReleaseRunCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("release run", flag.ExitOnError)
+
+	configPath := fs.String("config", "", "Path to release config YAML (required)")
+	statePath := fs.String("state", "", "Path to checkpoint file (default: <config>.state.json)")
+	restart := fs.Bool("restart", false, "Discard the existing checkpoint and run every stage")
+	dryRun := fs.Bool("dry-run", false, "Show which stages would run without executing them")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "run",
+		ShortUsage: "asc release run --config release.yaml [flags]",
+		ShortHelp:  "Run the release stages described in a config file.",
+		LongHelp: `Run the release stages described in a config file.
+
+Stages run in a fixed order and only when configured:
+  publish, metadata, screenshots, validate, submit, wait-for-review,
+  phased-release, notify
+
+A checkpoint is written after every stage. Re-running the same config resumes
+at the first stage that has not completed; use --restart to start over.
+An interrupted publish, screenshots, or submit stage is not re-run, since it
+may already have uploaded the build, added screenshots, or created a
+submission; check App Store Connect and use --restart. Root flags such as --profile are passed to every stage.
+The validate stage stops the run when blocking issues are found, and
+wait-for-review polls the version until review finishes or is rejected.
+
+stdout is JSON-only; stage command output streams to stderr.
+
+Examples:
+  asc release run --config release.yaml
+  asc release run --config release.yaml --dry-run --output table
+  asc release run --config release.yaml --restart
+
+File format:
+  app: "123456789"
+  version: "2.1.0"
+  platform: IOS
+  publish:
+    ipa: ./build/App.ipa
+  metadata:
+    dir: ./metadata
+  screenshots:
+    - locale: en-US
+      deviceType: IPHONE_65
+      path: ./screenshots/en-US
+  validate:
+    strict: true
+  submit: true
+  waitForReview:
+    pollInterval: 10m
+    timeout: 72h
+  phasedRelease: true
+  notify:
+    slack:
+      message: "2.1.0 approved and scheduled for phased release"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			path := strings.TrimSpace(*configPath)
+			if path == "" {
+				fmt.Fprintln(os.Stderr, "Error: --config is required")
+				return flag.ErrHelp
+			}
+
+			cfg, err := readReleaseConfig(path)
+			if err != nil {
+				return err
+			}
+
+			checkpointPath := strings.TrimSpace(*statePath)
+			if checkpointPath == "" {
+				checkpointPath = defaultReleaseStatePath(path)
+			}
+			state, err := loadReleaseState(checkpointPath, cfg, *restart)
+			if err != nil {
+				return fmt.Errorf("release run: %w", err)
+			}
+
+			runErr := runRelease(ctx, cfg, state, checkpointPath, *dryRun)
+
+			result := &ReleaseRunResult{
+				App:          state.App,
+				Version:      state.Version,
+				Platform:     state.Platform,
+				StatePath:    checkpointPath,
+				DryRun:       *dryRun,
+				Status:       stageStatusCompleted,
+				BuildID:      state.BuildID,
+				VersionID:    state.VersionID,
+				SubmissionID: state.SubmissionID,
+				Stages:       state.Stages,
+			}
+			if *dryRun {
+				result.Status = "planned"
+			}
+			if runErr != nil {
+				result.Status = stageStatusFailed
+				result.Error = runErr.Error()
+			}
+
+			if err := shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderReleaseRunResult(result, false) },
+				func() error { return renderReleaseRunResult(result, true) },
+			); err != nil {
+				return err
+			}
+			if runErr != nil {
+				return shared.NewReportedError(fmt.Errorf("release run: %w", runErr))
+			}
+			return nil
+		},
+	}
+}
+
+// runRelease executes pending stages in order, persisting the checkpoint
+// before and after each one so an interrupted run resumes where it stopped.
+func runRelease(ctx context.Context, cfg *ReleaseConfig, state *releaseState, statePath string, dryRun bool) error {
+	stages := releaseStages(cfg)
+	if dryRun {
+		return nil
+	}
+
+	runner := &releaseRunner{cfg: cfg, state: state}
+	for idx, stage := range stages {
+		entry := state.stage(stage.name)
+		if entry.Status == stageStatusCompleted {
+			fmt.Fprintf(os.Stderr, "release: [%d/%d] %s already completed, skipping\n", idx+1, len(stages), stage.name)
+			continue
+		}
+		if entry.Status == stageStatusRunning && stage.notIdempotent {
+			return fmt.Errorf(
+				"stage %s was interrupted while running and may have partially completed; check App Store Connect, then rerun with --restart",
+				stage.name,
+			)
+		}
+
+		fmt.Fprintf(os.Stderr, "release: [%d/%d] %s\n", idx+1, len(stages), stage.name)
+		entry.Status = stageStatusRunning
+		entry.StartedAt = time.Now().UTC().Format(time.RFC3339)
+		entry.CompletedAt = ""
+		entry.Detail = ""
+		entry.Error = ""
+		if err := saveReleaseState(statePath, state, time.Now()); err != nil {
+			return fmt.Errorf("write checkpoint: %w", err)
+		}
+
+		detail, stageErr := stage.run(runner, ctx)
+		entry = state.stage(stage.name)
+		entry.Detail = detail
+		if stageErr != nil {
+			entry.Status = stageStatusFailed
+			entry.Error = stageErr.Error()
+		} else {
+			entry.Status = stageStatusCompleted
+			entry.CompletedAt = time.Now().UTC().Format(time.RFC3339)
+		}
+		if err := saveReleaseState(statePath, state, time.Now()); err != nil {
+			return fmt.Errorf("write checkpoint: %w", err)
+		}
+		if stageErr != nil {
+			return fmt.Errorf("stage %s failed: %w", stage.name, stageErr)
+		}
+	}
+	return nil
+}
+
+func renderReleaseRunResult(result *ReleaseRunResult, markdown bool) error {
+	if result == nil {
+		return fmt.Errorf("result is nil")
+	}
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	rows := make([][]string, 0, len(result.Stages))
+	for _, stage := range result.Stages {
+		note := stage.Detail
+		if stage.Error != "" {
+			note = stage.Error
+		}
+		rows = append(rows, []string{stage.Name, stage.Status, stage.CompletedAt, note})
+	}
+	render([]string{"Stage", "Status", "Completed", "Detail"}, rows)
+	return nil
+}
@@ -0,0 +1,257 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalizeReleaseConfig_DefaultsAndPaths(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+
+	cfg := ReleaseConfig{
+		App:           " 123 ",
+		Version:       "2.1.0",
+		Publish:       &ReleasePublishConfig{IPA: "build/App.ipa"},
+		Screenshots:   []ReleaseScreenshotsConfig{{Locale: "en-US", DeviceType: "iphone_65", Path: "/abs/shots"}},
+		WaitForReview: &ReleaseWaitForReviewConfig{Timeout: "24h"},
+	}
+	if err := normalizeReleaseConfig(&cfg, "/work"); err != nil {
+		t.Fatalf("normalizeReleaseConfig() error: %v", err)
+	}
+	if cfg.App != "123" || cfg.Platform != "IOS" {
+		t.Fatalf("unexpected app/platform: %q %q", cfg.App, cfg.Platform)
+	}
+	if cfg.Publish.IPA != filepath.Join("/work", "build/App.ipa") {
+		t.Fatalf("expected IPA relative to config dir, got %q", cfg.Publish.IPA)
+	}
+	if cfg.Screenshots[0].Path != "/abs/shots" || cfg.Screenshots[0].DeviceType != "IPHONE_65" {
+		t.Fatalf("unexpected screenshots entry: %+v", cfg.Screenshots[0])
+	}
+	if cfg.WaitForReview.pollInterval != defaultReviewPollInterval || cfg.WaitForReview.timeout != 24*time.Hour {
+		t.Fatalf("unexpected wait durations: %+v", cfg.WaitForReview)
+	}
+
+	var names []string
+	for _, stage := range releaseStages(&cfg) {
+		names = append(names, stage.name)
+	}
+	if strings.Join(names, ",") != "publish,screenshots,wait-for-review" {
+		t.Fatalf("unexpected stages: %v", names)
+	}
+}
+
+func TestNormalizeReleaseConfig_RejectsInvalidConfig(t *testing.T) {
+	t.Setenv("ASC_APP_ID", "")
+
+	tests := []struct {
+		name string
+		cfg  ReleaseConfig
+	}{
+		{name: "missing app", cfg: ReleaseConfig{Version: "1.0", Submit: true}},
+		{name: "missing version", cfg: ReleaseConfig{App: "1", Submit: true}},
+		{name: "bad platform", cfg: ReleaseConfig{App: "1", Version: "1.0", Platform: "ANDROID", Submit: true}},
+		{name: "no stages", cfg: ReleaseConfig{App: "1", Version: "1.0"}},
+		{name: "missing ipa", cfg: ReleaseConfig{App: "1", Version: "1.0", Publish: &ReleasePublishConfig{}}},
+		{name: "incomplete screenshots", cfg: ReleaseConfig{App: "1", Version: "1.0", Screenshots: []ReleaseScreenshotsConfig{{Locale: "en-US"}}}},
+		{name: "bad poll interval", cfg: ReleaseConfig{App: "1", Version: "1.0", WaitForReview: &ReleaseWaitForReviewConfig{PollInterval: "soon"}}},
+		{name: "missing slack message", cfg: ReleaseConfig{App: "1", Version: "1.0", Notify: &ReleaseNotifyConfig{Slack: &ReleaseSlackConfig{}}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := test.cfg
+			if err := normalizeReleaseConfig(&cfg, "."); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadReleaseState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "release.state.json")
+	cfg := &ReleaseConfig{App: "1", Version: "1.0", Platform: "IOS", Submit: true, PhasedRelease: true}
+
+	state, err := loadReleaseState(path, cfg, false)
+	if err != nil {
+		t.Fatalf("loadReleaseState() error: %v", err)
+	}
+	if len(state.Stages) != 2 || state.Stages[0].Status != stageStatusPending {
+		t.Fatalf("expected fresh pending stages, got %+v", state.Stages)
+	}
+
+	state.Stages[0].Status = stageStatusCompleted
+	state.SubmissionID = "sub-1"
+	if err := saveReleaseState(path, state, time.Now()); err != nil {
+		t.Fatalf("saveReleaseState() error: %v", err)
+	}
+
+	cfg.Notify = &ReleaseNotifyConfig{Slack: &ReleaseSlackConfig{Message: "done"}}
+	resumed, err := loadReleaseState(path, cfg, false)
+	if err != nil {
+		t.Fatalf("loadReleaseState() resume error: %v", err)
+	}
+	if resumed.SubmissionID != "sub-1" || resumed.Stages[0].Status != stageStatusCompleted {
+		t.Fatalf("expected submit progress to be kept, got %+v", resumed)
+	}
+	if len(resumed.Stages) != 3 || resumed.Stages[2].Name != stageNotify {
+		t.Fatalf("expected notify stage to be added, got %+v", resumed.Stages)
+	}
+
+	restarted, err := loadReleaseState(path, cfg, true)
+	if err != nil {
+		t.Fatalf("loadReleaseState() restart error: %v", err)
+	}
+	if restarted.SubmissionID != "" || restarted.Stages[0].Status != stageStatusPending {
+		t.Fatalf("expected restart to discard progress, got %+v", restarted)
+	}
+
+	other := &ReleaseConfig{App: "1", Version: "1.1", Platform: "IOS", Submit: true}
+	if _, err := loadReleaseState(path, other, false); err == nil || !strings.Contains(err.Error(), "--restart") {
+		t.Fatalf("expected mismatch error, got %v", err)
+	}
+}
+
+func TestRunRelease_ResumesAfterFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "release.state.json")
+	cfg := &ReleaseConfig{
+		App:      "1",
+		Version:  "1.0",
+		Platform: "IOS",
+		Metadata: &ReleaseMetadataConfig{Dir: "./metadata"},
+		Notify:   &ReleaseNotifyConfig{Slack: &ReleaseSlackConfig{Message: "shipped"}},
+	}
+
+	var calls []string
+	failNotify := true
+	restore := SetStageRunner(func(ctx context.Context, args []string) ([]byte, error) {
+		calls = append(calls, args[0])
+		if args[0] == "notify" && failNotify {
+			return nil, errors.New("webhook unreachable")
+		}
+		return []byte(`{}`), nil
+	})
+	defer restore()
+
+	state, err := loadReleaseState(path, cfg, false)
+	if err != nil {
+		t.Fatalf("loadReleaseState() error: %v", err)
+	}
+	err = runRelease(context.Background(), cfg, state, path, false)
+	if err == nil || !strings.Contains(err.Error(), "stage notify failed") {
+		t.Fatalf("expected notify failure, got %v", err)
+	}
+	if state.Stages[1].Status != stageStatusFailed || state.Stages[1].Error != "webhook unreachable" {
+		t.Fatalf("expected failed notify checkpoint, got %+v", state.Stages[1])
+	}
+
+	failNotify = false
+	calls = nil
+	state, err = loadReleaseState(path, cfg, false)
+	if err != nil {
+		t.Fatalf("loadReleaseState() resume error: %v", err)
+	}
+	if err := runRelease(context.Background(), cfg, state, path, false); err != nil {
+		t.Fatalf("runRelease() resume error: %v", err)
+	}
+	if strings.Join(calls, ",") != "notify" {
+		t.Fatalf("expected only notify to rerun, got %v", calls)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected checkpoint file: %v", err)
+	}
+}
+
+func TestClassifyReviewState(t *testing.T) {
+	tests := []struct {
+		state   string
+		done    bool
+		wantErr bool
+	}{
+		{state: "WAITING_FOR_REVIEW"},
+		{state: "IN_REVIEW"},
+		{state: "PENDING_DEVELOPER_RELEASE", done: true},
+		{state: "READY_FOR_SALE", done: true},
+		{state: "REJECTED", wantErr: true},
+		{state: "METADATA_REJECTED", wantErr: true},
+	}
+
+	for _, test := range tests {
+		done, err := classifyReviewState(test.state)
+		if done != test.done || (err != nil) != test.wantErr {
+			t.Fatalf("classifyReviewState(%q) = %v, %v", test.state, done, err)
+		}
+	}
+}
+
+func TestReleaseStages_MarksUploadStagesNotIdempotent(t *testing.T) {
+	cfg := &ReleaseConfig{
+		Publish:     &ReleasePublishConfig{IPA: "/tmp/App.ipa"},
+		Metadata:    &ReleaseMetadataConfig{Dir: "./metadata"},
+		Screenshots: []ReleaseScreenshotsConfig{{Locale: "en-US", DeviceType: "iphone_65", Path: "/abs/shots"}},
+		Submit:      true,
+	}
+	var notIdempotent []string
+	for _, stage := range releaseStages(cfg) {
+		if stage.notIdempotent {
+			notIdempotent = append(notIdempotent, stage.name)
+		}
+	}
+	if got := strings.Join(notIdempotent, ","); got != "publish,screenshots,submit" {
+		t.Fatalf("expected publish, screenshots and submit to be non-idempotent, got %q", got)
+	}
+}
+
+func TestRunRelease_RefusesToResumeInterruptedPublish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "release.state.json")
+	cfg := &ReleaseConfig{
+		App:      "1",
+		Version:  "1.0",
+		Platform: "IOS",
+		Publish:  &ReleasePublishConfig{IPA: "/tmp/App.ipa"},
+		Metadata: &ReleaseMetadataConfig{Dir: "./metadata"},
+	}
+
+	var calls []string
+	restore := SetStageRunner(func(ctx context.Context, args []string) ([]byte, error) {
+		calls = append(calls, args[0])
+		return []byte(`{}`), nil
+	})
+	defer restore()
+
+	state, err := loadReleaseState(path, cfg, false)
+	if err != nil {
+		t.Fatalf("loadReleaseState() error: %v", err)
+	}
+	state.Stages[0].Status = stageStatusRunning
+	if err := saveReleaseState(path, state, time.Now()); err != nil {
+		t.Fatalf("saveReleaseState() error: %v", err)
+	}
+
+	state, err = loadReleaseState(path, cfg, false)
+	if err != nil {
+		t.Fatalf("loadReleaseState() resume error: %v", err)
+	}
+	err = runRelease(context.Background(), cfg, state, path, false)
+	if err == nil || !strings.Contains(err.Error(), "stage publish was interrupted") || !strings.Contains(err.Error(), "--restart") {
+		t.Fatalf("expected interrupted publish error, got %v", err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("expected no stages to run, got %v", calls)
+	}
+
+	state, err = loadReleaseState(path, cfg, true)
+	if err != nil {
+		t.Fatalf("loadReleaseState() restart error: %v", err)
+	}
+	if err := runRelease(context.Background(), cfg, state, path, false); err != nil {
+		t.Fatalf("runRelease() restart error: %v", err)
+	}
+	if strings.Join(calls, ",") != "publish,metadata" {
+		t.Fatalf("expected every stage to run after restart, got %v", calls)
+	}
+}
@@ -0,0 +1,394 @@
+package release
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/validate"
+)
+
+const (
+	stagePublish       = "publish"
+	stageMetadata      = "metadata"
+	stageScreenshots   = "screenshots"
+	stageValidate      = "validate"
+	stageSubmit        = "submit"
+	stageWaitForReview = "wait-for-review"
+	stagePhasedRelease = "phased-release"
+	stageNotify        = "notify"
+)
+
+// stageRunner executes an asc subcommand and returns its stdout.
+type stageRunner func(ctx context.Context, args []string) ([]byte, error)
+
+var (
+	clientFactory             = shared.GetASCClient
+	runStage      stageRunner = runASCSubcommand
+)
+
+// releaseStage runs one step of the release. It returns a short detail
+// recorded in the checkpoint. Stages that create remote state (uploads,
+// submissions) are not idempotent and are never re-run after an interruption.
+type releaseStage struct {
+	name          string
+	run           func(r *releaseRunner, ctx context.Context) (string, error)
+	notIdempotent bool
+}
+
+// releaseStages returns the configured stages in execution order.
+func releaseStages(cfg *ReleaseConfig) []releaseStage {
+	var stages []releaseStage
+	if cfg.Publish != nil {
+		stages = append(stages, releaseStage{name: stagePublish, run: (*releaseRunner).publish, notIdempotent: true})
+	}
+	if cfg.Metadata != nil {
+		stages = append(stages, releaseStage{name: stageMetadata, run: (*releaseRunner).metadata})
+	}
+	if len(cfg.Screenshots) > 0 {
+		stages = append(stages, releaseStage{name: stageScreenshots, run: (*releaseRunner).screenshots, notIdempotent: true})
+	}
+	if cfg.Validate != nil {
+		stages = append(stages, releaseStage{name: stageValidate, run: (*releaseRunner).validate})
+	}
+	if cfg.Submit {
+		stages = append(stages, releaseStage{name: stageSubmit, run: (*releaseRunner).submit, notIdempotent: true})
+	}
+	if cfg.WaitForReview != nil {
+		stages = append(stages, releaseStage{name: stageWaitForReview, run: (*releaseRunner).waitForReview})
+	}
+	if cfg.PhasedRelease {
+		stages = append(stages, releaseStage{name: stagePhasedRelease, run: (*releaseRunner).phasedRelease})
+	}
+	if cfg.Notify != nil {
+		stages = append(stages, releaseStage{name: stageNotify, run: (*releaseRunner).notify})
+	}
+	return stages
+}
+
+// runASCSubcommand re-invokes the current binary so each stage behaves
+// exactly like the standalone command. Output is echoed to stderr to keep
+// stdout reserved for the release summary.
+func runASCSubcommand(ctx context.Context, args []string) ([]byte, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("locate asc executable: %w", err)
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, executable, args...)
+	cmd.Stdout = io.MultiWriter(&stdout, os.Stderr)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return stdout.Bytes(), fmt.Errorf("asc %s: %w", stageCommandName(args), err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// stageCommandName returns the first two words of the subcommand, skipping
+// the forwarded root flags.
+func stageCommandName(args []string) string {
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		args = args[1:]
+	}
+	return strings.Join(args[:min(2, len(args))], " ")
+}
+
+type releaseRunner struct {
+	cfg    *ReleaseConfig
+	state  *releaseState
+	client *asc.Client
+}
+
+func (r *releaseRunner) ascClient() (*asc.Client, error) {
+	if r.client != nil {
+		return r.client, nil
+	}
+	client, err := clientFactory()
+	if err != nil {
+		return nil, err
+	}
+	r.client = client
+	return client, nil
+}
+
+func (r *releaseRunner) runJSON(ctx context.Context, args []string, out any) error {
+	stdout, err := runStage(ctx, append(shared.RootFlagArgs(), args...))
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(bytes.TrimSpace(stdout), out); err != nil {
+		return fmt.Errorf("asc %s: parse output: %w", stageCommandName(args), err)
+	}
+	return nil
+}
+
+// versionID returns the App Store version ID, looking it up by version
+// string when no earlier stage recorded it.
+func (r *releaseRunner) versionID(ctx context.Context) (string, error) {
+	if r.state.VersionID != "" {
+		return r.state.VersionID, nil
+	}
+	client, err := r.ascClient()
+	if err != nil {
+		return "", err
+	}
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	versionID, err := shared.ResolveAppStoreVersionID(requestCtx, client, r.cfg.App, r.cfg.Version, r.cfg.Platform)
+	if err != nil {
+		return "", err
+	}
+	r.state.VersionID = versionID
+	return versionID, nil
+}
+
+// buildID returns the build attached to the version when publish did not run.
+func (r *releaseRunner) buildID(ctx context.Context, versionID string) (string, error) {
+	if r.state.BuildID != "" {
+		return r.state.BuildID, nil
+	}
+	client, err := r.ascClient()
+	if err != nil {
+		return "", err
+	}
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	resp, err := client.GetAppStoreVersionBuild(requestCtx, versionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch attached build: %w", err)
+	}
+	if strings.TrimSpace(resp.Data.ID) == "" {
+		return "", fmt.Errorf("no build attached to version %s", r.cfg.Version)
+	}
+	r.state.BuildID = resp.Data.ID
+	return r.state.BuildID, nil
+}
+
+func (r *releaseRunner) publish(ctx context.Context) (string, error) {
+	args := []string{
+		"publish", "appstore",
+		"--app", r.cfg.App,
+		"--ipa", r.cfg.Publish.IPA,
+		"--version", r.cfg.Version,
+		"--platform", r.cfg.Platform,
+		"--wait",
+		"--output", "json",
+	}
+	if r.cfg.Publish.BuildNumber != "" {
+		args = append(args, "--build-number", r.cfg.Publish.BuildNumber)
+	}
+
+	var result asc.AppStorePublishResult
+	if err := r.runJSON(ctx, args, &result); err != nil {
+		return "", err
+	}
+	r.state.BuildID = result.BuildID
+	r.state.VersionID = result.VersionID
+	return fmt.Sprintf("build %s attached", result.BuildID), nil
+}
+
+func (r *releaseRunner) metadata(ctx context.Context) (string, error) {
+	args := []string{
+		"metadata", "push",
+		"--app", r.cfg.App,
+		"--version", r.cfg.Version,
+		"--platform", r.cfg.Platform,
+		"--dir", r.cfg.Metadata.Dir,
+		"--output", "json",
+	}
+	if err := r.runJSON(ctx, args, nil); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+func (r *releaseRunner) screenshots(ctx context.Context) (string, error) {
+	versionID, err := r.versionID(ctx)
+	if err != nil {
+		return "", err
+	}
+	client, err := r.ascClient()
+	if err != nil {
+		return "", err
+	}
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	resp, err := client.GetAppStoreVersionLocalizations(requestCtx, versionID, asc.WithAppStoreVersionLocalizationsLimit(200))
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch version localizations: %w", err)
+	}
+	localizationIDs := make(map[string]string, len(resp.Data))
+	for _, item := range resp.Data {
+		localizationIDs[item.Attributes.Locale] = item.ID
+	}
+
+	for _, entry := range r.cfg.Screenshots {
+		localizationID, ok := localizationIDs[entry.Locale]
+		if !ok {
+			return "", fmt.Errorf("version %s has no %s localization", r.cfg.Version, entry.Locale)
+		}
+		args := []string{
+			"screenshots", "upload",
+			"--version-localization", localizationID,
+			"--path", entry.Path,
+			"--device-type", entry.DeviceType,
+			"--output", "json",
+		}
+		if err := r.runJSON(ctx, args, nil); err != nil {
+			return "", fmt.Errorf("%s %s: %w", entry.Locale, entry.DeviceType, err)
+		}
+	}
+	return fmt.Sprintf("%d screenshot set(s) uploaded", len(r.cfg.Screenshots)), nil
+}
+
+func (r *releaseRunner) validate(ctx context.Context) (string, error) {
+	versionID, err := r.versionID(ctx)
+	if err != nil {
+		return "", err
+	}
+	client, err := r.ascClient()
+	if err != nil {
+		return "", err
+	}
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	report, err := validate.BuildReport(requestCtx, client, r.cfg.App, versionID, r.cfg.Platform, r.cfg.Validate.Strict)
+	if err != nil {
+		return "", err
+	}
+	if report.Summary.Blocking > 0 {
+		for _, check := range report.Checks {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", check.Severity, check.Message)
+		}
+		return "", fmt.Errorf("found %d blocking issue(s); run asc validate --app %s --version-id %s for details", report.Summary.Blocking, r.cfg.App, versionID)
+	}
+	return fmt.Sprintf("%d error(s), %d warning(s)", report.Summary.Errors, report.Summary.Warnings), nil
+}
+
+func (r *releaseRunner) submit(ctx context.Context) (string, error) {
+	versionID, err := r.versionID(ctx)
+	if err != nil {
+		return "", err
+	}
+	buildID, err := r.buildID(ctx, versionID)
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{
+		"submit", "create",
+		"--app", r.cfg.App,
+		"--version-id", versionID,
+		"--build", buildID,
+		"--platform", r.cfg.Platform,
+		"--confirm",
+		"--output", "json",
+	}
+	var result asc.AppStoreVersionSubmissionCreateResult
+	if err := r.runJSON(ctx, args, &result); err != nil {
+		return "", err
+	}
+	r.state.SubmissionID = result.SubmissionID
+	return fmt.Sprintf("submission %s", result.SubmissionID), nil
+}
+
+func (r *releaseRunner) waitForReview(ctx context.Context) (string, error) {
+	versionID, err := r.versionID(ctx)
+	if err != nil {
+		return "", err
+	}
+	client, err := r.ascClient()
+	if err != nil {
+		return "", err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, r.cfg.WaitForReview.timeout)
+	defer cancel()
+
+	lastState := ""
+	state, err := asc.PollUntil(waitCtx, r.cfg.WaitForReview.pollInterval, func(ctx context.Context) (string, bool, error) {
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		defer cancel()
+
+		resp, err := client.GetAppStoreVersion(requestCtx, versionID)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to fetch app store version: %w", err)
+		}
+		state := shared.ResolveAppStoreVersionState(resp.Data.Attributes)
+		if state != lastState {
+			fmt.Fprintf(os.Stderr, "release: version %s is %s\n", r.cfg.Version, state)
+			lastState = state
+		}
+
+		done, err := classifyReviewState(state)
+		return state, done, err
+	})
+	if err != nil {
+		if waitCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+			return "", fmt.Errorf("timed out after %s waiting for review (last state %s)", r.cfg.WaitForReview.timeout, lastState)
+		}
+		return "", err
+	}
+	return state, nil
+}
+
+// classifyReviewState reports whether review finished. Rejections are
+// returned as errors so the run stops before phased release and notify.
+func classifyReviewState(state string) (bool, error) {
+	switch state {
+	case "PENDING_DEVELOPER_RELEASE", "PENDING_APPLE_RELEASE", "PROCESSING_FOR_DISTRIBUTION",
+		"READY_FOR_DISTRIBUTION", "READY_FOR_SALE", "ACCEPTED":
+		return true, nil
+	case "REJECTED", "METADATA_REJECTED", "INVALID_BINARY", "DEVELOPER_REJECTED",
+		"DEVELOPER_REMOVED_FROM_SALE", "REMOVED_FROM_SALE", "REPLACED_WITH_NEW_VERSION":
+		return false, fmt.Errorf("review ended in state %s", state)
+	default:
+		return false, nil
+	}
+}
+
+func (r *releaseRunner) phasedRelease(ctx context.Context) (string, error) {
+	versionID, err := r.versionID(ctx)
+	if err != nil {
+		return "", err
+	}
+	args := []string{
+		"versions", "phased-release", "create",
+		"--version-id", versionID,
+		"--output", "json",
+	}
+	if err := r.runJSON(ctx, args, nil); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+func (r *releaseRunner) notify(ctx context.Context) (string, error) {
+	slack := r.cfg.Notify.Slack
+	args := []string{"notify", "slack", "--message", slack.Message}
+	if strings.TrimSpace(slack.Webhook) != "" {
+		args = append(args, "--webhook", strings.TrimSpace(slack.Webhook))
+	}
+	if strings.TrimSpace(slack.Channel) != "" {
+		args = append(args, "--channel", strings.TrimSpace(slack.Channel))
+	}
+	if err := r.runJSON(ctx, args, nil); err != nil {
+		return "", err
+	}
+	return "", nil
+}
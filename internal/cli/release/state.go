@@ -0,0 +1,123 @@
+package release
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	stageStatusPending   = "pending"
+	stageStatusRunning   = "running"
+	stageStatusCompleted = "completed"
+	stageStatusFailed    = "failed"
+)
+
+// releaseState is the checkpoint persisted after every stage transition.
+type releaseState struct {
+	App          string              `json:"app"`
+	Version      string              `json:"version"`
+	Platform     string              `json:"platform"`
+	BuildID      string              `json:"buildId,omitempty"`
+	VersionID    string              `json:"versionId,omitempty"`
+	SubmissionID string              `json:"submissionId,omitempty"`
+	Stages       []releaseStageState `json:"stages"`
+	UpdatedAt    string              `json:"updatedAt,omitempty"`
+}
+
+type releaseStageState struct {
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	StartedAt   string `json:"startedAt,omitempty"`
+	CompletedAt string `json:"completedAt,omitempty"`
+	Detail      string `json:"detail,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// defaultReleaseStatePath stores the checkpoint next to the config file,
+// e.g. release.yaml -> release.state.json.
+func defaultReleaseStatePath(configPath string) string {
+	return strings.TrimSuffix(configPath, filepath.Ext(configPath)) + ".state.json"
+}
+
+// loadReleaseState returns the checkpoint for cfg, creating a fresh one when
+// none exists or restart is set. A checkpoint for another app, version, or
+// platform is rejected so unrelated runs never resume each other.
+func loadReleaseState(path string, cfg *ReleaseConfig, restart bool) (*releaseState, error) {
+	fresh := &releaseState{App: cfg.App, Version: cfg.Version, Platform: cfg.Platform}
+	if restart {
+		fresh.syncStages(releaseStages(cfg))
+		return fresh, nil
+	}
+
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			fresh.syncStages(releaseStages(cfg))
+			return fresh, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	var state releaseState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("invalid state file %s: %w", path, err)
+	}
+	if state.App != cfg.App || state.Version != cfg.Version || state.Platform != cfg.Platform {
+		return nil, fmt.Errorf("state file %s belongs to app %s version %s (%s); use --restart to discard it", path, state.App, state.Version, state.Platform)
+	}
+	state.syncStages(releaseStages(cfg))
+	return &state, nil
+}
+
+// syncStages aligns the checkpoint with the configured stages, keeping
+// progress for stages that are still configured.
+func (s *releaseState) syncStages(stages []releaseStage) {
+	existing := make(map[string]releaseStageState, len(s.Stages))
+	for _, stage := range s.Stages {
+		existing[stage.Name] = stage
+	}
+
+	synced := make([]releaseStageState, 0, len(stages))
+	for _, stage := range stages {
+		entry, ok := existing[stage.name]
+		if !ok {
+			entry = releaseStageState{Name: stage.name, Status: stageStatusPending}
+		}
+		synced = append(synced, entry)
+	}
+	s.Stages = synced
+}
+
+func (s *releaseState) stage(name string) *releaseStageState {
+	for idx := range s.Stages {
+		if s.Stages[idx].Name == name {
+			return &s.Stages[idx]
+		}
+	}
+	return nil
+}
+
+func saveReleaseState(path string, state *releaseState, now time.Time) error {
+	state.UpdatedAt = now.UTC().Format(time.RFC3339)
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	_, err = shared.WriteFileNoSymlinkOverwrite(path, bytes.NewReader(data), 0o600, ".asc-release-state-*", ".asc-release-state-backup-*")
+	return err
+}
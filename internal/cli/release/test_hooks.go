@@ -0,0 +1,36 @@
+package release
+
+import (
+	"context"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// SetClientFactory replaces the ASC client factory for tests.
+// It returns a restore function to reset the previous handler.
+func SetClientFactory(fn func() (*asc.Client, error)) func() {
+	previous := clientFactory
+	if fn == nil {
+		clientFactory = shared.GetASCClient
+	} else {
+		clientFactory = fn
+	}
+	return func() {
+		clientFactory = previous
+	}
+}
+
+// SetStageRunner replaces the asc subcommand runner used by release stages.
+// It returns a restore function to reset the previous runner.
+func SetStageRunner(fn func(ctx context.Context, args []string) ([]byte, error)) func() {
+	previous := runStage
+	if fn == nil {
+		runStage = runASCSubcommand
+	} else {
+		runStage = fn
+	}
+	return func() {
+		runStage = previous
+	}
+}
@@ -47,7 +47,9 @@ Examples:
   asc reviews ratings --app "123456789"
   asc reviews ratings --app "123456789" --all
   asc reviews summarizations --app "123456789" --platform IOS --territory US
+  asc reviews analyze --app "123456789" --since "2026-01-01" --output markdown
   asc reviews respond --review-id "REVIEW_ID" --response "Thanks!"
+  asc reviews triage --app "123456789" --rules "./rules.yaml"
   asc reviews response get --id "RESPONSE_ID"
   asc reviews response delete --id "RESPONSE_ID" --confirm
   asc reviews response for-review --review-id "REVIEW_ID"`,
@@ -58,7 +60,9 @@ Examples:
 			ReviewsGetCommand(),
 			ReviewsRatingsCommand(),
 			ReviewsSummarizationsCommand(),
+			ReviewsAnalyzeCommand(),
 			ReviewsRespondCommand(),
+			ReviewsTriageCommand(),
 			ReviewsResponseCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
@@ -0,0 +1,581 @@
+package reviews
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const reviewsAnalysisUnknownVersion = "unknown"
+
+// reviewsAnalysisVersionAttribution describes how reviews are grouped by
+// version. Reviews carry no version, so the grouping is approximate.
+const reviewsAnalysisVersionAttribution = "approximate: each review is attributed to the newest shipped version released before it (scheduled release date, else the date its App Store review submission was sent)"
+
+// reviewsAnalysisShippedStates are version states that were live on the App
+// Store at some point.
+var reviewsAnalysisShippedStates = map[string]bool{
+	"READY_FOR_SALE":              true,
+	"READY_FOR_DISTRIBUTION":      true,
+	"REPLACED_WITH_NEW_VERSION":   true,
+	"REMOVED_FROM_SALE":           true,
+	"DEVELOPER_REMOVED_FROM_SALE": true,
+}
+
+// reviewsAnalysisBuckets groups star ratings into sentiment buckets.
+var reviewsAnalysisBuckets = []struct {
+	name  string
+	stars []int
+}{
+	{"negative", []int{1, 2}},
+	{"mixed", []int{3}},
+	{"positive", []int{4, 5}},
+}
+
+// ReviewsAnalysisResult is the report produced by reviews analyze.
+type ReviewsAnalysisResult struct {
+	AppID              string                         `json:"appId"`
+	Platform           string                         `json:"platform"`
+	Since              string                         `json:"since,omitempty"`
+	Reviews            int                            `json:"reviews"`
+	AverageRating      float64                        `json:"averageRating"`
+	Sentiment          ReviewsAnalysisSentiment       `json:"sentiment"`
+	VersionAttribution string                         `json:"versionAttribution"`
+	Versions           []ReviewsAnalysisGroup         `json:"versions"`
+	Territories        []ReviewsAnalysisGroup         `json:"territories"`
+	Groups             []ReviewsAnalysisGroup         `json:"groups"`
+	Keywords           []ReviewsAnalysisKeywords      `json:"keywords"`
+	Summaries          []ReviewsAnalysisSummarization `json:"summarizations"`
+}
+
+// ReviewsAnalysisSentiment counts reviews per sentiment bucket.
+type ReviewsAnalysisSentiment struct {
+	Negative int `json:"negative"`
+	Mixed    int `json:"mixed"`
+	Positive int `json:"positive"`
+}
+
+// ReviewsAnalysisGroup aggregates reviews for a version, a territory, or a
+// version and territory pair.
+type ReviewsAnalysisGroup struct {
+	Version       string                   `json:"version,omitempty"`
+	Territory     string                   `json:"territory,omitempty"`
+	Reviews       int                      `json:"reviews"`
+	AverageRating float64                  `json:"averageRating"`
+	Ratings       map[string]int           `json:"ratings"`
+	Sentiment     ReviewsAnalysisSentiment `json:"sentiment"`
+}
+
+// ReviewsAnalysisKeywords holds the top terms for one sentiment bucket.
+type ReviewsAnalysisKeywords struct {
+	Bucket   string                `json:"bucket"`
+	Stars    []int                 `json:"stars"`
+	Reviews  int                   `json:"reviews"`
+	Keywords []ReviewsAnalysisTerm `json:"keywords"`
+	Bigrams  []ReviewsAnalysisTerm `json:"bigrams"`
+}
+
+// ReviewsAnalysisSummarization is an App Store review summarization.
+type ReviewsAnalysisSummarization struct {
+	Platform    string `json:"platform,omitempty"`
+	Territory   string `json:"territory,omitempty"`
+	Locale      string `json:"locale,omitempty"`
+	CreatedDate string `json:"createdDate,omitempty"`
+	Text        string `json:"text"`
+}
+
+// reviewsAnalysisVersion is a shipped version and an estimate of when it was
+// released, used to attribute reviews to the version that was live when they
+// were written.
+type reviewsAnalysisVersion struct {
+	version  string
+	released time.Time
+}
+
+// ReviewsAnalyzeCommand returns the reviews analyze subcommand.
+func ReviewsAnalyzeCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	since := fs.String("since", "", "Only analyze reviews created on or after this date (YYYY-MM-DD)")
+	platform := fs.String("platform", "IOS", "Platform for versions and summarizations: "+strings.Join(reviewSummarizationPlatformList(), ", "))
+	top := fs.Int("top", 10, "Keywords and bigrams to report per sentiment bucket (1-100)")
+	summaries := fs.Bool("summarizations", true, "Include App Store review summarizations")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "analyze",
+		ShortUsage: "asc reviews analyze --app \"APP_ID\" [--since YYYY-MM-DD] [flags]",
+		ShortHelp:  "Aggregate reviews by version and territory with top keywords.",
+		LongHelp: `Aggregate reviews by version and territory with top keywords.
+
+Paginates every customer review (or those since --since) and reports rating
+averages, star histograms, and sentiment per app version, per territory, and
+per version and territory pair. Reviews are bucketed as negative (1-2 stars),
+mixed (3), and positive (4-5); each bucket lists the keywords and bigrams
+mentioned by the most reviews, after dropping built-in stopwords for the
+review language (en, de, fr, es, it, pt, nl). App Store review
+summarizations are included unless --summarizations=false.
+
+Reviews carry no version, so the version grouping is approximate: each review
+is attributed to the newest shipped version for --platform released before
+the review. A version counts as released on its scheduled release date, or
+otherwise when its last completed App Store review submission was sent,
+which is usually a day or two before it shipped. Versions with neither date
+are skipped, and reviews older than every dated version are reported as
+"unknown".
+
+Use --output markdown for a shareable report.
+
+Examples:
+  asc reviews analyze --app "123456789"
+  asc reviews analyze --app "123456789" --since "2026-01-01" --output markdown
+  asc reviews analyze --app "123456789" --platform MAC_OS --top 20 --summarizations=false`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
+				return flag.ErrHelp
+			}
+			if *top < 1 || *top > 100 {
+				return shared.UsageError("--top must be between 1 and 100")
+			}
+			var sinceTime time.Time
+			sinceValue := strings.TrimSpace(*since)
+			if sinceValue != "" {
+				normalized, err := shared.NormalizeDate(sinceValue, "--since")
+				if err != nil {
+					return shared.UsageError(err.Error())
+				}
+				sinceValue = normalized
+				sinceTime, _ = time.Parse("2006-01-02", normalized)
+			}
+			platforms, err := shared.NormalizeAppStoreVersionPlatforms(shared.SplitCSVUpper(*platform))
+			if err != nil || len(platforms) != 1 {
+				return shared.UsageErrorf("--platform must be one of: %s", strings.Join(reviewSummarizationPlatformList(), ", "))
+			}
+			platformValue := platforms[0]
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("reviews analyze: %w", err)
+			}
+
+			reviews, err := shared.FetchReviewsNewestFirst(ctx, client, resolvedAppID, func(created time.Time) bool {
+				return !sinceTime.IsZero() && created.Before(sinceTime)
+			})
+			if err != nil {
+				return fmt.Errorf("reviews analyze: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			versions, err := fetchReviewsAnalysisVersions(requestCtx, client, resolvedAppID, platformValue)
+			if err != nil {
+				return fmt.Errorf("reviews analyze: %w", err)
+			}
+
+			result := analyzeReviews(reviews, versions, *top)
+			result.AppID = resolvedAppID
+			result.Platform = platformValue
+			result.Since = sinceValue
+
+			if *summaries {
+				result.Summaries, err = fetchReviewsAnalysisSummarizations(requestCtx, client, resolvedAppID, platformValue)
+				if err != nil {
+					return fmt.Errorf("reviews analyze: %w", err)
+				}
+			}
+
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { renderReviewsAnalysis(result, false); return nil },
+				func() error { renderReviewsAnalysis(result, true); return nil },
+			)
+		},
+	}
+}
+
+func fetchReviewsAnalysisVersions(ctx context.Context, client *asc.Client, appID, platform string) ([]reviewsAnalysisVersion, error) {
+	firstPage, err := client.GetAppStoreVersions(ctx, appID,
+		asc.WithAppStoreVersionsPlatforms([]string{platform}),
+		asc.WithAppStoreVersionsLimit(200),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch app store versions: %w", err)
+	}
+	paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetAppStoreVersions(ctx, appID, asc.WithAppStoreVersionsNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch app store versions: %w", err)
+	}
+	typed, ok := paginated.(*asc.AppStoreVersionsResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected pagination response type")
+	}
+
+	submitted, err := fetchReviewsAnalysisSubmittedDates(ctx, client, appID, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]reviewsAnalysisVersion, 0, len(typed.Data))
+	for _, version := range typed.Data {
+		attrs := version.Attributes
+		if !reviewsAnalysisShippedStates[attrs.AppStoreState] && !reviewsAnalysisShippedStates[attrs.AppVersionState] {
+			continue
+		}
+		if strings.TrimSpace(attrs.VersionString) == "" {
+			continue
+		}
+		released, ok := submitted[version.ID]
+		if scheduled, err := time.Parse(time.RFC3339, attrs.EarliestReleaseDate); err == nil && (!ok || scheduled.After(released)) {
+			released, ok = scheduled, true
+		}
+		if !ok {
+			continue
+		}
+		versions = append(versions, reviewsAnalysisVersion{version: attrs.VersionString, released: released})
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].released.Before(versions[j].released) })
+	return versions, nil
+}
+
+// fetchReviewsAnalysisSubmittedDates returns, per app store version ID, when
+// its last completed review submission was sent. App Store versions carry no
+// release date unless the release was scheduled, and approval usually follows
+// submission within a day or two.
+func fetchReviewsAnalysisSubmittedDates(ctx context.Context, client *asc.Client, appID, platform string) (map[string]time.Time, error) {
+	firstPage, err := client.GetReviewSubmissions(ctx, appID,
+		asc.WithReviewSubmissionsPlatforms([]string{platform}),
+		asc.WithReviewSubmissionsStates([]string{string(asc.ReviewSubmissionStateComplete)}),
+		asc.WithReviewSubmissionsInclude([]string{"appStoreVersionForReview"}),
+		asc.WithReviewSubmissionsLimit(200),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch review submissions: %w", err)
+	}
+	paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetReviewSubmissions(ctx, appID, asc.WithReviewSubmissionsNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch review submissions: %w", err)
+	}
+	typed, ok := paginated.(*asc.ReviewSubmissionsResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected pagination response type")
+	}
+
+	submitted := make(map[string]time.Time)
+	for _, submission := range typed.Data {
+		if submission.Relationships == nil || submission.Relationships.AppStoreVersionForReview == nil {
+			continue
+		}
+		versionID := submission.Relationships.AppStoreVersionForReview.Data.ID
+		date, err := time.Parse(time.RFC3339, submission.Attributes.SubmittedDate)
+		if versionID == "" || err != nil {
+			continue
+		}
+		if date.After(submitted[versionID]) {
+			submitted[versionID] = date
+		}
+	}
+	return submitted, nil
+}
+
+func fetchReviewsAnalysisSummarizations(ctx context.Context, client *asc.Client, appID, platform string) ([]ReviewsAnalysisSummarization, error) {
+	firstPage, err := client.GetCustomerReviewSummarizations(ctx, appID,
+		asc.WithCustomerReviewSummarizationsPlatforms([]string{platform}),
+		asc.WithCustomerReviewSummarizationsLimit(200),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch review summarizations: %w", err)
+	}
+	paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetCustomerReviewSummarizations(ctx, appID, asc.WithCustomerReviewSummarizationsNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch review summarizations: %w", err)
+	}
+	typed, ok := paginated.(*asc.CustomerReviewSummarizationsResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected pagination response type")
+	}
+
+	summaries := make([]ReviewsAnalysisSummarization, 0, len(typed.Data))
+	for _, item := range typed.Data {
+		summary := ReviewsAnalysisSummarization{
+			Platform:    string(item.Attributes.Platform),
+			Locale:      item.Attributes.Locale,
+			CreatedDate: item.Attributes.CreatedDate,
+			Text:        strings.TrimSpace(item.Attributes.Text),
+		}
+		if item.Relationships != nil && item.Relationships.Territory != nil {
+			summary.Territory = item.Relationships.Territory.Data.ID
+		}
+		if summary.Text != "" {
+			summaries = append(summaries, summary)
+		}
+	}
+	return summaries, nil
+}
+
+// reviewVersion returns the newest version released at or before the review.
+func reviewVersion(versions []reviewsAnalysisVersion, createdDate string) string {
+	created, err := time.Parse(time.RFC3339, createdDate)
+	if err != nil {
+		return reviewsAnalysisUnknownVersion
+	}
+	index := sort.Search(len(versions), func(i int) bool { return versions[i].released.After(created) })
+	if index == 0 {
+		return reviewsAnalysisUnknownVersion
+	}
+	return versions[index-1].version
+}
+
+type reviewsAnalysisAccumulator struct {
+	group ReviewsAnalysisGroup
+	sum   int
+}
+
+func (a *reviewsAnalysisAccumulator) add(rating int) {
+	a.group.Reviews++
+	a.sum += rating
+	if rating >= 1 && rating <= 5 {
+		a.group.Ratings[strconv.Itoa(rating)]++
+	}
+	addReviewsAnalysisSentiment(&a.group.Sentiment, rating)
+}
+
+func (a *reviewsAnalysisAccumulator) finish() ReviewsAnalysisGroup {
+	a.group.AverageRating = reviewsAnalysisAverage(a.sum, a.group.Reviews)
+	return a.group
+}
+
+func addReviewsAnalysisSentiment(sentiment *ReviewsAnalysisSentiment, rating int) {
+	switch {
+	case rating <= 2:
+		sentiment.Negative++
+	case rating == 3:
+		sentiment.Mixed++
+	default:
+		sentiment.Positive++
+	}
+}
+
+func reviewsAnalysisAverage(sum, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return math.Round(float64(sum)/float64(count)*100) / 100
+}
+
+func analyzeReviews(reviews []asc.Resource[asc.ReviewAttributes], versions []reviewsAnalysisVersion, top int) *ReviewsAnalysisResult {
+	result := &ReviewsAnalysisResult{Reviews: len(reviews), VersionAttribution: reviewsAnalysisVersionAttribution}
+
+	byVersion := map[string]*reviewsAnalysisAccumulator{}
+	byTerritory := map[string]*reviewsAnalysisAccumulator{}
+	byGroup := map[[2]string]*reviewsAnalysisAccumulator{}
+	accumulator := func(version, territory string) *reviewsAnalysisAccumulator {
+		return &reviewsAnalysisAccumulator{group: ReviewsAnalysisGroup{Version: version, Territory: territory, Ratings: map[string]int{}}}
+	}
+
+	bucketReviews := make([]int, len(reviewsAnalysisBuckets))
+	bucketKeywords := make([]reviewsTermCounter, len(reviewsAnalysisBuckets))
+	bucketBigrams := make([]reviewsTermCounter, len(reviewsAnalysisBuckets))
+	for i := range reviewsAnalysisBuckets {
+		bucketKeywords[i] = reviewsTermCounter{}
+		bucketBigrams[i] = reviewsTermCounter{}
+	}
+
+	sum := 0
+	for _, review := range reviews {
+		attrs := review.Attributes
+		sum += attrs.Rating
+		addReviewsAnalysisSentiment(&result.Sentiment, attrs.Rating)
+
+		version := reviewVersion(versions, attrs.CreatedDate)
+		territory := strings.ToUpper(strings.TrimSpace(attrs.Territory))
+		if byVersion[version] == nil {
+			byVersion[version] = accumulator(version, "")
+		}
+		if byTerritory[territory] == nil {
+			byTerritory[territory] = accumulator("", territory)
+		}
+		key := [2]string{version, territory}
+		if byGroup[key] == nil {
+			byGroup[key] = accumulator(version, territory)
+		}
+		byVersion[version].add(attrs.Rating)
+		byTerritory[territory].add(attrs.Rating)
+		byGroup[key].add(attrs.Rating)
+
+		keywords, bigrams := reviewTerms(detectReviewLanguage(attrs), attrs.Title+"\n"+attrs.Body)
+		for i, bucket := range reviewsAnalysisBuckets {
+			if containsReviewsTriageStars(bucket.stars, attrs.Rating) {
+				bucketReviews[i]++
+				bucketKeywords[i].add(keywords)
+				bucketBigrams[i].add(bigrams)
+			}
+		}
+	}
+	result.AverageRating = reviewsAnalysisAverage(sum, len(reviews))
+
+	versionOrder := map[string]int{}
+	for i, version := range versions {
+		versionOrder[version.version] = i
+	}
+	versionRank := func(version string) int {
+		if rank, ok := versionOrder[version]; ok {
+			return rank
+		}
+		return -1
+	}
+
+	for _, acc := range byVersion {
+		result.Versions = append(result.Versions, acc.finish())
+	}
+	sort.Slice(result.Versions, func(i, j int) bool {
+		return versionRank(result.Versions[i].Version) > versionRank(result.Versions[j].Version)
+	})
+
+	for _, acc := range byTerritory {
+		result.Territories = append(result.Territories, acc.finish())
+	}
+	sortReviewsAnalysisByVolume(result.Territories)
+
+	for _, acc := range byGroup {
+		result.Groups = append(result.Groups, acc.finish())
+	}
+	sort.Slice(result.Groups, func(i, j int) bool {
+		left, right := result.Groups[i], result.Groups[j]
+		if left.Version != right.Version {
+			return versionRank(left.Version) > versionRank(right.Version)
+		}
+		if left.Reviews != right.Reviews {
+			return left.Reviews > right.Reviews
+		}
+		return left.Territory < right.Territory
+	})
+
+	for i, bucket := range reviewsAnalysisBuckets {
+		result.Keywords = append(result.Keywords, ReviewsAnalysisKeywords{
+			Bucket:   bucket.name,
+			Stars:    bucket.stars,
+			Reviews:  bucketReviews[i],
+			Keywords: bucketKeywords[i].top(top, 1),
+			Bigrams:  bucketBigrams[i].top(top, 2),
+		})
+	}
+	return result
+}
+
+func sortReviewsAnalysisByVolume(groups []ReviewsAnalysisGroup) {
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Reviews != groups[j].Reviews {
+			return groups[i].Reviews > groups[j].Reviews
+		}
+		return groups[i].Territory < groups[j].Territory
+	})
+}
+
+func renderReviewsAnalysis(result *ReviewsAnalysisResult, markdown bool) {
+	shared.RenderSection("Overview",
+		[]string{"App", "Platform", "Since", "Reviews", "Average", "Negative", "Mixed", "Positive"},
+		[][]string{{
+			result.AppID,
+			result.Platform,
+			shared.OrNA(result.Since),
+			strconv.Itoa(result.Reviews),
+			formatReviewsAnalysisAverage(result.AverageRating),
+			strconv.Itoa(result.Sentiment.Negative),
+			strconv.Itoa(result.Sentiment.Mixed),
+			strconv.Itoa(result.Sentiment.Positive),
+		}},
+		markdown,
+	)
+
+	groupHeaders := []string{"Reviews", "Average", "1-star", "2-star", "3-star", "4-star", "5-star"}
+	groupRow := func(group ReviewsAnalysisGroup, labels ...string) []string {
+		row := append([]string{}, labels...)
+		row = append(row, strconv.Itoa(group.Reviews), formatReviewsAnalysisAverage(group.AverageRating))
+		for stars := 1; stars <= 5; stars++ {
+			row = append(row, strconv.Itoa(group.Ratings[strconv.Itoa(stars)]))
+		}
+		return row
+	}
+
+	versionRows := make([][]string, 0, len(result.Versions))
+	for _, group := range result.Versions {
+		versionRows = append(versionRows, groupRow(group, group.Version))
+	}
+	shared.RenderSection("By Version (approximate)", append([]string{"Version"}, groupHeaders...), versionRows, markdown)
+
+	territoryRows := make([][]string, 0, len(result.Territories))
+	for _, group := range result.Territories {
+		territoryRows = append(territoryRows, groupRow(group, group.Territory))
+	}
+	shared.RenderSection("By Territory", append([]string{"Territory"}, groupHeaders...), territoryRows, markdown)
+
+	groupRows := make([][]string, 0, len(result.Groups))
+	for _, group := range result.Groups {
+		groupRows = append(groupRows, groupRow(group, group.Version, group.Territory))
+	}
+	shared.RenderSection("By Version and Territory", append([]string{"Version", "Territory"}, groupHeaders...), groupRows, markdown)
+
+	keywordRows := make([][]string, 0, len(result.Keywords))
+	for _, bucket := range result.Keywords {
+		keywordRows = append(keywordRows, []string{
+			bucket.Bucket,
+			strconv.Itoa(bucket.Reviews),
+			formatReviewsAnalysisTerms(bucket.Keywords),
+			formatReviewsAnalysisTerms(bucket.Bigrams),
+		})
+	}
+	shared.RenderSection("Top Keywords", []string{"Bucket", "Reviews", "Keywords", "Bigrams"}, keywordRows, markdown)
+
+	if len(result.Summaries) > 0 {
+		summaryRows := make([][]string, 0, len(result.Summaries))
+		for _, summary := range result.Summaries {
+			summaryRows = append(summaryRows, []string{
+				shared.OrNA(summary.Territory),
+				shared.OrNA(summary.Locale),
+				shared.OrNA(summary.CreatedDate),
+				strings.Join(strings.Fields(summary.Text), " "),
+			})
+		}
+		shared.RenderSection("Review Summarizations", []string{"Territory", "Locale", "Created", "Summary"}, summaryRows, markdown)
+	}
+}
+
+func formatReviewsAnalysisAverage(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+func formatReviewsAnalysisTerms(terms []ReviewsAnalysisTerm) string {
+	if len(terms) == 0 {
+		return "n/a"
+	}
+	parts := make([]string, 0, len(terms))
+	for _, term := range terms {
+		parts = append(parts, fmt.Sprintf("%s (%d)", term.Term, term.Reviews))
+	}
+	return strings.Join(parts, ", ")
+}
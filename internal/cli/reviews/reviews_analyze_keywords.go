@@ -0,0 +1,128 @@
+package reviews
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ReviewsAnalysisTerm is a keyword or bigram with the number of reviews that
+// mention it.
+type ReviewsAnalysisTerm struct {
+	Term    string `json:"term"`
+	Reviews int    `json:"reviews"`
+}
+
+// reviewsStopwords lists words that carry no complaint signal per language.
+// Languages without a list only drop short tokens and digits.
+var reviewsStopwords = map[string]map[string]bool{
+	"en": reviewsStopwordSet(`a about after again all also always am an and any app apps are as at be because been before being
+		but by can cannot could did do does doesn don done even every for from get gets getting got had has have having he her
+		here him his how i if in into is it its it's i'm i've just know like make many me more most much my need new no not now
+		of off on once one only or other our out over please really same see she should since so some still such than that the
+		their them then there these they this those through too up us use used using very want was way we well were what when
+		where which while who why will with would yet you your ve ll re isn didn won wasn aren can't won't don't doesn't didn't`),
+	"de": reviewsStopwordSet(`aber alle als also am an auch auf aus bei bin bis bitte da damit dann das dass dem den der des die
+		dies diese doch du durch ein eine einem einen einer es für gibt hat hatte habe haben ich ihr im immer in ist ja jetzt
+		kann kein keine man mehr mein meine mit muss nach nicht noch nun nur ob oder schon sehr sein sich sie sind so um und
+		uns von vor war was weil wenn wie wieder wir wird zu zum zur app`),
+	"fr": reviewsStopwordSet(`au aux avec ce ces cette dans de des du elle en est et être eux il ils je la le les leur lui ma
+		mais me même mes moi mon ne nos notre nous on ou où par pas pour plus qu que qui sa se ses son sur ta te tes toi ton
+		tous tout très tu un une vos votre vous ça c'est j'ai application appli`),
+	"es": reviewsStopwordSet(`a al algo como con de del el ella ellos en es esta este esto fue ha hay la las le les lo los me mi
+		muy más no nos o para pero por porque que se sea ser si sin sobre su sus también te tiene todo un una uno y ya yo aplicación app`),
+	"it": reviewsStopwordSet(`a ai al alla anche che chi ci come con da dal dei del della di e è gli ha ho i il in io la le lo
+		ma mi molto ne nel non o per più questo se si sono su tra tu un una uno app applicazione`),
+	"pt": reviewsStopwordSet(`a ao aos as com como da das de do dos e é ela ele em era essa esse está eu foi isso já mais mas me
+		meu minha muito na não nas no nos o os ou para pela pelo por que se sem ser seu sua também tem um uma você app aplicativo`),
+	"nl": reviewsStopwordSet(`aan al als bij dan dat de die dit door een en er geen had heb heeft het hij hoe ik in is je kan
+		maar me met mijn na naar niet nog nu of om ook op te tot uit van voor was wat we wel wij zijn zo zou app`),
+}
+
+func reviewsStopwordSet(words string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
+
+func isReviewsStopword(language, token string) bool {
+	if reviewsStopwords[language][token] {
+		return true
+	}
+	// English is mixed into reviews from every storefront.
+	return language != "en" && reviewsStopwords["en"][token]
+}
+
+// tokenizeReview lowercases text and splits it into word tokens, keeping
+// apostrophes inside words. Tokens of digits only are dropped.
+func tokenizeReview(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '’'
+	})
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.Trim(strings.ReplaceAll(field, "’", "'"), "'")
+		if field == "" || strings.IndexFunc(field, unicode.IsLetter) < 0 {
+			continue
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
+
+// reviewTerms returns the distinct keywords and bigrams in a review. A bigram
+// is two adjacent keywords in the same sentence with no stopword between them.
+func reviewTerms(language, text string) (map[string]bool, map[string]bool) {
+	keywords := map[string]bool{}
+	bigrams := map[string]bool{}
+	sentences := strings.FieldsFunc(text, func(r rune) bool {
+		return strings.ContainsRune(".!?;:\n。！？", r)
+	})
+	for _, sentence := range sentences {
+		previous := ""
+		for _, token := range tokenizeReview(sentence) {
+			if len([]rune(token)) < 3 || isReviewsStopword(language, token) {
+				previous = ""
+				continue
+			}
+			keywords[token] = true
+			if previous != "" {
+				bigrams[previous+" "+token] = true
+			}
+			previous = token
+		}
+	}
+	return keywords, bigrams
+}
+
+// reviewsTermCounter counts in how many reviews each term appears.
+type reviewsTermCounter map[string]int
+
+func (c reviewsTermCounter) add(terms map[string]bool) {
+	for term := range terms {
+		c[term]++
+	}
+}
+
+// top returns the most frequent terms, most reviews first and alphabetically
+// on ties. Terms seen in fewer than minReviews reviews are left out.
+func (c reviewsTermCounter) top(limit, minReviews int) []ReviewsAnalysisTerm {
+	terms := make([]ReviewsAnalysisTerm, 0, len(c))
+	for term, count := range c {
+		if count >= minReviews {
+			terms = append(terms, ReviewsAnalysisTerm{Term: term, Reviews: count})
+		}
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Reviews != terms[j].Reviews {
+			return terms[i].Reviews > terms[j].Reviews
+		}
+		return terms[i].Term < terms[j].Term
+	})
+	if len(terms) > limit {
+		terms = terms[:limit]
+	}
+	return terms
+}
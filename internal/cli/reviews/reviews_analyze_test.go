@@ -0,0 +1,120 @@
+package reviews
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func sortedTerms(terms map[string]bool) []string {
+	values := make([]string, 0, len(terms))
+	for term := range terms {
+		values = append(values, term)
+	}
+	sort.Strings(values)
+	return values
+}
+
+func TestReviewTerms(t *testing.T) {
+	keywords, bigrams := reviewTerms("en", "The app crashes on launch!! Crashes every time, since 2.0 — sync broken.")
+	if got := sortedTerms(keywords); !reflect.DeepEqual(got, []string{"broken", "crashes", "launch", "sync", "time"}) {
+		t.Fatalf("unexpected keywords %v", got)
+	}
+	if got := sortedTerms(bigrams); !reflect.DeepEqual(got, []string{"sync broken"}) {
+		t.Fatalf("unexpected bigrams %v", got)
+	}
+
+	keywords, _ = reviewTerms("de", "Die App stürzt immer ab, nicht gut")
+	if got := sortedTerms(keywords); !reflect.DeepEqual(got, []string{"gut", "stürzt"}) {
+		t.Fatalf("unexpected German keywords %v", got)
+	}
+
+	keywords, _ = reviewTerms("en", "Don’t buy. It won't open")
+	if got := sortedTerms(keywords); !reflect.DeepEqual(got, []string{"buy", "open"}) {
+		t.Fatalf("unexpected keywords with apostrophes %v", got)
+	}
+}
+
+func TestReviewsTermCounterTop(t *testing.T) {
+	counter := reviewsTermCounter{}
+	counter.add(map[string]bool{"crash": true, "sync": true})
+	counter.add(map[string]bool{"crash": true, "login": true})
+	counter.add(map[string]bool{"crash": true, "sync": true})
+
+	got := counter.top(2, 1)
+	want := []ReviewsAnalysisTerm{{Term: "crash", Reviews: 3}, {Term: "sync", Reviews: 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("top() = %+v, want %+v", got, want)
+	}
+	if got := counter.top(10, 2); len(got) != 2 {
+		t.Fatalf("expected terms below minimum to be dropped, got %+v", got)
+	}
+}
+
+func TestReviewVersion(t *testing.T) {
+	versions := []reviewsAnalysisVersion{
+		{version: "1.0", released: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{version: "1.1", released: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	tests := map[string]string{
+		"2025-12-31T00:00:00Z": "unknown",
+		"2026-01-01T00:00:00Z": "1.0",
+		"2026-01-31T23:59:59Z": "1.0",
+		"2026-03-01T00:00:00Z": "1.1",
+		"not-a-date":           "unknown",
+	}
+	for created, want := range tests {
+		if got := reviewVersion(versions, created); got != want {
+			t.Fatalf("reviewVersion(%q) = %q, want %q", created, got, want)
+		}
+	}
+}
+
+func TestAnalyzeReviews(t *testing.T) {
+	versions := []reviewsAnalysisVersion{
+		{version: "1.0", released: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{version: "1.1", released: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	review := func(rating int, territory, created, body string) asc.Resource[asc.ReviewAttributes] {
+		return asc.Resource[asc.ReviewAttributes]{Attributes: asc.ReviewAttributes{Rating: rating, Territory: territory, CreatedDate: created, Body: body}}
+	}
+	result := analyzeReviews([]asc.Resource[asc.ReviewAttributes]{
+		review(1, "USA", "2026-02-03T00:00:00Z", "Login broken after update"),
+		review(2, "USA", "2026-02-02T00:00:00Z", "login broken again"),
+		review(5, "DEU", "2026-01-10T00:00:00Z", "Super schnell"),
+		review(3, "USA", "2026-01-05T00:00:00Z", "Okay"),
+	}, versions, 5)
+
+	if result.Reviews != 4 || result.AverageRating != 2.75 {
+		t.Fatalf("unexpected totals %+v", result)
+	}
+	if result.Sentiment != (ReviewsAnalysisSentiment{Negative: 2, Mixed: 1, Positive: 1}) {
+		t.Fatalf("unexpected sentiment %+v", result.Sentiment)
+	}
+	if len(result.Versions) != 2 || result.Versions[0].Version != "1.1" || result.Versions[0].Reviews != 2 || result.Versions[0].AverageRating != 1.5 {
+		t.Fatalf("unexpected versions %+v", result.Versions)
+	}
+	if len(result.Territories) != 2 || result.Territories[0].Territory != "USA" || result.Territories[0].Ratings["3"] != 1 {
+		t.Fatalf("unexpected territories %+v", result.Territories)
+	}
+	if len(result.Groups) != 3 || result.Groups[0].Version != "1.1" || result.Groups[0].Territory != "USA" {
+		t.Fatalf("unexpected groups %+v", result.Groups)
+	}
+
+	negative := result.Keywords[0]
+	if negative.Bucket != "negative" || negative.Reviews != 2 {
+		t.Fatalf("unexpected negative bucket %+v", negative)
+	}
+	if negative.Keywords[0] != (ReviewsAnalysisTerm{Term: "broken", Reviews: 2}) || negative.Keywords[1] != (ReviewsAnalysisTerm{Term: "login", Reviews: 2}) {
+		t.Fatalf("unexpected negative keywords %+v", negative.Keywords)
+	}
+	if !reflect.DeepEqual(negative.Bigrams, []ReviewsAnalysisTerm{{Term: "login broken", Reviews: 2}}) {
+		t.Fatalf("unexpected negative bigrams %+v", negative.Bigrams)
+	}
+	if positive := result.Keywords[2]; len(positive.Keywords) != 2 || positive.Keywords[0].Term != "schnell" {
+		t.Fatalf("unexpected positive keywords %+v", positive.Keywords)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
 
@@ -23,11 +24,12 @@ func ReviewsRatingsCommand() *ffcli.Command {
 	country := fs.String("country", "us", "Country code (e.g., us, gb, de)")
 	all := fs.Bool("all", false, "Fetch ratings from all countries")
 	workers := fs.Int("workers", 10, "Number of parallel workers for --all")
+	historyDir := fs.String("history-dir", "", "Append a dated snapshot of --all results to this directory")
 	output := shared.BindOutputFlags(fs)
 
 	return &ffcli.Command{
 		Name:       "ratings",
-		ShortUsage: "asc reviews ratings [flags]",
+		ShortUsage: "asc reviews ratings [flags] | asc reviews ratings history [flags]",
 		ShortHelp:  "Show App Store rating statistics.",
 		LongHelp: `Show App Store rating statistics using the public iTunes API.
 
@@ -36,14 +38,22 @@ histogram) that is not available through the App Store Connect API.
 
 No authentication is required.
 
+With --all and --history-dir, each run also appends a dated snapshot that
+'asc reviews ratings history' compares over time.
+
 Examples:
   asc reviews ratings --app "1479784361"
   asc reviews ratings --app "1479784361" --country de
   asc reviews ratings --app "1479784361" --output table
   asc reviews ratings --app "1479784361" --all
-  asc reviews ratings --app "1479784361" --all --workers 20`,
+  asc reviews ratings --app "1479784361" --all --workers 20
+  asc reviews ratings --app "1479784361" --all --history-dir "./ratings-history"
+  asc reviews ratings history --app "1479784361" --history-dir "./ratings-history"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			ReviewsRatingsHistoryCommand(),
+		},
 		Exec: func(ctx context.Context, args []string) error {
 			if strings.TrimSpace(*appID) == "" {
 				fmt.Fprintln(os.Stderr, "Error: --app is required")
@@ -54,13 +64,16 @@ Examples:
 				fmt.Fprintln(os.Stderr, "Error: --workers must be at least 1")
 				return flag.ErrHelp
 			}
+			if strings.TrimSpace(*historyDir) != "" && !*all {
+				return shared.UsageError("--history-dir requires --all")
+			}
 
-			return executeRatings(ctx, *appID, *country, *all, *workers, *output.Output, *output.Pretty)
+			return executeRatings(ctx, *appID, *country, *all, *workers, strings.TrimSpace(*historyDir), *output.Output, *output.Pretty)
 		},
 	}
 }
 
-func executeRatings(ctx context.Context, appID, country string, all bool, workers int, output string, pretty bool) error {
+func executeRatings(ctx context.Context, appID, country string, all bool, workers int, historyDir, output string, pretty bool) error {
 	format, err := normalizeRatingsOutput(output, pretty)
 	if err != nil {
 		return err
@@ -72,7 +85,7 @@ func executeRatings(ctx context.Context, appID, country string, all bool, worker
 	defer cancel()
 
 	if all {
-		return executeAllRatings(requestCtx, client, appID, workers, format, pretty)
+		return executeAllRatings(requestCtx, client, appID, workers, historyDir, format, pretty)
 	}
 
 	return executeSingleRatings(requestCtx, client, appID, country, format, pretty)
@@ -92,11 +105,16 @@ func executeSingleRatings(ctx context.Context, client *itunes.Client, appID, cou
 	)
 }
 
-func executeAllRatings(ctx context.Context, client *itunes.Client, appID string, workers int, output string, pretty bool) error {
+func executeAllRatings(ctx context.Context, client *itunes.Client, appID string, workers int, historyDir, output string, pretty bool) error {
 	global, err := client.GetAllRatings(ctx, appID, workers)
 	if err != nil {
 		return fmt.Errorf("reviews ratings: %w", err)
 	}
+	if historyDir != "" {
+		if _, err := writeRatingsSnapshot(historyDir, appID, time.Now(), global); err != nil {
+			return fmt.Errorf("reviews ratings: failed to write snapshot: %w", err)
+		}
+	}
 	return shared.PrintOutputWithRenderers(
 		global,
 		output,
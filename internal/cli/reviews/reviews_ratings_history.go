@@ -0,0 +1,470 @@
+package reviews
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/itunes"
+)
+
+const ratingsSnapshotTimeLayout = "2006-01-02T150405Z"
+
+// ratingsSnapshot is one dated --all run stored under --history-dir.
+type ratingsSnapshot struct {
+	CapturedAt string                `json:"capturedAt"`
+	Ratings    *itunes.GlobalRatings `json:"ratings"`
+}
+
+// RatingsHistoryResult compares rating snapshots over time.
+type RatingsHistoryResult struct {
+	AppID      string                   `json:"appId"`
+	AppName    string                   `json:"appName,omitempty"`
+	HistoryDir string                   `json:"historyDir"`
+	MinDrop    float64                  `json:"minDrop"`
+	Snapshots  []RatingsHistorySnapshot `json:"snapshots"`
+	Countries  []RatingsHistoryCountry  `json:"countries"`
+	Drops      []RatingsHistoryDrop     `json:"drops"`
+}
+
+// RatingsHistorySnapshot is the global rating at one snapshot.
+type RatingsHistorySnapshot struct {
+	CapturedAt    string  `json:"capturedAt"`
+	Version       string  `json:"version,omitempty"`
+	AverageRating float64 `json:"averageRating"`
+	TotalCount    int64   `json:"totalCount"`
+	CountryCount  int     `json:"countryCount"`
+	CountDelta    int64   `json:"countDelta"`
+	AverageDelta  float64 `json:"averageDelta"`
+}
+
+// RatingsHistoryCountry is the rating history of one storefront. Deltas
+// compare the last snapshot with the first one that included the storefront.
+type RatingsHistoryCountry struct {
+	Country       string                `json:"country"`
+	CountryName   string                `json:"countryName,omitempty"`
+	RatingCount   int64                 `json:"ratingCount"`
+	AverageRating float64               `json:"averageRating"`
+	CountDelta    int64                 `json:"countDelta"`
+	AverageDelta  float64               `json:"averageDelta"`
+	Dropped       bool                  `json:"droppedAfterRelease"`
+	Points        []RatingsHistoryPoint `json:"points"`
+}
+
+// RatingsHistoryPoint is a storefront rating at one snapshot, with deltas
+// from the storefront's previous snapshot.
+type RatingsHistoryPoint struct {
+	CapturedAt    string  `json:"capturedAt"`
+	Version       string  `json:"version,omitempty"`
+	AverageRating float64 `json:"averageRating"`
+	RatingCount   int64   `json:"ratingCount"`
+	CountDelta    int64   `json:"countDelta"`
+	AverageDelta  float64 `json:"averageDelta"`
+
+	histogram map[int]int64
+}
+
+// RatingsHistoryDrop flags a storefront whose ratings received after a
+// release average well below its average before the release. AverageAfter
+// and AverageDelta describe the lifetime average, which barely moves once a
+// storefront has many ratings.
+type RatingsHistoryDrop struct {
+	Country           string  `json:"country"`
+	CountryName       string  `json:"countryName,omitempty"`
+	FromVersion       string  `json:"fromVersion"`
+	ToVersion         string  `json:"toVersion"`
+	FirstSeenAt       string  `json:"firstSeenAt"`
+	LastSeenAt        string  `json:"lastSeenAt"`
+	AverageBefore     float64 `json:"averageBefore"`
+	AverageAfter      float64 `json:"averageAfter"`
+	AverageDelta      float64 `json:"averageDelta"`
+	NewRatings        int64   `json:"newRatings"`
+	NewRatingsAverage float64 `json:"newRatingsAverage"`
+	NewRatingsDelta   float64 `json:"newRatingsDelta"`
+}
+
+// ReviewsRatingsHistoryCommand returns the reviews ratings history subcommand.
+func ReviewsRatingsHistoryCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store app ID (required)")
+	historyDir := fs.String("history-dir", "", "Directory written by 'asc reviews ratings --all --history-dir' (required)")
+	countries := fs.String("country", "", "Filter by country code(s), comma-separated (e.g., us, gb)")
+	minDrop := fs.Float64("min-drop", 0.25, "Flag storefronts whose new ratings after a release average at least this much below the previous average")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "history",
+		ShortUsage: "asc reviews ratings history --app \"APP_ID\" --history-dir \"DIR\" [flags]",
+		ShortHelp:  "Compare stored rating snapshots per storefront over time.",
+		LongHelp: `Compare stored rating snapshots per storefront over time.
+
+Reads the snapshots appended by 'asc reviews ratings --all --history-dir' and
+reports, per storefront, the rating count and average at every snapshot with
+deltas from the previous one, and the change from the first snapshot to the
+last.
+
+A release is detected when the App Store version reported for a storefront
+changes between two snapshots. The ratings received between the last snapshot
+of the previous version and the last snapshot of the new version are averaged,
+from the histogram when both snapshots have one and from the rating counts and
+averages otherwise. The storefront is flagged when that average is at least
+--min-drop below the average before the release. The lifetime average is
+reported too, but it barely moves once a storefront has many ratings.
+
+Examples:
+  asc reviews ratings history --app "1479784361" --history-dir "./ratings-history"
+  asc reviews ratings history --app "1479784361" --history-dir "./ratings-history" --country us,gb --output table
+  asc reviews ratings history --app "1479784361" --history-dir "./ratings-history" --min-drop 0.5`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			appValue := strings.TrimSpace(*appID)
+			if appValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --app is required")
+				return flag.ErrHelp
+			}
+			dirValue := strings.TrimSpace(*historyDir)
+			if dirValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --history-dir is required")
+				return flag.ErrHelp
+			}
+			if *minDrop < 0 || math.IsNaN(*minDrop) {
+				return shared.UsageError("--min-drop must be zero or greater")
+			}
+
+			snapshots, err := readRatingsSnapshots(dirValue, appValue)
+			if err != nil {
+				return fmt.Errorf("reviews ratings history: %w", err)
+			}
+			if len(snapshots) == 0 {
+				return fmt.Errorf("reviews ratings history: no snapshots for app %s in %s; run 'asc reviews ratings --app %s --all --history-dir %s' first", appValue, dirValue, appValue, dirValue)
+			}
+
+			filter := map[string]bool{}
+			for _, country := range shared.SplitCSV(*countries) {
+				filter[strings.ToUpper(country)] = true
+			}
+
+			result := buildRatingsHistory(snapshots, filter, *minDrop)
+			result.AppID = appValue
+			result.HistoryDir = filepath.Clean(dirValue)
+
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { renderRatingsHistory(result, false); return nil },
+				func() error { renderRatingsHistory(result, true); return nil },
+			)
+		},
+	}
+}
+
+func ratingsHistoryAppDir(dir, appID string) (string, error) {
+	if appID == "." || appID == ".." || filepath.Base(appID) != appID || strings.ContainsAny(appID, `/\`) {
+		return "", fmt.Errorf("invalid app ID %q", appID)
+	}
+	return filepath.Join(dir, appID), nil
+}
+
+// writeRatingsSnapshot stores a dated snapshot and returns its path.
+func writeRatingsSnapshot(dir, appID string, capturedAt time.Time, ratings *itunes.GlobalRatings) (string, error) {
+	appDir, err := ratingsHistoryAppDir(dir, strings.TrimSpace(appID))
+	if err != nil {
+		return "", err
+	}
+	capturedAt = capturedAt.UTC()
+	data, err := json.MarshalIndent(ratingsSnapshot{CapturedAt: capturedAt.Format(time.RFC3339), Ratings: ratings}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	data = append(data, '\n')
+
+	path := filepath.Join(appDir, capturedAt.Format(ratingsSnapshotTimeLayout)+".json")
+	if _, err := shared.WriteFileNoSymlinkOverwrite(path, bytes.NewReader(data), 0o644, ".ratings-*.tmp", ".ratings-*.bak"); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// readRatingsSnapshots loads every snapshot for the app, oldest first.
+func readRatingsSnapshots(dir, appID string) ([]ratingsSnapshot, error) {
+	appDir, err := ratingsHistoryAppDir(dir, appID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(appDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	snapshots := make([]ratingsSnapshot, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(appDir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var snapshot ratingsSnapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+		}
+		if snapshot.Ratings == nil {
+			return nil, fmt.Errorf("invalid snapshot %s: missing ratings", path)
+		}
+		if _, err := time.Parse(time.RFC3339, snapshot.CapturedAt); err != nil {
+			return nil, fmt.Errorf("invalid snapshot %s: capturedAt must be RFC3339", path)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		left, _ := time.Parse(time.RFC3339, snapshots[i].CapturedAt)
+		right, _ := time.Parse(time.RFC3339, snapshots[j].CapturedAt)
+		return left.Before(right)
+	})
+	return snapshots, nil
+}
+
+func roundRatingsDelta(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
+
+func buildRatingsHistory(snapshots []ratingsSnapshot, filter map[string]bool, minDrop float64) *RatingsHistoryResult {
+	result := &RatingsHistoryResult{MinDrop: minDrop}
+
+	byCountry := map[string]*RatingsHistoryCountry{}
+	for i, snapshot := range snapshots {
+		global := snapshot.Ratings
+		if global.AppName != "" {
+			result.AppName = global.AppName
+		}
+		entry := RatingsHistorySnapshot{
+			CapturedAt:    snapshot.CapturedAt,
+			Version:       global.Version,
+			AverageRating: global.AverageRating,
+			TotalCount:    global.TotalCount,
+			CountryCount:  global.CountryCount,
+		}
+		if i > 0 {
+			previous := result.Snapshots[i-1]
+			entry.CountDelta = entry.TotalCount - previous.TotalCount
+			entry.AverageDelta = roundRatingsDelta(entry.AverageRating - previous.AverageRating)
+		}
+		result.Snapshots = append(result.Snapshots, entry)
+
+		for _, ratings := range global.ByCountry {
+			code := strings.ToUpper(ratings.Country)
+			if len(filter) > 0 && !filter[code] {
+				continue
+			}
+			country := byCountry[code]
+			if country == nil {
+				country = &RatingsHistoryCountry{Country: code}
+				byCountry[code] = country
+			}
+			if ratings.CountryName != "" {
+				country.CountryName = ratings.CountryName
+			}
+			version := ratings.Version
+			if version == "" {
+				version = global.Version
+			}
+			point := RatingsHistoryPoint{
+				CapturedAt:    snapshot.CapturedAt,
+				Version:       version,
+				AverageRating: ratings.AverageRating,
+				RatingCount:   ratings.RatingCount,
+				histogram:     ratings.Histogram,
+			}
+			if len(country.Points) > 0 {
+				previous := country.Points[len(country.Points)-1]
+				point.CountDelta = point.RatingCount - previous.RatingCount
+				point.AverageDelta = roundRatingsDelta(point.AverageRating - previous.AverageRating)
+			}
+			country.Points = append(country.Points, point)
+		}
+	}
+
+	for _, country := range byCountry {
+		first, last := country.Points[0], country.Points[len(country.Points)-1]
+		country.RatingCount = last.RatingCount
+		country.AverageRating = last.AverageRating
+		country.CountDelta = last.RatingCount - first.RatingCount
+		country.AverageDelta = roundRatingsDelta(last.AverageRating - first.AverageRating)
+
+		drops := ratingsReleaseDrops(country, minDrop)
+		country.Dropped = len(drops) > 0
+		result.Drops = append(result.Drops, drops...)
+		result.Countries = append(result.Countries, *country)
+	}
+
+	sort.Slice(result.Countries, func(i, j int) bool {
+		if result.Countries[i].RatingCount != result.Countries[j].RatingCount {
+			return result.Countries[i].RatingCount > result.Countries[j].RatingCount
+		}
+		return result.Countries[i].Country < result.Countries[j].Country
+	})
+	sort.Slice(result.Drops, func(i, j int) bool {
+		if result.Drops[i].NewRatingsDelta != result.Drops[j].NewRatingsDelta {
+			return result.Drops[i].NewRatingsDelta < result.Drops[j].NewRatingsDelta
+		}
+		return result.Drops[i].Country < result.Drops[j].Country
+	})
+	return result
+}
+
+// ratingsReleaseDrops compares the ratings received between the last
+// snapshot of each version and the last snapshot of the version that replaced
+// it with the average before the release.
+func ratingsReleaseDrops(country *RatingsHistoryCountry, minDrop float64) []RatingsHistoryDrop {
+	var drops []RatingsHistoryDrop
+	points := country.Points
+	for i := 1; i < len(points); i++ {
+		before := points[i-1]
+		if before.Version == "" || points[i].Version == "" || points[i].Version == before.Version {
+			continue
+		}
+		end := i
+		for end+1 < len(points) && points[end+1].Version == points[i].Version {
+			end++
+		}
+		after := points[end]
+		newAverage, ok := newRatingsAverage(before, after)
+		if !ok {
+			continue
+		}
+		newDelta := roundRatingsDelta(newAverage - before.AverageRating)
+		if newDelta < 0 && -newDelta >= minDrop {
+			drops = append(drops, RatingsHistoryDrop{
+				Country:           country.Country,
+				CountryName:       country.CountryName,
+				FromVersion:       before.Version,
+				ToVersion:         points[i].Version,
+				FirstSeenAt:       points[i].CapturedAt,
+				LastSeenAt:        after.CapturedAt,
+				AverageBefore:     before.AverageRating,
+				AverageAfter:      after.AverageRating,
+				AverageDelta:      roundRatingsDelta(after.AverageRating - before.AverageRating),
+				NewRatings:        after.RatingCount - before.RatingCount,
+				NewRatingsAverage: roundRatingsDelta(newAverage),
+				NewRatingsDelta:   newDelta,
+			})
+		}
+	}
+	return drops
+}
+
+// newRatingsAverage returns the average of the ratings received between two
+// snapshots. It uses the histogram delta when both snapshots have one and
+// otherwise recovers the rating sums from the averages and counts. It reports
+// false when no ratings were added or the counts went down.
+func newRatingsAverage(before, after RatingsHistoryPoint) (float64, bool) {
+	if after.RatingCount <= before.RatingCount {
+		return 0, false
+	}
+	if len(before.histogram) > 0 && len(after.histogram) > 0 {
+		var count, sum int64
+		valid := true
+		for star := 1; star <= 5; star++ {
+			delta := after.histogram[star] - before.histogram[star]
+			if delta < 0 {
+				valid = false
+				break
+			}
+			count += delta
+			sum += delta * int64(star)
+		}
+		if valid && count > 0 {
+			return float64(sum) / float64(count), true
+		}
+	}
+	count := float64(after.RatingCount - before.RatingCount)
+	sum := after.AverageRating*float64(after.RatingCount) - before.AverageRating*float64(before.RatingCount)
+	return math.Min(5, math.Max(1, sum/count)), true
+}
+
+func formatRatingsDelta(value float64) string {
+	return strconv.FormatFloat(value, 'f', 3, 64)
+}
+
+func formatRatingsCountDelta(value int64) string {
+	if value > 0 {
+		return "+" + strconv.FormatInt(value, 10)
+	}
+	return strconv.FormatInt(value, 10)
+}
+
+func renderRatingsHistory(result *RatingsHistoryResult, markdown bool) {
+	snapshotRows := make([][]string, 0, len(result.Snapshots))
+	for _, snapshot := range result.Snapshots {
+		snapshotRows = append(snapshotRows, []string{
+			snapshot.CapturedAt,
+			shared.OrNA(snapshot.Version),
+			fmt.Sprintf("%.2f", snapshot.AverageRating),
+			formatRatingsDelta(snapshot.AverageDelta),
+			formatNumber(snapshot.TotalCount),
+			formatRatingsCountDelta(snapshot.CountDelta),
+			strconv.Itoa(snapshot.CountryCount),
+		})
+	}
+	shared.RenderSection("Global", []string{"Captured", "Version", "Average", "Avg Delta", "Count", "Count Delta", "Countries"}, snapshotRows, markdown)
+
+	countryRows := make([][]string, 0, len(result.Countries))
+	for _, country := range result.Countries {
+		name := country.CountryName
+		if name == "" {
+			name = country.Country
+		}
+		countryRows = append(countryRows, []string{
+			name,
+			strconv.Itoa(len(country.Points)),
+			fmt.Sprintf("%.2f", country.AverageRating),
+			formatRatingsDelta(country.AverageDelta),
+			formatNumber(country.RatingCount),
+			formatRatingsCountDelta(country.CountDelta),
+			strconv.FormatBool(country.Dropped),
+		})
+	}
+	shared.RenderSection("By Country", []string{"Country", "Snapshots", "Average", "Avg Delta", "Count", "Count Delta", "Dropped"}, countryRows, markdown)
+
+	if len(result.Drops) > 0 {
+		dropRows := make([][]string, 0, len(result.Drops))
+		for _, drop := range result.Drops {
+			name := drop.CountryName
+			if name == "" {
+				name = drop.Country
+			}
+			dropRows = append(dropRows, []string{
+				name,
+				drop.FromVersion + " -> " + drop.ToVersion,
+				drop.FirstSeenAt,
+				fmt.Sprintf("%.2f", drop.AverageBefore),
+				formatRatingsCountDelta(drop.NewRatings),
+				fmt.Sprintf("%.2f", drop.NewRatingsAverage),
+				formatRatingsDelta(drop.NewRatingsDelta),
+				fmt.Sprintf("%.2f", drop.AverageAfter),
+			})
+		}
+		shared.RenderSection("Drops After Release", []string{"Country", "Release", "First Seen", "Before", "New Ratings", "New Avg", "New Avg Delta", "Lifetime After"}, dropRows, markdown)
+	}
+}
@@ -1,8 +1,12 @@
 package reviews
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/itunes"
 )
 
 func TestNormalizeRatingsOutput(t *testing.T) {
@@ -38,3 +42,117 @@ func TestNormalizeRatingsOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestRatingsSnapshotRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	first := &itunes.GlobalRatings{AppName: "Demo", AverageRating: 4.5, TotalCount: 100, Version: "1.0"}
+	second := &itunes.GlobalRatings{AppName: "Demo", AverageRating: 4.4, TotalCount: 120, Version: "1.1"}
+	if _, err := writeRatingsSnapshot(dir, "123", time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC), second); err != nil {
+		t.Fatalf("writeRatingsSnapshot() error: %v", err)
+	}
+	path, err := writeRatingsSnapshot(dir, "123", time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC), first)
+	if err != nil {
+		t.Fatalf("writeRatingsSnapshot() error: %v", err)
+	}
+	if filepath.Base(path) != "2026-01-01T090000Z.json" {
+		t.Fatalf("unexpected snapshot path %s", path)
+	}
+
+	snapshots, err := readRatingsSnapshots(dir, "123")
+	if err != nil {
+		t.Fatalf("readRatingsSnapshots() error: %v", err)
+	}
+	if len(snapshots) != 2 || snapshots[0].Ratings.Version != "1.0" || snapshots[1].CapturedAt != "2026-02-01T09:00:00Z" {
+		t.Fatalf("unexpected snapshots %+v", snapshots)
+	}
+
+	if snapshots, err := readRatingsSnapshots(dir, "456"); err != nil || len(snapshots) != 0 {
+		t.Fatalf("expected no snapshots for another app, got %v, %v", snapshots, err)
+	}
+	if _, err := writeRatingsSnapshot(dir, "../escape", time.Now(), first); err == nil {
+		t.Fatal("expected invalid app ID error")
+	}
+}
+
+func TestBuildRatingsHistory(t *testing.T) {
+	snapshot := func(capturedAt, version string, countries ...itunes.AppRatings) ratingsSnapshot {
+		var total int64
+		for _, country := range countries {
+			total += country.RatingCount
+		}
+		return ratingsSnapshot{CapturedAt: capturedAt, Ratings: &itunes.GlobalRatings{
+			AppName: "Demo", Version: version, TotalCount: total, CountryCount: len(countries), ByCountry: countries,
+		}}
+	}
+	snapshots := []ratingsSnapshot{
+		snapshot("2026-01-01T00:00:00Z", "1.0",
+			itunes.AppRatings{Country: "US", AverageRating: 4.6, RatingCount: 1000},
+			itunes.AppRatings{Country: "GB", AverageRating: 4.5, RatingCount: 200}),
+		snapshot("2026-01-08T00:00:00Z", "1.0",
+			itunes.AppRatings{Country: "US", AverageRating: 4.62, RatingCount: 1010},
+			itunes.AppRatings{Country: "GB", AverageRating: 4.5, RatingCount: 205}),
+		snapshot("2026-01-15T00:00:00Z", "1.1",
+			itunes.AppRatings{Country: "US", AverageRating: 4.55, RatingCount: 1050},
+			itunes.AppRatings{Country: "GB", AverageRating: 4.49, RatingCount: 210},
+			itunes.AppRatings{Country: "DE", AverageRating: 4.0, RatingCount: 10}),
+		snapshot("2026-01-22T00:00:00Z", "1.1",
+			itunes.AppRatings{Country: "US", AverageRating: 4.5, RatingCount: 1100},
+			itunes.AppRatings{Country: "GB", AverageRating: 4.52, RatingCount: 215}),
+	}
+
+	result := buildRatingsHistory(snapshots, nil, 0.05)
+	if len(result.Snapshots) != 4 || result.Snapshots[3].CountDelta != 45 {
+		t.Fatalf("unexpected global snapshots %+v", result.Snapshots)
+	}
+	if len(result.Countries) != 3 || result.Countries[0].Country != "US" {
+		t.Fatalf("unexpected countries %+v", result.Countries)
+	}
+	us := result.Countries[0]
+	if us.CountDelta != 100 || us.AverageDelta != -0.1 || !us.Dropped || len(us.Points) != 4 || us.Points[2].AverageDelta != -0.07 {
+		t.Fatalf("unexpected US history %+v", us)
+	}
+	if gb := result.Countries[1]; gb.Country != "GB" || gb.Dropped {
+		t.Fatalf("expected GB not to be flagged, got %+v", gb)
+	}
+	if len(result.Drops) != 1 {
+		t.Fatalf("expected one drop, got %+v", result.Drops)
+	}
+	drop := result.Drops[0]
+	if drop.Country != "US" || drop.FromVersion != "1.0" || drop.ToVersion != "1.1" || drop.AverageBefore != 4.62 || drop.AverageAfter != 4.5 || drop.AverageDelta != -0.12 || drop.NewRatings != 90 || drop.LastSeenAt != "2026-01-22T00:00:00Z" {
+		t.Fatalf("unexpected drop %+v", drop)
+	}
+	if drop.NewRatingsAverage != 3.153 || drop.NewRatingsDelta != -1.467 {
+		t.Fatalf("unexpected new ratings average %+v", drop)
+	}
+
+	filtered := buildRatingsHistory(snapshots, map[string]bool{"GB": true}, 0.01)
+	if len(filtered.Countries) != 1 || filtered.Countries[0].Country != "GB" || len(filtered.Drops) != 0 {
+		t.Fatalf("unexpected filtered history %+v", filtered)
+	}
+}
+
+func TestRatingsReleaseDrops_UsesNewRatings(t *testing.T) {
+	// 100 one-star ratings on top of 10,000 move the lifetime average by less
+	// than 0.05, but every new rating is a one-star.
+	country := &RatingsHistoryCountry{Country: "US", Points: []RatingsHistoryPoint{
+		{Version: "1.0", AverageRating: 4.5, RatingCount: 10000},
+		{Version: "1.1", AverageRating: (4.5*10000 + 100) / 10100, RatingCount: 10100},
+	}}
+	drops := ratingsReleaseDrops(country, 0.25)
+	if len(drops) != 1 || drops[0].NewRatingsAverage != 1 || drops[0].NewRatingsDelta != -3.5 || drops[0].AverageDelta > -0.03 {
+		t.Fatalf("expected a drop from new one-star ratings, got %+v", drops)
+	}
+
+	// The histogram delta is preferred when both snapshots have one.
+	country.Points[0].histogram = map[int]int64{1: 500, 5: 9500}
+	country.Points[1].histogram = map[int]int64{1: 500, 4: 100, 5: 9500}
+	if drops := ratingsReleaseDrops(country, 0.25); len(drops) != 1 || drops[0].NewRatingsAverage != 4 || drops[0].NewRatingsDelta != -0.5 {
+		t.Fatalf("expected histogram-based new ratings average, got %+v", drops)
+	}
+
+	// No new ratings means nothing to judge.
+	country.Points[1] = RatingsHistoryPoint{Version: "1.1", AverageRating: 4.4, RatingCount: 10000}
+	if drops := ratingsReleaseDrops(country, 0.25); len(drops) != 0 {
+		t.Fatalf("expected no drop without new ratings, got %+v", drops)
+	}
+}
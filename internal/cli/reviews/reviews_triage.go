@@ -0,0 +1,402 @@
+package reviews
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	reviewsTriageActionRespond   = "respond"
+	reviewsTriageActionResponded = "responded"
+	reviewsTriageActionSkip      = "skip"
+	reviewsTriageActionUnmatched = "unmatched"
+	reviewsTriageActionDuplicate = "already-answered"
+	reviewsTriageActionFailed    = "failed"
+)
+
+// ReviewsTriageResult is the plan or outcome of a reviews triage run.
+type ReviewsTriageResult struct {
+	AppID      string               `json:"appId"`
+	Rules      string               `json:"rules"`
+	State      string               `json:"state"`
+	Since      string               `json:"since,omitempty"`
+	NextCursor string               `json:"nextCursor,omitempty"`
+	Applied    bool                 `json:"applied"`
+	Summary    ReviewsTriageSummary `json:"summary"`
+	Items      []ReviewsTriageItem  `json:"items"`
+}
+
+// ReviewsTriageSummary counts triaged reviews by action.
+type ReviewsTriageSummary struct {
+	Reviews   int `json:"reviews"`
+	Respond   int `json:"respond"`
+	Skipped   int `json:"skipped"`
+	Unmatched int `json:"unmatched"`
+	Duplicate int `json:"alreadyAnswered"`
+	Failed    int `json:"failed"`
+}
+
+// ReviewsTriageItem is the triage decision for one review.
+type ReviewsTriageItem struct {
+	ReviewID    string `json:"reviewId"`
+	CreatedDate string `json:"createdDate"`
+	Rating      int    `json:"rating"`
+	Territory   string `json:"territory"`
+	Language    string `json:"language"`
+	Title       string `json:"title,omitempty"`
+	Rule        string `json:"rule,omitempty"`
+	Locale      string `json:"locale,omitempty"`
+	Action      string `json:"action"`
+	Response    string `json:"response,omitempty"`
+	ResponseID  string `json:"responseId,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// ReviewsTriageCommand returns the reviews triage subcommand.
+func ReviewsTriageCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("triage", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	rulesPath := fs.String("rules", "", "Rules file path, YAML (required)")
+	statePath := fs.String("state", filepath.Join(".asc", "reviews-triage.json"), "State file recording the cursor and answered reviews")
+	since := fs.String("since", "", "Only triage reviews created after this date (YYYY-MM-DD or RFC3339); overrides the stored cursor")
+	apply := fs.Bool("apply", false, "Post responses and update the state file (default: print the plan)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "triage",
+		ShortUsage: "asc reviews triage --app \"APP_ID\" --rules \"./rules.yaml\" [--apply]",
+		ShortHelp:  "Classify unanswered reviews and answer them from rule templates.",
+		LongHelp: `Classify unanswered reviews and answer them from rule templates.
+
+Fetches reviews without a published response that were created after the
+cursor in the state file (or --since), newest first, and matches each one
+against the rules in order. The first rule whose stars, territories,
+languages, and keywords all match wins; empty matchers match everything.
+Keywords match case-insensitively anywhere in the title or body. The review
+language is detected from its script, falling back to the storefront language.
+
+Without --apply the plan is printed and nothing is posted or saved. With
+--apply, responses are posted and the state file records each answered
+review as soon as it is posted. The cursor then advances past every review
+handled without error, so re-running never answers a review twice, even
+while a response is still pending publication. A failure for one review does
+not stop the others.
+
+Response templates use Go template syntax with the fields .Nickname,
+.Rating, .Title, .Body, .Territory, .Language, and .Rule. A rule picks the
+most specific entry in responses (pt-BR, then pt) and falls back to response.
+
+Rules file:
+  rules:
+    - name: crash-reports
+      stars: [1, 2]
+      keywords: [crash, freeze, "won't open"]
+      response: "Sorry about the trouble, {{.Nickname}}. Please contact support@example.com so we can fix it."
+      responses:
+        de: "Das tut uns leid, {{.Nickname}}. Bitte schreib uns an support@example.com."
+        pt-BR: "Sentimos muito, {{.Nickname}}. Fale com support@example.com."
+    - name: thanks
+      stars: [5]
+      languages: [en]
+      territories: [USA, GBR]
+      response: "Thanks for the {{.Rating}} stars!"
+    - name: ignore-short-praise
+      stars: [4, 5]
+      skip: true
+
+Examples:
+  asc reviews triage --app "123456789" --rules "./rules.yaml"
+  asc reviews triage --app "123456789" --rules "./rules.yaml" --since "2026-01-01" --output table
+  asc reviews triage --app "123456789" --rules "./rules.yaml" --apply`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
+				return flag.ErrHelp
+			}
+			rulesValue := strings.TrimSpace(*rulesPath)
+			if rulesValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --rules is required")
+				return flag.ErrHelp
+			}
+			stateValue := strings.TrimSpace(*statePath)
+			if stateValue == "" {
+				return shared.UsageError("--state must not be empty")
+			}
+			var sinceTime time.Time
+			if value := strings.TrimSpace(*since); value != "" {
+				parsed, err := parseReviewsTriageSince(value)
+				if err != nil {
+					return shared.UsageErrorf("--since must be YYYY-MM-DD or RFC3339, got %q", value)
+				}
+				sinceTime = parsed
+			}
+
+			rules, err := readReviewsTriageRules(rulesValue)
+			if err != nil {
+				return err
+			}
+			state, err := loadReviewsTriageState(stateValue, resolvedAppID)
+			if err != nil {
+				return fmt.Errorf("reviews triage: %w", err)
+			}
+			if sinceTime.IsZero() && state.Cursor != "" {
+				sinceTime, err = time.Parse(time.RFC3339, state.Cursor)
+				if err != nil {
+					return fmt.Errorf("reviews triage: invalid cursor %q in %s", state.Cursor, stateValue)
+				}
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("reviews triage: %w", err)
+			}
+
+			reviews, err := shared.FetchReviewsNewestFirst(ctx, client, resolvedAppID, func(created time.Time) bool {
+				return !sinceTime.IsZero() && !created.After(sinceTime)
+			}, asc.WithReviewPublishedResponse(false))
+			if err != nil {
+				return fmt.Errorf("reviews triage: %w", err)
+			}
+
+			result := &ReviewsTriageResult{
+				AppID:   resolvedAppID,
+				Rules:   filepath.Clean(rulesValue),
+				State:   filepath.Clean(stateValue),
+				Applied: *apply,
+				Items:   planReviewsTriage(rules, state, reviews),
+			}
+			if !sinceTime.IsZero() {
+				result.Since = sinceTime.UTC().Format(time.RFC3339)
+			}
+
+			if *apply {
+				if err := applyReviewsTriage(ctx, client, state, stateValue, result.Items); err != nil {
+					return fmt.Errorf("reviews triage: failed to write state: %w", err)
+				}
+			}
+			result.NextCursor = nextReviewsTriageCursor(state.Cursor, result.Items)
+			result.Summary = summarizeReviewsTriage(result.Items)
+
+			if *apply {
+				state.Cursor = result.NextCursor
+				state.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+				if err := state.save(stateValue); err != nil {
+					return fmt.Errorf("reviews triage: failed to write state: %w", err)
+				}
+			}
+
+			if err := shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { renderReviewsTriageResult(result, false); return nil },
+				func() error { renderReviewsTriageResult(result, true); return nil },
+			); err != nil {
+				return err
+			}
+
+			if result.Summary.Failed > 0 {
+				return shared.NewReportedError(fmt.Errorf("reviews triage: %d review(s) failed", result.Summary.Failed))
+			}
+			return nil
+		},
+	}
+}
+
+func parseReviewsTriageSince(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// planReviewsTriage classifies reviews oldest first and renders responses.
+func planReviewsTriage(rules *ReviewsTriageRules, state *reviewsTriageState, reviews []asc.Resource[asc.ReviewAttributes]) []ReviewsTriageItem {
+	sorted := append([]asc.Resource[asc.ReviewAttributes](nil), reviews...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Attributes.CreatedDate < sorted[j].Attributes.CreatedDate
+	})
+
+	items := make([]ReviewsTriageItem, 0, len(sorted))
+	for _, review := range sorted {
+		attrs := review.Attributes
+		item := ReviewsTriageItem{
+			ReviewID:    review.ID,
+			CreatedDate: attrs.CreatedDate,
+			Rating:      attrs.Rating,
+			Territory:   attrs.Territory,
+			Language:    detectReviewLanguage(attrs),
+			Title:       attrs.Title,
+		}
+
+		if answered, ok := state.Answered[review.ID]; ok {
+			item.Rule = answered.Rule
+			item.ResponseID = answered.ResponseID
+			item.Action = reviewsTriageActionDuplicate
+			items = append(items, item)
+			continue
+		}
+
+		rule := rules.match(attrs, item.Language)
+		switch {
+		case rule == nil:
+			item.Action = reviewsTriageActionUnmatched
+		case rule.Skip:
+			item.Rule = rule.Name
+			item.Action = reviewsTriageActionSkip
+		default:
+			item.Rule = rule.Name
+			response, locale, err := rule.render(reviewsTriageTemplateData{
+				Nickname:  attrs.ReviewerNickname,
+				Rating:    attrs.Rating,
+				Title:     attrs.Title,
+				Body:      attrs.Body,
+				Territory: attrs.Territory,
+				Language:  item.Language,
+				Rule:      rule.Name,
+			})
+			item.Locale = locale
+			if err != nil {
+				item.Action = reviewsTriageActionFailed
+				item.Error = err.Error()
+			} else {
+				item.Action = reviewsTriageActionRespond
+				item.Response = response
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// applyReviewsTriage posts the planned responses. The state file is written
+// after every posted response so an interrupted run never answers a review
+// twice; a write failure stops before posting anything else.
+func applyReviewsTriage(ctx context.Context, client *asc.Client, state *reviewsTriageState, statePath string, items []ReviewsTriageItem) error {
+	for i := range items {
+		item := &items[i]
+		if item.Action != reviewsTriageActionRespond {
+			continue
+		}
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		resp, err := client.CreateCustomerReviewResponse(requestCtx, item.ReviewID, item.Response)
+		cancel()
+		if err != nil {
+			item.Action = reviewsTriageActionFailed
+			item.Error = err.Error()
+			continue
+		}
+		item.Action = reviewsTriageActionResponded
+		item.ResponseID = resp.Data.ID
+		state.Answered[item.ReviewID] = reviewsTriageStateResponse{
+			Rule:        item.Rule,
+			ResponseID:  item.ResponseID,
+			CreatedDate: item.CreatedDate,
+			AnsweredAt:  time.Now().UTC().Format(time.RFC3339),
+		}
+		state.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+		if err := state.save(statePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextReviewsTriageCursor advances the cursor to the newest review handled
+// without a failure before it, so failed reviews are retried next run. The
+// cursor never moves backwards, even when --since reaches further back.
+func nextReviewsTriageCursor(cursor string, items []ReviewsTriageItem) string {
+	current, _ := time.Parse(time.RFC3339, cursor)
+	for _, item := range items {
+		if item.Action == reviewsTriageActionFailed {
+			break
+		}
+		created, err := time.Parse(time.RFC3339, item.CreatedDate)
+		if err != nil {
+			break
+		}
+		if created.After(current) {
+			cursor, current = item.CreatedDate, created
+		}
+	}
+	return cursor
+}
+
+func summarizeReviewsTriage(items []ReviewsTriageItem) ReviewsTriageSummary {
+	summary := ReviewsTriageSummary{Reviews: len(items)}
+	for _, item := range items {
+		switch item.Action {
+		case reviewsTriageActionRespond, reviewsTriageActionResponded:
+			summary.Respond++
+		case reviewsTriageActionSkip:
+			summary.Skipped++
+		case reviewsTriageActionUnmatched:
+			summary.Unmatched++
+		case reviewsTriageActionDuplicate:
+			summary.Duplicate++
+		case reviewsTriageActionFailed:
+			summary.Failed++
+		}
+	}
+	return summary
+}
+
+func renderReviewsTriageResult(result *ReviewsTriageResult, markdown bool) {
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	summary := result.Summary
+	render(
+		[]string{"Reviews", "Respond", "Skipped", "Unmatched", "Already Answered", "Failed", "Applied", "Next Cursor"},
+		[][]string{{
+			strconv.Itoa(summary.Reviews),
+			strconv.Itoa(summary.Respond),
+			strconv.Itoa(summary.Skipped),
+			strconv.Itoa(summary.Unmatched),
+			strconv.Itoa(summary.Duplicate),
+			strconv.Itoa(summary.Failed),
+			strconv.FormatBool(result.Applied),
+			shared.OrNA(result.NextCursor),
+		}},
+	)
+
+	rows := make([][]string, 0, len(result.Items))
+	for _, item := range result.Items {
+		detail := item.Response
+		if item.Error != "" {
+			detail = item.Error
+		}
+		rows = append(rows, []string{
+			item.ReviewID,
+			item.CreatedDate,
+			strconv.Itoa(item.Rating),
+			item.Territory,
+			item.Language,
+			shared.OrNA(item.Rule),
+			item.Action,
+			strings.Join(strings.Fields(detail), " "),
+		})
+	}
+	if len(rows) == 0 {
+		rows = append(rows, []string{"", "", "", "", "", "", "none", ""})
+	}
+	render([]string{"Review", "Created", "Stars", "Territory", "Language", "Rule", "Action", "Response"}, rows)
+}
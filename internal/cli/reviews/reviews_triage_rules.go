@@ -0,0 +1,400 @@
+package reviews
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const reviewsTriageStateVersion = 1
+
+// ReviewsTriageRules is the rules file read by reviews triage.
+type ReviewsTriageRules struct {
+	Rules []ReviewsTriageRule `yaml:"rules"`
+}
+
+// ReviewsTriageRule classifies reviews and describes how to answer them.
+// Empty matchers match every review; the first matching rule wins.
+type ReviewsTriageRule struct {
+	Name        string            `yaml:"name"`
+	Stars       []int             `yaml:"stars"`
+	Territories []string          `yaml:"territories"`
+	Languages   []string          `yaml:"languages"`
+	Keywords    []string          `yaml:"keywords"`
+	Skip        bool              `yaml:"skip"`
+	Response    string            `yaml:"response"`
+	Responses   map[string]string `yaml:"responses"`
+
+	templates map[string]*template.Template
+}
+
+// reviewsTriageTemplateData is the data available to response templates.
+type reviewsTriageTemplateData struct {
+	Nickname  string
+	Rating    int
+	Title     string
+	Body      string
+	Territory string
+	Language  string
+	Rule      string
+}
+
+func readReviewsTriageRules(path string) (*ReviewsTriageRules, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return nil, fmt.Errorf("reviews triage: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var rules ReviewsTriageRules
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&rules); err != nil && !errors.Is(err, io.EOF) {
+		return nil, shared.UsageErrorf("invalid rules file %s: %v", path, err)
+	}
+	if err := rules.normalize(); err != nil {
+		return nil, shared.UsageErrorf("invalid rules file %s: %v", path, err)
+	}
+	return &rules, nil
+}
+
+func (r *ReviewsTriageRules) normalize() error {
+	if len(r.Rules) == 0 {
+		return fmt.Errorf("at least one rule is required")
+	}
+
+	seen := make(map[string]bool, len(r.Rules))
+	for i := range r.Rules {
+		rule := &r.Rules[i]
+		rule.Name = strings.TrimSpace(rule.Name)
+		if rule.Name == "" {
+			return fmt.Errorf("rules[%d]: name is required", i)
+		}
+		if seen[rule.Name] {
+			return fmt.Errorf("rules[%d]: duplicate name %q", i, rule.Name)
+		}
+		seen[rule.Name] = true
+
+		for _, stars := range rule.Stars {
+			if stars < 1 || stars > 5 {
+				return fmt.Errorf("rules[%d] (%s): stars must be between 1 and 5", i, rule.Name)
+			}
+		}
+		rule.Territories = normalizeReviewsTriageList(rule.Territories, strings.ToUpper)
+		rule.Languages = normalizeReviewsTriageList(rule.Languages, normalizeReviewsTriageLanguage)
+		rule.Keywords = normalizeReviewsTriageList(rule.Keywords, strings.ToLower)
+
+		responses := make(map[string]string, len(rule.Responses)+1)
+		for locale, text := range rule.Responses {
+			locale = normalizeReviewsTriageLocale(locale)
+			if locale == "" {
+				return fmt.Errorf("rules[%d] (%s): responses keys must be locales such as en or pt-BR", i, rule.Name)
+			}
+			responses[locale] = text
+		}
+		if strings.TrimSpace(rule.Response) != "" {
+			responses[""] = rule.Response
+		}
+		if rule.Skip {
+			if len(responses) > 0 {
+				return fmt.Errorf("rules[%d] (%s): skip rules cannot define responses", i, rule.Name)
+			}
+			continue
+		}
+		if len(responses) == 0 {
+			return fmt.Errorf("rules[%d] (%s): response or responses is required unless skip is set", i, rule.Name)
+		}
+
+		rule.templates = make(map[string]*template.Template, len(responses))
+		for locale, text := range responses {
+			if strings.TrimSpace(text) == "" {
+				return fmt.Errorf("rules[%d] (%s): response for %q is empty", i, rule.Name, shared.OrNA(locale))
+			}
+			tmpl, err := template.New(rule.Name).Option("missingkey=error").Parse(text)
+			if err != nil {
+				return fmt.Errorf("rules[%d] (%s): %w", i, rule.Name, err)
+			}
+			rule.templates[locale] = tmpl
+		}
+	}
+	return nil
+}
+
+func normalizeReviewsTriageList(values []string, normalize func(string) string) []string {
+	normalized := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = normalize(strings.TrimSpace(value)); value != "" {
+			normalized = append(normalized, value)
+		}
+	}
+	return normalized
+}
+
+// normalizeReviewsTriageLocale maps locale spellings such as pt_br or PT-BR to
+// pt-BR so response keys match consistently.
+func normalizeReviewsTriageLocale(locale string) string {
+	parts := strings.FieldsFunc(strings.TrimSpace(locale), func(r rune) bool { return r == '-' || r == '_' })
+	if len(parts) == 0 {
+		return ""
+	}
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.ToUpper(parts[i])
+	}
+	return strings.Join(parts, "-")
+}
+
+func normalizeReviewsTriageLanguage(language string) string {
+	locale := normalizeReviewsTriageLocale(language)
+	language, _, _ = strings.Cut(locale, "-")
+	return language
+}
+
+// match returns the first rule that matches the review, or nil.
+func (r *ReviewsTriageRules) match(review asc.ReviewAttributes, language string) *ReviewsTriageRule {
+	for i := range r.Rules {
+		if r.Rules[i].matches(review, language) {
+			return &r.Rules[i]
+		}
+	}
+	return nil
+}
+
+func (r *ReviewsTriageRule) matches(review asc.ReviewAttributes, language string) bool {
+	if len(r.Stars) > 0 && !containsReviewsTriageStars(r.Stars, review.Rating) {
+		return false
+	}
+	if len(r.Territories) > 0 && !containsReviewsTriageValue(r.Territories, strings.ToUpper(strings.TrimSpace(review.Territory))) {
+		return false
+	}
+	if len(r.Languages) > 0 && !containsReviewsTriageValue(r.Languages, language) {
+		return false
+	}
+	if len(r.Keywords) > 0 {
+		text := strings.ToLower(review.Title + "\n" + review.Body)
+		found := false
+		for _, keyword := range r.Keywords {
+			if strings.Contains(text, keyword) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func containsReviewsTriageStars(values []int, rating int) bool {
+	for _, value := range values {
+		if value == rating {
+			return true
+		}
+	}
+	return false
+}
+
+func containsReviewsTriageValue(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// render picks the response template for the review language, falling back
+// from a regional locale (pt-BR) to its language (pt) and then to the default
+// response. It returns the chosen locale ("" for the default).
+func (r *ReviewsTriageRule) render(data reviewsTriageTemplateData) (string, string, error) {
+	locale, ok := r.responseLocale(data.Language, data.Territory)
+	if !ok {
+		return "", "", fmt.Errorf("rule %q has no response for language %q and no default response", r.Name, data.Language)
+	}
+
+	var buf bytes.Buffer
+	if err := r.templates[locale].Execute(&buf, data); err != nil {
+		return "", locale, fmt.Errorf("rule %q: %w", r.Name, err)
+	}
+	text := strings.TrimSpace(buf.String())
+	if text == "" {
+		return "", locale, fmt.Errorf("rule %q rendered an empty response", r.Name)
+	}
+	return text, locale, nil
+}
+
+func (r *ReviewsTriageRule) responseLocale(language, territory string) (string, bool) {
+	if region := reviewsTriageTerritoryRegions[strings.ToUpper(territory)]; region != "" {
+		if _, ok := r.templates[language+"-"+region]; ok {
+			return language + "-" + region, true
+		}
+	}
+	if _, ok := r.templates[language]; ok {
+		return language, true
+	}
+	if _, ok := r.templates[""]; ok {
+		return "", true
+	}
+	return "", false
+}
+
+// reviewsTriageTerritoryLanguages maps App Store territories to the language
+// most reviews from that storefront are written in.
+var reviewsTriageTerritoryLanguages = map[string]string{
+	"USA": "en", "GBR": "en", "AUS": "en", "CAN": "en", "IRL": "en", "NZL": "en",
+	"IND": "en", "SGP": "en", "ZAF": "en", "PHL": "en", "NGA": "en",
+	"DEU": "de", "AUT": "de", "CHE": "de",
+	"FRA": "fr", "BEL": "fr", "LUX": "fr",
+	"ESP": "es", "MEX": "es", "ARG": "es", "COL": "es", "CHL": "es", "PER": "es",
+	"ITA": "it", "BRA": "pt", "PRT": "pt", "NLD": "nl",
+	"SWE": "sv", "NOR": "no", "DNK": "da", "FIN": "fi", "POL": "pl", "CZE": "cs",
+	"HUN": "hu", "ROU": "ro", "TUR": "tr", "GRC": "el", "RUS": "ru", "UKR": "uk",
+	"JPN": "ja", "KOR": "ko", "CHN": "zh", "TWN": "zh", "HKG": "zh",
+	"THA": "th", "VNM": "vi", "IDN": "id", "MYS": "ms",
+	"SAU": "ar", "ARE": "ar", "EGY": "ar", "ISR": "he",
+}
+
+// reviewsTriageTerritoryRegions lets per-locale templates such as pt-BR or
+// en-GB take precedence over the language template for those storefronts.
+var reviewsTriageTerritoryRegions = map[string]string{
+	"USA": "US", "GBR": "GB", "AUS": "AU", "CAN": "CA",
+	"BRA": "BR", "PRT": "PT", "MEX": "MX", "ESP": "ES",
+	"CHN": "CN", "TWN": "TW", "HKG": "HK", "FRA": "FR", "BEL": "BE",
+	"DEU": "DE", "AUT": "AT", "CHE": "CH",
+}
+
+// detectReviewLanguage guesses the review language from its script, falling
+// back to the storefront language for Latin-script text.
+func detectReviewLanguage(review asc.ReviewAttributes) string {
+	territoryLanguage := reviewsTriageTerritoryLanguages[strings.ToUpper(strings.TrimSpace(review.Territory))]
+
+	counts := map[string]int{}
+	for _, r := range review.Title + " " + review.Body {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			counts["kana"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Han, r):
+			counts["han"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["cyrillic"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["ar"]++
+		case unicode.Is(unicode.Hebrew, r):
+			counts["he"]++
+		case unicode.Is(unicode.Thai, r):
+			counts["th"]++
+		case unicode.Is(unicode.Greek, r):
+			counts["el"]++
+		case unicode.Is(unicode.Latin, r):
+			counts["latin"]++
+		}
+	}
+
+	script, best := "", 0
+	for _, name := range []string{"latin", "kana", "han", "ko", "cyrillic", "ar", "he", "th", "el"} {
+		if counts[name] > best {
+			script, best = name, counts[name]
+		}
+	}
+	switch script {
+	case "", "latin":
+		if territoryLanguage == "" {
+			return "en"
+		}
+		if isReviewsTriageNonLatinLanguage(territoryLanguage) {
+			return "en"
+		}
+		return territoryLanguage
+	case "kana":
+		return "ja"
+	case "han":
+		if counts["kana"] > 0 || territoryLanguage == "ja" {
+			return "ja"
+		}
+		return "zh"
+	case "cyrillic":
+		if territoryLanguage == "uk" {
+			return "uk"
+		}
+		return "ru"
+	default:
+		return script
+	}
+}
+
+func isReviewsTriageNonLatinLanguage(language string) bool {
+	switch language {
+	case "ja", "ko", "zh", "ru", "uk", "ar", "he", "th", "el":
+		return true
+	}
+	return false
+}
+
+// reviewsTriageState records the reviews a triage run already answered and
+// how far it has read, so later runs neither re-read nor re-answer them.
+type reviewsTriageState struct {
+	Version   int                                   `json:"version"`
+	AppID     string                                `json:"appId"`
+	Cursor    string                                `json:"cursor,omitempty"`
+	UpdatedAt string                                `json:"updatedAt,omitempty"`
+	Answered  map[string]reviewsTriageStateResponse `json:"answered"`
+}
+
+type reviewsTriageStateResponse struct {
+	Rule        string `json:"rule"`
+	ResponseID  string `json:"responseId,omitempty"`
+	CreatedDate string `json:"createdDate,omitempty"`
+	AnsweredAt  string `json:"answeredAt"`
+}
+
+func loadReviewsTriageState(path, appID string) (*reviewsTriageState, error) {
+	state := &reviewsTriageState{
+		Version:  reviewsTriageStateVersion,
+		AppID:    appID,
+		Answered: map[string]reviewsTriageStateResponse{},
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return state, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("invalid state file %s: %w", path, err)
+	}
+	if state.AppID != "" && state.AppID != appID {
+		return nil, fmt.Errorf("%s tracks app %s, not %s; use a separate --state per app", path, state.AppID, appID)
+	}
+	state.AppID = appID
+	if state.Answered == nil {
+		state.Answered = map[string]reviewsTriageStateResponse{}
+	}
+	return state, nil
+}
+
+func (s *reviewsTriageState) save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	pattern := filepath.Base(path)
+	_, err = shared.WriteFileNoSymlinkOverwrite(path, bytes.NewReader(data), 0o600, "."+pattern+"-*.tmp", "."+pattern+"-*.bak")
+	return err
+}
@@ -0,0 +1,134 @@
+package reviews
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func TestDetectReviewLanguage(t *testing.T) {
+	tests := []struct {
+		review asc.ReviewAttributes
+		want   string
+	}{
+		{asc.ReviewAttributes{Body: "Great app", Territory: "USA"}, "en"},
+		{asc.ReviewAttributes{Body: "Stürzt ständig ab", Territory: "DEU"}, "de"},
+		{asc.ReviewAttributes{Body: "Works fine", Territory: "JPN"}, "en"},
+		{asc.ReviewAttributes{Body: "すぐに落ちます", Territory: "USA"}, "ja"},
+		{asc.ReviewAttributes{Body: "应用崩溃", Territory: "CHN"}, "zh"},
+		{asc.ReviewAttributes{Body: "起動しない", Territory: "JPN"}, "ja"},
+		{asc.ReviewAttributes{Body: "Не работает", Territory: "UKR"}, "uk"},
+		{asc.ReviewAttributes{Body: "Не работает", Territory: "DEU"}, "ru"},
+		{asc.ReviewAttributes{Body: "앱이 멈춰요", Territory: "KOR"}, "ko"},
+		{asc.ReviewAttributes{Body: "ok", Territory: "ATA"}, "en"},
+	}
+	for _, test := range tests {
+		if got := detectReviewLanguage(test.review); got != test.want {
+			t.Fatalf("detectReviewLanguage(%q, %s) = %q, want %q", test.review.Body, test.review.Territory, got, test.want)
+		}
+	}
+}
+
+func TestReviewsTriageRulesMatchAndRender(t *testing.T) {
+	rules := &ReviewsTriageRules{Rules: []ReviewsTriageRule{
+		{
+			Name:     "crash",
+			Stars:    []int{1, 2},
+			Keywords: []string{"Crash"},
+			Response: "Sorry {{.Nickname}}",
+			Responses: map[string]string{
+				"de":    "Entschuldigung {{.Nickname}}",
+				"pt_br": "Desculpe {{.Nickname}}",
+			},
+		},
+		{Name: "praise", Stars: []int{5}, Skip: true},
+		{Name: "german", Languages: []string{"DE"}, Territories: []string{"deu"}, Response: "Danke!"},
+	}}
+	if err := rules.normalize(); err != nil {
+		t.Fatalf("normalize() error: %v", err)
+	}
+
+	tests := []struct {
+		review   asc.ReviewAttributes
+		wantRule string
+		wantText string
+	}{
+		{asc.ReviewAttributes{Rating: 1, Body: "It CRASHES", Territory: "USA", ReviewerNickname: "sam"}, "crash", "Sorry sam"},
+		{asc.ReviewAttributes{Rating: 2, Title: "Crash", Territory: "DEU", ReviewerNickname: "max"}, "crash", "Entschuldigung max"},
+		{asc.ReviewAttributes{Rating: 1, Body: "crash", Territory: "BRA", ReviewerNickname: "ana"}, "crash", "Desculpe ana"},
+		{asc.ReviewAttributes{Rating: 1, Body: "crash", Territory: "PRT", ReviewerNickname: "rui"}, "crash", "Sorry rui"},
+		{asc.ReviewAttributes{Rating: 5, Body: "crash free", Territory: "USA"}, "praise", ""},
+		{asc.ReviewAttributes{Rating: 3, Body: "Gut", Territory: "DEU"}, "german", "Danke!"},
+		{asc.ReviewAttributes{Rating: 3, Body: "Good", Territory: "USA"}, "", ""},
+	}
+	for _, test := range tests {
+		language := detectReviewLanguage(test.review)
+		rule := rules.match(test.review, language)
+		if test.wantRule == "" {
+			if rule != nil {
+				t.Fatalf("expected no rule for %+v, got %q", test.review, rule.Name)
+			}
+			continue
+		}
+		if rule == nil || rule.Name != test.wantRule {
+			t.Fatalf("expected rule %q for %+v, got %+v", test.wantRule, test.review, rule)
+		}
+		if rule.Skip {
+			continue
+		}
+		text, _, err := rule.render(reviewsTriageTemplateData{Nickname: test.review.ReviewerNickname, Territory: test.review.Territory, Language: language})
+		if err != nil {
+			t.Fatalf("render() error: %v", err)
+		}
+		if text != test.wantText {
+			t.Fatalf("render() = %q, want %q", text, test.wantText)
+		}
+	}
+}
+
+func TestReviewsTriageRulesValidation(t *testing.T) {
+	tests := []struct {
+		rules ReviewsTriageRules
+		want  string
+	}{
+		{ReviewsTriageRules{}, "at least one rule is required"},
+		{ReviewsTriageRules{Rules: []ReviewsTriageRule{{Response: "hi"}}}, "name is required"},
+		{ReviewsTriageRules{Rules: []ReviewsTriageRule{{Name: "a", Response: "hi"}, {Name: "a", Response: "hi"}}}, "duplicate name"},
+		{ReviewsTriageRules{Rules: []ReviewsTriageRule{{Name: "a", Stars: []int{6}, Response: "hi"}}}, "stars must be between 1 and 5"},
+		{ReviewsTriageRules{Rules: []ReviewsTriageRule{{Name: "a"}}}, "response or responses is required"},
+		{ReviewsTriageRules{Rules: []ReviewsTriageRule{{Name: "a", Skip: true, Response: "hi"}}}, "skip rules cannot define responses"},
+		{ReviewsTriageRules{Rules: []ReviewsTriageRule{{Name: "a", Response: "{{.Nickname"}}}, "unclosed action"},
+	}
+	for _, test := range tests {
+		err := test.rules.normalize()
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Fatalf("normalize() error = %v, want %q", err, test.want)
+		}
+	}
+}
+
+func TestReviewsTriageRenderUnknownField(t *testing.T) {
+	rules := &ReviewsTriageRules{Rules: []ReviewsTriageRule{{Name: "a", Response: "Hi {{.Email}}"}}}
+	if err := rules.normalize(); err != nil {
+		t.Fatalf("normalize() error: %v", err)
+	}
+	if _, _, err := rules.Rules[0].render(reviewsTriageTemplateData{}); err == nil {
+		t.Fatal("expected render error for unknown field")
+	}
+}
+
+func TestNextReviewsTriageCursor(t *testing.T) {
+	items := []ReviewsTriageItem{
+		{CreatedDate: "2026-01-01T00:00:00Z", Action: reviewsTriageActionResponded},
+		{CreatedDate: "2026-01-02T00:00:00Z", Action: reviewsTriageActionUnmatched},
+		{CreatedDate: "2026-01-03T00:00:00Z", Action: reviewsTriageActionFailed},
+		{CreatedDate: "2026-01-04T00:00:00Z", Action: reviewsTriageActionResponded},
+	}
+	if got := nextReviewsTriageCursor("", items); got != "2026-01-02T00:00:00Z" {
+		t.Fatalf("nextReviewsTriageCursor() = %q", got)
+	}
+	if got := nextReviewsTriageCursor("2026-01-05T00:00:00Z", items); got != "2026-01-05T00:00:00Z" {
+		t.Fatalf("expected cursor not to move backwards, got %q", got)
+	}
+}
@@ -0,0 +1,41 @@
+package shared
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// PriceResourceID is the payload App Store Connect encodes into price and
+// price point resource IDs. PricePointID is the price tier.
+type PriceResourceID struct {
+	TerritoryID      string  `json:"t"`
+	PricePointID     string  `json:"p"`
+	StartDateSeconds float64 `json:"sd"`
+	EndDateSeconds   float64 `json:"ed"`
+}
+
+// DecodePriceResourceID decodes the base64 JSON payload of a price or price
+// point resource ID. It reports false when the ID is not in that format.
+func DecodePriceResourceID(resourceID string) (PriceResourceID, bool) {
+	resourceID = strings.TrimSpace(resourceID)
+	if resourceID == "" {
+		return PriceResourceID{}, false
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(resourceID)
+	if err != nil {
+		decoded, err = base64.URLEncoding.DecodeString(resourceID)
+		if err != nil {
+			return PriceResourceID{}, false
+		}
+	}
+
+	var payload PriceResourceID
+	if err := json.Unmarshal(decoded, &payload); err != nil {
+		return PriceResourceID{}, false
+	}
+	payload.TerritoryID = strings.ToUpper(strings.TrimSpace(payload.TerritoryID))
+	payload.PricePointID = strings.TrimSpace(payload.PricePointID)
+	return payload, true
+}
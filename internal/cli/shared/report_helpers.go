@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/config"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/reports"
 )
 
 // ResolveVendorNumber resolves the vendor number for reports.
@@ -109,3 +110,22 @@ func DecompressGzipFile(sourcePath, destPath string) (int64, error) {
 	}
 	return written, out.Sync()
 }
+
+// ReportRowsFormat returns "json" or "csv" when a report command's --output
+// flag selects parsed rows on stdout instead of a file path, and "" otherwise.
+func ReportRowsFormat(output string) string {
+	switch normalized := strings.ToLower(strings.TrimSpace(output)); normalized {
+	case "json", "csv":
+		return normalized
+	default:
+		return ""
+	}
+}
+
+// PrintReportRows prints parsed report rows as JSON or CSV.
+func PrintReportRows[T any](rows []T, format string, pretty bool) error {
+	if format == "csv" {
+		return reports.WriteCSV(os.Stdout, rows)
+	}
+	return PrintOutput(rows, "json", pretty)
+}
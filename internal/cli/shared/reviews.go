@@ -0,0 +1,44 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+// FetchReviewsNewestFirst pages through reviews sorted newest first, giving
+// each page its own timeout, and stops at the first review whose creation
+// time reaches the cutoff. Reviews with an unparseable date never stop paging.
+func FetchReviewsNewestFirst(
+	ctx context.Context,
+	client *asc.Client,
+	appID string,
+	reachedCutoff func(created time.Time) bool,
+	filters ...asc.ReviewOption,
+) ([]asc.Resource[asc.ReviewAttributes], error) {
+	opts := append([]asc.ReviewOption{asc.WithReviewSort("-createdDate"), asc.WithLimit(200)}, filters...)
+
+	var reviews []asc.Resource[asc.ReviewAttributes]
+	for {
+		pageCtx, cancel := ContextWithTimeout(ctx)
+		resp, err := client.GetReviews(pageCtx, appID, opts...)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch reviews: %w", err)
+		}
+		for _, review := range resp.Data {
+			created, err := time.Parse(time.RFC3339, review.Attributes.CreatedDate)
+			if err == nil && reachedCutoff(created) {
+				return reviews, nil
+			}
+			reviews = append(reviews, review)
+		}
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return reviews, nil
+		}
+		opts = []asc.ReviewOption{asc.WithNextURL(resp.Links.Next)}
+	}
+}
@@ -2,6 +2,7 @@ package shared
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/base64"
 	"errors"
 	"flag"
@@ -65,7 +66,9 @@ var (
 	debug               OptionalBool
 	apiDebug            OptionalBool
 
-	getCredentialsWithSourceFn = auth.GetCredentialsWithSource
+	getCredentialsWithSourceFn  = auth.GetCredentialsWithSource
+	credentialProcessCommandFn  = auth.CredentialProcessCommand
+	resolveProcessCredentialsFn = auth.ResolveProcessCredentials
 )
 
 var (
@@ -77,6 +80,9 @@ var (
 func BindRootFlags(fs *flag.FlagSet) {
 	// Keep root debug/retry flags ergonomic while command-level OptionalBool
 	// flags continue to require explicit values.
+	retryLog = OptionalBool{}
+	debug = OptionalBool{}
+	apiDebug = OptionalBool{}
 	retryLog.EnableBoolFlag()
 	debug.EnableBoolFlag()
 	apiDebug.EnableBoolFlag()
@@ -94,6 +100,31 @@ func SelectedProfile() string {
 	return selectedProfile
 }
 
+// RootFlagArgs re-serializes the root auth and logging flags that were set,
+// so child asc processes resolve credentials and log the same way.
+func RootFlagArgs() []string {
+	var args []string
+	if strings.TrimSpace(selectedProfile) != "" {
+		args = append(args, "--profile="+selectedProfile)
+	}
+	if strictAuth {
+		args = append(args, "--strict-auth=true")
+	}
+	for _, flag := range []struct {
+		name  string
+		value OptionalBool
+	}{
+		{name: "retry-log", value: retryLog},
+		{name: "debug", value: debug},
+		{name: "api-debug", value: apiDebug},
+	} {
+		if flag.value.IsSet() {
+			args = append(args, "--"+flag.name+"="+flag.value.String())
+		}
+	}
+	return args
+}
+
 // ProgressEnabled reports whether it's safe/appropriate to emit progress messages.
 // Progress must be stderr-only and must not appear when stderr is non-interactive.
 func ProgressEnabled() bool {
@@ -270,6 +301,9 @@ type resolvedCredentials struct {
 	keyID    string
 	issuerID string
 	keyPath  string
+	// privateKey is set instead of keyPath for credential_process credentials,
+	// which are never written to disk.
+	privateKey *ecdsa.PrivateKey
 }
 
 type credentialSource struct {
@@ -329,7 +363,22 @@ func resolveCredentials() (resolvedCredentials, error) {
 		}
 	}
 
-	// Priority 1: Stored credentials (keychain/config)
+	// Priority 1: credential_process from config, unless a profile is selected
+	if profile == "" {
+		if command, _, err := credentialProcessCommandFn(); err == nil && command != "" {
+			creds, err := resolveProcessCredentialsFn(context.Background(), command)
+			if err != nil {
+				return resolvedCredentials{}, err
+			}
+			return resolvedCredentials{
+				keyID:      creds.KeyID,
+				issuerID:   creds.IssuerID,
+				privateKey: creds.PrivateKey,
+			}, nil
+		}
+	}
+
+	// Priority 2: Stored credentials (keychain/config)
 	cfg, storedSource, err := getCredentialsWithSourceFn(profile)
 	if err != nil {
 		if profile != "" {
@@ -349,7 +398,7 @@ func resolveCredentials() (resolvedCredentials, error) {
 		sources.keyPath = storedSource
 	}
 
-	// Priority 2: Environment variables (fallback for CI/CD or when keychain unavailable)
+	// Priority 3: Environment variables (fallback for CI/CD or when keychain unavailable)
 	if actualKeyID == "" || actualIssuerID == "" || actualKeyPath == "" {
 		if !envResolved {
 			resolved, err := resolveEnvCredentials()
@@ -412,6 +461,9 @@ func getASCClient() (*asc.Client, error) {
 	} else {
 		asc.SetDebugHTTPOverride(nil)
 	}
+	if resolved.privateKey != nil {
+		return asc.NewClientWithPrivateKey(resolved.keyID, resolved.issuerID, resolved.privateKey)
+	}
 	return asc.NewClient(resolved.keyID, resolved.issuerID, resolved.keyPath)
 }
 
@@ -2,6 +2,7 @@ package shared
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -1052,3 +1053,59 @@ func TestSetNoProgress(t *testing.T) {
 		t.Fatal("expected noProgress to be false after SetNoProgress(false)")
 	}
 }
+
+func TestResolveCredentials_CredentialProcessUnlessProfileSelected(t *testing.T) {
+	t.Setenv("ASC_BYPASS_KEYCHAIN", "")
+	t.Setenv("ASC_PROFILE", "")
+	t.Setenv("ASC_KEY_ID", "")
+	t.Setenv("ASC_ISSUER_ID", "")
+	t.Setenv("ASC_PRIVATE_KEY_PATH", "")
+	t.Setenv("ASC_PRIVATE_KEY_B64", "")
+	t.Setenv("ASC_PRIVATE_KEY", "")
+
+	previousProfile := selectedProfile
+	selectedProfile = ""
+	t.Cleanup(func() { selectedProfile = previousProfile })
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error: %v", err)
+	}
+
+	previousCommand := credentialProcessCommandFn
+	previousResolve := resolveProcessCredentialsFn
+	previousStored := getCredentialsWithSourceFn
+	t.Cleanup(func() {
+		credentialProcessCommandFn = previousCommand
+		resolveProcessCredentialsFn = previousResolve
+		getCredentialsWithSourceFn = previousStored
+	})
+	credentialProcessCommandFn = func() (string, string, error) {
+		return "vault read asc", "/tmp/config.json", nil
+	}
+	resolveProcessCredentialsFn = func(ctx context.Context, command string) (*auth.ProcessCredentials, error) {
+		return &auth.ProcessCredentials{KeyID: "PROCKEY", IssuerID: "PROCISS", PrivateKey: key}, nil
+	}
+	storedCalls := 0
+	getCredentialsWithSourceFn = func(profile string) (*config.Config, string, error) {
+		storedCalls++
+		return &config.Config{KeyID: "STOREDKEY", IssuerID: "STOREDISS", PrivateKeyPath: "/tmp/key.p8"}, "keychain", nil
+	}
+
+	resolved, err := resolveCredentials()
+	if err != nil {
+		t.Fatalf("resolveCredentials() error: %v", err)
+	}
+	if resolved.keyID != "PROCKEY" || resolved.privateKey != key || resolved.keyPath != "" || storedCalls != 0 {
+		t.Fatalf("expected credential_process credentials, got %+v (stored calls %d)", resolved, storedCalls)
+	}
+
+	selectedProfile = "work"
+	resolved, err = resolveCredentials()
+	if err != nil {
+		t.Fatalf("resolveCredentials() with profile error: %v", err)
+	}
+	if resolved.keyID != "STOREDKEY" || resolved.privateKey != nil {
+		t.Fatalf("expected selected profile to win, got %+v", resolved)
+	}
+}
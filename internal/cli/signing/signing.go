@@ -19,11 +19,17 @@ func SigningCommand() *ffcli.Command {
 		LongHelp: `Manage signing assets in App Store Connect.
 
 Examples:
-  asc signing fetch --bundle-id com.example.app --profile-type IOS_APP_STORE --output ./signing`,
+  asc signing fetch --bundle-id com.example.app --profile-type IOS_APP_STORE --output ./signing
+  asc signing sync --store ./signing-store --bundle-id com.example.app --profile-type IOS_APP_STORE
+  asc signing nuke --store ./signing-store --confirm
+  asc signing audit --within 30d`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
 			SigningFetchCommand(),
+			SigningSyncCommand(),
+			SigningNukeCommand(),
+			SigningAuditCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
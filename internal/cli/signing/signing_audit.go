@@ -0,0 +1,634 @@
+package signing
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/profiles"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	signingAuditSeverityError   = "error"
+	signingAuditSeverityWarning = "warning"
+
+	signingAuditIssueExpired            = "expired"
+	signingAuditIssueExpiring           = "expiring"
+	signingAuditIssueInvalid            = "invalid"
+	signingAuditIssueRevokedCertificate = "revoked-certificate"
+	signingAuditIssueDisabledDevice     = "disabled-device"
+)
+
+type signingAuditFinding struct {
+	Severity  string `json:"severity"`
+	Source    string `json:"source"`
+	Kind      string `json:"kind"`
+	ID        string `json:"id"`
+	Name      string `json:"name,omitempty"`
+	Issue     string `json:"issue"`
+	ExpiresAt string `json:"expiresAt,omitempty"`
+	Detail    string `json:"detail,omitempty"`
+	Path      string `json:"path,omitempty"`
+}
+
+type signingAuditSkipped struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+type signingAuditSummary struct {
+	Certificates  int `json:"certificates"`
+	Profiles      int `json:"profiles"`
+	LocalProfiles int `json:"localProfiles"`
+	Errors        int `json:"errors"`
+	Warnings      int `json:"warnings"`
+}
+
+type signingAuditResult struct {
+	Within       string                `json:"within"`
+	CheckedAt    string                `json:"checkedAt"`
+	InstallDir   string                `json:"installDir,omitempty"`
+	TeamIDs      []string              `json:"teamIds,omitempty"`
+	Summary      signingAuditSummary   `json:"summary"`
+	Findings     []signingAuditFinding `json:"findings"`
+	SkippedLocal []signingAuditSkipped `json:"skippedLocal,omitempty"`
+}
+
+// signingAuditProfile is a remote profile with its resolved relationships.
+type signingAuditProfile struct {
+	ID             string
+	Attributes     asc.ProfileAttributes
+	CertificateIDs []string
+	DeviceIDs      []string
+}
+
+// signingAuditInput holds everything the audit checks, so the checks can run
+// without App Store Connect or the local filesystem.
+type signingAuditInput struct {
+	Certificates    []asc.Resource[asc.CertificateAttributes]
+	Profiles        []signingAuditProfile
+	DisabledDevices []asc.Resource[asc.DeviceAttributes]
+	LocalProfiles   []profiles.InstalledProfile
+	// Remote is false when App Store Connect was not queried; checks that
+	// need remote state are skipped for local profiles.
+	Remote bool
+}
+
+// SigningAuditCommand returns the signing audit subcommand.
+func SigningAuditCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("audit", flag.ExitOnError)
+
+	within := fs.String("within", "30d", "Report assets expiring within this window (e.g. 30d, 2w, 72h)")
+	installDir := fs.String("install-dir", "", "Local provisioning profiles directory (defaults to Xcode's directory on macOS)")
+	skipLocal := fs.Bool("skip-local", false, "Skip locally installed provisioning profiles")
+	localOnly := fs.Bool("local-only", false, "Only audit locally installed provisioning profiles")
+	teamID := fs.String("team-id", "", "Only audit local profiles for this team (default: teams of the remote certificates)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "audit",
+		ShortUsage: "asc signing audit [--within 30d] [flags]",
+		ShortHelp:  "Report expiring or broken certificates and profiles.",
+		LongHelp: `Report expiring or broken certificates and profiles.
+
+Scans certificates and profiles in App Store Connect and the provisioning
+profiles installed on this machine. Reports assets that are expired or expire
+within --within, invalid profiles, profiles signed by revoked certificates,
+and profiles that include disabled devices.
+
+Exits with a non-zero status when anything is reported, so it can run as a
+scheduled job. The report is printed either way.
+
+Examples:
+  asc signing audit
+  asc signing audit --within 14d --output table
+  asc signing audit --install-dir "./profiles" --team-id "TEAM123"
+  asc signing audit --local-only --within 2w`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			window, err := parseSigningAuditWindow(*within)
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+			if *skipLocal && *localOnly {
+				return shared.UsageError("--skip-local and --local-only cannot be used together")
+			}
+
+			now := time.Now().UTC()
+			result := &signingAuditResult{
+				Within:    strings.TrimSpace(*within),
+				CheckedAt: now.Format(time.RFC3339),
+			}
+			input := signingAuditInput{Remote: !*localOnly}
+
+			if input.Remote {
+				client, err := shared.GetASCClient()
+				if err != nil {
+					return fmt.Errorf("signing audit: %w", err)
+				}
+				requestCtx, cancel := shared.ContextWithTimeout(ctx)
+				defer cancel()
+
+				if err := loadSigningAuditRemote(requestCtx, client, &input); err != nil {
+					return fmt.Errorf("signing audit: %w", err)
+				}
+			}
+
+			if !*skipLocal {
+				resolvedDir, err := profiles.ResolveInstallDir(*installDir)
+				switch {
+				case err == nil:
+					installed, skipped, err := profiles.ScanInstalledProfiles(resolvedDir, now)
+					if err != nil {
+						return fmt.Errorf("signing audit: scan local profiles: %w", err)
+					}
+					result.InstallDir = resolvedDir
+					input.LocalProfiles = installed
+					for _, item := range skipped {
+						result.SkippedLocal = append(result.SkippedLocal, signingAuditSkipped{Path: item.Path, Reason: item.Reason})
+					}
+				case *localOnly:
+					return shared.UsageError(err.Error())
+				}
+			}
+
+			teams := shared.SplitCSVUpper(*teamID)
+			if len(teams) == 0 {
+				teams = signingCertificateTeamIDs(input.Certificates)
+			}
+			input.LocalProfiles = filterLocalProfilesByTeam(input.LocalProfiles, teams)
+			result.TeamIDs = teams
+
+			result.Findings = auditSigningAssets(input, now, window)
+			result.Summary = summarizeSigningAudit(input, result.Findings)
+
+			if err := shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderSigningAuditResult(result, false) },
+				func() error { return renderSigningAuditResult(result, true) },
+			); err != nil {
+				return err
+			}
+			if len(result.Findings) > 0 {
+				return shared.NewReportedError(fmt.Errorf("signing audit: found %d error(s) and %d warning(s)", result.Summary.Errors, result.Summary.Warnings))
+			}
+			return nil
+		},
+	}
+}
+
+// parseSigningAuditWindow accepts day and week suffixes in addition to Go
+// durations.
+func parseSigningAuditWindow(value string) (time.Duration, error) {
+	trimmed := strings.ToLower(strings.TrimSpace(value))
+	invalid := fmt.Errorf("--within must be a duration like 30d, 2w, or 72h")
+	if trimmed == "" {
+		return 0, invalid
+	}
+	if unit := trimmed[len(trimmed)-1]; unit == 'd' || unit == 'w' {
+		count, err := strconv.Atoi(trimmed[:len(trimmed)-1])
+		if err != nil || count < 0 {
+			return 0, invalid
+		}
+		days := count
+		if unit == 'w' {
+			days *= 7
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	duration, err := time.ParseDuration(trimmed)
+	if err != nil || duration < 0 {
+		return 0, invalid
+	}
+	return duration, nil
+}
+
+func loadSigningAuditRemote(ctx context.Context, client *asc.Client, input *signingAuditInput) error {
+	certs, err := listCertificatesByType(ctx, client, "")
+	if err != nil {
+		return fmt.Errorf("list certificates: %w", err)
+	}
+	input.Certificates = certs.Data
+
+	next := ""
+	for {
+		resp, err := client.GetDevices(ctx,
+			asc.WithDevicesStatus(string(asc.DeviceStatusDisabled)),
+			asc.WithDevicesLimit(200),
+			asc.WithDevicesNextURL(next),
+		)
+		if err != nil {
+			return fmt.Errorf("list devices: %w", err)
+		}
+		input.DisabledDevices = append(input.DisabledDevices, resp.Data...)
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			break
+		}
+		next = resp.Links.Next
+	}
+
+	next = ""
+	for {
+		resp, err := client.GetProfiles(ctx,
+			asc.WithProfilesInclude([]string{"certificates", "devices"}),
+			asc.WithProfilesLimit(200),
+			asc.WithProfilesNextURL(next),
+		)
+		if err != nil {
+			return fmt.Errorf("list profiles: %w", err)
+		}
+		for _, item := range resp.Data {
+			profile, err := resolveSigningAuditProfile(ctx, client, item)
+			if err != nil {
+				return err
+			}
+			input.Profiles = append(input.Profiles, profile)
+		}
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			break
+		}
+		next = resp.Links.Next
+	}
+	return nil
+}
+
+type signingAuditRelationship struct {
+	Data []asc.ResourceData `json:"data"`
+	Meta struct {
+		Paging struct {
+			Total int `json:"total"`
+		} `json:"paging"`
+	} `json:"meta"`
+}
+
+// complete reports whether the included linkage data lists every related
+// resource. Large relationships are truncated and must be paged separately.
+func (r *signingAuditRelationship) complete() bool {
+	return r != nil && r.Meta.Paging.Total <= len(r.Data)
+}
+
+func resolveSigningAuditProfile(ctx context.Context, client *asc.Client, item asc.Resource[asc.ProfileAttributes]) (signingAuditProfile, error) {
+	profile := signingAuditProfile{ID: item.ID, Attributes: item.Attributes}
+
+	var relationships struct {
+		Certificates *signingAuditRelationship `json:"certificates"`
+		Devices      *signingAuditRelationship `json:"devices"`
+	}
+	if len(item.Relationships) > 0 {
+		if err := json.Unmarshal(item.Relationships, &relationships); err != nil {
+			return profile, fmt.Errorf("profile %s: parse relationships: %w", item.ID, err)
+		}
+	}
+
+	var err error
+	if relationships.Certificates.complete() {
+		profile.CertificateIDs = resourceDataIDs(relationships.Certificates.Data)
+	} else if profile.CertificateIDs, err = listSigningLinkageIDs(ctx, func(next string) (*asc.LinkagesResponse, error) {
+		return client.GetProfileCertificatesRelationships(ctx, item.ID, asc.WithLinkagesLimit(200), asc.WithLinkagesNextURL(next))
+	}); err != nil {
+		return profile, fmt.Errorf("profile %s: list certificates: %w", item.ID, err)
+	}
+
+	if relationships.Devices.complete() {
+		profile.DeviceIDs = resourceDataIDs(relationships.Devices.Data)
+	} else if profile.DeviceIDs, err = listSigningLinkageIDs(ctx, func(next string) (*asc.LinkagesResponse, error) {
+		return client.GetProfileDevicesRelationships(ctx, item.ID, asc.WithLinkagesLimit(200), asc.WithLinkagesNextURL(next))
+	}); err != nil {
+		return profile, fmt.Errorf("profile %s: list devices: %w", item.ID, err)
+	}
+	return profile, nil
+}
+
+func listSigningLinkageIDs(ctx context.Context, fetch func(next string) (*asc.LinkagesResponse, error)) ([]string, error) {
+	var ids []string
+	next := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		resp, err := fetch(next)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, resourceDataIDs(resp.Data)...)
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return ids, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+func resourceDataIDs(items []asc.ResourceData) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+// signingCertificateTeamIDs returns the team IDs found in the subject OU of
+// the certificates, which is where Apple records the issuing team.
+func signingCertificateTeamIDs(certs []asc.Resource[asc.CertificateAttributes]) []string {
+	seen := make(map[string]bool)
+	var teams []string
+	for _, cert := range certs {
+		der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(cert.Attributes.CertificateContent))
+		if err != nil || len(der) == 0 {
+			continue
+		}
+		parsed, err := x509.ParseCertificate(der)
+		if err != nil {
+			continue
+		}
+		for _, unit := range parsed.Subject.OrganizationalUnit {
+			team := strings.ToUpper(strings.TrimSpace(unit))
+			if team != "" && !seen[team] {
+				seen[team] = true
+				teams = append(teams, team)
+			}
+		}
+	}
+	sort.Strings(teams)
+	return teams
+}
+
+func filterLocalProfilesByTeam(items []profiles.InstalledProfile, teams []string) []profiles.InstalledProfile {
+	if len(teams) == 0 {
+		return items
+	}
+	allowed := make(map[string]bool, len(teams))
+	for _, team := range teams {
+		allowed[team] = true
+	}
+	filtered := make([]profiles.InstalledProfile, 0, len(items))
+	for _, item := range items {
+		if allowed[strings.ToUpper(item.TeamID)] {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+// auditSigningAssets returns findings sorted with errors first.
+func auditSigningAssets(input signingAuditInput, now time.Time, window time.Duration) []signingAuditFinding {
+	findings := make([]signingAuditFinding, 0)
+
+	activeCerts := make(map[string]bool, len(input.Certificates))
+	activeSerials := make(map[string]bool, len(input.Certificates))
+	for _, cert := range input.Certificates {
+		attrs := cert.Attributes
+		activeCerts[cert.ID] = true
+		activeSerials[normalizeCertificateSerial(attrs.SerialNumber)] = true
+
+		expiresAt, ok := parseSigningAuditTime(attrs.ExpirationDate)
+		if !ok {
+			continue
+		}
+		if finding, ok := signingExpiryFinding(expiresAt, now, window); ok {
+			finding.Source = "remote"
+			finding.Kind = "certificate"
+			finding.ID = cert.ID
+			finding.Name = firstNonEmpty(attrs.DisplayName, attrs.Name)
+			finding.Detail = attrs.CertificateType
+			findings = append(findings, finding)
+		}
+	}
+
+	disabledIDs := make(map[string]string, len(input.DisabledDevices))
+	disabledUDIDs := make(map[string]string, len(input.DisabledDevices))
+	for _, device := range input.DisabledDevices {
+		disabledIDs[device.ID] = device.Attributes.Name
+		disabledUDIDs[strings.ToUpper(device.Attributes.UDID)] = device.Attributes.Name
+	}
+
+	for _, profile := range input.Profiles {
+		attrs := profile.Attributes
+		base := signingAuditFinding{
+			Source:    "remote",
+			Kind:      "profile",
+			ID:        profile.ID,
+			Name:      attrs.Name,
+			ExpiresAt: attrs.ExpirationDate,
+		}
+
+		if attrs.ProfileState == asc.ProfileStateInvalid {
+			finding := base
+			finding.Severity = signingAuditSeverityError
+			finding.Issue = signingAuditIssueInvalid
+			finding.Detail = "profile state is INVALID"
+			findings = append(findings, finding)
+		} else if expiresAt, ok := parseSigningAuditTime(attrs.ExpirationDate); ok {
+			if finding, ok := signingExpiryFinding(expiresAt, now, window); ok {
+				finding.Source, finding.Kind, finding.ID, finding.Name = base.Source, base.Kind, base.ID, base.Name
+				findings = append(findings, finding)
+			}
+		}
+
+		var revoked []string
+		for _, certID := range profile.CertificateIDs {
+			if !activeCerts[certID] {
+				revoked = append(revoked, certID)
+			}
+		}
+		if len(revoked) > 0 {
+			finding := base
+			finding.Severity = signingAuditSeverityError
+			finding.Issue = signingAuditIssueRevokedCertificate
+			finding.Detail = "certificate(s) no longer active: " + strings.Join(revoked, ", ")
+			findings = append(findings, finding)
+		}
+
+		var disabled []string
+		for _, deviceID := range profile.DeviceIDs {
+			if name, ok := disabledIDs[deviceID]; ok {
+				disabled = append(disabled, firstNonEmpty(name, deviceID))
+			}
+		}
+		if len(disabled) > 0 {
+			finding := base
+			finding.Severity = signingAuditSeverityWarning
+			finding.Issue = signingAuditIssueDisabledDevice
+			finding.Detail = "disabled device(s): " + strings.Join(disabled, ", ")
+			findings = append(findings, finding)
+		}
+	}
+
+	for _, local := range input.LocalProfiles {
+		base := signingAuditFinding{
+			Source: "local",
+			Kind:   "profile",
+			ID:     local.UUID,
+			Name:   local.Name,
+			Path:   local.Path,
+		}
+		if !local.ExpiresAt.IsZero() {
+			base.ExpiresAt = local.ExpiresAt.UTC().Format(time.RFC3339)
+			if finding, ok := signingExpiryFinding(local.ExpiresAt, now, window); ok {
+				finding.Source, finding.Kind, finding.ID, finding.Name, finding.Path = base.Source, base.Kind, base.ID, base.Name, base.Path
+				findings = append(findings, finding)
+			}
+		}
+		if !input.Remote {
+			continue
+		}
+
+		if len(local.CertificateSerials) > 0 {
+			signed := false
+			for _, serial := range local.CertificateSerials {
+				if activeSerials[normalizeCertificateSerial(serial)] {
+					signed = true
+					break
+				}
+			}
+			if !signed {
+				finding := base
+				finding.Severity = signingAuditSeverityError
+				finding.Issue = signingAuditIssueRevokedCertificate
+				finding.Detail = "none of the profile's certificates are active"
+				findings = append(findings, finding)
+			}
+		}
+
+		var disabled []string
+		for _, udid := range local.DeviceUDIDs {
+			if name, ok := disabledUDIDs[strings.ToUpper(udid)]; ok {
+				disabled = append(disabled, firstNonEmpty(name, udid))
+			}
+		}
+		if len(disabled) > 0 {
+			finding := base
+			finding.Severity = signingAuditSeverityWarning
+			finding.Issue = signingAuditIssueDisabledDevice
+			finding.Detail = "disabled device(s): " + strings.Join(disabled, ", ")
+			findings = append(findings, finding)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return findings[i].Severity == signingAuditSeverityError
+		}
+		if findings[i].ExpiresAt != findings[j].ExpiresAt {
+			return findings[i].ExpiresAt < findings[j].ExpiresAt
+		}
+		return findings[i].ID < findings[j].ID
+	})
+	return findings
+}
+
+// signingExpiryFinding reports expired assets as errors and assets expiring
+// within window as warnings.
+func signingExpiryFinding(expiresAt, now time.Time, window time.Duration) (signingAuditFinding, bool) {
+	finding := signingAuditFinding{ExpiresAt: expiresAt.UTC().Format(time.RFC3339)}
+	switch {
+	case !now.Before(expiresAt):
+		finding.Severity = signingAuditSeverityError
+		finding.Issue = signingAuditIssueExpired
+	case expiresAt.Sub(now) <= window:
+		finding.Severity = signingAuditSeverityWarning
+		finding.Issue = signingAuditIssueExpiring
+		finding.Detail = fmt.Sprintf("expires in %d day(s)", int(expiresAt.Sub(now).Hours()/24))
+	default:
+		return finding, false
+	}
+	return finding, true
+}
+
+func parseSigningAuditTime(value string) (time.Time, bool) {
+	parsed, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return parsed, true
+}
+
+// normalizeCertificateSerial makes API serial numbers comparable with the
+// hex serials parsed from profile certificates.
+func normalizeCertificateSerial(serial string) string {
+	normalized := strings.TrimLeft(strings.ToUpper(strings.TrimSpace(serial)), "0")
+	if normalized == "" {
+		return "0"
+	}
+	return normalized
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func summarizeSigningAudit(input signingAuditInput, findings []signingAuditFinding) signingAuditSummary {
+	summary := signingAuditSummary{
+		Certificates:  len(input.Certificates),
+		Profiles:      len(input.Profiles),
+		LocalProfiles: len(input.LocalProfiles),
+	}
+	for _, finding := range findings {
+		if finding.Severity == signingAuditSeverityError {
+			summary.Errors++
+		} else {
+			summary.Warnings++
+		}
+	}
+	return summary
+}
+
+func renderSigningAuditResult(result *signingAuditResult, markdown bool) error {
+	if result == nil {
+		return fmt.Errorf("result is nil")
+	}
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	render(
+		[]string{"Certificates", "Profiles", "Local Profiles", "Errors", "Warnings"},
+		[][]string{{
+			strconv.Itoa(result.Summary.Certificates),
+			strconv.Itoa(result.Summary.Profiles),
+			strconv.Itoa(result.Summary.LocalProfiles),
+			strconv.Itoa(result.Summary.Errors),
+			strconv.Itoa(result.Summary.Warnings),
+		}},
+	)
+	if len(result.Findings) == 0 {
+		fmt.Fprintln(os.Stdout, "No expiring or broken signing assets found.")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(result.Findings))
+	for _, finding := range result.Findings {
+		rows = append(rows, []string{
+			finding.Severity,
+			finding.Source + " " + finding.Kind,
+			finding.ID,
+			finding.Name,
+			finding.Issue,
+			finding.ExpiresAt,
+			finding.Detail,
+		})
+	}
+	render([]string{"Severity", "Asset", "ID", "Name", "Issue", "Expires", "Detail"}, rows)
+	return nil
+}
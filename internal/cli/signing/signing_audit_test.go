@@ -0,0 +1,92 @@
+package signing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/profiles"
+)
+
+func TestParseSigningAuditWindow(t *testing.T) {
+	tests := map[string]time.Duration{
+		"30d": 30 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+		"72h": 72 * time.Hour,
+		"0d":  0,
+	}
+	for value, want := range tests {
+		got, err := parseSigningAuditWindow(value)
+		if err != nil || got != want {
+			t.Fatalf("parseSigningAuditWindow(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "d", "soon", "-1d"} {
+		if _, err := parseSigningAuditWindow(value); err == nil {
+			t.Fatalf("expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestAuditSigningAssets(t *testing.T) {
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	input := signingAuditInput{
+		Remote: true,
+		Certificates: []asc.Resource[asc.CertificateAttributes]{
+			{ID: "CERT_OK", Attributes: asc.CertificateAttributes{SerialNumber: "0A1B", ExpirationDate: "2027-10-01T00:00:00.000+00:00"}},
+			{ID: "CERT_SOON", Attributes: asc.CertificateAttributes{Name: "Soon", ExpirationDate: "2026-11-01T00:00:00.000+00:00"}},
+		},
+		Profiles: []signingAuditProfile{
+			{ID: "P_OK", Attributes: asc.ProfileAttributes{ProfileState: asc.ProfileStateActive, ExpirationDate: "2027-10-01T00:00:00.000+00:00"}, CertificateIDs: []string{"CERT_OK"}},
+			{ID: "P_INVALID", Attributes: asc.ProfileAttributes{ProfileState: asc.ProfileStateInvalid, ExpirationDate: "2027-10-01T00:00:00.000+00:00"}, CertificateIDs: []string{"CERT_GONE"}, DeviceIDs: []string{"DEV_OFF"}},
+		},
+		DisabledDevices: []asc.Resource[asc.DeviceAttributes]{
+			{ID: "DEV_OFF", Attributes: asc.DeviceAttributes{Name: "Old iPhone", UDID: "udid-off"}},
+		},
+		LocalProfiles: []profiles.InstalledProfile{
+			{UUID: "LOCAL_OK", ExpiresAt: now.Add(365 * 24 * time.Hour), CertificateSerials: []string{"A1B"}},
+			{UUID: "LOCAL_EXPIRED", ExpiresAt: now.Add(-time.Hour), CertificateSerials: []string{"FFFF"}, DeviceUDIDs: []string{"UDID-OFF"}},
+		},
+	}
+
+	findings := auditSigningAssets(input, now, 30*24*time.Hour)
+
+	got := make(map[string]string)
+	for _, finding := range findings {
+		got[finding.ID+" "+finding.Issue] = finding.Severity
+	}
+	want := map[string]string{
+		"CERT_SOON expiring":                signingAuditSeverityWarning,
+		"P_INVALID invalid":                 signingAuditSeverityError,
+		"P_INVALID revoked-certificate":     signingAuditSeverityError,
+		"P_INVALID disabled-device":         signingAuditSeverityWarning,
+		"LOCAL_EXPIRED expired":             signingAuditSeverityError,
+		"LOCAL_EXPIRED revoked-certificate": signingAuditSeverityError,
+		"LOCAL_EXPIRED disabled-device":     signingAuditSeverityWarning,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("findings = %v, want %v", got, want)
+	}
+	for key, severity := range want {
+		if got[key] != severity {
+			t.Fatalf("finding %q = %q, want %q (all: %v)", key, got[key], severity, got)
+		}
+	}
+	if findings[0].Severity != signingAuditSeverityError || findings[len(findings)-1].Severity != signingAuditSeverityWarning {
+		t.Fatalf("expected errors before warnings, got %+v", findings)
+	}
+}
+
+func TestAuditSigningAssets_LocalOnlySkipsRemoteChecks(t *testing.T) {
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	input := signingAuditInput{
+		LocalProfiles: []profiles.InstalledProfile{
+			{UUID: "LOCAL", ExpiresAt: now.Add(10 * 24 * time.Hour), CertificateSerials: []string{"FFFF"}},
+		},
+	}
+
+	findings := auditSigningAssets(input, now, 30*24*time.Hour)
+	if len(findings) != 1 || findings[0].Issue != signingAuditIssueExpiring {
+		t.Fatalf("expected only an expiring finding, got %+v", findings)
+	}
+}
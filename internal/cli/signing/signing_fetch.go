@@ -185,6 +185,17 @@ func findCertificates(ctx context.Context, client *asc.Client, profileType, cert
 		certType = inferred
 	}
 
+	certs, err := listCertificatesByType(ctx, client, certType)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs.Data) == 0 {
+		return nil, fmt.Errorf("no certificates found for type %s", certType)
+	}
+	return certs, nil
+}
+
+func listCertificatesByType(ctx context.Context, client *asc.Client, certType string) (*asc.CertificatesResponse, error) {
 	var (
 		all   []asc.Resource[asc.CertificateAttributes]
 		links asc.Links
@@ -205,9 +216,6 @@ func findCertificates(ctx context.Context, client *asc.Client, profileType, cert
 		}
 		next = resp.Links.Next
 	}
-	if len(all) == 0 {
-		return nil, fmt.Errorf("no certificates found for type %s", certType)
-	}
 	return &asc.CertificatesResponse{Data: all, Links: links}, nil
 }
 
@@ -248,20 +256,24 @@ func findOrCreateProfile(ctx context.Context, client *asc.Client, bundleIDResour
 	if !createMissing {
 		return nil, false, fmt.Errorf("no active profile found for bundle ID; use --create-missing to create one")
 	}
-	if len(certIDs) == 0 {
-		return nil, false, fmt.Errorf("no certificates available to create profile")
-	}
 	name := fmt.Sprintf("%s-%s", profileType, time.Now().Format("20060102"))
-	profile, err := client.CreateProfile(ctx, asc.ProfileCreateAttributes{
-		Name:        name,
-		ProfileType: profileType,
-	}, bundleIDResourceID, certIDs, deviceIDs)
+	profile, err := createProfile(ctx, client, name, bundleIDResourceID, profileType, certIDs, deviceIDs)
 	if err != nil {
 		return nil, false, err
 	}
 	return profile, true, nil
 }
 
+func createProfile(ctx context.Context, client *asc.Client, name, bundleIDResourceID, profileType string, certIDs, deviceIDs []string) (*asc.ProfileResponse, error) {
+	if len(certIDs) == 0 {
+		return nil, fmt.Errorf("no certificates available to create profile")
+	}
+	return client.CreateProfile(ctx, asc.ProfileCreateAttributes{
+		Name:        name,
+		ProfileType: profileType,
+	}, bundleIDResourceID, certIDs, deviceIDs)
+}
+
 func isDevelopmentProfile(profileType string) bool {
 	normalized := strings.ToUpper(strings.TrimSpace(profileType))
 	return strings.Contains(normalized, "DEVELOPMENT") ||
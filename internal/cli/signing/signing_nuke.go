@@ -0,0 +1,205 @@
+package signing
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+type signingNukeResult struct {
+	Store               string   `json:"store"`
+	DryRun              bool     `json:"dryRun"`
+	RevokedCertificates []string `json:"revokedCertificates"`
+	DeletedProfiles     []string `json:"deletedProfiles"`
+	Committed           bool     `json:"committed"`
+}
+
+// SigningNukeCommand returns the signing nuke subcommand.
+func SigningNukeCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("nuke", flag.ExitOnError)
+
+	storePath := fs.String("store", "", "Signing store directory, optionally the root of a git working tree (required)")
+	certTypes := fs.String("certificate-type", "", "Only nuke certificates of these types, comma-separated (default: all)")
+	dryRun := fs.Bool("dry-run", false, "List assets that would be removed without changing anything")
+	confirm := fs.Bool("confirm", false, "Confirm revoking certificates and deleting profiles (required)")
+	output := shared.BindOutputFlagsWith(fs, "format", "json", "Output format for metadata: json (default), table, markdown")
+
+	return &ffcli.Command{
+		Name:       "nuke",
+		ShortUsage: "asc signing nuke --store ./signing-store --confirm [flags]",
+		ShortHelp:  "Revoke and remove the assets tracked by a signing store.",
+		LongHelp: `Revoke and remove the assets tracked by a signing store.
+
+Revokes the stored certificates in App Store Connect, deletes the profiles
+signed by them, and removes their encrypted files from the store. Run
+signing sync afterwards to create a fresh identity. No passphrase is needed.
+
+Examples:
+  asc signing nuke --store ./signing-store --dry-run
+  asc signing nuke --store ./signing-store --confirm
+  asc signing nuke --store ./signing-store --certificate-type IOS_DEVELOPMENT --confirm`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			storeDir := strings.TrimSpace(*storePath)
+			if storeDir == "" {
+				fmt.Fprintln(os.Stderr, "Error: --store is required")
+				return flag.ErrHelp
+			}
+			if !*dryRun && !*confirm {
+				fmt.Fprintln(os.Stderr, "Error: --confirm is required")
+				return flag.ErrHelp
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			store, err := openSigningStore(requestCtx, storeDir, "")
+			if err != nil {
+				return fmt.Errorf("signing nuke: %w", err)
+			}
+
+			filter := make(map[string]bool)
+			for _, certType := range shared.SplitCSVUpper(*certTypes) {
+				filter[certType] = true
+			}
+			certs, profiles := selectSigningStoreNukeTargets(store.index, filter)
+
+			result := &signingNukeResult{
+				Store:               storeDir,
+				DryRun:              *dryRun,
+				RevokedCertificates: make([]string, 0, len(certs)),
+				DeletedProfiles:     make([]string, 0, len(profiles)),
+			}
+			for _, profile := range profiles {
+				result.DeletedProfiles = append(result.DeletedProfiles, profile.ID)
+			}
+			for _, cert := range certs {
+				result.RevokedCertificates = append(result.RevokedCertificates, cert.ID)
+			}
+
+			if !*dryRun && (len(certs) > 0 || len(profiles) > 0) {
+				client, err := shared.GetASCClient()
+				if err != nil {
+					return fmt.Errorf("signing nuke: %w", err)
+				}
+				if err := nukeSigningStoreAssets(requestCtx, client, store, certs, profiles); err != nil {
+					return fmt.Errorf("signing nuke: %w", err)
+				}
+				result.Committed, err = store.commit(requestCtx, "Nuke signing assets")
+				if err != nil {
+					return fmt.Errorf("signing nuke: %w", err)
+				}
+			}
+
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderSigningNukeResult(result, false) },
+				func() error { return renderSigningNukeResult(result, true) },
+			)
+		},
+	}
+}
+
+// selectSigningStoreNukeTargets returns the certificates matching filter
+// (all when empty) and every profile signed by one of them.
+func selectSigningStoreNukeTargets(index *signingStoreIndex, filter map[string]bool) ([]signingStoreCertificate, []signingStoreProfile) {
+	var certs []signingStoreCertificate
+	selected := make(map[string]bool)
+	for _, cert := range index.Certificates {
+		if len(filter) > 0 && !filter[cert.CertificateType] {
+			continue
+		}
+		certs = append(certs, cert)
+		selected[cert.ID] = true
+	}
+
+	var profiles []signingStoreProfile
+	for _, profile := range index.Profiles {
+		if selected[profile.CertificateID] {
+			profiles = append(profiles, profile)
+		}
+	}
+	return certs, profiles
+}
+
+// nukeSigningStoreAssets deletes profiles before revoking their certificates.
+// The index is saved after each removal so a failed run can be retried.
+func nukeSigningStoreAssets(ctx context.Context, client *asc.Client, store *signingStore, certs []signingStoreCertificate, profiles []signingStoreProfile) error {
+	for _, profile := range profiles {
+		if err := client.DeleteProfile(ctx, profile.ID); err != nil && !asc.IsNotFound(err) {
+			return fmt.Errorf("delete profile %s: %w", profile.ID, err)
+		}
+		if err := store.removeFile(profile.File); err != nil {
+			return err
+		}
+		store.index.Profiles = removeSigningStoreEntry(store.index.Profiles, func(entry signingStoreProfile) bool {
+			return entry.ID == profile.ID
+		})
+		if err := store.saveIndex(); err != nil {
+			return fmt.Errorf("write index: %w", err)
+		}
+	}
+
+	for _, cert := range certs {
+		if err := client.RevokeCertificate(ctx, cert.ID); err != nil && !asc.IsNotFound(err) {
+			return fmt.Errorf("revoke certificate %s: %w", cert.ID, err)
+		}
+		if err := store.removeFile(cert.CertificateFile); err != nil {
+			return err
+		}
+		if err := store.removeFile(cert.KeyFile); err != nil {
+			return err
+		}
+		store.index.Certificates = removeSigningStoreEntry(store.index.Certificates, func(entry signingStoreCertificate) bool {
+			return entry.ID == cert.ID
+		})
+		if err := store.saveIndex(); err != nil {
+			return fmt.Errorf("write index: %w", err)
+		}
+	}
+	return nil
+}
+
+func removeSigningStoreEntry[T any](entries []T, match func(T) bool) []T {
+	kept := entries[:0]
+	for _, entry := range entries {
+		if !match(entry) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+func renderSigningNukeResult(result *signingNukeResult, markdown bool) error {
+	if result == nil {
+		return fmt.Errorf("result is nil")
+	}
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+	action := "removed"
+	if result.DryRun {
+		action = "would remove"
+	}
+	rows := make([][]string, 0, len(result.RevokedCertificates)+len(result.DeletedProfiles))
+	for _, id := range result.DeletedProfiles {
+		rows = append(rows, []string{"profile", id, action})
+	}
+	for _, id := range result.RevokedCertificates {
+		rows = append(rows, []string{"certificate", id, action})
+	}
+	render([]string{"Asset", "ID", "Action"}, rows)
+	return nil
+}
@@ -0,0 +1,376 @@
+package signing
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	signingStoreIndexFile      = "index.json"
+	signingStoreVersion        = 1
+	signingStoreKDF            = "pbkdf2-sha256"
+	signingStoreKDFIterations  = 600000
+	signingStoreCheckPlaintext = "asc-signing-store"
+	defaultPassphraseEnv       = "ASC_SIGNING_PASSPHRASE"
+)
+
+// signingStoreMagic prefixes every encrypted file so stray plaintext is
+// never mistaken for store content.
+var signingStoreMagic = []byte("ASCENC1\x00")
+
+// signingStoreIndex is the plaintext manifest of a store. It records only
+// identifiers and dates; certificates, keys, and profiles are encrypted.
+type signingStoreIndex struct {
+	Version      int                       `json:"version"`
+	KDF          signingStoreKDFParams     `json:"kdf"`
+	Check        string                    `json:"check"`
+	Certificates []signingStoreCertificate `json:"certificates"`
+	Profiles     []signingStoreProfile     `json:"profiles"`
+}
+
+type signingStoreKDFParams struct {
+	Algorithm  string `json:"algorithm"`
+	Iterations int    `json:"iterations"`
+	Salt       string `json:"salt"`
+}
+
+type signingStoreCertificate struct {
+	ID              string `json:"id"`
+	CertificateType string `json:"certificateType"`
+	SerialNumber    string `json:"serialNumber,omitempty"`
+	ExpirationDate  string `json:"expirationDate,omitempty"`
+	CertificateFile string `json:"certificateFile"`
+	KeyFile         string `json:"keyFile"`
+}
+
+type signingStoreProfile struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	ProfileType    string `json:"profileType"`
+	BundleID       string `json:"bundleId"`
+	CertificateID  string `json:"certificateId"`
+	ExpirationDate string `json:"expirationDate,omitempty"`
+	File           string `json:"file"`
+}
+
+// signingStore is an opened store directory with its derived key.
+type signingStore struct {
+	dir   string
+	index *signingStoreIndex
+	key   []byte
+	git   bool
+}
+
+// openSigningStore loads the store index, creating a new one when the
+// directory is empty. Git-backed stores are fast-forwarded first. An empty
+// passphrase opens the index without a key, which is enough for operations
+// that never touch encrypted content.
+func openSigningStore(ctx context.Context, dir, passphrase string) (*signingStore, error) {
+	store := &signingStore{dir: dir, git: isGitStoreRoot(ctx, dir)}
+	if err := store.pull(ctx); err != nil {
+		return nil, err
+	}
+
+	index, err := readSigningStoreIndex(filepath.Join(dir, signingStoreIndexFile))
+	if err != nil {
+		return nil, err
+	}
+	if index == nil {
+		if passphrase == "" {
+			return nil, fmt.Errorf("no signing store found in %s", dir)
+		}
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		index = &signingStoreIndex{
+			Version: signingStoreVersion,
+			KDF: signingStoreKDFParams{
+				Algorithm:  signingStoreKDF,
+				Iterations: signingStoreKDFIterations,
+				Salt:       base64.StdEncoding.EncodeToString(salt),
+			},
+		}
+	}
+	if index.Version != signingStoreVersion {
+		return nil, fmt.Errorf("unsupported signing store version %d", index.Version)
+	}
+	store.index = index
+
+	if passphrase == "" {
+		return store, nil
+	}
+	if err := store.unlock(passphrase); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func readSigningStoreIndex(path string) (*signingStoreIndex, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	var index signingStoreIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("invalid signing store index %s: %w", path, err)
+	}
+	return &index, nil
+}
+
+// unlock derives the store key and verifies it against the check value.
+func (s *signingStore) unlock(passphrase string) error {
+	if s.index.KDF.Algorithm != signingStoreKDF || s.index.KDF.Iterations <= 0 {
+		return fmt.Errorf("unsupported signing store key derivation %q", s.index.KDF.Algorithm)
+	}
+	salt, err := base64.StdEncoding.DecodeString(s.index.KDF.Salt)
+	if err != nil {
+		return fmt.Errorf("invalid signing store salt: %w", err)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, s.index.KDF.Iterations, 32)
+	if err != nil {
+		return err
+	}
+	s.key = key
+
+	if s.index.Check == "" {
+		sealed, err := s.seal([]byte(signingStoreCheckPlaintext), signingStoreIndexFile)
+		if err != nil {
+			return err
+		}
+		s.index.Check = base64.StdEncoding.EncodeToString(sealed)
+		return nil
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(s.index.Check)
+	if err != nil {
+		return fmt.Errorf("invalid signing store check value: %w", err)
+	}
+	plaintext, err := s.open(sealed, signingStoreIndexFile)
+	if err != nil || string(plaintext) != signingStoreCheckPlaintext {
+		return fmt.Errorf("incorrect signing store passphrase")
+	}
+	return nil
+}
+
+// seal encrypts data with AES-256-GCM. The store-relative path is bound as
+// additional data so encrypted files cannot be swapped between entries.
+func (s *signingStore) seal(data []byte, name string) ([]byte, error) {
+	gcm, err := s.cipher()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	out := append([]byte{}, signingStoreMagic...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, data, []byte(name)), nil
+}
+
+func (s *signingStore) open(data []byte, name string) ([]byte, error) {
+	gcm, err := s.cipher()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(data, signingStoreMagic) || len(data) < len(signingStoreMagic)+gcm.NonceSize() {
+		return nil, fmt.Errorf("%s is not an encrypted signing store file", name)
+	}
+	data = data[len(signingStoreMagic):]
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(name))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt %s: %w", name, err)
+	}
+	return plaintext, nil
+}
+
+func (s *signingStore) cipher() (cipher.AEAD, error) {
+	if len(s.key) == 0 {
+		return nil, fmt.Errorf("signing store is locked")
+	}
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// writeFile encrypts data into the store at the slash-separated name.
+func (s *signingStore) writeFile(name string, data []byte) error {
+	sealed, err := s.seal(data, name)
+	if err != nil {
+		return err
+	}
+	_, err = shared.WriteFileNoSymlinkOverwrite(s.path(name), bytes.NewReader(sealed), 0o600, ".asc-signing-*", ".asc-signing-backup-*")
+	return err
+}
+
+func (s *signingStore) readFile(name string) ([]byte, error) {
+	file, err := shared.OpenExistingNoFollow(s.path(name))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(data, name)
+}
+
+func (s *signingStore) removeFile(name string) error {
+	if err := os.Remove(s.path(name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *signingStore) path(name string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(name))
+}
+
+func (s *signingStore) saveIndex() error {
+	data, err := json.MarshalIndent(s.index, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	_, err = shared.WriteFileNoSymlinkOverwrite(filepath.Join(s.dir, signingStoreIndexFile), bytes.NewReader(data), 0o644, ".asc-signing-index-*", ".asc-signing-index-backup-*")
+	return err
+}
+
+func signingStoreCertificatePaths(certType, certID string) (string, string) {
+	base := path.Join("certificates", safeFileName(certType, "certificate"), safeFileName(certID, "certificate"))
+	return base + ".cer.enc", base + ".key.enc"
+}
+
+func signingStoreProfilePath(profileType, bundleID, profileID string) string {
+	name := safeFileName(bundleID, "profile") + "-" + safeFileName(profileID, "profile")
+	return path.Join("profiles", safeFileName(profileType, "profile"), name+".mobileprovision.enc")
+}
+
+// pull fast-forwards a git-backed store before it is read.
+func (s *signingStore) pull(ctx context.Context) error {
+	if !s.git || !s.hasUpstream(ctx) {
+		return nil
+	}
+	_, err := runStoreGit(ctx, s.dir, "pull", "--ff-only", "--quiet")
+	return err
+}
+
+// commit records store changes in a git-backed store and pushes them when
+// the branch tracks a remote. It reports whether a commit was created.
+func (s *signingStore) commit(ctx context.Context, message string) (bool, error) {
+	if !s.git {
+		return false, nil
+	}
+	if _, err := runStoreGit(ctx, s.dir, "add", "-A", "--", "."); err != nil {
+		return false, err
+	}
+	status, err := runStoreGit(ctx, s.dir, "status", "--porcelain", "--", ".")
+	if err != nil {
+		return false, err
+	}
+	if strings.TrimSpace(status) == "" {
+		return false, nil
+	}
+	if _, err := runStoreGit(ctx, s.dir, "commit", "--quiet", "-m", message, "--", "."); err != nil {
+		return false, err
+	}
+	if s.hasUpstream(ctx) {
+		if _, err := runStoreGit(ctx, s.dir, "push", "--quiet"); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// isGitStoreRoot reports whether dir is the top level of a git working tree,
+// including worktrees and submodules where .git is a file. A store nested
+// inside some other repository, such as the app's own, is not committed to.
+func isGitStoreRoot(ctx context.Context, dir string) bool {
+	out, err := runStoreGit(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return false
+	}
+	topLevel, err := filepath.EvalSymlinks(strings.TrimSpace(out))
+	if err != nil {
+		return false
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	storeDir, err := filepath.EvalSymlinks(absDir)
+	return err == nil && storeDir == topLevel
+}
+
+func (s *signingStore) hasUpstream(ctx context.Context) bool {
+	_, err := runStoreGit(ctx, s.dir, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}")
+	return err == nil
+}
+
+func runStoreGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = cleanGitEnv(os.Environ())
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return "", fmt.Errorf("git %s failed: %w", args[0], err)
+		}
+		return "", fmt.Errorf("git %s failed: %s", args[0], msg)
+	}
+	return stdout.String(), nil
+}
+
+// cleanGitEnv drops repo override variables exported by git hooks so
+// commands target the store directory.
+func cleanGitEnv(env []string) []string {
+	out := make([]string, 0, len(env))
+	for _, kv := range env {
+		switch {
+		case strings.HasPrefix(kv, "GIT_DIR="),
+			strings.HasPrefix(kv, "GIT_WORK_TREE="),
+			strings.HasPrefix(kv, "GIT_INDEX_FILE="),
+			strings.HasPrefix(kv, "GIT_COMMON_DIR="):
+			continue
+		}
+		out = append(out, kv)
+	}
+	return out
+}
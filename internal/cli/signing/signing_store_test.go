@@ -0,0 +1,164 @@
+package signing
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSigningStore_EncryptsAndReopens(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := openSigningStore(context.Background(), dir, "correct horse")
+	if err != nil {
+		t.Fatalf("openSigningStore() error: %v", err)
+	}
+	certFile, keyFile := signingStoreCertificatePaths("IOS_DISTRIBUTION", "CERT_1")
+	if err := store.writeFile(keyFile, []byte("private key")); err != nil {
+		t.Fatalf("writeFile() error: %v", err)
+	}
+	store.index.Certificates = append(store.index.Certificates, signingStoreCertificate{ID: "CERT_1", CertificateType: "IOS_DISTRIBUTION", CertificateFile: certFile, KeyFile: keyFile})
+	if err := store.saveIndex(); err != nil {
+		t.Fatalf("saveIndex() error: %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(keyFile)))
+	if err != nil {
+		t.Fatalf("read encrypted file: %v", err)
+	}
+	if bytes.Contains(raw, []byte("private key")) {
+		t.Fatal("expected key to be encrypted at rest")
+	}
+
+	reopened, err := openSigningStore(context.Background(), dir, "correct horse")
+	if err != nil {
+		t.Fatalf("reopen error: %v", err)
+	}
+	data, err := reopened.readFile(keyFile)
+	if err != nil || string(data) != "private key" {
+		t.Fatalf("readFile() = %q, %v", data, err)
+	}
+	if len(reopened.index.Certificates) != 1 {
+		t.Fatalf("expected index to persist, got %+v", reopened.index.Certificates)
+	}
+
+	if _, err := openSigningStore(context.Background(), dir, "wrong"); err == nil || !strings.Contains(err.Error(), "incorrect signing store passphrase") {
+		t.Fatalf("expected passphrase error, got %v", err)
+	}
+
+	// Encrypted files are bound to their path and cannot be swapped.
+	if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(certFile)), raw, 0o600); err != nil {
+		t.Fatalf("copy encrypted file: %v", err)
+	}
+	if _, err := reopened.readFile(certFile); err == nil {
+		t.Fatal("expected moved ciphertext to fail authentication")
+	}
+}
+
+func TestOpenSigningStore_RequiresIndexWithoutPassphrase(t *testing.T) {
+	if _, err := openSigningStore(context.Background(), t.TempDir(), ""); err == nil || !strings.Contains(err.Error(), "no signing store found") {
+		t.Fatalf("expected missing store error, got %v", err)
+	}
+}
+
+func TestSelectSigningStoreNukeTargets(t *testing.T) {
+	index := &signingStoreIndex{
+		Certificates: []signingStoreCertificate{
+			{ID: "DIST", CertificateType: "IOS_DISTRIBUTION"},
+			{ID: "DEV", CertificateType: "IOS_DEVELOPMENT"},
+		},
+		Profiles: []signingStoreProfile{
+			{ID: "P_STORE", CertificateID: "DIST"},
+			{ID: "P_DEV", CertificateID: "DEV"},
+		},
+	}
+
+	certs, profiles := selectSigningStoreNukeTargets(index, map[string]bool{"IOS_DEVELOPMENT": true})
+	if len(certs) != 1 || certs[0].ID != "DEV" || len(profiles) != 1 || profiles[0].ID != "P_DEV" {
+		t.Fatalf("unexpected targets: %+v %+v", certs, profiles)
+	}
+
+	certs, profiles = selectSigningStoreNukeTargets(index, nil)
+	if len(certs) != 2 || len(profiles) != 2 {
+		t.Fatalf("expected all assets without filter, got %+v %+v", certs, profiles)
+	}
+}
+
+func TestSigningAssetExpired(t *testing.T) {
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	if !signingAssetExpired("2026-10-01T00:00:00.000+00:00", now) {
+		t.Fatal("expected past expiration to be expired")
+	}
+	if signingAssetExpired("2027-10-01T00:00:00.000+00:00", now) {
+		t.Fatal("expected future expiration to be valid")
+	}
+	if signingAssetExpired("", now) {
+		t.Fatal("expected missing expiration to be treated as valid")
+	}
+}
+
+func TestSigningStore_CommitsGitWorkingTree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"config", "user.email", "ci@example.com"},
+		{"config", "user.name", "CI"},
+	} {
+		if _, err := runStoreGit(context.Background(), dir, args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+
+	store, err := openSigningStore(context.Background(), dir, "secret")
+	if err != nil {
+		t.Fatalf("openSigningStore() error: %v", err)
+	}
+	if !store.git {
+		t.Fatal("expected git working tree to be detected")
+	}
+	if err := store.saveIndex(); err != nil {
+		t.Fatalf("saveIndex() error: %v", err)
+	}
+
+	committed, err := store.commit(context.Background(), "Sync signing assets")
+	if err != nil || !committed {
+		t.Fatalf("commit() = %v, %v", committed, err)
+	}
+	committed, err = store.commit(context.Background(), "Sync signing assets")
+	if err != nil || committed {
+		t.Fatalf("expected no-op commit without changes, got %v, %v", committed, err)
+	}
+}
+
+func TestSigningStore_IgnoresStoreNestedInAnotherRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	if _, err := runStoreGit(context.Background(), dir, "init", "--quiet"); err != nil {
+		t.Fatalf("git init: %v", err)
+	}
+	storeDir := filepath.Join(dir, "signing")
+	if err := os.MkdirAll(storeDir, 0o700); err != nil {
+		t.Fatalf("create store dir: %v", err)
+	}
+
+	store, err := openSigningStore(context.Background(), storeDir, "secret")
+	if err != nil {
+		t.Fatalf("openSigningStore() error: %v", err)
+	}
+	if store.git {
+		t.Fatal("expected a store nested inside another repo not to be git-backed")
+	}
+	if committed, err := store.commit(context.Background(), "Sync signing assets"); err != nil || committed {
+		t.Fatalf("expected no commit for nested store, got %v, %v", committed, err)
+	}
+}
@@ -0,0 +1,477 @@
+package signing
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+type signingSyncResult struct {
+	Store              string   `json:"store"`
+	BundleID           string   `json:"bundleId"`
+	ProfileType        string   `json:"profileType"`
+	CertificateType    string   `json:"certificateType"`
+	CertificateID      string   `json:"certificateId"`
+	CertificateCreated bool     `json:"certificateCreated"`
+	ProfileID          string   `json:"profileId"`
+	ProfileName        string   `json:"profileName,omitempty"`
+	ProfileCreated     bool     `json:"profileCreated"`
+	Readonly           bool     `json:"readonly"`
+	Committed          bool     `json:"committed"`
+	OutputFiles        []string `json:"outputFiles,omitempty"`
+}
+
+// SigningSyncCommand returns the signing sync subcommand.
+func SigningSyncCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+
+	storePath := fs.String("store", "", "Signing store directory, optionally the root of a git working tree (required)")
+	passphraseEnv := fs.String("passphrase-env", defaultPassphraseEnv, "Environment variable holding the store passphrase")
+	bundleID := fs.String("bundle-id", "", "Bundle identifier (e.g., com.example.app) - required")
+	profileType := fs.String("profile-type", "", "Profile type: IOS_APP_STORE, IOS_APP_DEVELOPMENT, MAC_APP_STORE, etc. (required)")
+	deviceIDs := fs.String("device", "", "Device ID(s), comma-separated (required for development profiles)")
+	certType := fs.String("certificate-type", "", "Certificate type (inferred from --profile-type when omitted)")
+	readonly := fs.Bool("readonly", false, "Only read the store; never create assets or write to the store")
+	outputPath := fs.String("output", "", "Directory to write decrypted certificate, key, and profile (optional)")
+	output := shared.BindOutputFlagsWith(fs, "format", "json", "Output format for metadata: json (default), table, markdown")
+
+	return &ffcli.Command{
+		Name:       "sync",
+		ShortUsage: "asc signing sync --store ./signing-store --bundle-id BUNDLE_ID --profile-type TYPE [flags]",
+		ShortHelp:  "Sync signing assets through an encrypted shared store.",
+		LongHelp: `Sync signing assets through an encrypted shared store.
+
+The store keeps one certificate identity per certificate type, its private
+key, and provisioning profiles, each encrypted with AES-256-GCM using a key
+derived from the passphrase. index.json lists asset IDs and dates only.
+
+Sync reuses stored assets that are still valid in App Store Connect. A
+missing or revoked certificate is replaced by a newly created one, and
+missing profiles are found or created for the stored certificate. When the
+store directory is the root of a git working tree, it is pulled before
+syncing and changes are committed (and pushed when a tracking branch is set).
+A store nested inside another repository is never committed to.
+
+Use --readonly in CI to decrypt existing assets without calling App Store
+Connect or changing the store.
+
+Examples:
+  ASC_SIGNING_PASSPHRASE=... asc signing sync --store ./signing-store --bundle-id com.example.app --profile-type IOS_APP_STORE
+  asc signing sync --store ./signing-store --passphrase-env MATCH_PASSWORD --bundle-id com.example.app --profile-type IOS_APP_DEVELOPMENT --device "DEVICE1,DEVICE2"
+  asc signing sync --store ./signing-store --bundle-id com.example.app --profile-type IOS_APP_STORE --readonly --output ./signing`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			storeDir := strings.TrimSpace(*storePath)
+			if storeDir == "" {
+				fmt.Fprintln(os.Stderr, "Error: --store is required")
+				return flag.ErrHelp
+			}
+			bundle := strings.TrimSpace(*bundleID)
+			if bundle == "" {
+				fmt.Fprintln(os.Stderr, "Error: --bundle-id is required")
+				return flag.ErrHelp
+			}
+			profType := strings.ToUpper(strings.TrimSpace(*profileType))
+			if profType == "" {
+				fmt.Fprintln(os.Stderr, "Error: --profile-type is required")
+				return flag.ErrHelp
+			}
+			devices := shared.SplitCSV(*deviceIDs)
+			if !*readonly && isDevelopmentProfile(profType) && len(devices) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --device is required for development profiles")
+				return flag.ErrHelp
+			}
+
+			envName := strings.TrimSpace(*passphraseEnv)
+			if envName == "" {
+				return shared.UsageError("--passphrase-env must not be empty")
+			}
+			passphrase := os.Getenv(envName)
+			if passphrase == "" {
+				return shared.UsageErrorf("%s is not set; export the store passphrase or pass --passphrase-env", envName)
+			}
+
+			resolvedCertType := strings.ToUpper(strings.TrimSpace(*certType))
+			if resolvedCertType == "" {
+				inferred, err := inferCertificateType(profType)
+				if err != nil {
+					return shared.UsageError(err.Error())
+				}
+				resolvedCertType = inferred
+			}
+
+			if *readonly {
+				if _, err := os.Stat(storeDir); err != nil {
+					return fmt.Errorf("signing sync: %w", err)
+				}
+			} else if err := os.MkdirAll(storeDir, 0o755); err != nil {
+				return fmt.Errorf("signing sync: create store dir: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			store, err := openSigningStore(requestCtx, storeDir, passphrase)
+			if err != nil {
+				return fmt.Errorf("signing sync: %w", err)
+			}
+
+			result := &signingSyncResult{
+				Store:           storeDir,
+				BundleID:        bundle,
+				ProfileType:     profType,
+				CertificateType: resolvedCertType,
+				Readonly:        *readonly,
+			}
+
+			var (
+				cert    signingStoreCertificate
+				profile signingStoreProfile
+			)
+			if *readonly {
+				cert, profile, err = findStoredSigningAssets(store, bundle, profType, resolvedCertType)
+				if err != nil {
+					return fmt.Errorf("signing sync: %w", err)
+				}
+			} else {
+				client, err := shared.GetASCClient()
+				if err != nil {
+					return fmt.Errorf("signing sync: %w", err)
+				}
+
+				bundleIDResp, err := findBundleID(requestCtx, client, bundle)
+				if err != nil {
+					return fmt.Errorf("signing sync: %w", err)
+				}
+
+				cert, result.CertificateCreated, err = syncStoreCertificate(requestCtx, client, store, resolvedCertType, time.Now())
+				if err != nil {
+					return fmt.Errorf("signing sync: %w", err)
+				}
+				// Record a new identity before touching profiles so a failure
+				// below cannot orphan its private key and use up another
+				// certificate on the next run.
+				if result.CertificateCreated {
+					if err := store.saveIndex(); err != nil {
+						return fmt.Errorf("signing sync: write index: %w", err)
+					}
+					result.Committed, err = store.commit(requestCtx, fmt.Sprintf("Add %s certificate", resolvedCertType))
+					if err != nil {
+						return fmt.Errorf("signing sync: %w", err)
+					}
+				}
+				profile, result.ProfileCreated, err = syncStoreProfile(requestCtx, client, store, bundleIDResp.Data.ID, bundle, profType, cert, devices)
+				if err != nil {
+					return fmt.Errorf("signing sync: %w", err)
+				}
+
+				if err := store.saveIndex(); err != nil {
+					return fmt.Errorf("signing sync: write index: %w", err)
+				}
+				committed, err := store.commit(requestCtx, fmt.Sprintf("Sync %s %s signing assets", bundle, profType))
+				if err != nil {
+					return fmt.Errorf("signing sync: %w", err)
+				}
+				result.Committed = result.Committed || committed
+			}
+			result.CertificateID = cert.ID
+			result.ProfileID = profile.ID
+			result.ProfileName = profile.Name
+
+			if dir := strings.TrimSpace(*outputPath); dir != "" {
+				result.OutputFiles, err = exportSigningAssets(store, cert, profile, dir)
+				if err != nil {
+					return fmt.Errorf("signing sync: %w", err)
+				}
+			}
+
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderSigningSyncResult(result, false) },
+				func() error { return renderSigningSyncResult(result, true) },
+			)
+		},
+	}
+}
+
+// findStoredSigningAssets selects stored assets without contacting App Store Connect.
+func findStoredSigningAssets(store *signingStore, bundleID, profileType, certType string) (signingStoreCertificate, signingStoreProfile, error) {
+	for _, cert := range store.index.Certificates {
+		if cert.CertificateType != certType {
+			continue
+		}
+		for _, profile := range store.index.Profiles {
+			if profile.BundleID == bundleID && profile.ProfileType == profileType && profile.CertificateID == cert.ID {
+				return cert, profile, nil
+			}
+		}
+	}
+	return signingStoreCertificate{}, signingStoreProfile{}, fmt.Errorf("store has no %s profile for %s signed by a %s certificate; run signing sync without --readonly", profileType, bundleID, certType)
+}
+
+// syncStoreCertificate returns the stored certificate of certType that is
+// still valid, creating and storing a new identity when none is. Stored
+// certificates that were revoked or expired are dropped from the store.
+func syncStoreCertificate(ctx context.Context, client *asc.Client, store *signingStore, certType string, now time.Time) (signingStoreCertificate, bool, error) {
+	remote, err := listCertificatesByType(ctx, client, certType)
+	if err != nil {
+		return signingStoreCertificate{}, false, err
+	}
+	valid := make(map[string]bool, len(remote.Data))
+	for _, cert := range remote.Data {
+		if !signingAssetExpired(cert.Attributes.ExpirationDate, now) {
+			valid[cert.ID] = true
+		}
+	}
+
+	var (
+		kept     []signingStoreCertificate
+		selected *signingStoreCertificate
+	)
+	for _, entry := range store.index.Certificates {
+		if entry.CertificateType == certType && !valid[entry.ID] {
+			if err := store.removeFile(entry.CertificateFile); err != nil {
+				return signingStoreCertificate{}, false, err
+			}
+			if err := store.removeFile(entry.KeyFile); err != nil {
+				return signingStoreCertificate{}, false, err
+			}
+			continue
+		}
+		kept = append(kept, entry)
+		if entry.CertificateType == certType && selected == nil {
+			selected = &entry
+		}
+	}
+	store.index.Certificates = kept
+	if selected != nil {
+		return *selected, false, nil
+	}
+
+	entry, err := createStoreCertificate(ctx, client, store, certType)
+	if err != nil {
+		return signingStoreCertificate{}, false, err
+	}
+	return entry, true, nil
+}
+
+func createStoreCertificate(ctx context.Context, client *asc.Client, store *signingStore, certType string) (signingStoreCertificate, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return signingStoreCertificate{}, fmt.Errorf("generate key: %w", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return signingStoreCertificate{}, fmt.Errorf("marshal key: %w", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		SignatureAlgorithm: x509.SHA256WithRSA,
+		Subject:            pkix.Name{CommonName: "asc signing sync"},
+	}, privateKey)
+	if err != nil {
+		return signingStoreCertificate{}, fmt.Errorf("create csr: %w", err)
+	}
+
+	resp, err := client.CreateCertificate(ctx, base64.StdEncoding.EncodeToString(csrDER), certType)
+	if err != nil {
+		return signingStoreCertificate{}, fmt.Errorf("create certificate: %w", err)
+	}
+	certDER, err := decodeBase64Content("certificate", resp.Data.Attributes.CertificateContent)
+	if err != nil {
+		return signingStoreCertificate{}, err
+	}
+
+	certFile, keyFile := signingStoreCertificatePaths(certType, resp.Data.ID)
+	if err := store.writeFile(keyFile, keyPEM); err != nil {
+		return signingStoreCertificate{}, fmt.Errorf("store key: %w", err)
+	}
+	if err := store.writeFile(certFile, certDER); err != nil {
+		return signingStoreCertificate{}, fmt.Errorf("store certificate: %w", err)
+	}
+
+	entry := signingStoreCertificate{
+		ID:              resp.Data.ID,
+		CertificateType: certType,
+		SerialNumber:    resp.Data.Attributes.SerialNumber,
+		ExpirationDate:  resp.Data.Attributes.ExpirationDate,
+		CertificateFile: certFile,
+		KeyFile:         keyFile,
+	}
+	store.index.Certificates = append(store.index.Certificates, entry)
+	return entry, nil
+}
+
+// syncStoreProfile returns the stored profile for the bundle and type when it
+// is still active and signed by cert; otherwise it finds or creates one.
+func syncStoreProfile(ctx context.Context, client *asc.Client, store *signingStore, bundleIDResourceID, bundleID, profileType string, cert signingStoreCertificate, deviceIDs []string) (signingStoreProfile, bool, error) {
+	var (
+		kept     []signingStoreProfile
+		selected *signingStoreProfile
+	)
+	for _, entry := range store.index.Profiles {
+		if entry.BundleID == bundleID && entry.ProfileType == profileType {
+			active, err := signingProfileActive(ctx, client, entry.ID)
+			if err != nil {
+				return signingStoreProfile{}, false, err
+			}
+			if entry.CertificateID != cert.ID || !active {
+				if err := store.removeFile(entry.File); err != nil {
+					return signingStoreProfile{}, false, err
+				}
+				continue
+			}
+		}
+		kept = append(kept, entry)
+		if entry.BundleID == bundleID && entry.ProfileType == profileType && selected == nil {
+			selected = &entry
+		}
+	}
+	store.index.Profiles = kept
+	if selected != nil {
+		return *selected, false, nil
+	}
+
+	profile, created, err := findOrCreateProfile(ctx, client, bundleIDResourceID, bundleID, profileType, []string{cert.ID}, deviceIDs, true)
+	if err != nil {
+		return signingStoreProfile{}, false, err
+	}
+	if !created {
+		included, err := signingProfileIncludesCertificate(ctx, client, profile.Data.ID, cert.ID)
+		if err != nil {
+			return signingStoreProfile{}, false, err
+		}
+		if !included {
+			name := fmt.Sprintf("%s %s %s", bundleID, profileType, time.Now().Format("20060102150405"))
+			profile, err = createProfile(ctx, client, name, bundleIDResourceID, profileType, []string{cert.ID}, deviceIDs)
+			if err != nil {
+				return signingStoreProfile{}, false, err
+			}
+			created = true
+		}
+	}
+
+	content, err := decodeBase64Content("profile", profile.Data.Attributes.ProfileContent)
+	if err != nil {
+		return signingStoreProfile{}, false, err
+	}
+	file := signingStoreProfilePath(profileType, bundleID, profile.Data.ID)
+	if err := store.writeFile(file, content); err != nil {
+		return signingStoreProfile{}, false, fmt.Errorf("store profile: %w", err)
+	}
+
+	entry := signingStoreProfile{
+		ID:             profile.Data.ID,
+		Name:           profile.Data.Attributes.Name,
+		ProfileType:    profileType,
+		BundleID:       bundleID,
+		CertificateID:  cert.ID,
+		ExpirationDate: profile.Data.Attributes.ExpirationDate,
+		File:           file,
+	}
+	store.index.Profiles = append(store.index.Profiles, entry)
+	return entry, created, nil
+}
+
+func signingProfileActive(ctx context.Context, client *asc.Client, profileID string) (bool, error) {
+	resp, err := client.GetProfile(ctx, profileID)
+	if err != nil {
+		if asc.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return resp.Data.Attributes.ProfileState == asc.ProfileStateActive, nil
+}
+
+func signingProfileIncludesCertificate(ctx context.Context, client *asc.Client, profileID, certID string) (bool, error) {
+	next := ""
+	for {
+		resp, err := client.GetProfileCertificatesRelationships(ctx, profileID, asc.WithLinkagesLimit(200), asc.WithLinkagesNextURL(next))
+		if err != nil {
+			return false, err
+		}
+		for _, item := range resp.Data {
+			if item.ID == certID {
+				return true, nil
+			}
+		}
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return false, nil
+		}
+		next = resp.Links.Next
+	}
+}
+
+func signingAssetExpired(expirationDate string, now time.Time) bool {
+	expires, err := time.Parse(time.RFC3339, strings.TrimSpace(expirationDate))
+	if err != nil {
+		return false
+	}
+	return !expires.After(now)
+}
+
+// exportSigningAssets decrypts the selected assets into dir. The private key
+// is written with owner-only permissions.
+func exportSigningAssets(store *signingStore, cert signingStoreCertificate, profile signingStoreProfile, dir string) ([]string, error) {
+	certName := safeFileName(cert.SerialNumber, cert.ID)
+	files := []struct {
+		source string
+		target string
+		perm   os.FileMode
+	}{
+		{source: cert.CertificateFile, target: filepath.Join(dir, certName+".cer"), perm: 0o644},
+		{source: cert.KeyFile, target: filepath.Join(dir, certName+".key"), perm: 0o600},
+		{source: profile.File, target: filepath.Join(dir, safeFileName(profile.Name, profile.ID)+".mobileprovision"), perm: 0o644},
+	}
+
+	written := make([]string, 0, len(files))
+	for _, file := range files {
+		data, err := store.readFile(file.source)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := shared.WriteFileNoSymlinkOverwrite(file.target, bytes.NewReader(data), file.perm, ".asc-signing-*", ".asc-signing-backup-*"); err != nil {
+			return nil, fmt.Errorf("write %s: %w", file.target, err)
+		}
+		written = append(written, file.target)
+	}
+	return written, nil
+}
+
+func renderSigningSyncResult(result *signingSyncResult, markdown bool) error {
+	if result == nil {
+		return fmt.Errorf("result is nil")
+	}
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+	rows := [][]string{
+		{"certificate", result.CertificateType, result.CertificateID, fmt.Sprintf("%t", result.CertificateCreated)},
+		{"profile", result.ProfileType, result.ProfileID, fmt.Sprintf("%t", result.ProfileCreated)},
+	}
+	render([]string{"Asset", "Type", "ID", "Created"}, rows)
+	return nil
+}
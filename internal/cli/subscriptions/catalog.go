@@ -0,0 +1,584 @@
+package subscriptions
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// SubscriptionCatalog is the YAML schema for an app's subscription catalog.
+type SubscriptionCatalog struct {
+	GracePeriod *SubscriptionCatalogGracePeriod `yaml:"gracePeriod,omitempty"`
+	Groups      []SubscriptionCatalogGroup      `yaml:"groups"`
+}
+
+// SubscriptionCatalogGracePeriod describes the app's billing grace period.
+type SubscriptionCatalogGracePeriod struct {
+	OptIn        bool   `yaml:"optIn"`
+	SandboxOptIn bool   `yaml:"sandboxOptIn"`
+	Duration     string `yaml:"duration"`
+	RenewalType  string `yaml:"renewalType"`
+}
+
+// SubscriptionCatalogGroup describes one subscription group and its subscriptions.
+type SubscriptionCatalogGroup struct {
+	ReferenceName string                                 `yaml:"referenceName"`
+	Localizations []SubscriptionCatalogGroupLocalization `yaml:"localizations,omitempty"`
+	Subscriptions []SubscriptionCatalogSubscription      `yaml:"subscriptions,omitempty"`
+}
+
+// SubscriptionCatalogGroupLocalization describes a group display name for a locale.
+type SubscriptionCatalogGroupLocalization struct {
+	Locale        string `yaml:"locale"`
+	Name          string `yaml:"name"`
+	CustomAppName string `yaml:"customAppName,omitempty"`
+}
+
+// SubscriptionCatalogSubscription describes one auto-renewable subscription.
+type SubscriptionCatalogSubscription struct {
+	ProductID          string                                 `yaml:"productId"`
+	ReferenceName      string                                 `yaml:"referenceName"`
+	Period             string                                 `yaml:"period,omitempty"`
+	GroupLevel         int                                    `yaml:"groupLevel,omitempty"`
+	FamilySharable     *bool                                  `yaml:"familySharable,omitempty"`
+	ReviewNote         string                                 `yaml:"reviewNote,omitempty"`
+	Localizations      []SubscriptionCatalogLocalization      `yaml:"localizations,omitempty"`
+	Prices             []SubscriptionCatalogPrice             `yaml:"prices,omitempty"`
+	IntroductoryOffers []SubscriptionCatalogIntroductoryOffer `yaml:"introductoryOffers,omitempty"`
+	PromotionalOffers  []SubscriptionCatalogPromotionalOffer  `yaml:"promotionalOffers,omitempty"`
+}
+
+// SubscriptionCatalogLocalization describes a subscription display name and description.
+type SubscriptionCatalogLocalization struct {
+	Locale      string `yaml:"locale"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// SubscriptionCatalogPrice describes the current customer price in one territory.
+type SubscriptionCatalogPrice struct {
+	Territory string `yaml:"territory"`
+	Price     string `yaml:"price"`
+}
+
+// SubscriptionCatalogIntroductoryOffer describes the introductory offer for one
+// territory. An empty territory applies the offer to every territory.
+type SubscriptionCatalogIntroductoryOffer struct {
+	Territory       string `yaml:"territory,omitempty"`
+	OfferMode       string `yaml:"offerMode"`
+	Duration        string `yaml:"duration"`
+	NumberOfPeriods int    `yaml:"numberOfPeriods"`
+	Price           string `yaml:"price,omitempty"`
+	StartDate       string `yaml:"startDate,omitempty"`
+	EndDate         string `yaml:"endDate,omitempty"`
+}
+
+// SubscriptionCatalogPromotionalOffer describes a promotional offer and its
+// per-territory prices.
+type SubscriptionCatalogPromotionalOffer struct {
+	OfferCode       string                     `yaml:"offerCode"`
+	Name            string                     `yaml:"name"`
+	OfferMode       string                     `yaml:"offerMode"`
+	Duration        string                     `yaml:"duration"`
+	NumberOfPeriods int                        `yaml:"numberOfPeriods"`
+	Prices          []SubscriptionCatalogPrice `yaml:"prices"`
+}
+
+type subscriptionCatalogExportSummary struct {
+	AppID         string `json:"appId"`
+	File          string `json:"file"`
+	Groups        int    `json:"groups"`
+	Subscriptions int    `json:"subscriptions"`
+}
+
+// SubscriptionsCatalogCommand returns the subscriptions catalog command group.
+func SubscriptionsCatalogCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("catalog", flag.ExitOnError)
+
+	return &ffcli.Command{
+		Name:       "catalog",
+		ShortUsage: "asc subscriptions catalog <subcommand> [flags]",
+		ShortHelp:  "Export and apply subscription catalogs as YAML.",
+		LongHelp: `Export and apply subscription catalogs as YAML.
+
+A catalog covers the app's grace period and every subscription group with
+its localizations, subscriptions, subscription localizations,
+per-territory prices, and introductory and promotional offers.
+
+Examples:
+  asc subscriptions catalog export --app "APP_ID" --file "./subscriptions.yaml"
+  asc subscriptions catalog apply --app "APP_ID" --file "./subscriptions.yaml" --dry-run
+  asc subscriptions catalog apply --app "APP_ID" --file "./subscriptions.yaml"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			SubscriptionsCatalogExportCommand(),
+			SubscriptionsCatalogApplyCommand(),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+// SubscriptionsCatalogExportCommand returns the subscriptions catalog export subcommand.
+func SubscriptionsCatalogExportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("catalog export", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	file := fs.String("file", "", "Output catalog YAML path (required)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "export",
+		ShortUsage: "asc subscriptions catalog export --app \"APP_ID\" --file \"./subscriptions.yaml\"",
+		ShortHelp:  "Export all subscription groups of an app to a catalog file.",
+		LongHelp: `Export all subscription groups of an app to a catalog file.
+
+Prices are exported as the current customer price for every territory
+with a price assigned. Offer prices are exported as customer prices too.
+
+Examples:
+  asc subscriptions catalog export --app "APP_ID" --file "./subscriptions.yaml"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
+				return flag.ErrHelp
+			}
+			fileValue := strings.TrimSpace(*file)
+			if fileValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --file is required")
+				return flag.ErrHelp
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("subscriptions catalog export: %w", err)
+			}
+
+			remote, err := fetchSubscriptionCatalogRemote(ctx, client, resolvedAppID)
+			if err != nil {
+				return fmt.Errorf("subscriptions catalog export: %w", err)
+			}
+
+			catalog := remote.catalog()
+			if err := writeSubscriptionCatalogFile(fileValue, catalog); err != nil {
+				return fmt.Errorf("subscriptions catalog export: %w", err)
+			}
+
+			summary := subscriptionCatalogExportSummary{
+				AppID:  resolvedAppID,
+				File:   filepath.Clean(fileValue),
+				Groups: len(catalog.Groups),
+			}
+			for _, group := range catalog.Groups {
+				summary.Subscriptions += len(group.Subscriptions)
+			}
+			return shared.PrintOutput(summary, *output.Output, *output.Pretty)
+		},
+	}
+}
+
+// SubscriptionsCatalogApplyCommand returns the subscriptions catalog apply subcommand.
+func SubscriptionsCatalogApplyCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("catalog apply", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	file := fs.String("file", "", "Catalog YAML path (required)")
+	dryRun := fs.Bool("dry-run", false, "Print the plan without mutating App Store Connect")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "apply",
+		ShortUsage: "asc subscriptions catalog apply --app \"APP_ID\" --file \"./subscriptions.yaml\" [--dry-run]",
+		ShortHelp:  "Reconcile subscription groups with a catalog file.",
+		LongHelp: `Reconcile subscription groups with a catalog file.
+
+The catalog is diffed against App Store Connect and only the differences
+are applied, so re-running an applied catalog is a no-op. Groups are
+matched by referenceName and subscriptions by productId. A failure for one
+subscription is reported in the summary and does not stop the others.
+
+Prices accept the same territory values as "subscriptions prices import"
+(USA, US, or United States) and are resolved to price points the same way.
+Offer prices are resolved the same way.
+
+Introductory offers are matched by territory and promotional offers by
+offerCode. App Store Connect only allows an introductory offer's endDate to
+change, so any other change replaces the offer. A promotional offer's
+prices can change, but its name, mode, duration, and number of periods
+cannot; use a new offerCode instead.
+
+Notes:
+  - groups, subscriptions, localizations, and territories missing from the
+    file are left untouched.
+  - price changes take effect immediately and do not preserve the current
+    price for existing subscribers.
+  - offers and offer prices missing from the file are left untouched.
+
+Examples:
+  asc subscriptions catalog apply --app "APP_ID" --file "./subscriptions.yaml" --dry-run
+  asc subscriptions catalog apply --app "APP_ID" --file "./subscriptions.yaml" --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
+				return flag.ErrHelp
+			}
+			fileValue := strings.TrimSpace(*file)
+			if fileValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --file is required")
+				return flag.ErrHelp
+			}
+
+			catalog, err := readSubscriptionCatalogFile(fileValue)
+			if err != nil {
+				return err
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("subscriptions catalog apply: %w", err)
+			}
+
+			remote, err := fetchSubscriptionCatalogRemote(ctx, client, resolvedAppID)
+			if err != nil {
+				return fmt.Errorf("subscriptions catalog apply: %w", err)
+			}
+
+			plan := buildSubscriptionCatalogPlan(catalog, remote)
+			result := &SubscriptionCatalogApplyResult{
+				AppID:  resolvedAppID,
+				File:   filepath.Clean(fileValue),
+				DryRun: *dryRun,
+			}
+
+			if !*dryRun {
+				applySubscriptionCatalogPlan(ctx, client, resolvedAppID, plan)
+				result.Applied = true
+			}
+			result.collect(plan)
+
+			if err := shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderSubscriptionCatalogApplyResult(result, false) },
+				func() error { return renderSubscriptionCatalogApplyResult(result, true) },
+			); err != nil {
+				return err
+			}
+
+			if result.Failed > 0 {
+				return shared.NewReportedError(fmt.Errorf("subscriptions catalog apply: %d item(s) failed", result.Failed))
+			}
+			return nil
+		},
+	}
+}
+
+func readSubscriptionCatalogFile(path string) (SubscriptionCatalog, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return SubscriptionCatalog{}, fmt.Errorf("subscriptions catalog apply: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var catalog SubscriptionCatalog
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&catalog); err != nil {
+		return SubscriptionCatalog{}, shared.UsageErrorf("invalid catalog %s: %v", path, err)
+	}
+	if err := normalizeSubscriptionCatalog(&catalog); err != nil {
+		return SubscriptionCatalog{}, shared.UsageErrorf("invalid catalog %s: %v", path, err)
+	}
+	return catalog, nil
+}
+
+func normalizeSubscriptionCatalog(catalog *SubscriptionCatalog) error {
+	if len(catalog.Groups) == 0 && catalog.GracePeriod == nil {
+		return fmt.Errorf("at least one group or a gracePeriod is required")
+	}
+
+	if grace := catalog.GracePeriod; grace != nil {
+		duration, err := normalizeSubscriptionGracePeriodDuration(grace.Duration, true)
+		if err != nil {
+			return fmt.Errorf("gracePeriod: %s", strings.TrimPrefix(err.Error(), "--"))
+		}
+		grace.Duration = duration
+		renewalType, err := normalizeSubscriptionGracePeriodRenewalType(grace.RenewalType, true)
+		if err != nil {
+			return fmt.Errorf("gracePeriod: %s", strings.Replace(strings.TrimPrefix(err.Error(), "--"), "renewal-type", "renewalType", 1))
+		}
+		grace.RenewalType = string(renewalType)
+	}
+
+	seenGroups := make(map[string]struct{}, len(catalog.Groups))
+	seenProducts := make(map[string]struct{})
+	for idx := range catalog.Groups {
+		group := &catalog.Groups[idx]
+		group.ReferenceName = strings.TrimSpace(group.ReferenceName)
+		if group.ReferenceName == "" {
+			return fmt.Errorf("groups[%d]: referenceName is required", idx)
+		}
+		if _, ok := seenGroups[group.ReferenceName]; ok {
+			return fmt.Errorf("groups[%d]: duplicate referenceName %q", idx, group.ReferenceName)
+		}
+		seenGroups[group.ReferenceName] = struct{}{}
+
+		seenLocales := make(map[string]struct{}, len(group.Localizations))
+		for locIdx := range group.Localizations {
+			loc := &group.Localizations[locIdx]
+			loc.Locale = strings.TrimSpace(loc.Locale)
+			loc.Name = strings.TrimSpace(loc.Name)
+			loc.CustomAppName = strings.TrimSpace(loc.CustomAppName)
+			if err := checkSubscriptionCatalogLocale(seenLocales, loc.Locale, loc.Name, locIdx); err != nil {
+				return fmt.Errorf("group %q: %w", group.ReferenceName, err)
+			}
+		}
+
+		for subIdx := range group.Subscriptions {
+			sub := &group.Subscriptions[subIdx]
+			if err := normalizeSubscriptionCatalogSubscription(sub, subIdx); err != nil {
+				return fmt.Errorf("group %q: %w", group.ReferenceName, err)
+			}
+			if _, ok := seenProducts[sub.ProductID]; ok {
+				return fmt.Errorf("group %q: duplicate productId %q", group.ReferenceName, sub.ProductID)
+			}
+			seenProducts[sub.ProductID] = struct{}{}
+		}
+	}
+	return nil
+}
+
+func normalizeSubscriptionCatalogSubscription(sub *SubscriptionCatalogSubscription, idx int) error {
+	sub.ProductID = strings.TrimSpace(sub.ProductID)
+	if sub.ProductID == "" {
+		return fmt.Errorf("subscriptions[%d]: productId is required", idx)
+	}
+	sub.ReferenceName = strings.TrimSpace(sub.ReferenceName)
+	if sub.ReferenceName == "" {
+		return fmt.Errorf("%s: referenceName is required", sub.ProductID)
+	}
+	period, err := normalizeSubscriptionPeriod(sub.Period, false)
+	if err != nil {
+		return fmt.Errorf("%s: %s", sub.ProductID, strings.Replace(strings.TrimPrefix(err.Error(), "--"), "subscription-period", "period", 1))
+	}
+	sub.Period = string(period)
+	if sub.GroupLevel < 0 {
+		return fmt.Errorf("%s: groupLevel must not be negative", sub.ProductID)
+	}
+	sub.ReviewNote = strings.TrimSpace(sub.ReviewNote)
+
+	seenLocales := make(map[string]struct{}, len(sub.Localizations))
+	for locIdx := range sub.Localizations {
+		loc := &sub.Localizations[locIdx]
+		loc.Locale = strings.TrimSpace(loc.Locale)
+		loc.Name = strings.TrimSpace(loc.Name)
+		loc.Description = strings.TrimSpace(loc.Description)
+		if err := checkSubscriptionCatalogLocale(seenLocales, loc.Locale, loc.Name, locIdx); err != nil {
+			return fmt.Errorf("%s: %w", sub.ProductID, err)
+		}
+	}
+
+	seenTerritories := make(map[string]struct{}, len(sub.Prices))
+	for priceIdx := range sub.Prices {
+		price := &sub.Prices[priceIdx]
+		territoryID, err := resolveSubscriptionPriceImportTerritoryID(price.Territory)
+		if err != nil {
+			return fmt.Errorf("%s: prices[%d]: %w", sub.ProductID, priceIdx, err)
+		}
+		if _, ok := seenTerritories[territoryID]; ok {
+			return fmt.Errorf("%s: duplicate price for territory %q", sub.ProductID, territoryID)
+		}
+		seenTerritories[territoryID] = struct{}{}
+		price.Territory = territoryID
+
+		price.Price = strings.TrimSpace(price.Price)
+		if _, err := normalizeSubscriptionPriceImportPrice(price.Price); err != nil {
+			return fmt.Errorf("%s: prices[%d]: %w", sub.ProductID, priceIdx, err)
+		}
+	}
+	sort.Slice(sub.Prices, func(i, j int) bool {
+		return sub.Prices[i].Territory < sub.Prices[j].Territory
+	})
+
+	seenIntroTerritories := make(map[string]struct{}, len(sub.IntroductoryOffers))
+	for offerIdx := range sub.IntroductoryOffers {
+		offer := &sub.IntroductoryOffers[offerIdx]
+		if err := normalizeSubscriptionCatalogIntroductoryOffer(offer); err != nil {
+			return fmt.Errorf("%s: introductoryOffers[%d]: %w", sub.ProductID, offerIdx, err)
+		}
+		if _, ok := seenIntroTerritories[offer.Territory]; ok {
+			return fmt.Errorf("%s: duplicate introductory offer for territory %q", sub.ProductID, offer.Territory)
+		}
+		seenIntroTerritories[offer.Territory] = struct{}{}
+	}
+	sort.Slice(sub.IntroductoryOffers, func(i, j int) bool {
+		return sub.IntroductoryOffers[i].Territory < sub.IntroductoryOffers[j].Territory
+	})
+
+	seenOfferCodes := make(map[string]struct{}, len(sub.PromotionalOffers))
+	for offerIdx := range sub.PromotionalOffers {
+		offer := &sub.PromotionalOffers[offerIdx]
+		if err := normalizeSubscriptionCatalogPromotionalOffer(offer); err != nil {
+			return fmt.Errorf("%s: promotionalOffers[%d]: %w", sub.ProductID, offerIdx, err)
+		}
+		if _, ok := seenOfferCodes[offer.OfferCode]; ok {
+			return fmt.Errorf("%s: duplicate promotional offer %q", sub.ProductID, offer.OfferCode)
+		}
+		seenOfferCodes[offer.OfferCode] = struct{}{}
+	}
+	sort.Slice(sub.PromotionalOffers, func(i, j int) bool {
+		return sub.PromotionalOffers[i].OfferCode < sub.PromotionalOffers[j].OfferCode
+	})
+	return nil
+}
+
+func normalizeSubscriptionCatalogIntroductoryOffer(offer *SubscriptionCatalogIntroductoryOffer) error {
+	if strings.TrimSpace(offer.Territory) != "" {
+		territoryID, err := resolveSubscriptionPriceImportTerritoryID(offer.Territory)
+		if err != nil {
+			return err
+		}
+		offer.Territory = territoryID
+	}
+	if err := normalizeSubscriptionCatalogOfferTerms(&offer.OfferMode, &offer.Duration, offer.NumberOfPeriods); err != nil {
+		return err
+	}
+
+	offer.Price = strings.TrimSpace(offer.Price)
+	switch {
+	case offer.OfferMode == string(asc.SubscriptionOfferModeFreeTrial) && offer.Price != "":
+		return fmt.Errorf("price is not allowed for %s offers", offer.OfferMode)
+	case offer.OfferMode != string(asc.SubscriptionOfferModeFreeTrial) && offer.Price == "":
+		return fmt.Errorf("price is required for %s offers", offer.OfferMode)
+	case offer.Price != "" && offer.Territory == "":
+		return fmt.Errorf("territory is required when price is set")
+	case offer.Price != "":
+		if _, err := normalizeSubscriptionPriceImportPrice(offer.Price); err != nil {
+			return err
+		}
+	}
+
+	for _, date := range []struct {
+		value *string
+		name  string
+	}{{&offer.StartDate, "startDate"}, {&offer.EndDate, "endDate"}} {
+		if strings.TrimSpace(*date.value) == "" {
+			*date.value = ""
+			continue
+		}
+		normalized, err := shared.NormalizeDate(*date.value, date.name)
+		if err != nil {
+			return err
+		}
+		*date.value = normalized
+	}
+	return nil
+}
+
+func normalizeSubscriptionCatalogPromotionalOffer(offer *SubscriptionCatalogPromotionalOffer) error {
+	offer.OfferCode = strings.TrimSpace(offer.OfferCode)
+	if offer.OfferCode == "" {
+		return fmt.Errorf("offerCode is required")
+	}
+	offer.Name = strings.TrimSpace(offer.Name)
+	if offer.Name == "" {
+		return fmt.Errorf("%s: name is required", offer.OfferCode)
+	}
+	if err := normalizeSubscriptionCatalogOfferTerms(&offer.OfferMode, &offer.Duration, offer.NumberOfPeriods); err != nil {
+		return fmt.Errorf("%s: %w", offer.OfferCode, err)
+	}
+	if len(offer.Prices) == 0 {
+		return fmt.Errorf("%s: at least one price is required", offer.OfferCode)
+	}
+
+	freeTrial := offer.OfferMode == string(asc.SubscriptionOfferModeFreeTrial)
+	seenTerritories := make(map[string]struct{}, len(offer.Prices))
+	for priceIdx := range offer.Prices {
+		price := &offer.Prices[priceIdx]
+		territoryID, err := resolveSubscriptionPriceImportTerritoryID(price.Territory)
+		if err != nil {
+			return fmt.Errorf("%s: prices[%d]: %w", offer.OfferCode, priceIdx, err)
+		}
+		if _, ok := seenTerritories[territoryID]; ok {
+			return fmt.Errorf("%s: duplicate price for territory %q", offer.OfferCode, territoryID)
+		}
+		seenTerritories[territoryID] = struct{}{}
+		price.Territory = territoryID
+
+		price.Price = strings.TrimSpace(price.Price)
+		switch {
+		case freeTrial && price.Price != "":
+			return fmt.Errorf("%s: prices[%d]: price is not allowed for %s offers", offer.OfferCode, priceIdx, offer.OfferMode)
+		case !freeTrial:
+			if _, err := normalizeSubscriptionPriceImportPrice(price.Price); err != nil {
+				return fmt.Errorf("%s: prices[%d]: %w", offer.OfferCode, priceIdx, err)
+			}
+		}
+	}
+	sort.Slice(offer.Prices, func(i, j int) bool {
+		return offer.Prices[i].Territory < offer.Prices[j].Territory
+	})
+	return nil
+}
+
+// normalizeSubscriptionCatalogOfferTerms validates the mode, duration, and
+// number of periods shared by introductory and promotional offers.
+func normalizeSubscriptionCatalogOfferTerms(mode, duration *string, numberOfPeriods int) error {
+	offerMode, err := normalizeSubscriptionOfferMode(*mode)
+	if err != nil {
+		return fmt.Errorf("%s", strings.Replace(strings.TrimPrefix(err.Error(), "--"), "offer-mode", "offerMode", 1))
+	}
+	*mode = string(offerMode)
+	offerDuration, err := normalizeSubscriptionOfferDuration(*duration)
+	if err != nil {
+		return fmt.Errorf("%s", strings.Replace(strings.TrimPrefix(err.Error(), "--"), "offer-duration", "duration", 1))
+	}
+	*duration = string(offerDuration)
+	if numberOfPeriods <= 0 {
+		return fmt.Errorf("numberOfPeriods must be greater than 0")
+	}
+	return nil
+}
+
+func checkSubscriptionCatalogLocale(seen map[string]struct{}, locale, name string, idx int) error {
+	if locale == "" {
+		return fmt.Errorf("localizations[%d]: locale is required", idx)
+	}
+	if name == "" {
+		return fmt.Errorf("localization %q: name is required", locale)
+	}
+	if _, ok := seen[locale]; ok {
+		return fmt.Errorf("duplicate localization %q", locale)
+	}
+	seen[locale] = struct{}{}
+	return nil
+}
+
+func writeSubscriptionCatalogFile(path string, catalog SubscriptionCatalog) error {
+	data, err := yaml.Marshal(catalog)
+	if err != nil {
+		return err
+	}
+	_, err = shared.WriteFileNoSymlinkOverwrite(path, bytes.NewReader(data), 0o644, ".asc-subscriptions-catalog-*", ".asc-subscriptions-catalog-backup-*")
+	return err
+}
@@ -0,0 +1,1361 @@
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	subscriptionCatalogActionUpdateGracePeriod       = "update-grace-period"
+	subscriptionCatalogActionCreateGroup             = "create-group"
+	subscriptionCatalogActionCreateGroupLocalization = "create-group-localization"
+	subscriptionCatalogActionUpdateGroupLocalization = "update-group-localization"
+	subscriptionCatalogActionCreateSubscription      = "create-subscription"
+	subscriptionCatalogActionUpdateSubscription      = "update-subscription"
+	subscriptionCatalogActionCreateLocalization      = "create-localization"
+	subscriptionCatalogActionUpdateLocalization      = "update-localization"
+	subscriptionCatalogActionSetPrice                = "set-price"
+	subscriptionCatalogActionCreateIntroOffer        = "create-introductory-offer"
+	subscriptionCatalogActionUpdateIntroOffer        = "update-introductory-offer"
+	subscriptionCatalogActionReplaceIntroOffer       = "replace-introductory-offer"
+	subscriptionCatalogActionCreatePromoOffer        = "create-promotional-offer"
+	subscriptionCatalogActionUpdatePromoOffer        = "update-promotional-offer"
+
+	subscriptionCatalogKindGracePeriod  = "grace-period"
+	subscriptionCatalogKindGroup        = "group"
+	subscriptionCatalogKindSubscription = "subscription"
+
+	subscriptionCatalogStatusUnchanged = "unchanged"
+	subscriptionCatalogStatusPlanned   = "planned"
+	subscriptionCatalogStatusApplied   = "applied"
+	subscriptionCatalogStatusFailed    = "failed"
+)
+
+// SubscriptionCatalogChange is one planned catalog mutation.
+type SubscriptionCatalogChange struct {
+	Group     string `json:"group,omitempty"`
+	ProductID string `json:"productId,omitempty"`
+	Action    string `json:"action"`
+	Key       string `json:"key,omitempty"`
+	From      string `json:"from,omitempty"`
+	To        string `json:"to,omitempty"`
+}
+
+// SubscriptionCatalogItemResult reports the outcome for the grace period, a
+// group, or a subscription.
+type SubscriptionCatalogItemResult struct {
+	Kind      string `json:"kind"`
+	Group     string `json:"group,omitempty"`
+	ProductID string `json:"productId,omitempty"`
+	ID        string `json:"id,omitempty"`
+	Status    string `json:"status"`
+	Changes   int    `json:"changes"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SubscriptionCatalogApplyResult is the output of subscriptions catalog apply.
+type SubscriptionCatalogApplyResult struct {
+	AppID   string                          `json:"appId"`
+	File    string                          `json:"file"`
+	DryRun  bool                            `json:"dryRun"`
+	Applied bool                            `json:"applied,omitempty"`
+	Changes []SubscriptionCatalogChange     `json:"changes"`
+	Items   []SubscriptionCatalogItemResult `json:"items"`
+	Failed  int                             `json:"failed"`
+}
+
+type subscriptionCatalogRemote struct {
+	GracePeriodID string
+	GracePeriod   *SubscriptionCatalogGracePeriod
+	Groups        []subscriptionCatalogRemoteGroup
+}
+
+type subscriptionCatalogRemoteGroup struct {
+	ID              string
+	Group           SubscriptionCatalogGroup
+	LocalizationIDs map[string]string
+	Subscriptions   []subscriptionCatalogRemoteSubscription
+}
+
+type subscriptionCatalogRemoteSubscription struct {
+	ID                   string
+	GroupName            string
+	Subscription         SubscriptionCatalogSubscription
+	LocalizationIDs      map[string]string
+	IntroductoryOfferIDs map[string]string
+	PromotionalOfferIDs  map[string]string
+}
+
+// subscriptionCatalogItemPlan is the plan for one item. Subscription items
+// point at their group item through parent so a group created during apply
+// can hand its ID to the subscriptions that follow it.
+type subscriptionCatalogItemPlan struct {
+	kind               string
+	groupName          string
+	productID          string
+	id                 string
+	parent             int
+	gracePeriod        *SubscriptionCatalogGracePeriod
+	group              *SubscriptionCatalogGroup
+	subscription       *SubscriptionCatalogSubscription
+	remoteSubscription *subscriptionCatalogRemoteSubscription
+	localizationIDs    map[string]string
+	changes            []SubscriptionCatalogChange
+	applied            bool
+	err                error
+}
+
+func (r *subscriptionCatalogRemote) catalog() SubscriptionCatalog {
+	catalog := SubscriptionCatalog{
+		GracePeriod: r.GracePeriod,
+		Groups:      make([]SubscriptionCatalogGroup, 0, len(r.Groups)),
+	}
+	for _, remoteGroup := range r.Groups {
+		group := remoteGroup.Group
+		group.Subscriptions = make([]SubscriptionCatalogSubscription, 0, len(remoteGroup.Subscriptions))
+		for _, sub := range remoteGroup.Subscriptions {
+			group.Subscriptions = append(group.Subscriptions, sub.Subscription)
+		}
+		catalog.Groups = append(catalog.Groups, group)
+	}
+	return catalog
+}
+
+func fetchSubscriptionCatalogRemote(ctx context.Context, client *asc.Client, appID string) (*subscriptionCatalogRemote, error) {
+	remote := &subscriptionCatalogRemote{}
+
+	graceCtx, graceCancel := shared.ContextWithTimeout(ctx)
+	grace, err := client.GetAppSubscriptionGracePeriod(graceCtx, appID)
+	graceCancel()
+	switch {
+	case err != nil && !asc.IsNotFound(err):
+		return nil, fmt.Errorf("fetch grace period: %w", err)
+	case err == nil && grace != nil && strings.TrimSpace(grace.Data.ID) != "":
+		remote.GracePeriodID = grace.Data.ID
+		remote.GracePeriod = &SubscriptionCatalogGracePeriod{
+			OptIn:        grace.Data.Attributes.OptIn,
+			SandboxOptIn: grace.Data.Attributes.SandboxOptIn,
+			Duration:     grace.Data.Attributes.Duration,
+			RenewalType:  grace.Data.Attributes.RenewalType,
+		}
+	}
+
+	groupsCtx, groupsCancel := shared.ContextWithTimeout(ctx)
+	defer groupsCancel()
+	groupsFirstPage, err := client.GetSubscriptionGroups(groupsCtx, appID, asc.WithSubscriptionGroupsLimit(200))
+	if err != nil {
+		return nil, fmt.Errorf("fetch subscription groups: %w", err)
+	}
+	groupsPaginated, err := asc.PaginateAll(groupsCtx, groupsFirstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetSubscriptionGroups(ctx, appID, asc.WithSubscriptionGroupsNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("paginate subscription groups: %w", err)
+	}
+	groups, ok := groupsPaginated.(*asc.SubscriptionGroupsResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected subscription groups response type %T", groupsPaginated)
+	}
+
+	for _, group := range groups.Data {
+		remoteGroup, err := fetchSubscriptionCatalogRemoteGroup(ctx, client, group)
+		if err != nil {
+			return nil, fmt.Errorf("group %q: %w", group.Attributes.ReferenceName, err)
+		}
+		remote.Groups = append(remote.Groups, remoteGroup)
+	}
+	sort.Slice(remote.Groups, func(i, j int) bool {
+		return remote.Groups[i].Group.ReferenceName < remote.Groups[j].Group.ReferenceName
+	})
+
+	if err := fetchSubscriptionCatalogRemoteSubscriptions(ctx, client, remote.Groups); err != nil {
+		return nil, err
+	}
+	return remote, nil
+}
+
+func fetchSubscriptionCatalogRemoteGroup(
+	ctx context.Context,
+	client *asc.Client,
+	group asc.Resource[asc.SubscriptionGroupAttributes],
+) (subscriptionCatalogRemoteGroup, error) {
+	remote := subscriptionCatalogRemoteGroup{
+		ID:              group.ID,
+		Group:           SubscriptionCatalogGroup{ReferenceName: strings.TrimSpace(group.Attributes.ReferenceName)},
+		LocalizationIDs: make(map[string]string),
+	}
+
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	locFirstPage, err := client.GetSubscriptionGroupLocalizations(requestCtx, group.ID, asc.WithSubscriptionGroupLocalizationsLimit(200))
+	if err != nil {
+		return remote, fmt.Errorf("fetch localizations: %w", err)
+	}
+	locPaginated, err := asc.PaginateAll(requestCtx, locFirstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetSubscriptionGroupLocalizations(ctx, group.ID, asc.WithSubscriptionGroupLocalizationsNextURL(nextURL))
+	})
+	if err != nil {
+		return remote, fmt.Errorf("paginate localizations: %w", err)
+	}
+	if locResp, ok := locPaginated.(*asc.SubscriptionGroupLocalizationsResponse); ok {
+		for _, item := range locResp.Data {
+			locale := strings.TrimSpace(item.Attributes.Locale)
+			if locale == "" {
+				continue
+			}
+			remote.LocalizationIDs[locale] = item.ID
+			remote.Group.Localizations = append(remote.Group.Localizations, SubscriptionCatalogGroupLocalization{
+				Locale:        locale,
+				Name:          strings.TrimSpace(item.Attributes.Name),
+				CustomAppName: strings.TrimSpace(item.Attributes.CustomAppName),
+			})
+		}
+		sort.Slice(remote.Group.Localizations, func(i, j int) bool {
+			return remote.Group.Localizations[i].Locale < remote.Group.Localizations[j].Locale
+		})
+	}
+
+	subsFirstPage, err := client.GetSubscriptions(requestCtx, group.ID, asc.WithSubscriptionsLimit(200))
+	if err != nil {
+		return remote, fmt.Errorf("fetch subscriptions: %w", err)
+	}
+	subsPaginated, err := asc.PaginateAll(requestCtx, subsFirstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetSubscriptions(ctx, group.ID, asc.WithSubscriptionsNextURL(nextURL))
+	})
+	if err != nil {
+		return remote, fmt.Errorf("paginate subscriptions: %w", err)
+	}
+	subsResp, ok := subsPaginated.(*asc.SubscriptionsResponse)
+	if !ok {
+		return remote, fmt.Errorf("unexpected subscriptions response type %T", subsPaginated)
+	}
+	for _, sub := range subsResp.Data {
+		familySharable := sub.Attributes.FamilySharable
+		remote.Subscriptions = append(remote.Subscriptions, subscriptionCatalogRemoteSubscription{
+			ID:        sub.ID,
+			GroupName: remote.Group.ReferenceName,
+			Subscription: SubscriptionCatalogSubscription{
+				ProductID:      sub.Attributes.ProductID,
+				ReferenceName:  sub.Attributes.Name,
+				Period:         sub.Attributes.SubscriptionPeriod,
+				GroupLevel:     sub.Attributes.GroupLevel,
+				FamilySharable: &familySharable,
+				ReviewNote:     strings.TrimSpace(sub.Attributes.ReviewNote),
+			},
+			LocalizationIDs:      make(map[string]string),
+			IntroductoryOfferIDs: make(map[string]string),
+			PromotionalOfferIDs:  make(map[string]string),
+		})
+	}
+	sort.Slice(remote.Subscriptions, func(i, j int) bool {
+		left, right := remote.Subscriptions[i].Subscription, remote.Subscriptions[j].Subscription
+		if left.GroupLevel != right.GroupLevel {
+			return left.GroupLevel < right.GroupLevel
+		}
+		return left.ProductID < right.ProductID
+	})
+
+	return remote, nil
+}
+
+// fetchSubscriptionCatalogRemoteSubscriptions fills localizations, current
+// prices, and offers for every subscription, fetching subscriptions
+// concurrently.
+func fetchSubscriptionCatalogRemoteSubscriptions(ctx context.Context, client *asc.Client, groups []subscriptionCatalogRemoteGroup) error {
+	subs := make([]*subscriptionCatalogRemoteSubscription, 0)
+	for groupIdx := range groups {
+		for subIdx := range groups[groupIdx].Subscriptions {
+			subs = append(subs, &groups[groupIdx].Subscriptions[subIdx])
+		}
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	workers := max(min(len(subs), defaultSubscriptionPricingWorkers), 1)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, workers)
+	errs := make(chan error, len(subs))
+	var once sync.Once
+	var wg sync.WaitGroup
+	now := time.Now().UTC()
+
+	for _, sub := range subs {
+		wg.Go(func() {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+
+			if err := fetchSubscriptionCatalogRemoteSubscription(ctx, client, sub, now); err != nil {
+				once.Do(cancel)
+				errs <- fmt.Errorf("%s: %w", sub.Subscription.ProductID, err)
+			}
+		})
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("context cancelled: %w", err)
+	}
+	return nil
+}
+
+func fetchSubscriptionCatalogRemoteSubscription(
+	ctx context.Context,
+	client *asc.Client,
+	sub *subscriptionCatalogRemoteSubscription,
+	now time.Time,
+) error {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	locFirstPage, err := client.GetSubscriptionLocalizations(requestCtx, sub.ID, asc.WithSubscriptionLocalizationsLimit(200))
+	if err != nil {
+		return fmt.Errorf("fetch localizations: %w", err)
+	}
+	locPaginated, err := asc.PaginateAll(requestCtx, locFirstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetSubscriptionLocalizations(ctx, sub.ID, asc.WithSubscriptionLocalizationsNextURL(nextURL))
+	})
+	if err != nil {
+		return fmt.Errorf("paginate localizations: %w", err)
+	}
+	if locResp, ok := locPaginated.(*asc.SubscriptionLocalizationsResponse); ok {
+		for _, item := range locResp.Data {
+			locale := strings.TrimSpace(item.Attributes.Locale)
+			if locale == "" {
+				continue
+			}
+			sub.LocalizationIDs[locale] = item.ID
+			sub.Subscription.Localizations = append(sub.Subscription.Localizations, SubscriptionCatalogLocalization{
+				Locale:      locale,
+				Name:        strings.TrimSpace(item.Attributes.Name),
+				Description: strings.TrimSpace(item.Attributes.Description),
+			})
+		}
+		sort.Slice(sub.Subscription.Localizations, func(i, j int) bool {
+			return sub.Subscription.Localizations[i].Locale < sub.Subscription.Localizations[j].Locale
+		})
+	}
+
+	prices, err := fetchSubscriptionCatalogPrices(ctx, client, sub.ID, now)
+	if err != nil {
+		return err
+	}
+	sub.Subscription.Prices = prices
+
+	if err := fetchSubscriptionCatalogIntroductoryOffers(ctx, client, sub); err != nil {
+		return err
+	}
+	return fetchSubscriptionCatalogPromotionalOffers(ctx, client, sub)
+}
+
+// fetchSubscriptionCatalogPrices returns the current customer price for every
+// territory with a price assigned to the subscription.
+func fetchSubscriptionCatalogPrices(ctx context.Context, client *asc.Client, subscriptionID string, now time.Time) ([]SubscriptionCatalogPrice, error) {
+	pricesByTerritory := make(map[string][]asc.Resource[asc.SubscriptionPriceAttributes])
+	pricePointValues := make(map[string]subscriptionPricePointValue)
+
+	err := forEachSubscriptionCatalogPage(
+		ctx,
+		func(ctx context.Context) (*asc.SubscriptionPricesResponse, error) {
+			return client.GetSubscriptionPrices(
+				ctx,
+				subscriptionID,
+				asc.WithSubscriptionPricesInclude([]string{"subscriptionPricePoint", "territory"}),
+				asc.WithSubscriptionPricesPricePointFields([]string{"customerPrice"}),
+				asc.WithSubscriptionPricesLimit(200),
+			)
+		},
+		func(ctx context.Context, nextURL string) (*asc.SubscriptionPricesResponse, error) {
+			return client.GetSubscriptionPrices(ctx, subscriptionID, asc.WithSubscriptionPricesNextURL(nextURL))
+		},
+		func(resp *asc.SubscriptionPricesResponse) {
+			values, _ := parseSubscriptionPricesIncluded(resp.Included)
+			for id, value := range values {
+				pricePointValues[id] = value
+			}
+			for _, price := range resp.Data {
+				territory := extractSubscriptionPriceTerritoryID(price)
+				if territory == "" {
+					continue
+				}
+				pricesByTerritory[territory] = append(pricesByTerritory[territory], price)
+			}
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fetch prices: %w", err)
+	}
+
+	prices := make([]SubscriptionCatalogPrice, 0, len(pricesByTerritory))
+	for territory, territoryPrices := range pricesByTerritory {
+		value, ok := selectCurrentSubscriptionPriceValue(territoryPrices, pricePointValues, now)
+		if !ok || value.CustomerPrice == "" {
+			continue
+		}
+		prices = append(prices, SubscriptionCatalogPrice{Territory: territory, Price: value.CustomerPrice})
+	}
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Territory < prices[j].Territory
+	})
+	return prices, nil
+}
+
+// fetchSubscriptionCatalogIntroductoryOffers fills the subscription's
+// introductory offers. When a territory has more than one offer scheduled,
+// the one starting last is kept.
+func fetchSubscriptionCatalogIntroductoryOffers(ctx context.Context, client *asc.Client, sub *subscriptionCatalogRemoteSubscription) error {
+	byTerritory := make(map[string]SubscriptionCatalogIntroductoryOffer)
+	err := forEachSubscriptionCatalogPage(
+		ctx,
+		func(ctx context.Context) (*asc.SubscriptionIntroductoryOffersResponse, error) {
+			return client.GetSubscriptionIntroductoryOffers(
+				ctx,
+				sub.ID,
+				asc.WithSubscriptionIntroductoryOffersInclude([]string{"territory", "subscriptionPricePoint"}),
+				asc.WithSubscriptionIntroductoryOffersPricePointFields([]string{"customerPrice"}),
+				asc.WithSubscriptionIntroductoryOffersLimit(200),
+			)
+		},
+		func(ctx context.Context, nextURL string) (*asc.SubscriptionIntroductoryOffersResponse, error) {
+			return client.GetSubscriptionIntroductoryOffers(ctx, sub.ID, asc.WithSubscriptionIntroductoryOffersNextURL(nextURL))
+		},
+		func(resp *asc.SubscriptionIntroductoryOffersResponse) {
+			values, _ := parseSubscriptionPricesIncluded(resp.Included)
+			for _, item := range resp.Data {
+				territory, pricePointID := extractSubscriptionCatalogOfferRelationships(item.Relationships)
+				offer := SubscriptionCatalogIntroductoryOffer{
+					Territory:       territory,
+					OfferMode:       string(item.Attributes.OfferMode),
+					Duration:        string(item.Attributes.Duration),
+					NumberOfPeriods: item.Attributes.NumberOfPeriods,
+					Price:           values[pricePointID].CustomerPrice,
+					StartDate:       strings.TrimSpace(item.Attributes.StartDate),
+					EndDate:         strings.TrimSpace(item.Attributes.EndDate),
+				}
+				if existing, ok := byTerritory[territory]; ok && existing.StartDate > offer.StartDate {
+					continue
+				}
+				byTerritory[territory] = offer
+				sub.IntroductoryOfferIDs[territory] = item.ID
+			}
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("fetch introductory offers: %w", err)
+	}
+
+	for _, offer := range byTerritory {
+		sub.Subscription.IntroductoryOffers = append(sub.Subscription.IntroductoryOffers, offer)
+	}
+	sort.Slice(sub.Subscription.IntroductoryOffers, func(i, j int) bool {
+		return sub.Subscription.IntroductoryOffers[i].Territory < sub.Subscription.IntroductoryOffers[j].Territory
+	})
+	return nil
+}
+
+// fetchSubscriptionCatalogPromotionalOffers fills the subscription's
+// promotional offers and their per-territory prices.
+func fetchSubscriptionCatalogPromotionalOffers(ctx context.Context, client *asc.Client, sub *subscriptionCatalogRemoteSubscription) error {
+	var offers []asc.Resource[asc.SubscriptionPromotionalOfferAttributes]
+	err := forEachSubscriptionCatalogPage(
+		ctx,
+		func(ctx context.Context) (*asc.SubscriptionPromotionalOffersResponse, error) {
+			return client.GetSubscriptionPromotionalOffers(ctx, sub.ID, asc.WithSubscriptionPromotionalOffersLimit(200))
+		},
+		func(ctx context.Context, nextURL string) (*asc.SubscriptionPromotionalOffersResponse, error) {
+			return client.GetSubscriptionPromotionalOffers(ctx, sub.ID, asc.WithSubscriptionPromotionalOffersNextURL(nextURL))
+		},
+		func(resp *asc.SubscriptionPromotionalOffersResponse) {
+			offers = append(offers, resp.Data...)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("fetch promotional offers: %w", err)
+	}
+
+	for _, item := range offers {
+		offer := SubscriptionCatalogPromotionalOffer{
+			OfferCode:       strings.TrimSpace(item.Attributes.OfferCode),
+			Name:            strings.TrimSpace(item.Attributes.Name),
+			OfferMode:       string(item.Attributes.OfferMode),
+			Duration:        string(item.Attributes.Duration),
+			NumberOfPeriods: item.Attributes.NumberOfPeriods,
+		}
+		err := forEachSubscriptionCatalogPage(
+			ctx,
+			func(ctx context.Context) (*asc.SubscriptionPromotionalOfferPricesResponse, error) {
+				return client.GetSubscriptionPromotionalOfferPrices(
+					ctx,
+					item.ID,
+					asc.WithSubscriptionPromotionalOfferPricesInclude([]string{"territory", "subscriptionPricePoint"}),
+					asc.WithSubscriptionPromotionalOfferPricesPricePointFields([]string{"customerPrice"}),
+					asc.WithSubscriptionPromotionalOfferPricesLimit(200),
+				)
+			},
+			func(ctx context.Context, nextURL string) (*asc.SubscriptionPromotionalOfferPricesResponse, error) {
+				return client.GetSubscriptionPromotionalOfferPrices(ctx, item.ID, asc.WithSubscriptionPromotionalOfferPricesNextURL(nextURL))
+			},
+			func(resp *asc.SubscriptionPromotionalOfferPricesResponse) {
+				values, _ := parseSubscriptionPricesIncluded(resp.Included)
+				for _, price := range resp.Data {
+					territory, pricePointID := extractSubscriptionCatalogOfferRelationships(price.Relationships)
+					if territory == "" {
+						continue
+					}
+					offer.Prices = append(offer.Prices, SubscriptionCatalogPrice{
+						Territory: territory,
+						Price:     values[pricePointID].CustomerPrice,
+					})
+				}
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("fetch promotional offer %q prices: %w", offer.OfferCode, err)
+		}
+		sort.Slice(offer.Prices, func(i, j int) bool {
+			return offer.Prices[i].Territory < offer.Prices[j].Territory
+		})
+		sub.PromotionalOfferIDs[offer.OfferCode] = item.ID
+		sub.Subscription.PromotionalOffers = append(sub.Subscription.PromotionalOffers, offer)
+	}
+	sort.Slice(sub.Subscription.PromotionalOffers, func(i, j int) bool {
+		return sub.Subscription.PromotionalOffers[i].OfferCode < sub.Subscription.PromotionalOffers[j].OfferCode
+	})
+	return nil
+}
+
+// forEachSubscriptionCatalogPage visits every page of a list endpoint, giving
+// each request its own timeout.
+func forEachSubscriptionCatalogPage[T any](
+	ctx context.Context,
+	first func(context.Context) (*asc.Response[T], error),
+	next func(context.Context, string) (*asc.Response[T], error),
+	visit func(*asc.Response[T]),
+) error {
+	seenNext := make(map[string]struct{})
+	nextURL := ""
+	for {
+		pageCtx, pageCancel := shared.ContextWithTimeout(ctx)
+		var (
+			resp *asc.Response[T]
+			err  error
+		)
+		if nextURL == "" {
+			resp, err = first(pageCtx)
+		} else {
+			resp, err = next(pageCtx, nextURL)
+		}
+		pageCancel()
+		if err != nil {
+			return err
+		}
+		visit(resp)
+
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return nil
+		}
+		if _, seen := seenNext[resp.Links.Next]; seen {
+			return fmt.Errorf("repeated next URL")
+		}
+		seenNext[resp.Links.Next] = struct{}{}
+		nextURL = resp.Links.Next
+	}
+}
+
+// extractSubscriptionCatalogOfferRelationships returns the territory and
+// subscription price point IDs of an introductory offer or a promotional
+// offer price.
+func extractSubscriptionCatalogOfferRelationships(raw json.RawMessage) (string, string) {
+	if len(raw) == 0 {
+		return "", ""
+	}
+	var rels struct {
+		Territory              *asc.Relationship `json:"territory"`
+		SubscriptionPricePoint *asc.Relationship `json:"subscriptionPricePoint"`
+	}
+	if err := json.Unmarshal(raw, &rels); err != nil {
+		return "", ""
+	}
+
+	var territory, pricePointID string
+	if rels.Territory != nil {
+		territory = strings.ToUpper(strings.TrimSpace(rels.Territory.Data.ID))
+	}
+	if rels.SubscriptionPricePoint != nil {
+		pricePointID = strings.TrimSpace(rels.SubscriptionPricePoint.Data.ID)
+	}
+	return territory, pricePointID
+}
+
+func extractSubscriptionPriceTerritoryID(price asc.Resource[asc.SubscriptionPriceAttributes]) string {
+	if price.Relationships == nil {
+		return ""
+	}
+
+	var rels struct {
+		Territory *asc.Relationship `json:"territory"`
+	}
+
+	rawRels, err := json.Marshal(price.Relationships)
+	if err != nil {
+		return ""
+	}
+	if err := json.Unmarshal(rawRels, &rels); err != nil {
+		return ""
+	}
+
+	if rels.Territory == nil {
+		return ""
+	}
+
+	return strings.ToUpper(strings.TrimSpace(rels.Territory.Data.ID))
+}
+
+// buildSubscriptionCatalogPlan diffs the catalog against remote state. Every
+// group and subscription is planned independently so one invalid entry does
+// not block the others.
+func buildSubscriptionCatalogPlan(catalog SubscriptionCatalog, remote *subscriptionCatalogRemote) []subscriptionCatalogItemPlan {
+	if remote == nil {
+		remote = &subscriptionCatalogRemote{}
+	}
+
+	remoteGroups := make(map[string]*subscriptionCatalogRemoteGroup, len(remote.Groups))
+	remoteSubs := make(map[string]*subscriptionCatalogRemoteSubscription)
+	for groupIdx := range remote.Groups {
+		group := &remote.Groups[groupIdx]
+		remoteGroups[group.Group.ReferenceName] = group
+		for subIdx := range group.Subscriptions {
+			remoteSubs[group.Subscriptions[subIdx].Subscription.ProductID] = &group.Subscriptions[subIdx]
+		}
+	}
+
+	plans := make([]subscriptionCatalogItemPlan, 0)
+	if catalog.GracePeriod != nil {
+		plan := subscriptionCatalogItemPlan{
+			kind:        subscriptionCatalogKindGracePeriod,
+			id:          remote.GracePeriodID,
+			gracePeriod: catalog.GracePeriod,
+		}
+		plan.changes, plan.err = diffSubscriptionCatalogGracePeriod(catalog.GracePeriod, remote)
+		plans = append(plans, plan)
+	}
+
+	for groupIdx := range catalog.Groups {
+		local := &catalog.Groups[groupIdx]
+		groupPlan := subscriptionCatalogItemPlan{
+			kind:      subscriptionCatalogKindGroup,
+			groupName: local.ReferenceName,
+			parent:    -1,
+			group:     local,
+		}
+		remoteGroup := remoteGroups[local.ReferenceName]
+		if remoteGroup != nil {
+			groupPlan.id = remoteGroup.ID
+			groupPlan.localizationIDs = remoteGroup.LocalizationIDs
+		}
+		groupPlan.changes = diffSubscriptionCatalogGroup(*local, remoteGroup)
+		plans = append(plans, groupPlan)
+		parent := len(plans) - 1
+
+		for subIdx := range local.Subscriptions {
+			sub := &local.Subscriptions[subIdx]
+			subPlan := subscriptionCatalogItemPlan{
+				kind:         subscriptionCatalogKindSubscription,
+				groupName:    local.ReferenceName,
+				productID:    sub.ProductID,
+				parent:       parent,
+				subscription: sub,
+			}
+			remoteSub := remoteSubs[sub.ProductID]
+			if remoteSub != nil {
+				subPlan.id = remoteSub.ID
+				subPlan.localizationIDs = remoteSub.LocalizationIDs
+				subPlan.remoteSubscription = remoteSub
+			}
+			subPlan.changes, subPlan.err = diffSubscriptionCatalogSubscription(local.ReferenceName, *sub, remoteSub)
+			plans = append(plans, subPlan)
+		}
+	}
+	return plans
+}
+
+func diffSubscriptionCatalogGracePeriod(local *SubscriptionCatalogGracePeriod, remote *subscriptionCatalogRemote) ([]SubscriptionCatalogChange, error) {
+	if remote.GracePeriodID == "" || remote.GracePeriod == nil {
+		return nil, fmt.Errorf("app has no subscription grace period to update")
+	}
+	if *remote.GracePeriod == *local {
+		return []SubscriptionCatalogChange{}, nil
+	}
+	return []SubscriptionCatalogChange{{
+		Action: subscriptionCatalogActionUpdateGracePeriod,
+		From:   formatSubscriptionCatalogGracePeriod(remote.GracePeriod),
+		To:     formatSubscriptionCatalogGracePeriod(local),
+	}}, nil
+}
+
+func diffSubscriptionCatalogGroup(local SubscriptionCatalogGroup, remote *subscriptionCatalogRemoteGroup) []SubscriptionCatalogChange {
+	changes := make([]SubscriptionCatalogChange, 0)
+	add := func(action, key, from, to string) {
+		changes = append(changes, SubscriptionCatalogChange{
+			Group:  local.ReferenceName,
+			Action: action,
+			Key:    key,
+			From:   from,
+			To:     to,
+		})
+	}
+
+	current := SubscriptionCatalogGroup{}
+	if remote == nil {
+		add(subscriptionCatalogActionCreateGroup, "", "", local.ReferenceName)
+	} else {
+		current = remote.Group
+	}
+
+	currentLocs := make(map[string]SubscriptionCatalogGroupLocalization, len(current.Localizations))
+	for _, loc := range current.Localizations {
+		currentLocs[loc.Locale] = loc
+	}
+	for _, loc := range local.Localizations {
+		existing, ok := currentLocs[loc.Locale]
+		switch {
+		case !ok:
+			add(subscriptionCatalogActionCreateGroupLocalization, loc.Locale, "", formatSubscriptionCatalogText(loc.Name, loc.CustomAppName))
+		case existing != loc:
+			add(
+				subscriptionCatalogActionUpdateGroupLocalization,
+				loc.Locale,
+				formatSubscriptionCatalogText(existing.Name, existing.CustomAppName),
+				formatSubscriptionCatalogText(loc.Name, loc.CustomAppName),
+			)
+		}
+	}
+	return changes
+}
+
+func diffSubscriptionCatalogSubscription(
+	groupName string,
+	local SubscriptionCatalogSubscription,
+	remote *subscriptionCatalogRemoteSubscription,
+) ([]SubscriptionCatalogChange, error) {
+	changes := make([]SubscriptionCatalogChange, 0)
+	add := func(action, key, from, to string) {
+		changes = append(changes, SubscriptionCatalogChange{
+			Group:     groupName,
+			ProductID: local.ProductID,
+			Action:    action,
+			Key:       key,
+			From:      from,
+			To:        to,
+		})
+	}
+
+	current := SubscriptionCatalogSubscription{}
+	if remote == nil {
+		add(subscriptionCatalogActionCreateSubscription, "", "", local.ReferenceName)
+	} else {
+		if remote.GroupName != groupName {
+			return nil, fmt.Errorf("subscription belongs to group %q in App Store Connect and cannot be moved to %q", remote.GroupName, groupName)
+		}
+		current = remote.Subscription
+		if current.ReferenceName != local.ReferenceName {
+			add(subscriptionCatalogActionUpdateSubscription, "referenceName", current.ReferenceName, local.ReferenceName)
+		}
+		if local.Period != "" && current.Period != local.Period {
+			add(subscriptionCatalogActionUpdateSubscription, "period", current.Period, local.Period)
+		}
+		if local.GroupLevel > 0 && current.GroupLevel != local.GroupLevel {
+			add(subscriptionCatalogActionUpdateSubscription, "groupLevel", strconv.Itoa(current.GroupLevel), strconv.Itoa(local.GroupLevel))
+		}
+		if local.FamilySharable != nil {
+			currentSharable := current.FamilySharable != nil && *current.FamilySharable
+			switch {
+			case currentSharable && !*local.FamilySharable:
+				return nil, fmt.Errorf("familySharable cannot be turned off once Family Sharing is enabled")
+			case currentSharable != *local.FamilySharable:
+				add(subscriptionCatalogActionUpdateSubscription, "familySharable", strconv.FormatBool(currentSharable), strconv.FormatBool(*local.FamilySharable))
+			}
+		}
+		if local.ReviewNote != "" && current.ReviewNote != local.ReviewNote {
+			add(subscriptionCatalogActionUpdateSubscription, "reviewNote", current.ReviewNote, local.ReviewNote)
+		}
+	}
+
+	currentLocs := make(map[string]SubscriptionCatalogLocalization, len(current.Localizations))
+	for _, loc := range current.Localizations {
+		currentLocs[loc.Locale] = loc
+	}
+	for _, loc := range local.Localizations {
+		existing, ok := currentLocs[loc.Locale]
+		switch {
+		case !ok:
+			add(subscriptionCatalogActionCreateLocalization, loc.Locale, "", formatSubscriptionCatalogText(loc.Name, loc.Description))
+		case existing != loc:
+			add(
+				subscriptionCatalogActionUpdateLocalization,
+				loc.Locale,
+				formatSubscriptionCatalogText(existing.Name, existing.Description),
+				formatSubscriptionCatalogText(loc.Name, loc.Description),
+			)
+		}
+	}
+
+	currentPrices := make(map[string]string, len(current.Prices))
+	for _, price := range current.Prices {
+		currentPrices[price.Territory] = price.Price
+	}
+	for _, price := range local.Prices {
+		existing, ok := currentPrices[price.Territory]
+		if ok && subscriptionCatalogPricesEqual(existing, price.Price) {
+			continue
+		}
+		add(subscriptionCatalogActionSetPrice, price.Territory, existing, price.Price)
+	}
+
+	currentIntroOffers := make(map[string]SubscriptionCatalogIntroductoryOffer, len(current.IntroductoryOffers))
+	for _, offer := range current.IntroductoryOffers {
+		currentIntroOffers[offer.Territory] = offer
+	}
+	for _, offer := range local.IntroductoryOffers {
+		existing, ok := currentIntroOffers[offer.Territory]
+		withCurrentEndDate := offer
+		withCurrentEndDate.EndDate = existing.EndDate
+		switch {
+		case !ok:
+			add(subscriptionCatalogActionCreateIntroOffer, offer.Territory, "", formatSubscriptionCatalogIntroductoryOffer(offer))
+		case subscriptionCatalogIntroductoryOffersEqual(existing, offer):
+		case subscriptionCatalogIntroductoryOffersEqual(existing, withCurrentEndDate):
+			add(subscriptionCatalogActionUpdateIntroOffer, offer.Territory, existing.EndDate, offer.EndDate)
+		default:
+			add(
+				subscriptionCatalogActionReplaceIntroOffer,
+				offer.Territory,
+				formatSubscriptionCatalogIntroductoryOffer(existing),
+				formatSubscriptionCatalogIntroductoryOffer(offer),
+			)
+		}
+	}
+
+	currentPromoOffers := make(map[string]SubscriptionCatalogPromotionalOffer, len(current.PromotionalOffers))
+	for _, offer := range current.PromotionalOffers {
+		currentPromoOffers[offer.OfferCode] = offer
+	}
+	for _, offer := range local.PromotionalOffers {
+		existing, ok := currentPromoOffers[offer.OfferCode]
+		if !ok {
+			add(subscriptionCatalogActionCreatePromoOffer, offer.OfferCode, "", formatSubscriptionCatalogPromotionalOffer(offer))
+			continue
+		}
+		if formatSubscriptionCatalogPromotionalOfferTerms(existing) != formatSubscriptionCatalogPromotionalOfferTerms(offer) {
+			return nil, fmt.Errorf(
+				"promotional offer %q cannot change from %q to %q; only prices can change, use a new offerCode instead",
+				offer.OfferCode,
+				formatSubscriptionCatalogPromotionalOfferTerms(existing),
+				formatSubscriptionCatalogPromotionalOfferTerms(offer),
+			)
+		}
+		if merged, changed := mergeSubscriptionCatalogOfferPrices(existing.Prices, offer.Prices); changed {
+			add(
+				subscriptionCatalogActionUpdatePromoOffer,
+				offer.OfferCode,
+				formatSubscriptionCatalogOfferPrices(existing.Prices),
+				formatSubscriptionCatalogOfferPrices(merged),
+			)
+		}
+	}
+
+	return changes, nil
+}
+
+func subscriptionCatalogIntroductoryOffersEqual(current, desired SubscriptionCatalogIntroductoryOffer) bool {
+	if current.Price != "" || desired.Price != "" {
+		if !subscriptionCatalogPricesEqual(current.Price, desired.Price) {
+			return false
+		}
+		current.Price, desired.Price = "", ""
+	}
+	return current == desired
+}
+
+// mergeSubscriptionCatalogOfferPrices overlays the desired offer prices on the
+// current ones, keeping territories missing from the catalog. It reports
+// whether any territory changed.
+func mergeSubscriptionCatalogOfferPrices(current, desired []SubscriptionCatalogPrice) ([]SubscriptionCatalogPrice, bool) {
+	byTerritory := make(map[string]string, len(current)+len(desired))
+	for _, price := range current {
+		byTerritory[price.Territory] = price.Price
+	}
+	changed := false
+	for _, price := range desired {
+		existing, ok := byTerritory[price.Territory]
+		if ok && (existing == price.Price || subscriptionCatalogPricesEqual(existing, price.Price)) {
+			continue
+		}
+		byTerritory[price.Territory] = price.Price
+		changed = true
+	}
+
+	merged := make([]SubscriptionCatalogPrice, 0, len(byTerritory))
+	for territory, price := range byTerritory {
+		merged = append(merged, SubscriptionCatalogPrice{Territory: territory, Price: price})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Territory < merged[j].Territory
+	})
+	return merged, changed
+}
+
+func subscriptionCatalogPricesEqual(current, desired string) bool {
+	currentKey, err := normalizeSubscriptionPriceImportPrice(current)
+	if err != nil {
+		return false
+	}
+	desiredKey, err := normalizeSubscriptionPriceImportPrice(desired)
+	if err != nil {
+		return false
+	}
+	return currentKey == desiredKey
+}
+
+func formatSubscriptionCatalogText(name, detail string) string {
+	if detail == "" {
+		return name
+	}
+	return name + " / " + detail
+}
+
+func formatSubscriptionCatalogIntroductoryOffer(offer SubscriptionCatalogIntroductoryOffer) string {
+	parts := []string{fmt.Sprintf("%s %s x%d", offer.OfferMode, offer.Duration, offer.NumberOfPeriods)}
+	if offer.Price != "" {
+		parts = append(parts, offer.Price)
+	}
+	if offer.StartDate != "" {
+		parts = append(parts, "from "+offer.StartDate)
+	}
+	if offer.EndDate != "" {
+		parts = append(parts, "until "+offer.EndDate)
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatSubscriptionCatalogPromotionalOfferTerms(offer SubscriptionCatalogPromotionalOffer) string {
+	return fmt.Sprintf("%s / %s %s x%d", offer.Name, offer.OfferMode, offer.Duration, offer.NumberOfPeriods)
+}
+
+func formatSubscriptionCatalogPromotionalOffer(offer SubscriptionCatalogPromotionalOffer) string {
+	return formatSubscriptionCatalogPromotionalOfferTerms(offer) + " / " + formatSubscriptionCatalogOfferPrices(offer.Prices)
+}
+
+func formatSubscriptionCatalogOfferPrices(prices []SubscriptionCatalogPrice) string {
+	parts := make([]string, 0, len(prices))
+	for _, price := range prices {
+		if price.Price == "" {
+			parts = append(parts, price.Territory)
+			continue
+		}
+		parts = append(parts, price.Territory+"="+price.Price)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatSubscriptionCatalogGracePeriod(grace *SubscriptionCatalogGracePeriod) string {
+	if grace == nil {
+		return ""
+	}
+	return fmt.Sprintf("optIn=%t sandboxOptIn=%t %s %s", grace.OptIn, grace.SandboxOptIn, grace.Duration, grace.RenewalType)
+}
+
+// applySubscriptionCatalogPlan executes planned changes in catalog order. Each
+// item stops at its first failure; subscriptions of a group that could not be
+// created are marked failed without being attempted.
+func applySubscriptionCatalogPlan(ctx context.Context, client *asc.Client, appID string, plans []subscriptionCatalogItemPlan) {
+	for idx := range plans {
+		plan := &plans[idx]
+		if plan.err != nil || len(plan.changes) == 0 {
+			continue
+		}
+		if plan.kind == subscriptionCatalogKindSubscription && plans[plan.parent].id == "" {
+			plan.err = fmt.Errorf("group %q was not created", plan.groupName)
+			continue
+		}
+
+		lookupCache := &subscriptionPricePointLookupCache{byTerritory: make(map[string]map[string][]string)}
+		for _, change := range plan.changes {
+			if err := applySubscriptionCatalogChange(ctx, client, appID, plans, plan, change, lookupCache); err != nil {
+				plan.err = fmt.Errorf("%s: %w", change.Action, err)
+				break
+			}
+		}
+		plan.applied = plan.err == nil
+	}
+}
+
+func applySubscriptionCatalogChange(
+	ctx context.Context,
+	client *asc.Client,
+	appID string,
+	plans []subscriptionCatalogItemPlan,
+	plan *subscriptionCatalogItemPlan,
+	change SubscriptionCatalogChange,
+	lookupCache *subscriptionPricePointLookupCache,
+) error {
+	if change.Action == subscriptionCatalogActionSetPrice {
+		priceKey, err := normalizeSubscriptionPriceImportPrice(change.To)
+		if err != nil {
+			return err
+		}
+		pricePointID, err := lookupCache.lookupPricePointID(ctx, client, plan.id, change.Key, priceKey, change.To)
+		if err != nil {
+			return err
+		}
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		defer cancel()
+		_, err = client.CreateSubscriptionPrice(requestCtx, plan.id, pricePointID, change.Key, asc.SubscriptionPriceCreateAttributes{})
+		return err
+	}
+
+	switch change.Action {
+	case subscriptionCatalogActionCreateIntroOffer,
+		subscriptionCatalogActionUpdateIntroOffer,
+		subscriptionCatalogActionReplaceIntroOffer:
+		return applySubscriptionCatalogIntroductoryOfferChange(ctx, client, plan, change, lookupCache)
+	case subscriptionCatalogActionCreatePromoOffer, subscriptionCatalogActionUpdatePromoOffer:
+		return applySubscriptionCatalogPromotionalOfferChange(ctx, client, plan, change, lookupCache)
+	}
+
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	switch change.Action {
+	case subscriptionCatalogActionUpdateGracePeriod:
+		grace := *plan.gracePeriod
+		_, err := client.UpdateSubscriptionGracePeriod(requestCtx, plan.id, asc.SubscriptionGracePeriodUpdateAttributes{
+			OptIn:        &grace.OptIn,
+			SandboxOptIn: &grace.SandboxOptIn,
+			Duration:     &grace.Duration,
+			RenewalType:  &grace.RenewalType,
+		})
+		return err
+	case subscriptionCatalogActionCreateGroup:
+		resp, err := client.CreateSubscriptionGroup(requestCtx, appID, asc.SubscriptionGroupCreateAttributes{
+			ReferenceName: plan.group.ReferenceName,
+		})
+		if err != nil {
+			return err
+		}
+		plan.id = resp.Data.ID
+		return nil
+	case subscriptionCatalogActionCreateGroupLocalization, subscriptionCatalogActionUpdateGroupLocalization:
+		idx := slices.IndexFunc(plan.group.Localizations, func(loc SubscriptionCatalogGroupLocalization) bool {
+			return loc.Locale == change.Key
+		})
+		if idx < 0 {
+			return fmt.Errorf("localization %q not found in catalog", change.Key)
+		}
+		loc := plan.group.Localizations[idx]
+		if change.Action == subscriptionCatalogActionCreateGroupLocalization {
+			_, err := client.CreateSubscriptionGroupLocalization(requestCtx, plan.id, asc.SubscriptionGroupLocalizationCreateAttributes{
+				Name:          loc.Name,
+				CustomAppName: loc.CustomAppName,
+				Locale:        loc.Locale,
+			})
+			return err
+		}
+		_, err := client.UpdateSubscriptionGroupLocalization(requestCtx, plan.localizationIDs[loc.Locale], asc.SubscriptionGroupLocalizationUpdateAttributes{
+			Name:          &loc.Name,
+			CustomAppName: &loc.CustomAppName,
+		})
+		return err
+	case subscriptionCatalogActionCreateSubscription:
+		sub := plan.subscription
+		attrs := asc.SubscriptionCreateAttributes{
+			Name:               sub.ReferenceName,
+			ProductID:          sub.ProductID,
+			FamilySharable:     sub.FamilySharable,
+			SubscriptionPeriod: sub.Period,
+			ReviewNote:         sub.ReviewNote,
+		}
+		if sub.GroupLevel > 0 {
+			attrs.GroupLevel = &sub.GroupLevel
+		}
+		resp, err := client.CreateSubscription(requestCtx, plans[plan.parent].id, attrs)
+		if err != nil {
+			return err
+		}
+		plan.id = resp.Data.ID
+		return nil
+	case subscriptionCatalogActionUpdateSubscription:
+		sub := plan.subscription
+		attrs := asc.SubscriptionUpdateAttributes{}
+		switch change.Key {
+		case "referenceName":
+			attrs.Name = &sub.ReferenceName
+		case "period":
+			attrs.SubscriptionPeriod = &sub.Period
+		case "groupLevel":
+			attrs.GroupLevel = &sub.GroupLevel
+		case "familySharable":
+			attrs.FamilySharable = sub.FamilySharable
+		case "reviewNote":
+			attrs.ReviewNote = &sub.ReviewNote
+		default:
+			return fmt.Errorf("unsupported field %q", change.Key)
+		}
+		_, err := client.UpdateSubscription(requestCtx, plan.id, attrs)
+		return err
+	case subscriptionCatalogActionCreateLocalization, subscriptionCatalogActionUpdateLocalization:
+		idx := slices.IndexFunc(plan.subscription.Localizations, func(loc SubscriptionCatalogLocalization) bool {
+			return loc.Locale == change.Key
+		})
+		if idx < 0 {
+			return fmt.Errorf("localization %q not found in catalog", change.Key)
+		}
+		loc := plan.subscription.Localizations[idx]
+		if change.Action == subscriptionCatalogActionCreateLocalization {
+			_, err := client.CreateSubscriptionLocalization(requestCtx, plan.id, asc.SubscriptionLocalizationCreateAttributes{
+				Name:        loc.Name,
+				Locale:      loc.Locale,
+				Description: loc.Description,
+			})
+			return err
+		}
+		_, err := client.UpdateSubscriptionLocalization(requestCtx, plan.localizationIDs[loc.Locale], asc.SubscriptionLocalizationUpdateAttributes{
+			Name:        &loc.Name,
+			Description: &loc.Description,
+		})
+		return err
+	default:
+		return fmt.Errorf("unsupported action %q", change.Action)
+	}
+}
+
+// applySubscriptionCatalogIntroductoryOfferChange creates, updates, or
+// replaces the introductory offer for one territory. Only endDate can be
+// changed in place, so replacing creates the new offer before deleting the
+// current one; a failed create leaves the current offer untouched.
+func applySubscriptionCatalogIntroductoryOfferChange(
+	ctx context.Context,
+	client *asc.Client,
+	plan *subscriptionCatalogItemPlan,
+	change SubscriptionCatalogChange,
+	lookupCache *subscriptionPricePointLookupCache,
+) error {
+	idx := slices.IndexFunc(plan.subscription.IntroductoryOffers, func(offer SubscriptionCatalogIntroductoryOffer) bool {
+		return offer.Territory == change.Key
+	})
+	if idx < 0 {
+		return fmt.Errorf("introductory offer for territory %q not found in catalog", change.Key)
+	}
+	offer := plan.subscription.IntroductoryOffers[idx]
+
+	var remoteID string
+	if plan.remoteSubscription != nil {
+		remoteID = plan.remoteSubscription.IntroductoryOfferIDs[offer.Territory]
+	}
+	if change.Action != subscriptionCatalogActionCreateIntroOffer && remoteID == "" {
+		return fmt.Errorf("introductory offer for territory %q not found in App Store Connect", offer.Territory)
+	}
+
+	if change.Action == subscriptionCatalogActionUpdateIntroOffer {
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		defer cancel()
+		endDate := offer.EndDate
+		_, err := client.UpdateSubscriptionIntroductoryOffer(requestCtx, remoteID, asc.SubscriptionIntroductoryOfferUpdateAttributes{
+			EndDate: &endDate,
+		})
+		return err
+	}
+
+	var pricePointID string
+	if offer.Price != "" {
+		priceKey, err := normalizeSubscriptionPriceImportPrice(offer.Price)
+		if err != nil {
+			return err
+		}
+		pricePointID, err = lookupCache.lookupPricePointID(ctx, client, plan.id, offer.Territory, priceKey, offer.Price)
+		if err != nil {
+			return err
+		}
+	}
+
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	_, err := client.CreateSubscriptionIntroductoryOffer(requestCtx, plan.id, asc.SubscriptionIntroductoryOfferCreateAttributes{
+		StartDate:       offer.StartDate,
+		EndDate:         offer.EndDate,
+		Duration:        asc.SubscriptionOfferDuration(offer.Duration),
+		OfferMode:       asc.SubscriptionOfferMode(offer.OfferMode),
+		NumberOfPeriods: offer.NumberOfPeriods,
+	}, offer.Territory, pricePointID)
+	cancel()
+	if err != nil || change.Action != subscriptionCatalogActionReplaceIntroOffer {
+		return err
+	}
+
+	deleteCtx, deleteCancel := shared.ContextWithTimeout(ctx)
+	defer deleteCancel()
+	if err := client.DeleteSubscriptionIntroductoryOffer(deleteCtx, remoteID); err != nil {
+		return fmt.Errorf("created the new offer but failed to delete the current offer %s: %w", remoteID, err)
+	}
+	return nil
+}
+
+// applySubscriptionCatalogPromotionalOfferChange creates a promotional offer
+// or replaces its prices. Updates send the full price list, so territories
+// missing from the catalog keep their current price.
+func applySubscriptionCatalogPromotionalOfferChange(
+	ctx context.Context,
+	client *asc.Client,
+	plan *subscriptionCatalogItemPlan,
+	change SubscriptionCatalogChange,
+	lookupCache *subscriptionPricePointLookupCache,
+) error {
+	idx := slices.IndexFunc(plan.subscription.PromotionalOffers, func(offer SubscriptionCatalogPromotionalOffer) bool {
+		return offer.OfferCode == change.Key
+	})
+	if idx < 0 {
+		return fmt.Errorf("promotional offer %q not found in catalog", change.Key)
+	}
+	offer := plan.subscription.PromotionalOffers[idx]
+
+	desired := offer.Prices
+	var remoteID string
+	if change.Action == subscriptionCatalogActionUpdatePromoOffer {
+		if plan.remoteSubscription != nil {
+			remoteID = plan.remoteSubscription.PromotionalOfferIDs[offer.OfferCode]
+			remoteIdx := slices.IndexFunc(plan.remoteSubscription.Subscription.PromotionalOffers, func(remote SubscriptionCatalogPromotionalOffer) bool {
+				return remote.OfferCode == offer.OfferCode
+			})
+			if remoteIdx >= 0 {
+				desired, _ = mergeSubscriptionCatalogOfferPrices(plan.remoteSubscription.Subscription.PromotionalOffers[remoteIdx].Prices, offer.Prices)
+			}
+		}
+		if remoteID == "" {
+			return fmt.Errorf("promotional offer %q not found in App Store Connect", offer.OfferCode)
+		}
+	}
+
+	prices := make([]asc.SubscriptionPromotionalOfferPrice, 0, len(desired))
+	for _, price := range desired {
+		entry := asc.SubscriptionPromotionalOfferPrice{TerritoryID: price.Territory}
+		if price.Price != "" {
+			priceKey, err := normalizeSubscriptionPriceImportPrice(price.Price)
+			if err != nil {
+				return fmt.Errorf("%s: %w", price.Territory, err)
+			}
+			entry.PricePointID, err = lookupCache.lookupPricePointID(ctx, client, plan.id, price.Territory, priceKey, price.Price)
+			if err != nil {
+				return err
+			}
+		}
+		prices = append(prices, entry)
+	}
+
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+	if change.Action == subscriptionCatalogActionUpdatePromoOffer {
+		_, err := client.UpdateSubscriptionPromotionalOfferWithPrices(requestCtx, remoteID, prices)
+		return err
+	}
+	_, err := client.CreateSubscriptionPromotionalOfferWithPrices(requestCtx, plan.id, asc.SubscriptionPromotionalOfferCreateAttributes{
+		Duration:        asc.SubscriptionOfferDuration(offer.Duration),
+		Name:            offer.Name,
+		NumberOfPeriods: offer.NumberOfPeriods,
+		OfferCode:       offer.OfferCode,
+		OfferMode:       asc.SubscriptionOfferMode(offer.OfferMode),
+	}, prices)
+	return err
+}
+
+func (r *SubscriptionCatalogApplyResult) collect(plans []subscriptionCatalogItemPlan) {
+	r.Changes = make([]SubscriptionCatalogChange, 0)
+	r.Items = make([]SubscriptionCatalogItemResult, 0, len(plans))
+	r.Failed = 0
+	for _, plan := range plans {
+		item := SubscriptionCatalogItemResult{
+			Kind:      plan.kind,
+			Group:     plan.groupName,
+			ProductID: plan.productID,
+			ID:        plan.id,
+			Changes:   len(plan.changes),
+		}
+		switch {
+		case plan.err != nil:
+			item.Status = subscriptionCatalogStatusFailed
+			item.Error = plan.err.Error()
+			r.Failed++
+		case len(plan.changes) == 0:
+			item.Status = subscriptionCatalogStatusUnchanged
+		case plan.applied:
+			item.Status = subscriptionCatalogStatusApplied
+		default:
+			item.Status = subscriptionCatalogStatusPlanned
+		}
+		r.Changes = append(r.Changes, plan.changes...)
+		r.Items = append(r.Items, item)
+	}
+}
+
+func renderSubscriptionCatalogApplyResult(result *SubscriptionCatalogApplyResult, markdown bool) error {
+	if result == nil {
+		return fmt.Errorf("result is nil")
+	}
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	changeRows := make([][]string, 0, len(result.Changes))
+	for _, change := range result.Changes {
+		changeRows = append(changeRows, []string{
+			change.Group,
+			change.ProductID,
+			change.Action,
+			change.Key,
+			compactSubText(change.From),
+			compactSubText(change.To),
+		})
+	}
+	if len(changeRows) == 0 {
+		changeRows = append(changeRows, []string{"", "", "none", "", "", ""})
+	}
+	render([]string{"Group", "Product ID", "Action", "Key", "From", "To"}, changeRows)
+
+	itemRows := make([][]string, 0, len(result.Items))
+	for _, item := range result.Items {
+		itemRows = append(itemRows, []string{
+			item.Kind,
+			item.Group,
+			item.ProductID,
+			item.ID,
+			item.Status,
+			fmt.Sprintf("%d", item.Changes),
+			item.Error,
+		})
+	}
+	render([]string{"Kind", "Group", "Product ID", "ID", "Status", "Changes", "Error"}, itemRows)
+	return nil
+}
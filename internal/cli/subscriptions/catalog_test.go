@@ -0,0 +1,500 @@
+package subscriptions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func TestNormalizeSubscriptionCatalog_NormalizesFields(t *testing.T) {
+	catalog := SubscriptionCatalog{
+		GracePeriod: &SubscriptionCatalogGracePeriod{Duration: "sixteen_days", RenewalType: "all_renewals"},
+		Groups: []SubscriptionCatalogGroup{{
+			ReferenceName: " Premium ",
+			Subscriptions: []SubscriptionCatalogSubscription{{
+				ProductID:     " com.example.monthly ",
+				ReferenceName: "Monthly",
+				Period:        "one_month",
+				Prices: []SubscriptionCatalogPrice{
+					{Territory: "United States", Price: " 9.99 "},
+					{Territory: "ca", Price: "12.99"},
+				},
+			}},
+		}},
+	}
+
+	if err := normalizeSubscriptionCatalog(&catalog); err != nil {
+		t.Fatalf("normalizeSubscriptionCatalog() error: %v", err)
+	}
+
+	if catalog.GracePeriod.Duration != "SIXTEEN_DAYS" || catalog.GracePeriod.RenewalType != "ALL_RENEWALS" {
+		t.Fatalf("expected normalized grace period, got %+v", catalog.GracePeriod)
+	}
+	group := catalog.Groups[0]
+	if group.ReferenceName != "Premium" {
+		t.Fatalf("expected trimmed group name, got %q", group.ReferenceName)
+	}
+	sub := group.Subscriptions[0]
+	if sub.ProductID != "com.example.monthly" || sub.Period != "ONE_MONTH" {
+		t.Fatalf("expected normalized subscription, got %+v", sub)
+	}
+	if sub.Prices[0].Territory != "CAN" || sub.Prices[1].Territory != "USA" || sub.Prices[1].Price != "9.99" {
+		t.Fatalf("expected resolved and sorted territories, got %+v", sub.Prices)
+	}
+}
+
+func TestNormalizeSubscriptionCatalog_RejectsInvalidEntries(t *testing.T) {
+	valid := func() SubscriptionCatalogSubscription {
+		return SubscriptionCatalogSubscription{ProductID: "a", ReferenceName: "A"}
+	}
+
+	tests := []struct {
+		name    string
+		catalog SubscriptionCatalog
+	}{
+		{name: "empty", catalog: SubscriptionCatalog{}},
+		{
+			name:    "invalid grace period duration",
+			catalog: SubscriptionCatalog{GracePeriod: &SubscriptionCatalogGracePeriod{Duration: "FOREVER", RenewalType: "ALL_RENEWALS"}},
+		},
+		{
+			name:    "missing group name",
+			catalog: SubscriptionCatalog{Groups: []SubscriptionCatalogGroup{{}}},
+		},
+		{
+			name:    "duplicate group",
+			catalog: SubscriptionCatalog{Groups: []SubscriptionCatalogGroup{{ReferenceName: "G"}, {ReferenceName: "G"}}},
+		},
+		{
+			name: "duplicate product across groups",
+			catalog: SubscriptionCatalog{Groups: []SubscriptionCatalogGroup{
+				{ReferenceName: "G1", Subscriptions: []SubscriptionCatalogSubscription{valid()}},
+				{ReferenceName: "G2", Subscriptions: []SubscriptionCatalogSubscription{valid()}},
+			}},
+		},
+		{
+			name: "invalid period",
+			catalog: SubscriptionCatalog{Groups: []SubscriptionCatalogGroup{{
+				ReferenceName: "G",
+				Subscriptions: []SubscriptionCatalogSubscription{{ProductID: "a", ReferenceName: "A", Period: "WEEKLY"}},
+			}}},
+		},
+		{
+			name: "unknown territory",
+			catalog: SubscriptionCatalog{Groups: []SubscriptionCatalogGroup{{
+				ReferenceName: "G",
+				Subscriptions: []SubscriptionCatalogSubscription{{
+					ProductID: "a", ReferenceName: "A",
+					Prices: []SubscriptionCatalogPrice{{Territory: "Atlantis", Price: "1.99"}},
+				}},
+			}}},
+		},
+		{
+			name: "duplicate territory",
+			catalog: SubscriptionCatalog{Groups: []SubscriptionCatalogGroup{{
+				ReferenceName: "G",
+				Subscriptions: []SubscriptionCatalogSubscription{{
+					ProductID: "a", ReferenceName: "A",
+					Prices: []SubscriptionCatalogPrice{{Territory: "USA", Price: "1.99"}, {Territory: "US", Price: "2.99"}},
+				}},
+			}}},
+		},
+		{
+			name: "free trial introductory offer with price",
+			catalog: SubscriptionCatalog{Groups: []SubscriptionCatalogGroup{{
+				ReferenceName: "G",
+				Subscriptions: []SubscriptionCatalogSubscription{{
+					ProductID: "a", ReferenceName: "A",
+					IntroductoryOffers: []SubscriptionCatalogIntroductoryOffer{{
+						Territory: "USA", OfferMode: "FREE_TRIAL", Duration: "ONE_WEEK", NumberOfPeriods: 1, Price: "0.99",
+					}},
+				}},
+			}}},
+		},
+		{
+			name: "paid introductory offer without price",
+			catalog: SubscriptionCatalog{Groups: []SubscriptionCatalogGroup{{
+				ReferenceName: "G",
+				Subscriptions: []SubscriptionCatalogSubscription{{
+					ProductID: "a", ReferenceName: "A",
+					IntroductoryOffers: []SubscriptionCatalogIntroductoryOffer{{
+						Territory: "USA", OfferMode: "PAY_UP_FRONT", Duration: "ONE_MONTH", NumberOfPeriods: 1,
+					}},
+				}},
+			}}},
+		},
+		{
+			name: "duplicate introductory offer territory",
+			catalog: SubscriptionCatalog{Groups: []SubscriptionCatalogGroup{{
+				ReferenceName: "G",
+				Subscriptions: []SubscriptionCatalogSubscription{{
+					ProductID: "a", ReferenceName: "A",
+					IntroductoryOffers: []SubscriptionCatalogIntroductoryOffer{
+						{Territory: "USA", OfferMode: "FREE_TRIAL", Duration: "ONE_WEEK", NumberOfPeriods: 1},
+						{Territory: "US", OfferMode: "FREE_TRIAL", Duration: "TWO_WEEKS", NumberOfPeriods: 1},
+					},
+				}},
+			}}},
+		},
+		{
+			name: "promotional offer without prices",
+			catalog: SubscriptionCatalog{Groups: []SubscriptionCatalogGroup{{
+				ReferenceName: "G",
+				Subscriptions: []SubscriptionCatalogSubscription{{
+					ProductID: "a", ReferenceName: "A",
+					PromotionalOffers: []SubscriptionCatalogPromotionalOffer{{
+						OfferCode: "WINBACK", Name: "Win back", OfferMode: "PAY_UP_FRONT", Duration: "THREE_MONTHS", NumberOfPeriods: 1,
+					}},
+				}},
+			}}},
+		},
+		{
+			name: "promotional offer invalid duration",
+			catalog: SubscriptionCatalog{Groups: []SubscriptionCatalogGroup{{
+				ReferenceName: "G",
+				Subscriptions: []SubscriptionCatalogSubscription{{
+					ProductID: "a", ReferenceName: "A",
+					PromotionalOffers: []SubscriptionCatalogPromotionalOffer{{
+						OfferCode: "WINBACK", Name: "Win back", OfferMode: "PAY_UP_FRONT", Duration: "FOREVER", NumberOfPeriods: 1,
+						Prices: []SubscriptionCatalogPrice{{Territory: "USA", Price: "4.99"}},
+					}},
+				}},
+			}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := normalizeSubscriptionCatalog(&test.catalog); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestBuildSubscriptionCatalogPlan_NewGroupPlansAllChanges(t *testing.T) {
+	catalog := SubscriptionCatalog{Groups: []SubscriptionCatalogGroup{{
+		ReferenceName: "Premium",
+		Localizations: []SubscriptionCatalogGroupLocalization{{Locale: "en-US", Name: "Premium"}},
+		Subscriptions: []SubscriptionCatalogSubscription{{
+			ProductID:     "com.example.monthly",
+			ReferenceName: "Monthly",
+			Localizations: []SubscriptionCatalogLocalization{{Locale: "en-US", Name: "Monthly"}},
+			Prices:        []SubscriptionCatalogPrice{{Territory: "USA", Price: "9.99"}},
+		}},
+	}}}
+
+	plans := buildSubscriptionCatalogPlan(catalog, nil)
+	if len(plans) != 2 {
+		t.Fatalf("expected group and subscription plans, got %d", len(plans))
+	}
+	if plans[1].parent != 0 {
+		t.Fatalf("expected subscription to reference group plan, got parent %d", plans[1].parent)
+	}
+
+	var actions []string
+	for _, plan := range plans {
+		if plan.err != nil {
+			t.Fatalf("unexpected plan error: %v", plan.err)
+		}
+		for _, change := range plan.changes {
+			actions = append(actions, change.Action)
+		}
+	}
+	want := []string{
+		subscriptionCatalogActionCreateGroup,
+		subscriptionCatalogActionCreateGroupLocalization,
+		subscriptionCatalogActionCreateSubscription,
+		subscriptionCatalogActionCreateLocalization,
+		subscriptionCatalogActionSetPrice,
+	}
+	if len(actions) != len(want) {
+		t.Fatalf("expected %v, got %v", want, actions)
+	}
+	for idx := range want {
+		if actions[idx] != want[idx] {
+			t.Fatalf("change %d: expected %s, got %s", idx, want[idx], actions[idx])
+		}
+	}
+}
+
+func TestBuildSubscriptionCatalogPlan_MatchingRemoteIsUnchanged(t *testing.T) {
+	familySharable := true
+	sub := SubscriptionCatalogSubscription{
+		ProductID:      "com.example.monthly",
+		ReferenceName:  "Monthly",
+		Period:         "ONE_MONTH",
+		GroupLevel:     1,
+		FamilySharable: &familySharable,
+		Localizations:  []SubscriptionCatalogLocalization{{Locale: "en-US", Name: "Monthly", Description: "All access"}},
+		Prices:         []SubscriptionCatalogPrice{{Territory: "USA", Price: "9.99"}},
+	}
+	grace := SubscriptionCatalogGracePeriod{OptIn: true, Duration: "SIXTEEN_DAYS", RenewalType: "ALL_RENEWALS"}
+	catalog := SubscriptionCatalog{
+		GracePeriod: &grace,
+		Groups: []SubscriptionCatalogGroup{{
+			ReferenceName: "Premium",
+			Localizations: []SubscriptionCatalogGroupLocalization{{Locale: "en-US", Name: "Premium"}},
+			Subscriptions: []SubscriptionCatalogSubscription{sub},
+		}},
+	}
+
+	remoteSub := sub
+	remoteSub.Prices = []SubscriptionCatalogPrice{{Territory: "USA", Price: "9.990"}, {Territory: "CAN", Price: "12.99"}}
+	remoteGrace := grace
+	remote := &subscriptionCatalogRemote{
+		GracePeriodID: "grace-1",
+		GracePeriod:   &remoteGrace,
+		Groups: []subscriptionCatalogRemoteGroup{{
+			ID:              "group-1",
+			Group:           SubscriptionCatalogGroup{ReferenceName: "Premium", Localizations: catalog.Groups[0].Localizations},
+			LocalizationIDs: map[string]string{"en-US": "gloc-1"},
+			Subscriptions: []subscriptionCatalogRemoteSubscription{{
+				ID:              "sub-1",
+				GroupName:       "Premium",
+				Subscription:    remoteSub,
+				LocalizationIDs: map[string]string{"en-US": "loc-1"},
+			}},
+		}},
+	}
+
+	plans := buildSubscriptionCatalogPlan(catalog, remote)
+	for _, plan := range plans {
+		if plan.err != nil {
+			t.Fatalf("unexpected plan error for %s: %v", plan.kind, plan.err)
+		}
+		if len(plan.changes) != 0 {
+			t.Fatalf("expected no changes for %s, got %+v", plan.kind, plan.changes)
+		}
+	}
+	if plans[2].id != "sub-1" {
+		t.Fatalf("expected remote subscription ID, got %q", plans[2].id)
+	}
+}
+
+func TestBuildSubscriptionCatalogPlan_ReportsPerItemErrors(t *testing.T) {
+	catalog := SubscriptionCatalog{
+		GracePeriod: &SubscriptionCatalogGracePeriod{Duration: "THREE_DAYS", RenewalType: "ALL_RENEWALS"},
+		Groups: []SubscriptionCatalogGroup{{
+			ReferenceName: "Premium",
+			Subscriptions: []SubscriptionCatalogSubscription{
+				{ProductID: "com.example.moved", ReferenceName: "Moved"},
+				{ProductID: "com.example.renamed", ReferenceName: "New Name"},
+			},
+		}},
+	}
+	remote := &subscriptionCatalogRemote{Groups: []subscriptionCatalogRemoteGroup{
+		{
+			ID:    "group-1",
+			Group: SubscriptionCatalogGroup{ReferenceName: "Premium"},
+			Subscriptions: []subscriptionCatalogRemoteSubscription{{
+				ID:           "sub-2",
+				GroupName:    "Premium",
+				Subscription: SubscriptionCatalogSubscription{ProductID: "com.example.renamed", ReferenceName: "Old Name"},
+			}},
+		},
+		{
+			ID:    "group-2",
+			Group: SubscriptionCatalogGroup{ReferenceName: "Legacy"},
+			Subscriptions: []subscriptionCatalogRemoteSubscription{{
+				ID:           "sub-1",
+				GroupName:    "Legacy",
+				Subscription: SubscriptionCatalogSubscription{ProductID: "com.example.moved", ReferenceName: "Moved"},
+			}},
+		},
+	}}
+
+	plans := buildSubscriptionCatalogPlan(catalog, remote)
+	if plans[0].err == nil {
+		t.Fatal("expected missing grace period error")
+	}
+	if plans[2].err == nil {
+		t.Fatal("expected group move error")
+	}
+	if plans[3].err != nil {
+		t.Fatalf("unexpected error for valid subscription: %v", plans[3].err)
+	}
+	if len(plans[3].changes) != 1 || plans[3].changes[0].Key != "referenceName" {
+		t.Fatalf("expected single rename change, got %+v", plans[3].changes)
+	}
+
+	result := &SubscriptionCatalogApplyResult{}
+	result.collect(plans)
+	if result.Failed != 2 {
+		t.Fatalf("expected 2 failed items, got %d", result.Failed)
+	}
+	if result.Items[1].Status != subscriptionCatalogStatusUnchanged {
+		t.Fatalf("expected unchanged group, got %q", result.Items[1].Status)
+	}
+	if result.Items[3].Status != subscriptionCatalogStatusPlanned {
+		t.Fatalf("expected planned subscription, got %q", result.Items[3].Status)
+	}
+}
+
+func TestBuildSubscriptionCatalogPlan_DiffsFamilySharableOnlyWhenSet(t *testing.T) {
+	enabled, disabled := true, false
+	remoteSharable, remoteNotSharable := true, false
+	catalog := SubscriptionCatalog{
+		Groups: []SubscriptionCatalogGroup{{
+			ReferenceName: "Premium",
+			Subscriptions: []SubscriptionCatalogSubscription{
+				{ProductID: "com.example.omitted", ReferenceName: "Omitted"},
+				{ProductID: "com.example.enable", ReferenceName: "Enable", FamilySharable: &enabled},
+				{ProductID: "com.example.disable", ReferenceName: "Disable", FamilySharable: &disabled},
+			},
+		}},
+	}
+	remoteSub := func(id, productID, name string, sharable *bool) subscriptionCatalogRemoteSubscription {
+		return subscriptionCatalogRemoteSubscription{
+			ID:           id,
+			GroupName:    "Premium",
+			Subscription: SubscriptionCatalogSubscription{ProductID: productID, ReferenceName: name, FamilySharable: sharable},
+		}
+	}
+	remote := &subscriptionCatalogRemote{Groups: []subscriptionCatalogRemoteGroup{{
+		ID:    "group-1",
+		Group: SubscriptionCatalogGroup{ReferenceName: "Premium"},
+		Subscriptions: []subscriptionCatalogRemoteSubscription{
+			remoteSub("sub-1", "com.example.omitted", "Omitted", &remoteSharable),
+			remoteSub("sub-2", "com.example.enable", "Enable", &remoteNotSharable),
+			remoteSub("sub-3", "com.example.disable", "Disable", &remoteSharable),
+		},
+	}}}
+
+	byProduct := map[string]*subscriptionCatalogItemPlan{}
+	plans := buildSubscriptionCatalogPlan(catalog, remote)
+	for i := range plans {
+		if plans[i].productID != "" {
+			byProduct[plans[i].productID] = &plans[i]
+		}
+	}
+	if plan := byProduct["com.example.omitted"]; plan.err != nil || len(plan.changes) != 0 {
+		t.Fatalf("expected omitted familySharable to be left alone, got %+v, %v", plan.changes, plan.err)
+	}
+	if plan := byProduct["com.example.enable"]; plan.err != nil || len(plan.changes) != 1 || plan.changes[0].Key != "familySharable" || plan.changes[0].To != "true" {
+		t.Fatalf("expected familySharable to be enabled, got %+v, %v", plan.changes, plan.err)
+	}
+	if plan := byProduct["com.example.disable"]; plan.err == nil || !strings.Contains(plan.err.Error(), "cannot be turned off") {
+		t.Fatalf("expected turning off Family Sharing to be rejected, got %v", plan.err)
+	}
+}
+
+func TestNormalizeSubscriptionCatalog_NormalizesOffers(t *testing.T) {
+	catalog := SubscriptionCatalog{Groups: []SubscriptionCatalogGroup{{
+		ReferenceName: "Premium",
+		Subscriptions: []SubscriptionCatalogSubscription{{
+			ProductID:     "com.example.monthly",
+			ReferenceName: "Monthly",
+			IntroductoryOffers: []SubscriptionCatalogIntroductoryOffer{
+				{Territory: "United States", OfferMode: "free_trial", Duration: "one_week", NumberOfPeriods: 1, EndDate: "2026-12-31"},
+				{Territory: "ca", OfferMode: "pay_up_front", Duration: "one_month", NumberOfPeriods: 1, Price: " 0.99 "},
+			},
+			PromotionalOffers: []SubscriptionCatalogPromotionalOffer{{
+				OfferCode:       " WINBACK ",
+				Name:            "Win back",
+				OfferMode:       "pay_as_you_go",
+				Duration:        "one_month",
+				NumberOfPeriods: 3,
+				Prices:          []SubscriptionCatalogPrice{{Territory: "US", Price: "4.99"}, {Territory: "Canada", Price: "5.99"}},
+			}},
+		}},
+	}}}
+
+	if err := normalizeSubscriptionCatalog(&catalog); err != nil {
+		t.Fatalf("normalizeSubscriptionCatalog() error: %v", err)
+	}
+
+	sub := catalog.Groups[0].Subscriptions[0]
+	intro := sub.IntroductoryOffers
+	if intro[0].Territory != "CAN" || intro[0].OfferMode != "PAY_UP_FRONT" || intro[0].Price != "0.99" {
+		t.Fatalf("expected normalized CAN offer first, got %+v", intro[0])
+	}
+	if intro[1].Territory != "USA" || intro[1].Duration != "ONE_WEEK" || intro[1].EndDate != "2026-12-31" {
+		t.Fatalf("expected normalized USA offer, got %+v", intro[1])
+	}
+	promo := sub.PromotionalOffers[0]
+	if promo.OfferCode != "WINBACK" || promo.OfferMode != "PAY_AS_YOU_GO" || promo.Duration != "ONE_MONTH" {
+		t.Fatalf("expected normalized promotional offer, got %+v", promo)
+	}
+	if promo.Prices[0].Territory != "CAN" || promo.Prices[1].Territory != "USA" {
+		t.Fatalf("expected resolved and sorted offer territories, got %+v", promo.Prices)
+	}
+}
+
+func TestBuildSubscriptionCatalogPlan_DiffsOffers(t *testing.T) {
+	local := SubscriptionCatalogSubscription{
+		ProductID:     "com.example.monthly",
+		ReferenceName: "Monthly",
+		IntroductoryOffers: []SubscriptionCatalogIntroductoryOffer{
+			{Territory: "CAN", OfferMode: "PAY_UP_FRONT", Duration: "ONE_MONTH", NumberOfPeriods: 1, Price: "1.99"},
+			{Territory: "GBR", OfferMode: "FREE_TRIAL", Duration: "ONE_WEEK", NumberOfPeriods: 1},
+			{Territory: "USA", OfferMode: "FREE_TRIAL", Duration: "ONE_WEEK", NumberOfPeriods: 1, EndDate: "2026-12-31"},
+		},
+		PromotionalOffers: []SubscriptionCatalogPromotionalOffer{
+			{
+				OfferCode: "NEW", Name: "New", OfferMode: "FREE_TRIAL", Duration: "ONE_MONTH", NumberOfPeriods: 1,
+				Prices: []SubscriptionCatalogPrice{{Territory: "USA"}},
+			},
+			{
+				OfferCode: "WINBACK", Name: "Win back", OfferMode: "PAY_UP_FRONT", Duration: "THREE_MONTHS", NumberOfPeriods: 1,
+				Prices: []SubscriptionCatalogPrice{{Territory: "USA", Price: "3.99"}},
+			},
+		},
+	}
+	remoteSub := SubscriptionCatalogSubscription{
+		ProductID:     "com.example.monthly",
+		ReferenceName: "Monthly",
+		IntroductoryOffers: []SubscriptionCatalogIntroductoryOffer{
+			{Territory: "CAN", OfferMode: "PAY_UP_FRONT", Duration: "ONE_MONTH", NumberOfPeriods: 1, Price: "0.99"},
+			{Territory: "USA", OfferMode: "FREE_TRIAL", Duration: "ONE_WEEK", NumberOfPeriods: 1},
+		},
+		PromotionalOffers: []SubscriptionCatalogPromotionalOffer{{
+			OfferCode: "WINBACK", Name: "Win back", OfferMode: "PAY_UP_FRONT", Duration: "THREE_MONTHS", NumberOfPeriods: 1,
+			Prices: []SubscriptionCatalogPrice{{Territory: "CAN", Price: "5.99"}, {Territory: "USA", Price: "4.99"}},
+		}},
+	}
+	remote := &subscriptionCatalogRemoteSubscription{ID: "sub-1", GroupName: "Premium", Subscription: remoteSub}
+
+	changes, err := diffSubscriptionCatalogSubscription("Premium", local, remote)
+	if err != nil {
+		t.Fatalf("diffSubscriptionCatalogSubscription() error: %v", err)
+	}
+
+	want := []SubscriptionCatalogChange{
+		{Action: subscriptionCatalogActionReplaceIntroOffer, Key: "CAN", From: "PAY_UP_FRONT ONE_MONTH x1 0.99", To: "PAY_UP_FRONT ONE_MONTH x1 1.99"},
+		{Action: subscriptionCatalogActionCreateIntroOffer, Key: "GBR", To: "FREE_TRIAL ONE_WEEK x1"},
+		{Action: subscriptionCatalogActionUpdateIntroOffer, Key: "USA", To: "2026-12-31"},
+		{Action: subscriptionCatalogActionCreatePromoOffer, Key: "NEW", To: "New / FREE_TRIAL ONE_MONTH x1 / USA"},
+		{Action: subscriptionCatalogActionUpdatePromoOffer, Key: "WINBACK", From: "CAN=5.99, USA=4.99", To: "CAN=5.99, USA=3.99"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), changes)
+	}
+	for idx, change := range changes {
+		change.Group, change.ProductID = "", ""
+		if change != want[idx] {
+			t.Fatalf("change %d: expected %+v, got %+v", idx, want[idx], change)
+		}
+	}
+
+	renamed := local
+	renamed.IntroductoryOffers = nil
+	renamed.PromotionalOffers = []SubscriptionCatalogPromotionalOffer{local.PromotionalOffers[1]}
+	renamed.PromotionalOffers[0].Name = "Come back"
+	if _, err := diffSubscriptionCatalogSubscription("Premium", renamed, remote); err == nil {
+		t.Fatal("expected error when changing promotional offer terms")
+	}
+}
+
+func TestExtractSubscriptionPriceTerritoryID(t *testing.T) {
+	price := asc.Resource[asc.SubscriptionPriceAttributes]{
+		Relationships: []byte(`{"territory":{"data":{"type":"territories","id":"usa"}}}`),
+	}
+	if got := extractSubscriptionPriceTerritoryID(price); got != "USA" {
+		t.Fatalf("expected USA, got %q", got)
+	}
+	if got := extractSubscriptionPriceTerritoryID(asc.Resource[asc.SubscriptionPriceAttributes]{}); got != "" {
+		t.Fatalf("expected empty territory, got %q", got)
+	}
+}
@@ -30,7 +30,8 @@ Examples:
   asc subscriptions list --group "GROUP_ID"
   asc subscriptions create --group "GROUP_ID" --ref-name "Monthly" --product-id "com.example.sub.monthly"
   asc subscriptions prices add --id "SUB_ID" --price-point "PRICE_POINT_ID"
-  asc subscriptions availability set --id "SUB_ID" --territory "USA,CAN"`,
+  asc subscriptions availability set --id "SUB_ID" --territory "USA,CAN"
+  asc subscriptions catalog export --app "APP_ID" --file "./subscriptions.yaml"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
@@ -54,6 +55,7 @@ Examples:
 			SubscriptionsPromotedPurchaseCommand(),
 			SubscriptionsGracePeriodsCommand(),
 			SubscriptionsSubmitCommand(),
+			SubscriptionsCatalogCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
@@ -31,7 +31,8 @@ Examples:
   asc testflight beta-testers list --app "APP_ID"
   asc testflight beta-feedback crash-submissions get --id "SUBMISSION_ID"
   asc testflight metrics beta-tester-usages --app "APP_ID"
-  asc testflight beta-crash-logs get --id "CRASH_LOG_ID"`,
+  asc testflight beta-crash-logs get --id "CRASH_LOG_ID"
+  asc testflight digest --app "APP_ID" --since 24h`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
@@ -47,6 +48,7 @@ Examples:
 			TestFlightRecruitmentCommand(),
 			TestFlightMetricsCommand(),
 			TestFlightSyncCommand(),
+			TestFlightDigestCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
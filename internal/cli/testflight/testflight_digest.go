@@ -0,0 +1,653 @@
+package testflight
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const testFlightDigestUnknown = "unknown"
+
+// TestFlightDigestResult is the report produced by testflight digest.
+type TestFlightDigestResult struct {
+	AppID         string                       `json:"appId"`
+	Since         string                       `json:"since"`
+	Until         string                       `json:"until"`
+	Dir           string                       `json:"dir,omitempty"`
+	Feedback      int                          `json:"feedback"`
+	Crashes       int                          `json:"crashes"`
+	UniqueCrashes int                          `json:"uniqueCrashes"`
+	Builds        []TestFlightDigestGroup      `json:"builds"`
+	DeviceModels  []TestFlightDigestGroup      `json:"deviceModels"`
+	OSVersions    []TestFlightDigestGroup      `json:"osVersions"`
+	CrashGroups   []TestFlightDigestCrashGroup `json:"crashGroups"`
+	FeedbackItems []TestFlightDigestFeedback   `json:"feedbackItems"`
+	Errors        []string                     `json:"errors,omitempty"`
+}
+
+// TestFlightDigestGroup counts submissions for one build, device model, or OS
+// version.
+type TestFlightDigestGroup struct {
+	Name     string `json:"name"`
+	Feedback int    `json:"feedback"`
+	Crashes  int    `json:"crashes"`
+}
+
+// TestFlightDigestCrashGroup is a set of crash submissions that share the same
+// top frames.
+type TestFlightDigestCrashGroup struct {
+	Signature    string   `json:"signature"`
+	Exception    string   `json:"exception,omitempty"`
+	Frames       []string `json:"frames"`
+	Count        int      `json:"count"`
+	Builds       []string `json:"builds"`
+	DeviceModels []string `json:"deviceModels"`
+	OSVersions   []string `json:"osVersions"`
+	FirstSeen    string   `json:"firstSeen"`
+	LastSeen     string   `json:"lastSeen"`
+	Submissions  []string `json:"submissions"`
+	Comments     []string `json:"comments,omitempty"`
+	Logs         []string `json:"logs,omitempty"`
+}
+
+// TestFlightDigestFeedback is one screenshot feedback submission.
+type TestFlightDigestFeedback struct {
+	ID          string   `json:"id"`
+	CreatedDate string   `json:"createdDate"`
+	Build       string   `json:"build"`
+	DeviceModel string   `json:"deviceModel"`
+	OSVersion   string   `json:"osVersion"`
+	Email       string   `json:"email,omitempty"`
+	Comment     string   `json:"comment,omitempty"`
+	Screenshots []string `json:"screenshots,omitempty"`
+}
+
+// testFlightDigestCrash is a crash submission with its resolved build and log.
+type testFlightDigestCrash struct {
+	resource  asc.Resource[asc.CrashAttributes]
+	logText   string
+	logPath   string
+	signature crashSignature
+}
+
+// TestFlightDigestCommand returns the testflight digest subcommand.
+func TestFlightDigestCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("digest", flag.ExitOnError)
+
+	appID := fs.String("app", "", "App Store Connect app ID (or ASC_APP_ID env)")
+	since := fs.String("since", "24h", "Window start: a duration like 24h, 7d or 2w, a date (YYYY-MM-DD), or an RFC3339 timestamp")
+	dir := fs.String("dir", "", "Directory to save crash logs and screenshots into (nothing is written when unset)")
+	frames := fs.Int("frames", 5, "Top crashing frames used to deduplicate crashes (1-20)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "digest",
+		ShortUsage: "asc testflight digest --app \"APP_ID\" [--since 24h] [flags]",
+		ShortHelp:  "Summarize recent TestFlight feedback and crashes.",
+		LongHelp: `Summarize recent TestFlight feedback and crashes.
+
+Fetches the screenshot feedback and crash submissions created since --since
+and counts them by build, device model, and OS version. Crash logs are fetched
+for every crash submission and crashes are deduplicated by the top --frames
+frames of the crashing thread (or the last exception backtrace), ignoring
+addresses, offsets, and source locations, so one bug seen on many devices and
+builds is reported once. Unsymbolicated frames are matched by image only.
+Crashes whose log could not be read are listed separately.
+
+Nothing is written to disk unless --dir is set. With --dir, crash logs are
+saved to <dir>/crash-logs/<submission>.crash and screenshots to
+<dir>/screenshots/<submission>-<n>.<ext>. Files that already exist are reused,
+so overlapping windows do not download them again.
+
+Use --output markdown for a digest that can be posted with asc notify.
+
+Examples:
+  asc testflight digest --app "123456789"
+  asc testflight digest --app "123456789" --since 7d --dir ./digest
+  asc testflight digest --app "123456789" --since "2026-01-01" --frames 3
+  asc notify slack --message "$(asc testflight digest --app "123456789" --output markdown)"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			resolvedAppID := shared.ResolveAppID(*appID)
+			if resolvedAppID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --app is required (or set ASC_APP_ID)")
+				return flag.ErrHelp
+			}
+			if *frames < 1 || *frames > 20 {
+				return shared.UsageError("--frames must be between 1 and 20")
+			}
+			dirValue := strings.TrimSpace(*dir)
+			if dirValue == "" && *dir != "" {
+				return shared.UsageError("--dir must not be blank")
+			}
+			now := time.Now().UTC()
+			sinceTime, err := parseTestFlightDigestSince(*since, now)
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("testflight digest: %w", err)
+			}
+
+			builds := map[string]string{}
+			feedback, err := fetchTestFlightDigestFeedback(ctx, client, resolvedAppID, sinceTime, builds)
+			if err != nil {
+				return fmt.Errorf("testflight digest: %w", err)
+			}
+			crashes, err := fetchTestFlightDigestCrashes(ctx, client, resolvedAppID, sinceTime, builds)
+			if err != nil {
+				return fmt.Errorf("testflight digest: %w", err)
+			}
+
+			var failures []string
+			for i := range crashes {
+				if err := loadTestFlightDigestCrashLog(ctx, client, dirValue, &crashes[i]); err != nil {
+					failures = append(failures, fmt.Sprintf("crash log %s: %v", crashes[i].resource.ID, err))
+				}
+				crashes[i].signature = parseCrashSignature(crashes[i].logText, *frames, crashes[i].resource.ID)
+			}
+
+			result := buildTestFlightDigest(feedback, crashes, builds)
+			result.AppID = resolvedAppID
+			result.Since = sinceTime.Format(time.RFC3339)
+			result.Until = now.Format(time.RFC3339)
+			result.Dir = dirValue
+
+			if dirValue != "" {
+				for i := range result.FeedbackItems {
+					item := &result.FeedbackItems[i]
+					paths, errs := downloadTestFlightDigestScreenshots(ctx, client, dirValue, item.ID, item.Screenshots)
+					item.Screenshots = paths
+					failures = append(failures, errs...)
+				}
+			}
+			result.Errors = failures
+
+			if err := shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { renderTestFlightDigest(result, false); return nil },
+				func() error { renderTestFlightDigest(result, true); return nil },
+			); err != nil {
+				return err
+			}
+			if len(failures) > 0 {
+				for _, failure := range failures {
+					fmt.Fprintf(os.Stderr, "Warning: %s\n", failure)
+				}
+				return shared.NewReportedError(fmt.Errorf("testflight digest: %d download(s) failed", len(failures)))
+			}
+			return nil
+		},
+	}
+}
+
+// parseTestFlightDigestSince resolves --since to an absolute time. Durations
+// are measured back from now and also accept d (day) and w (week) suffixes.
+func parseTestFlightDigestSince(value string, now time.Time) (time.Time, error) {
+	invalid := errors.New("--since must be a duration like 24h, 7d or 2w, a date (YYYY-MM-DD), or an RFC3339 timestamp")
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return time.Time{}, invalid
+	}
+	if parsed, err := time.Parse(time.RFC3339, trimmed); err == nil {
+		return parsed.UTC(), nil
+	}
+	if parsed, err := time.Parse("2006-01-02", trimmed); err == nil {
+		return parsed, nil
+	}
+
+	lower := strings.ToLower(trimmed)
+	var duration time.Duration
+	if unit := lower[len(lower)-1]; unit == 'd' || unit == 'w' {
+		count, err := strconv.Atoi(lower[:len(lower)-1])
+		if err != nil {
+			return time.Time{}, invalid
+		}
+		duration = time.Duration(count) * 24 * time.Hour
+		if unit == 'w' {
+			duration *= 7
+		}
+	} else {
+		parsed, err := time.ParseDuration(lower)
+		if err != nil {
+			return time.Time{}, invalid
+		}
+		duration = parsed
+	}
+	if duration <= 0 {
+		return time.Time{}, invalid
+	}
+	return now.Add(-duration), nil
+}
+
+// testFlightDigestRelationships is the build relationship of a feedback or
+// crash submission.
+type testFlightDigestRelationships struct {
+	Build struct {
+		Data *asc.ResourceData `json:"data"`
+	} `json:"build"`
+}
+
+func testFlightDigestBuildID(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var relationships testFlightDigestRelationships
+	if err := json.Unmarshal(raw, &relationships); err != nil || relationships.Build.Data == nil {
+		return ""
+	}
+	return relationships.Build.Data.ID
+}
+
+// collectTestFlightDigestBuilds records the version number of every included build.
+func collectTestFlightDigestBuilds(raw json.RawMessage, builds map[string]string) {
+	if len(raw) == 0 {
+		return
+	}
+	var included []asc.Resource[asc.BuildAttributes]
+	if err := json.Unmarshal(raw, &included); err != nil {
+		return
+	}
+	for _, resource := range included {
+		if resource.Type == asc.ResourceTypeBuilds && resource.Attributes.Version != "" {
+			builds[resource.ID] = resource.Attributes.Version
+		}
+	}
+}
+
+// createdBefore reports whether an RFC3339 createdDate is before since.
+// Unparseable dates are kept.
+func createdBefore(createdDate string, since time.Time) bool {
+	created, err := time.Parse(time.RFC3339, createdDate)
+	return err == nil && created.Before(since)
+}
+
+// fetchTestFlightDigestFeedback pages through screenshot feedback newest
+// first and stops at the first submission created before since.
+func fetchTestFlightDigestFeedback(ctx context.Context, client *asc.Client, appID string, since time.Time, builds map[string]string) ([]asc.Resource[asc.FeedbackAttributes], error) {
+	opts := []asc.FeedbackOption{
+		asc.WithFeedbackSort("-createdDate"),
+		asc.WithFeedbackIncludeBuild(),
+		asc.WithFeedbackLimit(200),
+	}
+
+	var feedback []asc.Resource[asc.FeedbackAttributes]
+	for {
+		pageCtx, pageCancel := shared.ContextWithTimeout(ctx)
+		resp, err := client.GetFeedback(pageCtx, appID, opts...)
+		pageCancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch feedback: %w", err)
+		}
+		collectTestFlightDigestBuilds(resp.Included, builds)
+		for _, item := range resp.Data {
+			if createdBefore(item.Attributes.CreatedDate, since) {
+				return feedback, nil
+			}
+			feedback = append(feedback, item)
+		}
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return feedback, nil
+		}
+		opts = []asc.FeedbackOption{asc.WithFeedbackNextURL(resp.Links.Next)}
+	}
+}
+
+// fetchTestFlightDigestCrashes pages through crash submissions newest first
+// and stops at the first submission created before since.
+func fetchTestFlightDigestCrashes(ctx context.Context, client *asc.Client, appID string, since time.Time, builds map[string]string) ([]testFlightDigestCrash, error) {
+	opts := []asc.CrashOption{
+		asc.WithCrashSort("-createdDate"),
+		asc.WithCrashIncludeBuild(),
+		asc.WithCrashLimit(200),
+	}
+
+	var crashes []testFlightDigestCrash
+	for {
+		pageCtx, pageCancel := shared.ContextWithTimeout(ctx)
+		resp, err := client.GetCrashes(pageCtx, appID, opts...)
+		pageCancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch crashes: %w", err)
+		}
+		collectTestFlightDigestBuilds(resp.Included, builds)
+		for _, item := range resp.Data {
+			if createdBefore(item.Attributes.CreatedDate, since) {
+				return crashes, nil
+			}
+			crashes = append(crashes, testFlightDigestCrash{resource: item})
+		}
+		if strings.TrimSpace(resp.Links.Next) == "" {
+			return crashes, nil
+		}
+		opts = []asc.CrashOption{asc.WithCrashNextURL(resp.Links.Next)}
+	}
+}
+
+// loadTestFlightDigestCrashLog fills in the crash log text, preferring a copy
+// already saved in dir, then the submission attributes, then the crash log
+// endpoint. When dir is set the log is saved there.
+func loadTestFlightDigestCrashLog(ctx context.Context, client *asc.Client, dir string, crash *testFlightDigestCrash) error {
+	if dir != "" {
+		crash.logPath = filepath.Join(dir, "crash-logs", testFlightDigestFileName(crash.resource.ID)+".crash")
+		if data, err := os.ReadFile(crash.logPath); err == nil {
+			crash.logText = string(data)
+			return nil
+		}
+	}
+
+	crash.logText = crash.resource.Attributes.CrashLog
+	if strings.TrimSpace(crash.logText) == "" {
+		requestCtx, cancel := shared.ContextWithTimeout(ctx)
+		resp, err := client.GetBetaFeedbackCrashSubmissionCrashLog(requestCtx, crash.resource.ID)
+		cancel()
+		if err != nil {
+			crash.logPath = ""
+			return err
+		}
+		crash.logText = resp.Data.Attributes.LogText
+	}
+
+	if crash.logPath != "" {
+		if _, err := shared.WriteFileNoSymlinkOverwrite(crash.logPath, strings.NewReader(crash.logText), 0o600, ".crash-*.tmp", ".crash-*.bak"); err != nil {
+			crash.logPath = ""
+			return err
+		}
+	}
+	return nil
+}
+
+// downloadTestFlightDigestScreenshots saves screenshot URLs into dir and
+// returns the local paths. URLs that fail to download are kept as-is.
+func downloadTestFlightDigestScreenshots(ctx context.Context, client *asc.Client, dir, submissionID string, urls []string) ([]string, []string) {
+	paths := make([]string, 0, len(urls))
+	var failures []string
+	for i, rawURL := range urls {
+		ext := strings.ToLower(path.Ext(strings.SplitN(rawURL, "?", 2)[0]))
+		if ext == "" || len(ext) > 5 {
+			ext = ".png"
+		}
+		dest := filepath.Join(dir, "screenshots", fmt.Sprintf("%s-%d%s", testFlightDigestFileName(submissionID), i+1, ext))
+		if _, err := os.Stat(dest); err == nil {
+			paths = append(paths, dest)
+			continue
+		}
+		if err := downloadTestFlightDigestScreenshot(ctx, client, rawURL, dest); err != nil {
+			failures = append(failures, fmt.Sprintf("screenshot %s #%d: %v", submissionID, i+1, err))
+			paths = append(paths, rawURL)
+			continue
+		}
+		paths = append(paths, dest)
+	}
+	return paths, failures
+}
+
+func downloadTestFlightDigestScreenshot(ctx context.Context, client *asc.Client, rawURL, dest string) error {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	download, err := client.DownloadBetaFeedbackScreenshot(requestCtx, rawURL)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = download.Body.Close() }()
+
+	_, err = shared.WriteFileNoSymlinkOverwrite(dest, download.Body, 0o600, ".screenshot-*.tmp", ".screenshot-*.bak")
+	return err
+}
+
+// testFlightDigestFileName keeps an API ID safe to use as a file name.
+func testFlightDigestFileName(id string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(id) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "submission"
+	}
+	return b.String()
+}
+
+// testFlightDigestBuildLabel returns the build version number, the build ID
+// when the version is unknown, or "unknown" when there is no build.
+func testFlightDigestBuildLabel(raw json.RawMessage, builds map[string]string) string {
+	buildID := testFlightDigestBuildID(raw)
+	if buildID == "" {
+		return testFlightDigestUnknown
+	}
+	if version := builds[buildID]; version != "" {
+		return version
+	}
+	return buildID
+}
+
+func testFlightDigestValue(value string) string {
+	if trimmed := strings.TrimSpace(value); trimmed != "" {
+		return trimmed
+	}
+	return testFlightDigestUnknown
+}
+
+// buildTestFlightDigest groups feedback and crashes by build, device model and
+// OS version, and crashes by signature. Crash signatures must already be set.
+func buildTestFlightDigest(feedback []asc.Resource[asc.FeedbackAttributes], crashes []testFlightDigestCrash, builds map[string]string) *TestFlightDigestResult {
+	result := &TestFlightDigestResult{
+		Feedback:      len(feedback),
+		Crashes:       len(crashes),
+		FeedbackItems: make([]TestFlightDigestFeedback, 0, len(feedback)),
+	}
+	byBuild := map[string]*TestFlightDigestGroup{}
+	byDevice := map[string]*TestFlightDigestGroup{}
+	byOS := map[string]*TestFlightDigestGroup{}
+	count := func(groups map[string]*TestFlightDigestGroup, name string, crash bool) {
+		group, ok := groups[name]
+		if !ok {
+			group = &TestFlightDigestGroup{Name: name}
+			groups[name] = group
+		}
+		if crash {
+			group.Crashes++
+		} else {
+			group.Feedback++
+		}
+	}
+
+	for _, item := range feedback {
+		attrs := item.Attributes
+		entry := TestFlightDigestFeedback{
+			ID:          item.ID,
+			CreatedDate: attrs.CreatedDate,
+			Build:       testFlightDigestBuildLabel(item.Relationships, builds),
+			DeviceModel: testFlightDigestValue(attrs.DeviceModel),
+			OSVersion:   testFlightDigestValue(attrs.OSVersion),
+			Email:       attrs.Email,
+			Comment:     strings.TrimSpace(attrs.Comment),
+		}
+		for _, screenshot := range attrs.Screenshots {
+			if strings.TrimSpace(screenshot.URL) != "" {
+				entry.Screenshots = append(entry.Screenshots, strings.TrimSpace(screenshot.URL))
+			}
+		}
+		count(byBuild, entry.Build, false)
+		count(byDevice, entry.DeviceModel, false)
+		count(byOS, entry.OSVersion, false)
+		result.FeedbackItems = append(result.FeedbackItems, entry)
+	}
+
+	signatures := map[string]*TestFlightDigestCrashGroup{}
+	for _, crash := range crashes {
+		attrs := crash.resource.Attributes
+		build := testFlightDigestBuildLabel(crash.resource.Relationships, builds)
+		device := testFlightDigestValue(attrs.DeviceModel)
+		osVersion := testFlightDigestValue(attrs.OSVersion)
+		count(byBuild, build, true)
+		count(byDevice, device, true)
+		count(byOS, osVersion, true)
+
+		group, ok := signatures[crash.signature.ID]
+		if !ok {
+			group = &TestFlightDigestCrashGroup{
+				Signature: crash.signature.ID,
+				Exception: crash.signature.Exception,
+				Frames:    append([]string{}, crash.signature.Frames...),
+				FirstSeen: attrs.CreatedDate,
+				LastSeen:  attrs.CreatedDate,
+			}
+			signatures[crash.signature.ID] = group
+		}
+		group.Count++
+		group.Builds = appendUniqueString(group.Builds, build)
+		group.DeviceModels = appendUniqueString(group.DeviceModels, device)
+		group.OSVersions = appendUniqueString(group.OSVersions, osVersion)
+		group.Submissions = append(group.Submissions, crash.resource.ID)
+		if comment := strings.TrimSpace(attrs.Comment); comment != "" {
+			group.Comments = append(group.Comments, comment)
+		}
+		if crash.logPath != "" {
+			group.Logs = append(group.Logs, crash.logPath)
+		}
+		if attrs.CreatedDate != "" && (group.FirstSeen == "" || attrs.CreatedDate < group.FirstSeen) {
+			group.FirstSeen = attrs.CreatedDate
+		}
+		if attrs.CreatedDate > group.LastSeen {
+			group.LastSeen = attrs.CreatedDate
+		}
+	}
+
+	result.Builds = sortTestFlightDigestGroups(byBuild)
+	result.DeviceModels = sortTestFlightDigestGroups(byDevice)
+	result.OSVersions = sortTestFlightDigestGroups(byOS)
+
+	result.CrashGroups = make([]TestFlightDigestCrashGroup, 0, len(signatures))
+	for _, group := range signatures {
+		result.CrashGroups = append(result.CrashGroups, *group)
+	}
+	sort.Slice(result.CrashGroups, func(i, j int) bool {
+		left, right := result.CrashGroups[i], result.CrashGroups[j]
+		if left.Count != right.Count {
+			return left.Count > right.Count
+		}
+		if left.LastSeen != right.LastSeen {
+			return left.LastSeen > right.LastSeen
+		}
+		return left.Signature < right.Signature
+	})
+	result.UniqueCrashes = len(result.CrashGroups)
+	return result
+}
+
+// sortTestFlightDigestGroups orders groups by total submissions, then crashes,
+// then name.
+func sortTestFlightDigestGroups(groups map[string]*TestFlightDigestGroup) []TestFlightDigestGroup {
+	sorted := make([]TestFlightDigestGroup, 0, len(groups))
+	for _, group := range groups {
+		sorted = append(sorted, *group)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		left, right := sorted[i], sorted[j]
+		if left.Feedback+left.Crashes != right.Feedback+right.Crashes {
+			return left.Feedback+left.Crashes > right.Feedback+right.Crashes
+		}
+		if left.Crashes != right.Crashes {
+			return left.Crashes > right.Crashes
+		}
+		return left.Name < right.Name
+	})
+	return sorted
+}
+
+func appendUniqueString(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+func renderTestFlightDigest(result *TestFlightDigestResult, markdown bool) {
+	shared.RenderSection("TestFlight Digest",
+		[]string{"App", "Since", "Until", "Feedback", "Crashes", "Unique Crashes"},
+		[][]string{{
+			result.AppID,
+			result.Since,
+			result.Until,
+			strconv.Itoa(result.Feedback),
+			strconv.Itoa(result.Crashes),
+			strconv.Itoa(result.UniqueCrashes),
+		}},
+		markdown,
+	)
+
+	groupRows := func(groups []TestFlightDigestGroup) [][]string {
+		rows := make([][]string, 0, len(groups))
+		for _, group := range groups {
+			rows = append(rows, []string{group.Name, strconv.Itoa(group.Crashes), strconv.Itoa(group.Feedback)})
+		}
+		return rows
+	}
+	shared.RenderSection("By Build", []string{"Build", "Crashes", "Feedback"}, groupRows(result.Builds), markdown)
+	shared.RenderSection("By Device Model", []string{"Device Model", "Crashes", "Feedback"}, groupRows(result.DeviceModels), markdown)
+	shared.RenderSection("By OS Version", []string{"OS Version", "Crashes", "Feedback"}, groupRows(result.OSVersions), markdown)
+
+	frameSeparator := "\n"
+	if markdown {
+		frameSeparator = "<br>"
+	}
+	crashRows := make([][]string, 0, len(result.CrashGroups))
+	for _, group := range result.CrashGroups {
+		crashRows = append(crashRows, []string{
+			group.Signature,
+			strconv.Itoa(group.Count),
+			shared.OrNA(group.Exception),
+			shared.OrNA(strings.Join(group.Frames, frameSeparator)),
+			strings.Join(group.Builds, ", "),
+			strings.Join(group.DeviceModels, ", "),
+			strings.Join(group.OSVersions, ", "),
+			group.LastSeen,
+		})
+	}
+	shared.RenderSection("Crashes", []string{"Signature", "Count", "Exception", "Top Frames", "Builds", "Devices", "OS Versions", "Last Seen"}, crashRows, markdown)
+
+	feedbackRows := make([][]string, 0, len(result.FeedbackItems))
+	for _, item := range result.FeedbackItems {
+		comment := strings.Join(strings.Fields(item.Comment), " ")
+		if markdown {
+			// Tester comments are free text; keep them from splitting the table.
+			comment = strings.ReplaceAll(comment, "|", "\\|")
+		}
+		feedbackRows = append(feedbackRows, []string{
+			item.CreatedDate,
+			item.Build,
+			item.DeviceModel,
+			item.OSVersion,
+			shared.OrNA(comment),
+			strconv.Itoa(len(item.Screenshots)),
+		})
+	}
+	shared.RenderSection("Feedback", []string{"Created", "Build", "Device Model", "OS Version", "Comment", "Screenshots"}, feedbackRows, markdown)
+}
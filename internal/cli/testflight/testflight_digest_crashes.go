@@ -0,0 +1,196 @@
+package testflight
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// crashFramePattern matches a symbolicated or unsymbolicated frame line from
+// an Apple crash report, e.g.
+// "3   MyApp   0x0000000104d8c123 ViewModel.load() + 120 (ViewModel.swift:42)".
+var crashFramePattern = regexp.MustCompile(`^\s*\d+\s+(\S+)\s+0x[0-9a-fA-F]+\s+(.+)$`)
+
+var (
+	crashFrameOffsetPattern   = regexp.MustCompile(`\s+\+\s+\d+$`)
+	crashFrameLocationPattern = regexp.MustCompile(`\s+\([^()]*:\d+\)$`)
+	crashUnsymbolicatedSymbol = regexp.MustCompile(`^0x[0-9a-fA-F]+\s+\+\s+(\d+)$`)
+	crashFrameImageOffset     = regexp.MustCompile(` \+\d+$`)
+)
+
+// crashThreadHeaderPattern matches the header of the thread that crashed.
+var crashThreadHeaderPattern = regexp.MustCompile(`^Thread \d+ Crashed:`)
+
+// crashSignature is the deduplication key derived from a crash log.
+type crashSignature struct {
+	ID        string
+	Frames    []string
+	Exception string
+}
+
+// parseCrashSignature extracts the exception type and the top frames of the
+// crashing thread from a crash log. Both the text (.crash) and JSON (.ips)
+// report formats are understood. Addresses, offsets and source locations are
+// dropped from the key so the same crash on different devices and builds
+// yields the same signature; unsymbolicated frames keep their image offset for
+// display but are keyed by image alone, since offsets move with every build.
+// Logs without frames or an exception are keyed by fallbackKey so unrelated
+// crashes whose log could not be read are not grouped together.
+func parseCrashSignature(logText string, maxFrames int, fallbackKey string) crashSignature {
+	var frames []string
+	var exception string
+	if ipsFrames, ipsException, ok := parseIPSCrash(logText); ok {
+		frames, exception = ipsFrames, ipsException
+	} else {
+		frames, exception = parseTextCrash(logText)
+	}
+	if len(frames) > maxFrames {
+		frames = frames[:maxFrames]
+	}
+
+	keyFrames := make([]string, len(frames))
+	for i, frame := range frames {
+		keyFrames[i] = crashFrameImageOffset.ReplaceAllString(frame, "")
+	}
+	key := strings.Join(keyFrames, "\n")
+	if key == "" {
+		key = exception
+	}
+	if key == "" {
+		key = "unknown " + fallbackKey
+	}
+	sum := sha256.Sum256([]byte(key))
+	return crashSignature{
+		ID:        hex.EncodeToString(sum[:])[:12],
+		Frames:    frames,
+		Exception: exception,
+	}
+}
+
+// parseTextCrash reads a classic text crash report. The last exception
+// backtrace is preferred over the crashed thread because uncaught exceptions
+// always crash inside abort().
+func parseTextCrash(logText string) ([]string, string) {
+	var exception string
+	var exceptionFrames, crashedFrames []string
+	var current *[]string
+
+	scanner := bufio.NewScanner(strings.NewReader(logText))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "Exception Type:") && exception == "":
+			exception = strings.Join(strings.Fields(strings.TrimPrefix(trimmed, "Exception Type:")), " ")
+			continue
+		case trimmed == "Last Exception Backtrace:":
+			current = &exceptionFrames
+			continue
+		case crashThreadHeaderPattern.MatchString(trimmed):
+			current = &crashedFrames
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+		match := crashFramePattern.FindStringSubmatch(line)
+		if match == nil {
+			if trimmed == "" && len(*current) > 0 {
+				current = nil
+			}
+			continue
+		}
+		*current = append(*current, normalizeCrashFrame(match[1], match[2]))
+	}
+
+	if len(exceptionFrames) > 0 {
+		return exceptionFrames, exception
+	}
+	return crashedFrames, exception
+}
+
+func normalizeCrashFrame(image, symbol string) string {
+	symbol = strings.TrimSpace(symbol)
+	if match := crashUnsymbolicatedSymbol.FindStringSubmatch(symbol); match != nil {
+		return image + " +" + match[1]
+	}
+	symbol = strings.TrimSuffix(symbol, " [inlined]")
+	symbol = crashFrameLocationPattern.ReplaceAllString(symbol, "")
+	symbol = crashFrameOffsetPattern.ReplaceAllString(symbol, "")
+	return image + " " + strings.TrimSpace(symbol)
+}
+
+// ipsCrashReport is the subset of a JSON (.ips) crash report body needed to
+// find the crashing frames.
+type ipsCrashReport struct {
+	Exception struct {
+		Type   string `json:"type"`
+		Signal string `json:"signal"`
+	} `json:"exception"`
+	Threads []struct {
+		Triggered bool            `json:"triggered"`
+		Frames    []ipsCrashFrame `json:"frames"`
+	} `json:"threads"`
+	LastExceptionBacktrace []ipsCrashFrame `json:"lastExceptionBacktrace"`
+	UsedImages             []struct {
+		Name string `json:"name"`
+	} `json:"usedImages"`
+}
+
+type ipsCrashFrame struct {
+	ImageIndex  int    `json:"imageIndex"`
+	ImageOffset int64  `json:"imageOffset"`
+	Symbol      string `json:"symbol"`
+}
+
+// parseIPSCrash reads a JSON crash report: a one-line JSON header followed by
+// the JSON report body.
+func parseIPSCrash(logText string) ([]string, string, bool) {
+	trimmed := strings.TrimSpace(logText)
+	if !strings.HasPrefix(trimmed, "{") {
+		return nil, "", false
+	}
+	body := trimmed
+	if header, rest, found := strings.Cut(trimmed, "\n"); found && json.Valid([]byte(header)) {
+		body = rest
+	}
+	var report ipsCrashReport
+	if err := json.Unmarshal([]byte(body), &report); err != nil || len(report.Threads) == 0 {
+		return nil, "", false
+	}
+
+	exception := report.Exception.Type
+	if report.Exception.Signal != "" {
+		exception = strings.TrimSpace(fmt.Sprintf("%s (%s)", exception, report.Exception.Signal))
+	}
+
+	rawFrames := report.LastExceptionBacktrace
+	if len(rawFrames) == 0 {
+		for _, thread := range report.Threads {
+			if thread.Triggered {
+				rawFrames = thread.Frames
+				break
+			}
+		}
+	}
+	frames := make([]string, 0, len(rawFrames))
+	for _, frame := range rawFrames {
+		image := "???"
+		if frame.ImageIndex >= 0 && frame.ImageIndex < len(report.UsedImages) && report.UsedImages[frame.ImageIndex].Name != "" {
+			image = report.UsedImages[frame.ImageIndex].Name
+		}
+		if frame.Symbol == "" {
+			frames = append(frames, fmt.Sprintf("%s +%d", image, frame.ImageOffset))
+			continue
+		}
+		frames = append(frames, image+" "+frame.Symbol)
+	}
+	return frames, exception, true
+}
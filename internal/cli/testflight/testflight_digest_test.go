@@ -0,0 +1,205 @@
+package testflight
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+const testFlightDigestTextCrash = `Incident Identifier: 1
+Hardware Model:      iPhone15,3
+Exception Type:  EXC_CRASH (SIGABRT)
+
+Last Exception Backtrace:
+0   CoreFoundation                	0x0000000180a4a2ec __exceptionPreprocess + 164 (NSException.m:249)
+1   libobjc.A.dylib               	0x0000000179ed2a90 objc_exception_throw + 60 (objc-exception.mm:356)
+2   MyApp                         	0x0000000104d8c123 ViewModel.load() + 120 (ViewModel.swift:42)
+
+Thread 0 name:   Dispatch queue: com.apple.main-thread
+Thread 0 Crashed:
+0   libsystem_kernel.dylib        	0x00000001e4a1e42c __pthread_kill + 8
+1   libsystem_c.dylib             	0x000000019a2ffc28 abort + 180
+`
+
+func TestParseCrashSignatureText(t *testing.T) {
+	signature := parseCrashSignature(testFlightDigestTextCrash, 2, "crash-1")
+	if signature.Exception != "EXC_CRASH (SIGABRT)" {
+		t.Fatalf("unexpected exception %q", signature.Exception)
+	}
+	want := []string{"CoreFoundation __exceptionPreprocess", "libobjc.A.dylib objc_exception_throw"}
+	if !reflect.DeepEqual(signature.Frames, want) {
+		t.Fatalf("unexpected frames %v", signature.Frames)
+	}
+
+	// Same crash on another device and build: different addresses, offsets and lines.
+	other := parseCrashSignature(`Exception Type:  EXC_CRASH (SIGABRT)
+
+Last Exception Backtrace:
+0   CoreFoundation 0x00000001aaaaaaaa __exceptionPreprocess + 172 (NSException.m:250)
+1   libobjc.A.dylib 0x00000001bbbbbbbb objc_exception_throw + 64
+`, 2, "crash-2")
+	if other.ID != signature.ID {
+		t.Fatalf("expected matching signatures, got %q and %q", other.ID, signature.ID)
+	}
+}
+
+func TestParseCrashSignatureCrashedThread(t *testing.T) {
+	signature := parseCrashSignature(`Exception Type:  EXC_BAD_ACCESS (SIGSEGV)
+
+Thread 3 Crashed:
+0   MyApp  0x0000000104d8c123 0x104d80000 + 49443
+1   MyApp  0x0000000104d8c456 Sync.run() + 12 (Sync.swift:9)
+
+Thread 4:
+0   libsystem_kernel.dylib 0x00000001e4a1e42c __workq_kernreturn + 8
+`, 5, "crash-1")
+	want := []string{"MyApp +49443", "MyApp Sync.run()"}
+	if !reflect.DeepEqual(signature.Frames, want) {
+		t.Fatalf("unexpected frames %v", signature.Frames)
+	}
+
+	// A later build moves the unsymbolicated frame's offset.
+	other := parseCrashSignature(`Exception Type:  EXC_BAD_ACCESS (SIGSEGV)
+
+Thread 3 Crashed:
+0   MyApp  0x0000000104e9d321 0x104e80000 + 119585
+1   MyApp  0x0000000104e9d654 Sync.run() + 12 (Sync.swift:11)
+`, 5, "crash-2")
+	if other.ID != signature.ID {
+		t.Fatalf("expected offsets to be ignored, got %q and %q", other.ID, signature.ID)
+	}
+}
+
+func TestParseCrashSignatureIPS(t *testing.T) {
+	logText := `{"app_name":"MyApp","bug_type":"309"}
+{
+  "exception": {"type": "EXC_BREAKPOINT", "signal": "SIGTRAP"},
+  "usedImages": [{"name": "MyApp"}, {"name": "libswiftCore.dylib"}],
+  "threads": [
+    {"frames": [{"imageIndex": 1, "symbol": "swift_release"}]},
+    {"triggered": true, "frames": [
+      {"imageIndex": 1, "symbol": "_assertionFailure"},
+      {"imageIndex": 0, "imageOffset": 4096}
+    ]}
+  ]
+}`
+	signature := parseCrashSignature(logText, 5, "crash-1")
+	if signature.Exception != "EXC_BREAKPOINT (SIGTRAP)" {
+		t.Fatalf("unexpected exception %q", signature.Exception)
+	}
+	want := []string{"libswiftCore.dylib _assertionFailure", "MyApp +4096"}
+	if !reflect.DeepEqual(signature.Frames, want) {
+		t.Fatalf("unexpected frames %v", signature.Frames)
+	}
+}
+
+func TestParseCrashSignatureWithoutFrames(t *testing.T) {
+	empty := parseCrashSignature("", 5, "crash-1")
+	garbage := parseCrashSignature("not a crash log", 5, "crash-2")
+	if len(empty.Frames) != 0 || len(garbage.Frames) != 0 {
+		t.Fatalf("expected no frames, got %+v and %+v", empty, garbage)
+	}
+	if empty.ID == garbage.ID {
+		t.Fatalf("expected logs without frames to get their own signature, got %q for both", empty.ID)
+	}
+	if again := parseCrashSignature("", 5, "crash-1"); again.ID != empty.ID {
+		t.Fatalf("expected a stable signature for the same submission, got %q and %q", again.ID, empty.ID)
+	}
+}
+
+func TestParseTestFlightDigestSince(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"24h":                  now.Add(-24 * time.Hour),
+		"90m":                  now.Add(-90 * time.Minute),
+		"7d":                   now.Add(-7 * 24 * time.Hour),
+		"2W":                   now.Add(-14 * 24 * time.Hour),
+		"2026-03-01":           time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		"2026-03-01T08:00:00Z": time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC),
+	}
+	for value, want := range tests {
+		got, err := parseTestFlightDigestSince(value, now)
+		if err != nil {
+			t.Fatalf("parseTestFlightDigestSince(%q) error: %v", value, err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("parseTestFlightDigestSince(%q) = %s, want %s", value, got, want)
+		}
+	}
+	for _, value := range []string{"", "0h", "-2d", "yesterday", "03/01/2026"} {
+		if _, err := parseTestFlightDigestSince(value, now); err == nil {
+			t.Fatalf("expected error for %q", value)
+		}
+	}
+}
+
+func TestBuildTestFlightDigest(t *testing.T) {
+	buildRel := func(id string) json.RawMessage {
+		return json.RawMessage(`{"build":{"data":{"type":"builds","id":"` + id + `"}}}`)
+	}
+	builds := map[string]string{"b1": "41", "b2": "42"}
+	crash := func(id, build, device, osVersion, created string, signature crashSignature) testFlightDigestCrash {
+		return testFlightDigestCrash{
+			resource: asc.Resource[asc.CrashAttributes]{
+				ID:            id,
+				Relationships: buildRel(build),
+				Attributes:    asc.CrashAttributes{DeviceModel: device, OSVersion: osVersion, CreatedDate: created},
+			},
+			signature: signature,
+		}
+	}
+	abort := crashSignature{ID: "aaa", Frames: []string{"MyApp ViewModel.load()"}}
+	segv := crashSignature{ID: "bbb", Frames: []string{"MyApp Sync.run()"}}
+
+	result := buildTestFlightDigest(
+		[]asc.Resource[asc.FeedbackAttributes]{{
+			ID:            "f1",
+			Relationships: buildRel("b2"),
+			Attributes: asc.FeedbackAttributes{
+				CreatedDate: "2026-03-10T09:00:00Z",
+				DeviceModel: "iPhone15,3",
+				OSVersion:   "18.3",
+				Comment:     "Button overlaps",
+				Screenshots: []asc.FeedbackScreenshotImage{{URL: "https://example.com/shot.png"}},
+			},
+		}},
+		[]testFlightDigestCrash{
+			crash("c1", "b2", "iPhone15,3", "18.3", "2026-03-10T08:00:00Z", abort),
+			crash("c2", "b1", "iPad13,1", "17.6", "2026-03-10T10:00:00Z", abort),
+			crash("c3", "missing", "iPhone15,3", "18.3", "2026-03-10T07:00:00Z", segv),
+		},
+		builds,
+	)
+
+	if result.Feedback != 1 || result.Crashes != 3 || result.UniqueCrashes != 2 {
+		t.Fatalf("unexpected totals %+v", result)
+	}
+	wantBuilds := []TestFlightDigestGroup{
+		{Name: "42", Feedback: 1, Crashes: 1},
+		{Name: "41", Crashes: 1},
+		{Name: "missing", Crashes: 1},
+	}
+	if !reflect.DeepEqual(result.Builds, wantBuilds) {
+		t.Fatalf("unexpected builds %+v", result.Builds)
+	}
+	if result.DeviceModels[0] != (TestFlightDigestGroup{Name: "iPhone15,3", Feedback: 1, Crashes: 2}) {
+		t.Fatalf("unexpected device models %+v", result.DeviceModels)
+	}
+	if len(result.OSVersions) != 2 || result.OSVersions[1].Name != "17.6" {
+		t.Fatalf("unexpected OS versions %+v", result.OSVersions)
+	}
+
+	top := result.CrashGroups[0]
+	if top.Signature != "aaa" || top.Count != 2 || !reflect.DeepEqual(top.Builds, []string{"42", "41"}) {
+		t.Fatalf("unexpected top crash group %+v", top)
+	}
+	if top.FirstSeen != "2026-03-10T08:00:00Z" || top.LastSeen != "2026-03-10T10:00:00Z" {
+		t.Fatalf("unexpected crash group window %+v", top)
+	}
+	if item := result.FeedbackItems[0]; item.Build != "42" || len(item.Screenshots) != 1 {
+		t.Fatalf("unexpected feedback item %+v", item)
+	}
+}
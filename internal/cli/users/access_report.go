@@ -0,0 +1,390 @@
+package users
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	usersAccessHighlightAdmin   = "admin"
+	usersAccessHighlightAllApps = "all-apps"
+)
+
+var usersAccessAdminRoles = []string{"ACCOUNT_HOLDER", "ADMIN"}
+
+var usersAccessReportCSVHeader = []string{
+	"kind",
+	"email",
+	"firstName",
+	"lastName",
+	"roles",
+	"allApps",
+	"visibleApps",
+	"provisioningAllowed",
+	"invitationExpires",
+	"highlights",
+}
+
+// UsersAccessReportApp is an app a user or invitation can see.
+type UsersAccessReportApp struct {
+	ID       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	BundleID string `json:"bundleId,omitempty"`
+}
+
+// UsersAccessReportEntry is one user or pending invitation in the report.
+type UsersAccessReportEntry struct {
+	Kind                string                 `json:"kind"`
+	ID                  string                 `json:"id"`
+	Email               string                 `json:"email"`
+	FirstName           string                 `json:"firstName,omitempty"`
+	LastName            string                 `json:"lastName,omitempty"`
+	Roles               []string               `json:"roles"`
+	AllApps             bool                   `json:"allApps"`
+	VisibleApps         []UsersAccessReportApp `json:"visibleApps"`
+	ProvisioningAllowed bool                   `json:"provisioningAllowed"`
+	InvitationExpires   string                 `json:"invitationExpires,omitempty"`
+	Highlights          []string               `json:"highlights"`
+}
+
+// UsersAccessReportSummary counts the people in the report.
+type UsersAccessReportSummary struct {
+	Users       int `json:"users"`
+	Invitations int `json:"invitations"`
+	Admins      int `json:"admins"`
+	AllApps     int `json:"allApps"`
+}
+
+// UsersAccessReport is the output of users access-report.
+type UsersAccessReport struct {
+	GeneratedAt string                   `json:"generatedAt"`
+	Summary     UsersAccessReportSummary `json:"summary"`
+	Entries     []UsersAccessReportEntry `json:"entries"`
+}
+
+type usersAccessReportInput struct {
+	Users       []asc.Resource[asc.UserAttributes]
+	Invitations []asc.Resource[asc.UserInvitationAttributes]
+	// VisibleApps is keyed by user or invitation ID.
+	VisibleApps map[string][]UsersAccessReportApp
+}
+
+// UsersAccessReportCommand returns the users access-report subcommand.
+func UsersAccessReportCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("access-report", flag.ExitOnError)
+
+	output := shared.BindOutputFlagsWith(fs, "output", shared.DefaultOutputFormat(), "Output format: json (default), table, markdown, csv")
+
+	return &ffcli.Command{
+		Name:       "access-report",
+		ShortUsage: "asc users access-report [flags]",
+		ShortHelp:  "Report who can access App Store Connect.",
+		LongHelp: `Report who can access App Store Connect.
+
+Joins users and pending invitations with their roles, all-apps access,
+visible apps, and provisioning access into one report. Admins (Account
+Holder or Admin role) and anyone with access to all apps are highlighted.
+
+Entries are sorted by kind and email, and roles and apps are sorted, so
+reports from different quarters can be diffed directly. CSV output has a
+fixed column layout with lists separated by ";".
+
+Notes:
+  - the App Store Connect API does not expose sign-in activity or the
+    team's API keys, so neither appears in the report.
+
+Examples:
+  asc users access-report
+  asc users access-report --output markdown > access-review.md
+  asc users access-report --output csv > access-review.csv`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			format, err := shared.ValidateOutputFormatAllowed(*output.Output, *output.Pretty, "json", "table", "markdown", "csv")
+			if err != nil {
+				return fmt.Errorf("users access-report: %w", err)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("users access-report: %w", err)
+			}
+
+			input, err := fetchUsersAccessReportInput(ctx, client)
+			if err != nil {
+				return fmt.Errorf("users access-report: %w", err)
+			}
+			report := buildUsersAccessReport(input, time.Now().UTC())
+
+			if format == "csv" {
+				return writeUsersAccessReportCSV(report)
+			}
+			return shared.PrintOutputWithRenderers(
+				report,
+				format,
+				*output.Pretty,
+				func() error { return renderUsersAccessReport(report, false) },
+				func() error { return renderUsersAccessReport(report, true) },
+			)
+		},
+	}
+}
+
+func fetchUsersAccessReportInput(ctx context.Context, client *asc.Client) (usersAccessReportInput, error) {
+	users, invitations, err := fetchUsersAndInvitations(ctx, client)
+	if err != nil {
+		return usersAccessReportInput{}, err
+	}
+	input := usersAccessReportInput{
+		Users:       users,
+		Invitations: invitations,
+		VisibleApps: make(map[string][]UsersAccessReportApp),
+	}
+
+	for _, user := range users {
+		if user.Attributes.AllAppsVisible {
+			continue
+		}
+		apps, err := fetchUsersAccessReportApps(ctx, func(ctx context.Context, nextURL string) (*asc.AppsResponse, error) {
+			if nextURL != "" {
+				return client.GetUserVisibleApps(ctx, user.ID, asc.WithUserVisibleAppsNextURL(nextURL))
+			}
+			return client.GetUserVisibleApps(ctx, user.ID, asc.WithUserVisibleAppsLimit(200))
+		})
+		if err != nil {
+			return usersAccessReportInput{}, fmt.Errorf("fetch visible apps for user %s: %w", user.ID, err)
+		}
+		input.VisibleApps[user.ID] = apps
+	}
+	for _, invitation := range invitations {
+		if invitation.Attributes.AllAppsVisible {
+			continue
+		}
+		apps, err := fetchUsersAccessReportApps(ctx, func(ctx context.Context, nextURL string) (*asc.AppsResponse, error) {
+			if nextURL != "" {
+				return client.GetUserInvitationVisibleApps(ctx, invitation.ID, asc.WithUserInvitationVisibleAppsNextURL(nextURL))
+			}
+			return client.GetUserInvitationVisibleApps(ctx, invitation.ID, asc.WithUserInvitationVisibleAppsLimit(200))
+		})
+		if err != nil {
+			return usersAccessReportInput{}, fmt.Errorf("fetch visible apps for invitation %s: %w", invitation.ID, err)
+		}
+		input.VisibleApps[invitation.ID] = apps
+	}
+	return input, nil
+}
+
+func fetchUsersAccessReportApps(ctx context.Context, fetch func(ctx context.Context, nextURL string) (*asc.AppsResponse, error)) ([]UsersAccessReportApp, error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	firstPage, err := fetch(requestCtx, "")
+	if err != nil {
+		return nil, err
+	}
+	paginated, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return fetch(ctx, nextURL)
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, ok := paginated.(*asc.AppsResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected apps response type %T", paginated)
+	}
+	apps := make([]UsersAccessReportApp, 0, len(resp.Data))
+	for _, app := range resp.Data {
+		apps = append(apps, UsersAccessReportApp{
+			ID:       app.ID,
+			Name:     strings.TrimSpace(app.Attributes.Name),
+			BundleID: strings.TrimSpace(app.Attributes.BundleID),
+		})
+	}
+	return apps, nil
+}
+
+func buildUsersAccessReport(input usersAccessReportInput, now time.Time) *UsersAccessReport {
+	report := &UsersAccessReport{
+		GeneratedAt: now.Format(time.RFC3339),
+		Entries:     make([]UsersAccessReportEntry, 0, len(input.Users)+len(input.Invitations)),
+	}
+
+	for _, user := range input.Users {
+		attrs := user.Attributes
+		report.Entries = append(report.Entries, newUsersAccessReportEntry(
+			usersApplyKindUser,
+			user.ID,
+			firstNonEmpty(attrs.Email, attrs.Username),
+			attrs.FirstName,
+			attrs.LastName,
+			attrs.Roles,
+			attrs.AllAppsVisible,
+			attrs.ProvisioningAllowed,
+			input.VisibleApps[user.ID],
+		))
+		report.Summary.Users++
+	}
+	for _, invitation := range input.Invitations {
+		attrs := invitation.Attributes
+		entry := newUsersAccessReportEntry(
+			usersApplyKindInvitation,
+			invitation.ID,
+			attrs.Email,
+			attrs.FirstName,
+			attrs.LastName,
+			attrs.Roles,
+			attrs.AllAppsVisible,
+			attrs.ProvisioningAllowed,
+			input.VisibleApps[invitation.ID],
+		)
+		entry.InvitationExpires = strings.TrimSpace(attrs.ExpirationDate)
+		report.Entries = append(report.Entries, entry)
+		report.Summary.Invitations++
+	}
+
+	for _, entry := range report.Entries {
+		for _, highlight := range entry.Highlights {
+			switch highlight {
+			case usersAccessHighlightAdmin:
+				report.Summary.Admins++
+			case usersAccessHighlightAllApps:
+				report.Summary.AllApps++
+			}
+		}
+	}
+
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		left, right := report.Entries[i], report.Entries[j]
+		if left.Kind != right.Kind {
+			return left.Kind == usersApplyKindUser
+		}
+		leftEmail, rightEmail := strings.ToLower(left.Email), strings.ToLower(right.Email)
+		if leftEmail != rightEmail {
+			return leftEmail < rightEmail
+		}
+		return left.ID < right.ID
+	})
+	return report
+}
+
+func newUsersAccessReportEntry(kind, id, email, firstName, lastName string, roles []string, allApps, provisioningAllowed bool, apps []UsersAccessReportApp) UsersAccessReportEntry {
+	entry := UsersAccessReportEntry{
+		Kind:                kind,
+		ID:                  id,
+		Email:               strings.TrimSpace(email),
+		FirstName:           strings.TrimSpace(firstName),
+		LastName:            strings.TrimSpace(lastName),
+		Roles:               normalizeUsersTeamSet(roles, true),
+		AllApps:             allApps,
+		VisibleApps:         make([]UsersAccessReportApp, 0, len(apps)),
+		ProvisioningAllowed: provisioningAllowed,
+		Highlights:          make([]string, 0, 2),
+	}
+	if !allApps {
+		entry.VisibleApps = append(entry.VisibleApps, apps...)
+		sort.Slice(entry.VisibleApps, func(i, j int) bool {
+			return entry.VisibleApps[i].ID < entry.VisibleApps[j].ID
+		})
+	}
+	for _, role := range usersAccessAdminRoles {
+		if slices.Contains(entry.Roles, role) {
+			entry.Highlights = append(entry.Highlights, usersAccessHighlightAdmin)
+			break
+		}
+	}
+	if allApps {
+		entry.Highlights = append(entry.Highlights, usersAccessHighlightAllApps)
+	}
+	return entry
+}
+
+func renderUsersAccessReport(report *UsersAccessReport, markdown bool) error {
+	if report == nil {
+		return fmt.Errorf("report is nil")
+	}
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	summary := report.Summary
+	render(
+		[]string{"Generated", "Users", "Invitations", "Admins", "All Apps"},
+		[][]string{{
+			report.GeneratedAt,
+			strconv.Itoa(summary.Users),
+			strconv.Itoa(summary.Invitations),
+			strconv.Itoa(summary.Admins),
+			strconv.Itoa(summary.AllApps),
+		}},
+	)
+
+	rows := make([][]string, 0, len(report.Entries))
+	for _, entry := range report.Entries {
+		rows = append(rows, []string{
+			entry.Kind,
+			entry.Email,
+			strings.TrimSpace(entry.FirstName + " " + entry.LastName),
+			strings.Join(entry.Roles, ", "),
+			usersAccessReportAppsLabel(entry, ", "),
+			strconv.FormatBool(entry.ProvisioningAllowed),
+			entry.InvitationExpires,
+			strings.Join(entry.Highlights, ", "),
+		})
+	}
+	render([]string{"Kind", "Email", "Name", "Roles", "Apps", "Provisioning", "Invitation Expires", "Highlights"}, rows)
+	return nil
+}
+
+func writeUsersAccessReportCSV(report *UsersAccessReport) error {
+	writer := csv.NewWriter(os.Stdout)
+	if err := writer.Write(usersAccessReportCSVHeader); err != nil {
+		return err
+	}
+	for _, entry := range report.Entries {
+		record := []string{
+			entry.Kind,
+			entry.Email,
+			entry.FirstName,
+			entry.LastName,
+			strings.Join(entry.Roles, ";"),
+			strconv.FormatBool(entry.AllApps),
+			usersAccessReportAppsLabel(entry, ";"),
+			strconv.FormatBool(entry.ProvisioningAllowed),
+			entry.InvitationExpires,
+			strings.Join(entry.Highlights, ";"),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// usersAccessReportAppsLabel prefers bundle IDs, which stay readable in a
+// review, and falls back to app IDs.
+func usersAccessReportAppsLabel(entry UsersAccessReportEntry, separator string) string {
+	if entry.AllApps {
+		return "all"
+	}
+	labels := make([]string, 0, len(entry.VisibleApps))
+	for _, app := range entry.VisibleApps {
+		labels = append(labels, firstNonEmpty(app.BundleID, app.ID))
+	}
+	return strings.Join(labels, separator)
+}
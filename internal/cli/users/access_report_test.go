@@ -0,0 +1,58 @@
+package users
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func TestBuildUsersAccessReport(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	input := usersAccessReportInput{
+		Users: []asc.Resource[asc.UserAttributes]{
+			{ID: "U_2", Attributes: asc.UserAttributes{Username: "zoe@example.com", Roles: []string{"DEVELOPER", "APP_MANAGER"}}},
+			{ID: "U_1", Attributes: asc.UserAttributes{Username: "Ada@example.com", Roles: []string{"ADMIN"}, AllAppsVisible: true}},
+		},
+		Invitations: []asc.Resource[asc.UserInvitationAttributes]{
+			{ID: "I_1", Attributes: asc.UserInvitationAttributes{Email: "bob@example.com", Roles: []string{"SALES"}, ExpirationDate: "2026-11-01T00:00:00Z"}},
+		},
+		VisibleApps: map[string][]UsersAccessReportApp{
+			"U_2": {{ID: "20", BundleID: "com.example.b"}, {ID: "10", BundleID: "com.example.a"}},
+			"I_1": {{ID: "30"}},
+		},
+	}
+
+	report := buildUsersAccessReport(input, now)
+
+	if report.GeneratedAt != "2026-10-18T12:00:00Z" {
+		t.Fatalf("unexpected generatedAt %q", report.GeneratedAt)
+	}
+	want := UsersAccessReportSummary{Users: 2, Invitations: 1, Admins: 1, AllApps: 1}
+	if report.Summary != want {
+		t.Fatalf("summary = %+v, want %+v", report.Summary, want)
+	}
+
+	var order []string
+	for _, entry := range report.Entries {
+		order = append(order, entry.Kind+":"+entry.Email)
+	}
+	if !reflect.DeepEqual(order, []string{"user:Ada@example.com", "user:zoe@example.com", "invitation:bob@example.com"}) {
+		t.Fatalf("unexpected entry order: %v", order)
+	}
+
+	ada, zoe, bob := report.Entries[0], report.Entries[1], report.Entries[2]
+	if !reflect.DeepEqual(ada.Highlights, []string{usersAccessHighlightAdmin, usersAccessHighlightAllApps}) || usersAccessReportAppsLabel(ada, ";") != "all" {
+		t.Fatalf("unexpected admin entry: %+v", ada)
+	}
+	if !reflect.DeepEqual(zoe.Roles, []string{"APP_MANAGER", "DEVELOPER"}) || len(zoe.Highlights) != 0 {
+		t.Fatalf("unexpected roles or highlights: %+v", zoe)
+	}
+	if got := usersAccessReportAppsLabel(zoe, ";"); got != "com.example.a;com.example.b" {
+		t.Fatalf("apps label = %q", got)
+	}
+	if bob.InvitationExpires != "2026-11-01T00:00:00Z" || usersAccessReportAppsLabel(bob, ";") != "30" {
+		t.Fatalf("unexpected invitation entry: %+v", bob)
+	}
+}
@@ -0,0 +1,292 @@
+package users
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// UsersTeamFile is the desired team described by a users apply file.
+type UsersTeamFile struct {
+	Users []UsersTeamMember `yaml:"users"`
+}
+
+// UsersTeamMember is one person in a users apply file.
+type UsersTeamMember struct {
+	Email               string   `yaml:"email"`
+	FirstName           string   `yaml:"firstName"`
+	LastName            string   `yaml:"lastName"`
+	Roles               []string `yaml:"roles"`
+	AllApps             bool     `yaml:"allApps"`
+	VisibleApps         []string `yaml:"visibleApps"`
+	ProvisioningAllowed *bool    `yaml:"provisioningAllowed"`
+}
+
+var usersTeamCSVColumns = []string{"email", "firstName", "lastName", "roles", "allApps", "visibleApps", "provisioningAllowed"}
+
+// UsersApplyCommand returns the users apply subcommand.
+func UsersApplyCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("apply", flag.ExitOnError)
+
+	file := fs.String("file", "", "Team file path, YAML or CSV (required)")
+	dryRun := fs.Bool("dry-run", false, "Print the plan without mutating App Store Connect")
+	prune := fs.Bool("prune", false, "Remove users and revoke invitations that are not in the file")
+	confirm := fs.Bool("confirm", false, "Confirm removals when using --prune")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "apply",
+		ShortUsage: "asc users apply --file \"./team.yaml\" [--dry-run] [--prune --confirm]",
+		ShortHelp:  "Reconcile users and invitations with a team file.",
+		LongHelp: `Reconcile users and invitations with a team file.
+
+Each person in the file is matched by email against existing users first and
+pending invitations second. People with neither are invited, users whose
+roles, all-apps access, or visible apps differ are updated, and pending
+invitations that differ are revoked and sent again. Re-running an applied
+file is a no-op. A failure for one person is reported in the summary and
+does not stop the others.
+
+With --prune, users and pending invitations missing from the file are
+removed as well. Removals require --confirm unless --dry-run is set.
+
+Notes:
+  - firstName and lastName are only used when sending an invitation.
+  - visibleApps takes App Store Connect app IDs and is ignored with allApps.
+  - provisioningAllowed is left untouched when omitted.
+  - the Account Holder is never removed by --prune.
+
+File format:
+  YAML:
+    users:
+      - email: jane@example.com
+        firstName: Jane
+        lastName: Doe
+        roles: [DEVELOPER, APP_MANAGER]
+        visibleApps: ["123456789"]
+      - email: ops@example.com
+        firstName: Ops
+        lastName: Team
+        roles: [ADMIN]
+        allApps: true
+
+  CSV (header required, lists separated by ";"):
+    email,firstName,lastName,roles,allApps,visibleApps,provisioningAllowed
+    jane@example.com,Jane,Doe,DEVELOPER;APP_MANAGER,false,123456789,
+
+Examples:
+  asc users apply --file "./team.yaml" --dry-run
+  asc users apply --file "./team.csv" --output table
+  asc users apply --file "./team.yaml" --prune --confirm`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			fileValue := strings.TrimSpace(*file)
+			if fileValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --file is required")
+				return flag.ErrHelp
+			}
+			if *prune && !*confirm && !*dryRun {
+				fmt.Fprintln(os.Stderr, "Error: --confirm is required with --prune")
+				return flag.ErrHelp
+			}
+
+			team, err := readUsersTeamFile(fileValue)
+			if err != nil {
+				return err
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("users apply: %w", err)
+			}
+
+			remote, err := fetchUsersApplyRemote(ctx, client, team)
+			if err != nil {
+				return fmt.Errorf("users apply: %w", err)
+			}
+
+			plan := buildUsersApplyPlan(team, remote, *prune)
+			result := &UsersApplyResult{
+				File:   filepath.Clean(fileValue),
+				DryRun: *dryRun,
+				Prune:  *prune,
+			}
+
+			if !*dryRun {
+				applyUsersApplyPlan(ctx, client, plan)
+				result.Applied = true
+			}
+			result.collect(plan)
+
+			if err := shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderUsersApplyResult(result, false) },
+				func() error { return renderUsersApplyResult(result, true) },
+			); err != nil {
+				return err
+			}
+
+			if result.Summary.Failed > 0 {
+				return shared.NewReportedError(fmt.Errorf("users apply: %d item(s) failed", result.Summary.Failed))
+			}
+			return nil
+		},
+	}
+}
+
+func readUsersTeamFile(path string) (UsersTeamFile, error) {
+	file, err := shared.OpenExistingNoFollow(path)
+	if err != nil {
+		return UsersTeamFile{}, fmt.Errorf("users apply: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var team UsersTeamFile
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		team, err = parseUsersTeamCSV(file)
+	} else {
+		decoder := yaml.NewDecoder(file)
+		decoder.KnownFields(true)
+		err = decoder.Decode(&team)
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return UsersTeamFile{}, shared.UsageErrorf("invalid team file %s: %v", path, err)
+	}
+	if err := normalizeUsersTeamFile(&team); err != nil {
+		return UsersTeamFile{}, shared.UsageErrorf("invalid team file %s: %v", path, err)
+	}
+	return team, nil
+}
+
+func parseUsersTeamCSV(r io.Reader) (UsersTeamFile, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return UsersTeamFile{}, nil
+	}
+	if err != nil {
+		return UsersTeamFile{}, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		known := false
+		for _, column := range usersTeamCSVColumns {
+			if strings.EqualFold(name, column) {
+				name = column
+				known = true
+				break
+			}
+		}
+		if !known {
+			return UsersTeamFile{}, fmt.Errorf("unknown column %q (expected %s)", name, strings.Join(usersTeamCSVColumns, ", "))
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["email"]; !ok {
+		return UsersTeamFile{}, fmt.Errorf("email column is required")
+	}
+
+	var team UsersTeamFile
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return UsersTeamFile{}, err
+		}
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			index, ok := columns[name]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+
+		member := UsersTeamMember{
+			Email:       field("email"),
+			FirstName:   field("firstName"),
+			LastName:    field("lastName"),
+			Roles:       splitUsersTeamList(field("roles")),
+			VisibleApps: splitUsersTeamList(field("visibleApps")),
+		}
+		if value := field("allApps"); value != "" {
+			allApps, err := strconv.ParseBool(value)
+			if err != nil {
+				return UsersTeamFile{}, fmt.Errorf("line %d: allApps must be true or false", line)
+			}
+			member.AllApps = allApps
+		}
+		if value := field("provisioningAllowed"); value != "" {
+			provisioningAllowed, err := strconv.ParseBool(value)
+			if err != nil {
+				return UsersTeamFile{}, fmt.Errorf("line %d: provisioningAllowed must be true or false", line)
+			}
+			member.ProvisioningAllowed = &provisioningAllowed
+		}
+		team.Users = append(team.Users, member)
+	}
+	return team, nil
+}
+
+func splitUsersTeamList(value string) []string {
+	return shared.SplitCSV(strings.ReplaceAll(value, ";", ","))
+}
+
+func normalizeUsersTeamFile(team *UsersTeamFile) error {
+	if len(team.Users) == 0 {
+		return fmt.Errorf("at least one user is required")
+	}
+
+	seen := make(map[string]bool, len(team.Users))
+	for i := range team.Users {
+		member := &team.Users[i]
+		member.Email = strings.TrimSpace(member.Email)
+		if member.Email == "" || !strings.Contains(member.Email, "@") {
+			return fmt.Errorf("users[%d]: a valid email is required", i)
+		}
+		key := strings.ToLower(member.Email)
+		if seen[key] {
+			return fmt.Errorf("users[%d]: duplicate email %q", i, member.Email)
+		}
+		seen[key] = true
+
+		member.FirstName = strings.TrimSpace(member.FirstName)
+		member.LastName = strings.TrimSpace(member.LastName)
+		member.Roles = normalizeUsersTeamSet(member.Roles, true)
+		if len(member.Roles) == 0 {
+			return fmt.Errorf("users[%d] (%s): at least one role is required", i, member.Email)
+		}
+		member.VisibleApps = normalizeUsersTeamSet(member.VisibleApps, false)
+		if member.AllApps && len(member.VisibleApps) > 0 {
+			return fmt.Errorf("users[%d] (%s): allApps and visibleApps cannot be used together", i, member.Email)
+		}
+		if !member.AllApps && len(member.VisibleApps) == 0 {
+			return fmt.Errorf("users[%d] (%s): allApps or visibleApps is required", i, member.Email)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,642 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	usersApplyActionInvite           = "invite"
+	usersApplyActionReinvite         = "reinvite"
+	usersApplyActionUpdateUser       = "update-user"
+	usersApplyActionSetVisibleApps   = "set-visible-apps"
+	usersApplyActionDeleteUser       = "delete-user"
+	usersApplyActionRevokeInvitation = "revoke-invitation"
+
+	usersApplyKindUser       = "user"
+	usersApplyKindInvitation = "invitation"
+
+	usersApplyStatusUnchanged = "unchanged"
+	usersApplyStatusPlanned   = "planned"
+	usersApplyStatusApplied   = "applied"
+	usersApplyStatusFailed    = "failed"
+
+	usersApplyAccountHolderRole = "ACCOUNT_HOLDER"
+)
+
+// UsersApplyChange is one planned user or invitation mutation.
+type UsersApplyChange struct {
+	Email  string `json:"email"`
+	Action string `json:"action"`
+	Key    string `json:"key,omitempty"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+// UsersApplyItemResult reports the outcome for one user or invitation.
+type UsersApplyItemResult struct {
+	Kind    string `json:"kind"`
+	Email   string `json:"email"`
+	ID      string `json:"id,omitempty"`
+	Status  string `json:"status"`
+	Changes int    `json:"changes"`
+	Error   string `json:"error,omitempty"`
+}
+
+// UsersApplyInvitation is an invitation sent (or planned) by users apply.
+type UsersApplyInvitation struct {
+	Email          string `json:"email"`
+	ID             string `json:"id,omitempty"`
+	ExpirationDate string `json:"expirationDate,omitempty"`
+	Status         string `json:"status"`
+}
+
+// UsersApplySummary counts the outcome of users apply by action.
+type UsersApplySummary struct {
+	Invited   int `json:"invited"`
+	Reinvited int `json:"reinvited"`
+	Updated   int `json:"updated"`
+	Removed   int `json:"removed"`
+	Revoked   int `json:"revoked"`
+	Unchanged int `json:"unchanged"`
+	Failed    int `json:"failed"`
+}
+
+// UsersApplyResult is the output of users apply.
+type UsersApplyResult struct {
+	File        string                 `json:"file"`
+	DryRun      bool                   `json:"dryRun"`
+	Prune       bool                   `json:"prune"`
+	Applied     bool                   `json:"applied,omitempty"`
+	Summary     UsersApplySummary      `json:"summary"`
+	Changes     []UsersApplyChange     `json:"changes"`
+	Items       []UsersApplyItemResult `json:"items"`
+	Invitations []UsersApplyInvitation `json:"invitations"`
+}
+
+type usersApplyRemote struct {
+	Users                 []asc.Resource[asc.UserAttributes]
+	Invitations           []asc.Resource[asc.UserInvitationAttributes]
+	UserVisibleApps       map[string][]string
+	InvitationVisibleApps map[string][]string
+}
+
+// usersApplyItemPlan is the plan for one person. Items for people in the file
+// carry their member entry; prune items only carry the remote ID.
+type usersApplyItemPlan struct {
+	kind           string
+	email          string
+	id             string
+	member         *UsersTeamMember
+	invitation     *asc.UserInvitationAttributes
+	changes        []UsersApplyChange
+	expirationDate string
+	applied        bool
+	err            error
+}
+
+func fetchUsersApplyRemote(ctx context.Context, client *asc.Client, team UsersTeamFile) (*usersApplyRemote, error) {
+	remote := &usersApplyRemote{
+		UserVisibleApps:       make(map[string][]string),
+		InvitationVisibleApps: make(map[string][]string),
+	}
+
+	users, invitations, err := fetchUsersAndInvitations(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	remote.Users = users
+	remote.Invitations = invitations
+
+	// Visible apps only matter for people the file limits to specific apps.
+	usersByEmail, invitationsByEmail := remote.index()
+	for _, member := range team.Users {
+		if member.AllApps {
+			continue
+		}
+		key := strings.ToLower(member.Email)
+		if user, ok := usersByEmail[key]; ok {
+			appIDs, err := fetchUsersApplyLinkages(ctx, func(ctx context.Context, opts ...asc.LinkagesOption) (*asc.LinkagesResponse, error) {
+				return client.GetUserVisibleAppsRelationships(ctx, user.ID, opts...)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("fetch visible apps for %s: %w", member.Email, err)
+			}
+			remote.UserVisibleApps[user.ID] = appIDs
+			continue
+		}
+		if invitation, ok := invitationsByEmail[key]; ok && !invitation.Attributes.AllAppsVisible {
+			appIDs, err := fetchUsersApplyLinkages(ctx, func(ctx context.Context, opts ...asc.LinkagesOption) (*asc.LinkagesResponse, error) {
+				return client.GetUserInvitationVisibleAppsRelationships(ctx, invitation.ID, opts...)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("fetch visible apps for invitation %s: %w", member.Email, err)
+			}
+			remote.InvitationVisibleApps[invitation.ID] = appIDs
+		}
+	}
+	return remote, nil
+}
+
+// fetchUsersAndInvitations returns every user and pending invitation on the team.
+func fetchUsersAndInvitations(ctx context.Context, client *asc.Client) ([]asc.Resource[asc.UserAttributes], []asc.Resource[asc.UserInvitationAttributes], error) {
+	usersCtx, usersCancel := shared.ContextWithTimeout(ctx)
+	defer usersCancel()
+	usersFirstPage, err := client.GetUsers(usersCtx, asc.WithUsersLimit(200))
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetch users: %w", err)
+	}
+	usersPaginated, err := asc.PaginateAll(usersCtx, usersFirstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetUsers(ctx, asc.WithUsersNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("paginate users: %w", err)
+	}
+	users, ok := usersPaginated.(*asc.UsersResponse)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected users response type %T", usersPaginated)
+	}
+
+	invitesCtx, invitesCancel := shared.ContextWithTimeout(ctx)
+	defer invitesCancel()
+	invitesFirstPage, err := client.GetUserInvitations(invitesCtx, asc.WithUserInvitationsLimit(200))
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetch invitations: %w", err)
+	}
+	invitesPaginated, err := asc.PaginateAll(invitesCtx, invitesFirstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetUserInvitations(ctx, asc.WithUserInvitationsNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("paginate invitations: %w", err)
+	}
+	invitations, ok := invitesPaginated.(*asc.UserInvitationsResponse)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected invitations response type %T", invitesPaginated)
+	}
+	return users.Data, invitations.Data, nil
+}
+
+func fetchUsersApplyLinkages(
+	ctx context.Context,
+	fetch func(ctx context.Context, opts ...asc.LinkagesOption) (*asc.LinkagesResponse, error),
+) ([]string, error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	firstPage, err := fetch(requestCtx, asc.WithLinkagesLimit(200))
+	if err != nil {
+		return nil, err
+	}
+	paginated, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return fetch(ctx, asc.WithLinkagesNextURL(nextURL))
+	})
+	if err != nil {
+		return nil, err
+	}
+	linkages, ok := paginated.(*asc.LinkagesResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected linkages response type %T", paginated)
+	}
+	ids := make([]string, 0, len(linkages.Data))
+	for _, item := range linkages.Data {
+		ids = append(ids, item.ID)
+	}
+	return normalizeUsersTeamSet(ids, false), nil
+}
+
+func (r *usersApplyRemote) index() (map[string]asc.Resource[asc.UserAttributes], map[string]asc.Resource[asc.UserInvitationAttributes]) {
+	users := make(map[string]asc.Resource[asc.UserAttributes], len(r.Users))
+	for _, user := range r.Users {
+		for _, email := range []string{user.Attributes.Username, user.Attributes.Email} {
+			if key := strings.ToLower(strings.TrimSpace(email)); key != "" {
+				users[key] = user
+			}
+		}
+	}
+	invitations := make(map[string]asc.Resource[asc.UserInvitationAttributes], len(r.Invitations))
+	for _, invitation := range r.Invitations {
+		if key := strings.ToLower(strings.TrimSpace(invitation.Attributes.Email)); key != "" {
+			invitations[key] = invitation
+		}
+	}
+	return users, invitations
+}
+
+func buildUsersApplyPlan(team UsersTeamFile, remote *usersApplyRemote, prune bool) []usersApplyItemPlan {
+	usersByEmail, invitationsByEmail := remote.index()
+	wanted := make(map[string]bool, len(team.Users))
+	plans := make([]usersApplyItemPlan, 0, len(team.Users))
+
+	for i := range team.Users {
+		member := &team.Users[i]
+		key := strings.ToLower(member.Email)
+		wanted[key] = true
+
+		if user, ok := usersByEmail[key]; ok {
+			plans = append(plans, planUsersApplyUser(member, user, remote.UserVisibleApps[user.ID]))
+			continue
+		}
+		if invitation, ok := invitationsByEmail[key]; ok {
+			plans = append(plans, planUsersApplyInvitation(member, invitation, remote.InvitationVisibleApps[invitation.ID]))
+			continue
+		}
+
+		plan := usersApplyItemPlan{
+			kind:   usersApplyKindInvitation,
+			email:  member.Email,
+			member: member,
+			changes: []UsersApplyChange{{
+				Email:  member.Email,
+				Action: usersApplyActionInvite,
+				Key:    "access",
+				To:     describeUsersApplyAccess(member.Roles, member.AllApps, member.VisibleApps),
+			}},
+		}
+		if member.FirstName == "" || member.LastName == "" {
+			plan.err = fmt.Errorf("firstName and lastName are required to send an invitation")
+		}
+		plans = append(plans, plan)
+	}
+
+	if !prune {
+		return plans
+	}
+
+	var prunePlans []usersApplyItemPlan
+	for _, user := range remote.Users {
+		email := firstNonEmpty(user.Attributes.Email, user.Attributes.Username)
+		if wanted[strings.ToLower(strings.TrimSpace(user.Attributes.Username))] || wanted[strings.ToLower(strings.TrimSpace(user.Attributes.Email))] {
+			continue
+		}
+		if slices.Contains(user.Attributes.Roles, usersApplyAccountHolderRole) {
+			continue
+		}
+		prunePlans = append(prunePlans, usersApplyItemPlan{
+			kind:  usersApplyKindUser,
+			email: email,
+			id:    user.ID,
+			changes: []UsersApplyChange{{
+				Email:  email,
+				Action: usersApplyActionDeleteUser,
+				Key:    "roles",
+				From:   strings.Join(normalizeUsersTeamSet(user.Attributes.Roles, true), ", "),
+			}},
+		})
+	}
+	for _, invitation := range remote.Invitations {
+		email := strings.TrimSpace(invitation.Attributes.Email)
+		key := strings.ToLower(email)
+		if wanted[key] {
+			continue
+		}
+		if _, ok := usersByEmail[key]; ok {
+			continue
+		}
+		prunePlans = append(prunePlans, usersApplyItemPlan{
+			kind:  usersApplyKindInvitation,
+			email: email,
+			id:    invitation.ID,
+			changes: []UsersApplyChange{{
+				Email:  email,
+				Action: usersApplyActionRevokeInvitation,
+				Key:    "roles",
+				From:   strings.Join(normalizeUsersTeamSet(invitation.Attributes.Roles, true), ", "),
+			}},
+		})
+	}
+	sort.SliceStable(prunePlans, func(i, j int) bool {
+		return strings.ToLower(prunePlans[i].email) < strings.ToLower(prunePlans[j].email)
+	})
+	return append(plans, prunePlans...)
+}
+
+func planUsersApplyUser(member *UsersTeamMember, user asc.Resource[asc.UserAttributes], visibleApps []string) usersApplyItemPlan {
+	plan := usersApplyItemPlan{
+		kind:   usersApplyKindUser,
+		email:  member.Email,
+		id:     user.ID,
+		member: member,
+	}
+	attrs := user.Attributes
+
+	if roles := normalizeUsersTeamSet(attrs.Roles, true); !slices.Equal(roles, member.Roles) {
+		plan.changes = append(plan.changes, UsersApplyChange{
+			Email:  member.Email,
+			Action: usersApplyActionUpdateUser,
+			Key:    "roles",
+			From:   strings.Join(roles, ", "),
+			To:     strings.Join(member.Roles, ", "),
+		})
+	}
+	if attrs.AllAppsVisible != member.AllApps {
+		plan.changes = append(plan.changes, UsersApplyChange{
+			Email:  member.Email,
+			Action: usersApplyActionUpdateUser,
+			Key:    "allApps",
+			From:   strconv.FormatBool(attrs.AllAppsVisible),
+			To:     strconv.FormatBool(member.AllApps),
+		})
+	}
+	if member.ProvisioningAllowed != nil && attrs.ProvisioningAllowed != *member.ProvisioningAllowed {
+		plan.changes = append(plan.changes, UsersApplyChange{
+			Email:  member.Email,
+			Action: usersApplyActionUpdateUser,
+			Key:    "provisioningAllowed",
+			From:   strconv.FormatBool(attrs.ProvisioningAllowed),
+			To:     strconv.FormatBool(*member.ProvisioningAllowed),
+		})
+	}
+	if !member.AllApps && !slices.Equal(visibleApps, member.VisibleApps) {
+		plan.changes = append(plan.changes, UsersApplyChange{
+			Email:  member.Email,
+			Action: usersApplyActionSetVisibleApps,
+			Key:    "visibleApps",
+			From:   strings.Join(visibleApps, ", "),
+			To:     strings.Join(member.VisibleApps, ", "),
+		})
+	}
+	return plan
+}
+
+// planUsersApplyInvitation compares a pending invitation with the file.
+// Invitations cannot be edited, so any difference becomes a reinvite.
+func planUsersApplyInvitation(member *UsersTeamMember, invitation asc.Resource[asc.UserInvitationAttributes], visibleApps []string) usersApplyItemPlan {
+	attrs := invitation.Attributes
+	plan := usersApplyItemPlan{
+		kind:           usersApplyKindInvitation,
+		email:          member.Email,
+		id:             invitation.ID,
+		member:         member,
+		invitation:     &attrs,
+		expirationDate: attrs.ExpirationDate,
+	}
+
+	roles := normalizeUsersTeamSet(attrs.Roles, true)
+	if attrs.AllAppsVisible {
+		visibleApps = nil
+	}
+	differs := !slices.Equal(roles, member.Roles) ||
+		attrs.AllAppsVisible != member.AllApps ||
+		(!member.AllApps && !slices.Equal(visibleApps, member.VisibleApps)) ||
+		(member.ProvisioningAllowed != nil && attrs.ProvisioningAllowed != *member.ProvisioningAllowed)
+	if differs {
+		plan.changes = append(plan.changes, UsersApplyChange{
+			Email:  member.Email,
+			Action: usersApplyActionReinvite,
+			Key:    "access",
+			From:   describeUsersApplyAccess(roles, attrs.AllAppsVisible, visibleApps),
+			To:     describeUsersApplyAccess(member.Roles, member.AllApps, member.VisibleApps),
+		})
+	}
+	return plan
+}
+
+func applyUsersApplyPlan(ctx context.Context, client *asc.Client, plans []usersApplyItemPlan) {
+	for i := range plans {
+		plan := &plans[i]
+		if plan.err != nil || len(plan.changes) == 0 {
+			continue
+		}
+		if err := applyUsersApplyItem(ctx, client, plan); err != nil {
+			plan.err = err
+			continue
+		}
+		plan.applied = true
+	}
+}
+
+func applyUsersApplyItem(ctx context.Context, client *asc.Client, plan *usersApplyItemPlan) error {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	switch plan.changes[0].Action {
+	case usersApplyActionDeleteUser:
+		return client.DeleteUser(requestCtx, plan.id)
+	case usersApplyActionRevokeInvitation:
+		return client.DeleteUserInvitation(requestCtx, plan.id)
+	case usersApplyActionReinvite:
+		if err := client.DeleteUserInvitation(requestCtx, plan.id); err != nil {
+			return fmt.Errorf("revoke invitation: %w", err)
+		}
+		return sendUsersApplyInvitation(requestCtx, client, plan)
+	case usersApplyActionInvite:
+		return sendUsersApplyInvitation(requestCtx, client, plan)
+	}
+
+	member := plan.member
+	var update *asc.UserUpdateAttributes
+	var setVisibleApps bool
+	for _, change := range plan.changes {
+		switch change.Action {
+		case usersApplyActionUpdateUser:
+			if update == nil {
+				update = &asc.UserUpdateAttributes{
+					Roles:               member.Roles,
+					AllAppsVisible:      &member.AllApps,
+					ProvisioningAllowed: member.ProvisioningAllowed,
+				}
+			}
+		case usersApplyActionSetVisibleApps:
+			setVisibleApps = true
+		default:
+			return fmt.Errorf("unsupported action %q", change.Action)
+		}
+	}
+	// Turn off all-apps access before narrowing the visible apps.
+	if update != nil {
+		if _, err := client.UpdateUser(requestCtx, plan.id, *update); err != nil {
+			return fmt.Errorf("update user: %w", err)
+		}
+	}
+	if setVisibleApps {
+		if err := client.SetUserVisibleApps(requestCtx, plan.id, member.VisibleApps); err != nil {
+			return fmt.Errorf("set visible apps: %w", err)
+		}
+	}
+	return nil
+}
+
+func sendUsersApplyInvitation(ctx context.Context, client *asc.Client, plan *usersApplyItemPlan) error {
+	member := plan.member
+	attrs := asc.UserInvitationCreateAttributes{
+		Email:               member.Email,
+		FirstName:           member.FirstName,
+		LastName:            member.LastName,
+		Roles:               member.Roles,
+		AllAppsVisible:      &member.AllApps,
+		ProvisioningAllowed: member.ProvisioningAllowed,
+	}
+	if previous := plan.invitation; previous != nil {
+		attrs.FirstName = firstNonEmpty(attrs.FirstName, previous.FirstName)
+		attrs.LastName = firstNonEmpty(attrs.LastName, previous.LastName)
+	}
+
+	var visibleAppIDs []string
+	if !member.AllApps {
+		visibleAppIDs = member.VisibleApps
+	}
+	resp, err := client.CreateUserInvitation(ctx, attrs, visibleAppIDs)
+	if err != nil {
+		return fmt.Errorf("send invitation: %w", err)
+	}
+	plan.id = resp.Data.ID
+	plan.expirationDate = resp.Data.Attributes.ExpirationDate
+	return nil
+}
+
+func (r *UsersApplyResult) collect(plans []usersApplyItemPlan) {
+	r.Summary = UsersApplySummary{}
+	r.Changes = make([]UsersApplyChange, 0)
+	r.Items = make([]UsersApplyItemResult, 0, len(plans))
+	r.Invitations = make([]UsersApplyInvitation, 0)
+	for _, plan := range plans {
+		item := UsersApplyItemResult{
+			Kind:    plan.kind,
+			Email:   plan.email,
+			ID:      plan.id,
+			Changes: len(plan.changes),
+		}
+		switch {
+		case plan.err != nil:
+			item.Status = usersApplyStatusFailed
+			item.Error = plan.err.Error()
+			r.Summary.Failed++
+		case len(plan.changes) == 0:
+			item.Status = usersApplyStatusUnchanged
+			r.Summary.Unchanged++
+		case plan.applied:
+			item.Status = usersApplyStatusApplied
+		default:
+			item.Status = usersApplyStatusPlanned
+		}
+		r.Changes = append(r.Changes, plan.changes...)
+		r.Items = append(r.Items, item)
+
+		if len(plan.changes) == 0 {
+			continue
+		}
+		action := plan.changes[0].Action
+		if action == usersApplyActionInvite || action == usersApplyActionReinvite {
+			r.Invitations = append(r.Invitations, UsersApplyInvitation{
+				Email:          plan.email,
+				ID:             plan.id,
+				ExpirationDate: plan.expirationDate,
+				Status:         item.Status,
+			})
+		}
+		if plan.err != nil {
+			continue
+		}
+		switch action {
+		case usersApplyActionInvite:
+			r.Summary.Invited++
+		case usersApplyActionReinvite:
+			r.Summary.Reinvited++
+		case usersApplyActionUpdateUser, usersApplyActionSetVisibleApps:
+			r.Summary.Updated++
+		case usersApplyActionDeleteUser:
+			r.Summary.Removed++
+		case usersApplyActionRevokeInvitation:
+			r.Summary.Revoked++
+		}
+	}
+}
+
+func renderUsersApplyResult(result *UsersApplyResult, markdown bool) error {
+	if result == nil {
+		return fmt.Errorf("result is nil")
+	}
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	summary := result.Summary
+	render(
+		[]string{"Invited", "Reinvited", "Updated", "Removed", "Revoked", "Unchanged", "Failed"},
+		[][]string{{
+			strconv.Itoa(summary.Invited),
+			strconv.Itoa(summary.Reinvited),
+			strconv.Itoa(summary.Updated),
+			strconv.Itoa(summary.Removed),
+			strconv.Itoa(summary.Revoked),
+			strconv.Itoa(summary.Unchanged),
+			strconv.Itoa(summary.Failed),
+		}},
+	)
+
+	changeRows := make([][]string, 0, len(result.Changes))
+	for _, change := range result.Changes {
+		changeRows = append(changeRows, []string{change.Email, change.Action, change.Key, change.From, change.To})
+	}
+	if len(changeRows) == 0 {
+		changeRows = append(changeRows, []string{"", "none", "", "", ""})
+	}
+	render([]string{"Email", "Action", "Key", "From", "To"}, changeRows)
+
+	itemRows := make([][]string, 0, len(result.Items))
+	for _, item := range result.Items {
+		itemRows = append(itemRows, []string{
+			item.Kind,
+			item.Email,
+			item.ID,
+			item.Status,
+			strconv.Itoa(item.Changes),
+			item.Error,
+		})
+	}
+	render([]string{"Kind", "Email", "ID", "Status", "Changes", "Error"}, itemRows)
+
+	if len(result.Invitations) > 0 {
+		inviteRows := make([][]string, 0, len(result.Invitations))
+		for _, invitation := range result.Invitations {
+			inviteRows = append(inviteRows, []string{invitation.Email, invitation.ID, invitation.ExpirationDate, invitation.Status})
+		}
+		render([]string{"Invitation Email", "Invitation ID", "Expires", "Status"}, inviteRows)
+	}
+	return nil
+}
+
+func describeUsersApplyAccess(roles []string, allApps bool, visibleApps []string) string {
+	apps := "all apps"
+	if !allApps {
+		apps = "apps " + strings.Join(visibleApps, ", ")
+		if len(visibleApps) == 0 {
+			apps = "no apps"
+		}
+	}
+	return strings.Join(roles, ", ") + "; " + apps
+}
+
+func normalizeUsersTeamSet(values []string, upper bool) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if upper {
+			value = strings.ToUpper(value)
+		}
+		if value == "" || slices.Contains(result, value) {
+			continue
+		}
+		result = append(result, value)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return trimmed
+		}
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package users
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func TestParseUsersTeamCSV(t *testing.T) {
+	content := "\ufeffEmail,firstName,lastName,roles,allApps,visibleApps,provisioningAllowed\n" +
+		"# contractors\n" +
+		"jane@example.com,Jane,Doe,developer;APP_MANAGER,,123;456,true\n" +
+		"ops@example.com,Ops,Team,ADMIN,true,,\n"
+
+	team, err := parseUsersTeamCSV(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("parseUsersTeamCSV() error: %v", err)
+	}
+	if err := normalizeUsersTeamFile(&team); err != nil {
+		t.Fatalf("normalizeUsersTeamFile() error: %v", err)
+	}
+	if len(team.Users) != 2 {
+		t.Fatalf("expected 2 users, got %+v", team.Users)
+	}
+	jane := team.Users[0]
+	if !reflect.DeepEqual(jane.Roles, []string{"APP_MANAGER", "DEVELOPER"}) || !reflect.DeepEqual(jane.VisibleApps, []string{"123", "456"}) {
+		t.Fatalf("unexpected member: %+v", jane)
+	}
+	if jane.ProvisioningAllowed == nil || !*jane.ProvisioningAllowed || team.Users[1].ProvisioningAllowed != nil || !team.Users[1].AllApps {
+		t.Fatalf("unexpected boolean columns: %+v", team.Users)
+	}
+
+	if _, err := parseUsersTeamCSV(strings.NewReader("email,team\n")); err == nil {
+		t.Fatal("expected unknown column to be rejected")
+	}
+}
+
+func TestNormalizeUsersTeamFile_Rejects(t *testing.T) {
+	tests := map[string]UsersTeamMember{
+		"email":     {Email: "nobody", Roles: []string{"ADMIN"}, AllApps: true},
+		"role":      {Email: "a@example.com", AllApps: true},
+		"apps":      {Email: "a@example.com", Roles: []string{"ADMIN"}},
+		"together":  {Email: "a@example.com", Roles: []string{"ADMIN"}, AllApps: true, VisibleApps: []string{"1"}},
+		"duplicate": {Email: "A@example.com", Roles: []string{"ADMIN"}, AllApps: true},
+	}
+	for name, member := range tests {
+		team := UsersTeamFile{Users: []UsersTeamMember{member}}
+		if name == "duplicate" {
+			team.Users = append(team.Users, UsersTeamMember{Email: "a@example.com", Roles: []string{"ADMIN"}, AllApps: true})
+		}
+		if err := normalizeUsersTeamFile(&team); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func TestBuildUsersApplyPlan(t *testing.T) {
+	allowed := true
+	team := UsersTeamFile{Users: []UsersTeamMember{
+		{Email: "same@example.com", Roles: []string{"DEVELOPER"}, VisibleApps: []string{"1"}},
+		{Email: "Change@example.com", Roles: []string{"ADMIN"}, VisibleApps: []string{"2"}, ProvisioningAllowed: &allowed},
+		{Email: "pending@example.com", Roles: []string{"MARKETING"}, AllApps: true},
+		{Email: "new@example.com", FirstName: "New", LastName: "Person", Roles: []string{"DEVELOPER"}, AllApps: true},
+		{Email: "noname@example.com", Roles: []string{"DEVELOPER"}, AllApps: true},
+	}}
+	remote := &usersApplyRemote{
+		Users: []asc.Resource[asc.UserAttributes]{
+			{ID: "U_SAME", Attributes: asc.UserAttributes{Username: "same@example.com", Roles: []string{"DEVELOPER"}}},
+			{ID: "U_CHANGE", Attributes: asc.UserAttributes{Username: "change@example.com", Roles: []string{"DEVELOPER"}, AllAppsVisible: true}},
+			{ID: "U_GONE", Attributes: asc.UserAttributes{Username: "gone@example.com", Roles: []string{"DEVELOPER"}}},
+			{ID: "U_OWNER", Attributes: asc.UserAttributes{Username: "owner@example.com", Roles: []string{"ACCOUNT_HOLDER", "ADMIN"}}},
+		},
+		Invitations: []asc.Resource[asc.UserInvitationAttributes]{
+			{ID: "I_PENDING", Attributes: asc.UserInvitationAttributes{Email: "pending@example.com", Roles: []string{"SALES"}, AllAppsVisible: true}},
+			{ID: "I_STALE", Attributes: asc.UserInvitationAttributes{Email: "stale@example.com", Roles: []string{"SALES"}, AllAppsVisible: true}},
+		},
+		UserVisibleApps: map[string][]string{"U_SAME": {"1"}},
+	}
+
+	plans := buildUsersApplyPlan(team, remote, true)
+
+	got := make([]string, 0, len(plans))
+	for _, plan := range plans {
+		actions := make([]string, 0, len(plan.changes))
+		for _, change := range plan.changes {
+			actions = append(actions, change.Action+":"+change.Key)
+		}
+		entry := plan.kind + " " + plan.email + " " + strings.Join(actions, ",")
+		if plan.err != nil {
+			entry += " error"
+		}
+		got = append(got, entry)
+	}
+	want := []string{
+		"user same@example.com ",
+		"user Change@example.com update-user:roles,update-user:allApps,update-user:provisioningAllowed,set-visible-apps:visibleApps",
+		"invitation pending@example.com reinvite:access",
+		"invitation new@example.com invite:access",
+		"invitation noname@example.com invite:access error",
+		"user gone@example.com delete-user:roles",
+		"invitation stale@example.com revoke-invitation:roles",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("plan =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	withoutPrune := buildUsersApplyPlan(team, remote, false)
+	if len(withoutPrune) != len(team.Users) {
+		t.Fatalf("expected no prune items without --prune, got %d items", len(withoutPrune))
+	}
+}
@@ -33,7 +33,9 @@ Examples:
   asc users invites list
   asc users invites visible-apps list --id "INVITE_ID"
   asc users visible-apps list --id "USER_ID"
-  asc users visible-apps get --id "USER_ID"`,
+  asc users visible-apps get --id "USER_ID"
+  asc users apply --file "./team.yaml" --dry-run
+  asc users access-report --output csv`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
@@ -43,6 +45,8 @@ Examples:
 			UsersDeleteCommand(),
 			UsersInviteCommand(),
 			UsersInvitesCommand(),
+			UsersApplyCommand(),
+			UsersAccessReportCommand(),
 			UsersVisibleAppsCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
@@ -404,6 +408,7 @@ func UsersInvitesCommand() *ffcli.Command {
 
 Examples:
   asc users invites list
+  asc users apply --file "./team.yaml" --dry-run
   asc users invites get --id "INVITE_ID"
   asc users invites revoke --id "INVITE_ID" --confirm
   asc users invites visible-apps list --id "INVITE_ID"`,
@@ -438,6 +443,7 @@ func UsersInvitesListCommand() *ffcli.Command {
 
 Examples:
   asc users invites list
+  asc users apply --file "./team.yaml" --dry-run
   asc users invites list --limit 50
   asc users invites list --paginate`,
 		FlagSet:   fs,
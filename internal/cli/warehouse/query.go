@@ -0,0 +1,87 @@
+package warehouse
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/warehouse"
+)
+
+// WarehouseQueryCommand runs a query against the local warehouse.
+func WarehouseQueryCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("query", flag.ExitOnError)
+
+	dir := fs.String("dir", "", "Warehouse directory (default: ~/.asc/warehouse)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "query",
+		ShortUsage: "asc warehouse query [flags] \"SELECT ...\"",
+		ShortHelp:  "Run a SQL-like query over the local warehouse.",
+		LongHelp: `Run a SQL-like query over the local warehouse.
+
+Queries run offline against tables loaded by 'asc warehouse sync'. Only
+SELECT is supported:
+
+  SELECT [DISTINCT] * | expr [[AS] alias], ...
+  FROM table
+  [WHERE expr] [GROUP BY expr, ...] [HAVING expr]
+  [ORDER BY expr|alias|position [ASC|DESC], ...] [LIMIT n]
+
+Expressions support columns, 'text' and numeric literals, NULL, + - * / %,
+= != <> < <= > >=, AND, OR, NOT, [NOT] LIKE ('%' and '_', case-insensitive),
+[NOT] IN (...), [NOT] BETWEEN ... AND ..., IS [NOT] NULL, the aggregates
+count(*), count([DISTINCT] x), sum, avg, min, max, and the functions lower,
+upper, length, substr(x, start[, length]), round(x[, digits]), abs, coalesce.
+
+` + warehouseTablesHelp + `
+
+Examples:
+  asc warehouse query "SELECT territory, sum(units) AS units FROM sales GROUP BY territory ORDER BY units DESC LIMIT 10"
+  asc warehouse query "SELECT substr(begin_date, 1, 7) AS month, sum(units * developer_proceeds) AS proceeds FROM sales GROUP BY month ORDER BY month"
+  asc warehouse query "SELECT rating, count(*) FROM reviews WHERE body LIKE '%crash%' GROUP BY rating" --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			query := strings.TrimSpace(strings.Join(args, " "))
+			if query == "" {
+				return shared.UsageError("a query is required, e.g. asc warehouse query \"SELECT * FROM sales LIMIT 10\"")
+			}
+
+			store, err := openStore(*dir)
+			if err != nil {
+				return fmt.Errorf("warehouse query: %w", err)
+			}
+			result, err := store.Query(query)
+			if err != nil {
+				return fmt.Errorf("warehouse query: %w", err)
+			}
+
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { asc.RenderTable(result.Columns, queryResultRows(result)); return nil },
+				func() error { asc.RenderMarkdown(result.Columns, queryResultRows(result)); return nil },
+			)
+		},
+	}
+}
+
+func queryResultRows(result *warehouse.Result) [][]string {
+	rows := make([][]string, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		values := make([]string, 0, len(row))
+		for _, value := range row {
+			values = append(values, warehouse.FormatValue(value))
+		}
+		rows = append(rows, values)
+	}
+	return rows
+}
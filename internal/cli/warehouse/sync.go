@@ -0,0 +1,416 @@
+package warehouse
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/reports"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/warehouse"
+)
+
+const (
+	syncSourceSales     = "sales"
+	syncSourceFinance   = "finance"
+	syncSourceReviews   = "reviews"
+	syncSourceAnalytics = "analytics"
+
+	syncDefaultDays = 30
+	syncMaxDays     = 366
+
+	// financeConsolidatedRegion is the single-file FINANCIAL report covering
+	// every region.
+	financeConsolidatedRegion = "ZZ"
+)
+
+var syncSources = []string{syncSourceSales, syncSourceFinance, syncSourceReviews, syncSourceAnalytics}
+
+// WarehouseSyncResult summarizes a sync run.
+type WarehouseSyncResult struct {
+	Dir     string                     `json:"dir"`
+	From    string                     `json:"from,omitempty"`
+	To      string                     `json:"to,omitempty"`
+	Sources []WarehouseSyncSourceStats `json:"sources"`
+}
+
+// WarehouseSyncSourceStats counts what one source loaded.
+type WarehouseSyncSourceStats struct {
+	Source  string   `json:"source"`
+	Tables  []string `json:"tables"`
+	Loaded  int      `json:"loaded"`
+	Skipped int      `json:"skipped"`
+	Missing []string `json:"missing,omitempty"`
+	Rows    int      `json:"rows"`
+}
+
+// WarehouseSyncCommand loads reports and reviews into the warehouse.
+func WarehouseSyncCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+
+	dir := fs.String("dir", "", "Warehouse directory (default: ~/.asc/warehouse)")
+	appID := fs.String("app", "", "App Store Connect app ID for reviews (or ASC_APP_ID env)")
+	vendor := fs.String("vendor", "", "Vendor number for sales and finance reports (or ASC_VENDOR_NUMBER)")
+	from := fs.String("from", "", "First report day (YYYY-MM-DD, default: 30 days before --to)")
+	to := fs.String("to", "", "Last report day (YYYY-MM-DD, default: yesterday)")
+	sources := fs.String("sources", "", "Comma-separated sources: sales, finance, reviews, analytics (default: every source whose inputs are set)")
+	analyticsDir := fs.String("analytics-dir", "", "Directory written by 'asc analytics mirror' to load analytics report CSVs from")
+	force := fs.Bool("force", false, "Re-download reports that are already in the warehouse")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "sync",
+		ShortUsage: "asc warehouse sync [flags]",
+		ShortHelp:  "Download and load reports and reviews into the local warehouse.",
+		LongHelp: `Download and load reports and reviews into the local warehouse.
+
+Sources:
+  sales      Daily SALES summary reports for every day from --from to --to (--vendor)
+  finance    FINANCIAL reports for region ZZ for every month in the range (--vendor)
+  reviews    All customer reviews for --app (always refreshed)
+  analytics  Analytics report CSVs under --analytics-dir, as written by
+             'asc analytics mirror' (always reloaded)
+
+Reports already in the warehouse are skipped unless --force is set, so
+repeated syncs only download new days. Days and months without a published
+report are listed as missing. Re-loading a report replaces its rows.
+
+Examples:
+  asc warehouse sync --app "123456789" --vendor "12345678"
+  asc warehouse sync --vendor "12345678" --sources sales --from "2026-01-01" --to "2026-03-31"
+  asc warehouse sync --app "123456789" --sources analytics --analytics-dir ./analytics`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) > 0 {
+				return shared.UsageErrorf("unexpected argument(s): %s", strings.Join(args, " "))
+			}
+
+			resolvedAppID := shared.ResolveAppID(*appID)
+			resolvedVendor := shared.ResolveVendorNumber(*vendor)
+			mirrorDir := strings.TrimSpace(*analyticsDir)
+
+			selected := shared.SplitCSV(strings.ToLower(*sources))
+			if len(selected) == 0 {
+				if resolvedVendor != "" {
+					selected = append(selected, syncSourceSales, syncSourceFinance)
+				}
+				if resolvedAppID != "" {
+					selected = append(selected, syncSourceReviews)
+				}
+				if mirrorDir != "" {
+					selected = append(selected, syncSourceAnalytics)
+				}
+				if len(selected) == 0 {
+					return shared.UsageError("nothing to sync: set --vendor, --app, or --analytics-dir")
+				}
+			}
+			for _, source := range selected {
+				if !slices.Contains(syncSources, source) {
+					return shared.UsageErrorf("--sources must be a comma-separated list of: %s", strings.Join(syncSources, ", "))
+				}
+			}
+			if (slices.Contains(selected, syncSourceSales) || slices.Contains(selected, syncSourceFinance)) && resolvedVendor == "" {
+				return shared.UsageError("--vendor is required for sales and finance (or set ASC_VENDOR_NUMBER)")
+			}
+			if slices.Contains(selected, syncSourceReviews) && resolvedAppID == "" {
+				return shared.UsageError("--app is required for reviews (or set ASC_APP_ID)")
+			}
+			if slices.Contains(selected, syncSourceAnalytics) && mirrorDir == "" {
+				return shared.UsageError("--analytics-dir is required for analytics")
+			}
+
+			fromDate, toDate, err := resolveSyncRange(*from, *to, time.Now().UTC())
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+
+			store, err := openStore(*dir)
+			if err != nil {
+				return fmt.Errorf("warehouse sync: %w", err)
+			}
+
+			result := &WarehouseSyncResult{Dir: store.Dir(), Sources: []WarehouseSyncSourceStats{}}
+			needsRange := slices.Contains(selected, syncSourceSales) || slices.Contains(selected, syncSourceFinance)
+			if needsRange {
+				result.From = fromDate.Format("2006-01-02")
+				result.To = toDate.Format("2006-01-02")
+			}
+
+			var client *asc.Client
+			if needsRange || slices.Contains(selected, syncSourceReviews) {
+				client, err = shared.GetASCClient()
+				if err != nil {
+					return fmt.Errorf("warehouse sync: %w", err)
+				}
+			}
+
+			for _, source := range syncSources {
+				if !slices.Contains(selected, source) {
+					continue
+				}
+				var stats WarehouseSyncSourceStats
+				switch source {
+				case syncSourceSales:
+					stats, err = syncSales(ctx, client, store, resolvedVendor, fromDate, toDate, *force)
+				case syncSourceFinance:
+					stats, err = syncFinance(ctx, client, store, resolvedVendor, fromDate, toDate, *force)
+				case syncSourceReviews:
+					stats, err = syncReviews(ctx, client, store, resolvedAppID)
+				case syncSourceAnalytics:
+					stats, err = syncAnalytics(store, mirrorDir)
+				}
+				if err != nil {
+					return fmt.Errorf("warehouse sync: %s: %w", source, err)
+				}
+				result.Sources = append(result.Sources, stats)
+			}
+
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { renderWarehouseSync(result, false); return nil },
+				func() error { renderWarehouseSync(result, true); return nil },
+			)
+		},
+	}
+}
+
+func resolveSyncRange(fromValue, toValue string, now time.Time) (time.Time, time.Time, error) {
+	toDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+	if strings.TrimSpace(toValue) != "" {
+		parsed, err := parseSyncDate(toValue, "--to")
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		toDate = parsed
+	}
+	fromDate := toDate.AddDate(0, 0, -(syncDefaultDays - 1))
+	if strings.TrimSpace(fromValue) != "" {
+		parsed, err := parseSyncDate(fromValue, "--from")
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		fromDate = parsed
+	}
+	if toDate.Before(fromDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--to must not be before --from")
+	}
+	if days := int(toDate.Sub(fromDate).Hours()/24) + 1; days > syncMaxDays {
+		return time.Time{}, time.Time{}, fmt.Errorf("--from/--to range must be at most %d days", syncMaxDays)
+	}
+	return fromDate, toDate, nil
+}
+
+func parseSyncDate(value, flagName string) (time.Time, error) {
+	normalized, err := shared.NormalizeDate(value, flagName)
+	if err != nil {
+		return time.Time{}, err
+	}
+	parsed, err := time.Parse("2006-01-02", normalized)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be in YYYY-MM-DD format", flagName)
+	}
+	return parsed, nil
+}
+
+func syncSales(ctx context.Context, client *asc.Client, store *warehouse.Store, vendor string, from, to time.Time, force bool) (WarehouseSyncSourceStats, error) {
+	stats := WarehouseSyncSourceStats{Source: syncSourceSales, Tables: []string{warehouse.TableSales}}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		key := vendor + "_" + date
+		if !force && store.HasPartition(warehouse.TableSales, key) {
+			stats.Skipped++
+			continue
+		}
+
+		report, err := fetchSalesReport(ctx, client, vendor, date)
+		if err != nil {
+			if asc.IsNotFound(err) {
+				stats.Missing = append(stats.Missing, date)
+				continue
+			}
+			return stats, fmt.Errorf("SALES report for %s: %w", date, err)
+		}
+		table := warehouse.SalesTable(vendor, report.Rows)
+		if err := store.Put(warehouse.TableSales, key, "SALES/SUMMARY/DAILY "+date, table); err != nil {
+			return stats, err
+		}
+		stats.Loaded++
+		stats.Rows += len(table.Rows)
+	}
+	return stats, nil
+}
+
+func fetchSalesReport(ctx context.Context, client *asc.Client, vendor, date string) (*reports.Report[reports.SalesRow], error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	download, err := client.GetSalesReport(requestCtx, asc.SalesReportParams{
+		VendorNumber:  vendor,
+		ReportType:    asc.SalesReportTypeSales,
+		ReportSubType: asc.SalesReportSubTypeSummary,
+		Frequency:     asc.SalesReportFrequencyDaily,
+		ReportDate:    date,
+		Version:       asc.SalesReportVersion1_0,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = download.Body.Close() }()
+	return reports.ParseSales(download.Body)
+}
+
+func syncFinance(ctx context.Context, client *asc.Client, store *warehouse.Store, vendor string, from, to time.Time, force bool) (WarehouseSyncSourceStats, error) {
+	stats := WarehouseSyncSourceStats{Source: syncSourceFinance, Tables: []string{warehouse.TableFinance}}
+	first := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for month := first; !month.After(to); month = month.AddDate(0, 1, 0) {
+		fiscalMonth := month.Format("2006-01")
+		key := vendor + "_" + fiscalMonth + "_" + financeConsolidatedRegion
+		if !force && store.HasPartition(warehouse.TableFinance, key) {
+			stats.Skipped++
+			continue
+		}
+
+		report, err := fetchFinanceReport(ctx, client, vendor, fiscalMonth)
+		if err != nil {
+			if asc.IsNotFound(err) {
+				stats.Missing = append(stats.Missing, fiscalMonth)
+				continue
+			}
+			return stats, fmt.Errorf("FINANCIAL report for %s: %w", fiscalMonth, err)
+		}
+		table := warehouse.FinanceTable(vendor, fiscalMonth, financeConsolidatedRegion, report.Rows)
+		if err := store.Put(warehouse.TableFinance, key, "FINANCIAL/"+financeConsolidatedRegion+" "+fiscalMonth, table); err != nil {
+			return stats, err
+		}
+		stats.Loaded++
+		stats.Rows += len(table.Rows)
+	}
+	return stats, nil
+}
+
+func fetchFinanceReport(ctx context.Context, client *asc.Client, vendor, fiscalMonth string) (*reports.Report[reports.FinancialRow], error) {
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	download, err := client.DownloadFinanceReport(requestCtx, asc.FinanceReportParams{
+		VendorNumber: vendor,
+		ReportType:   asc.FinanceReportTypeFinancial,
+		RegionCode:   financeConsolidatedRegion,
+		ReportDate:   fiscalMonth,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = download.Body.Close() }()
+	return reports.ParseFinancial(download.Body)
+}
+
+func syncReviews(ctx context.Context, client *asc.Client, store *warehouse.Store, appID string) (WarehouseSyncSourceStats, error) {
+	stats := WarehouseSyncSourceStats{Source: syncSourceReviews, Tables: []string{warehouse.TableReviews}}
+
+	reviews, err := shared.FetchReviewsNewestFirst(ctx, client, appID, func(time.Time) bool { return false })
+	if err != nil {
+		return stats, err
+	}
+
+	table := warehouse.ReviewsTable(appID, reviews)
+	if err := store.Put(warehouse.TableReviews, "app_"+appID, "customerReviews "+appID, table); err != nil {
+		return stats, err
+	}
+	stats.Loaded = 1
+	stats.Rows = len(table.Rows)
+	return stats, nil
+}
+
+// syncAnalytics loads <category>/<report>/<file>.csv files from an analytics
+// mirror into one table per report.
+func syncAnalytics(store *warehouse.Store, mirrorDir string) (WarehouseSyncSourceStats, error) {
+	stats := WarehouseSyncSourceStats{Source: syncSourceAnalytics, Tables: []string{}}
+	info, err := os.Stat(mirrorDir)
+	if err != nil {
+		return stats, err
+	}
+	if !info.IsDir() {
+		return stats, fmt.Errorf("%s is not a directory", mirrorDir)
+	}
+
+	err = filepath.WalkDir(mirrorDir, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !entry.Type().IsRegular() || !strings.EqualFold(filepath.Ext(path), ".csv") {
+			return nil
+		}
+		rel, err := filepath.Rel(mirrorDir, path)
+		if err != nil {
+			return err
+		}
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		if len(parts) < 2 {
+			return nil
+		}
+		tableName := warehouse.AnalyticsTablePrefix + warehouse.ColumnName(parts[len(parts)-2])
+		base := strings.TrimSuffix(parts[len(parts)-1], filepath.Ext(path))
+		reportDate := ""
+		if len(base) >= 10 {
+			if _, err := time.Parse("2006-01-02", base[:10]); err == nil {
+				reportDate = base[:10]
+			}
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+		table, err := warehouse.AnalyticsTable(reportDate, filepath.ToSlash(rel), file)
+		if err != nil {
+			return fmt.Errorf("%s: %w", rel, err)
+		}
+		if err := store.Put(tableName, filepath.ToSlash(rel), "analytics "+filepath.ToSlash(rel), table); err != nil {
+			return err
+		}
+		if !slices.Contains(stats.Tables, tableName) {
+			stats.Tables = append(stats.Tables, tableName)
+		}
+		stats.Loaded++
+		stats.Rows += len(table.Rows)
+		return nil
+	})
+	slices.Sort(stats.Tables)
+	return stats, err
+}
+
+func renderWarehouseSync(result *WarehouseSyncResult, markdown bool) {
+	contextRows := [][]string{{"dir", result.Dir}}
+	if result.From != "" {
+		contextRows = append(contextRows, []string{"range", result.From + " to " + result.To})
+	}
+	shared.RenderSection("Context", []string{"field", "value"}, contextRows, markdown)
+
+	rows := make([][]string, 0, len(result.Sources))
+	for _, source := range result.Sources {
+		rows = append(rows, []string{
+			source.Source,
+			shared.OrNA(strings.Join(source.Tables, ", ")),
+			strconv.Itoa(source.Loaded),
+			strconv.Itoa(source.Skipped),
+			shared.OrNA(strings.Join(source.Missing, ", ")),
+			strconv.Itoa(source.Rows),
+		})
+	}
+	shared.RenderSection("Sources", []string{"source", "tables", "loaded", "skipped", "missing", "rows"}, rows, markdown)
+}
@@ -0,0 +1,160 @@
+package warehouse
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/warehouse"
+)
+
+const warehouseTablesHelp = `TABLES:
+  sales                 Daily SALES summary report rows (asc warehouse sync --vendor)
+  finance               Monthly FINANCIAL report rows for all regions (--vendor)
+  reviews               Customer reviews (--app)
+  analytics_<report>    One table per analytics report mirrored with
+                        'asc analytics mirror' (--analytics-dir), e.g.
+                        analytics_app_downloads_standard
+
+Run 'asc warehouse tables' for the columns of every table.`
+
+// WarehouseCommand returns the warehouse command group.
+func WarehouseCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("warehouse", flag.ExitOnError)
+
+	return &ffcli.Command{
+		Name:       "warehouse",
+		ShortUsage: "asc warehouse <subcommand> [flags]",
+		ShortHelp:  "Sync reports and reviews into a local store and query them offline.",
+		LongHelp: `Sync reports and reviews into a local store and query them offline.
+
+The warehouse lives in ~/.asc/warehouse unless --dir is set. 'sync' downloads
+and parses sales and finance reports, customer reviews, and mirrored analytics
+report CSVs; 'query' answers SQL-like questions over the stored tables without
+calling the API.
+
+` + warehouseTablesHelp + `
+
+Examples:
+  asc warehouse sync --app "123456789" --vendor "12345678" --from "2026-01-01" --to "2026-01-31"
+  asc warehouse query "SELECT territory, sum(units) AS units FROM sales GROUP BY territory ORDER BY units DESC LIMIT 10"
+  asc warehouse tables --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			WarehouseSyncCommand(),
+			WarehouseQueryCommand(),
+			WarehouseTablesCommand(),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+// WarehouseTablesResult lists documented and stored tables.
+type WarehouseTablesResult struct {
+	Dir     string                `json:"dir"`
+	Schemas []warehouse.Schema    `json:"schemas"`
+	Stored  []warehouse.TableInfo `json:"stored"`
+}
+
+// WarehouseTablesCommand lists the warehouse tables and their columns.
+func WarehouseTablesCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("tables", flag.ExitOnError)
+
+	dir := fs.String("dir", "", "Warehouse directory (default: ~/.asc/warehouse)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "tables",
+		ShortUsage: "asc warehouse tables [flags]",
+		ShortHelp:  "List warehouse tables, columns, and row counts.",
+		LongHelp: `List warehouse tables, columns, and row counts.
+
+Shows the documented built-in tables and every table currently stored in the
+warehouse, including analytics report tables.
+
+Examples:
+  asc warehouse tables
+  asc warehouse tables --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) > 0 {
+				return shared.UsageErrorf("unexpected argument(s): %s", strings.Join(args, " "))
+			}
+			store, err := openStore(*dir)
+			if err != nil {
+				return fmt.Errorf("warehouse tables: %w", err)
+			}
+			stored, err := store.Tables()
+			if err != nil {
+				return fmt.Errorf("warehouse tables: %w", err)
+			}
+
+			result := &WarehouseTablesResult{Dir: store.Dir(), Schemas: warehouse.Schemas(), Stored: stored}
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { renderWarehouseTables(result, false); return nil },
+				func() error { renderWarehouseTables(result, true); return nil },
+			)
+		},
+	}
+}
+
+func openStore(dir string) (*warehouse.Store, error) {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		defaultDir, err := warehouse.DefaultDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = defaultDir
+	}
+	return warehouse.Open(dir), nil
+}
+
+func renderWarehouseTables(result *WarehouseTablesResult, markdown bool) {
+	stored := map[string]warehouse.TableInfo{}
+	tableRows := make([][]string, 0, len(result.Schemas)+len(result.Stored))
+	for _, info := range result.Stored {
+		stored[info.Name] = info
+	}
+	for _, schema := range result.Schemas {
+		rows, synced := "0", ""
+		if info, ok := stored[schema.Name]; ok {
+			rows, synced = strconv.Itoa(info.Rows), info.SyncedAt
+		}
+		tableRows = append(tableRows, []string{schema.Name, schema.Description, rows, shared.OrNA(synced)})
+	}
+	for _, info := range result.Stored {
+		if strings.HasPrefix(info.Name, warehouse.AnalyticsTablePrefix) {
+			tableRows = append(tableRows, []string{info.Name, "Analytics report", strconv.Itoa(info.Rows), shared.OrNA(info.SyncedAt)})
+		}
+	}
+	shared.RenderSection("Tables", []string{"table", "description", "rows", "syncedAt"}, tableRows, markdown)
+
+	columnRows := make([][]string, 0)
+	for _, schema := range result.Schemas {
+		for _, column := range schema.Columns {
+			columnRows = append(columnRows, []string{schema.Name, column.Name, string(column.Type), column.Description})
+		}
+	}
+	for _, info := range result.Stored {
+		if !strings.HasPrefix(info.Name, warehouse.AnalyticsTablePrefix) {
+			continue
+		}
+		for _, column := range info.Columns {
+			columnRows = append(columnRows, []string{info.Name, column.Name, string(column.Type), ""})
+		}
+	}
+	shared.RenderSection("Columns", []string{"table", "column", "type", "description"}, columnRows, markdown)
+}
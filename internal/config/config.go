@@ -126,6 +126,11 @@ type Config struct {
 	Keys           []Credential `json:"keys,omitempty"`
 	AppID          string       `json:"app_id"`
 
+	// CredentialProcess is a command that prints credentials as JSON. When
+	// set, it is used instead of stored credentials unless a profile is
+	// selected explicitly.
+	CredentialProcess string `json:"credential_process,omitempty"`
+
 	VendorNumber          string `json:"vendor_number"`
 	AnalyticsVendorNumber string `json:"analytics_vendor_number"`
 
@@ -177,6 +182,16 @@ func Path() (string, error) {
 	return resolvePath()
 }
 
+// TrustedPath returns ASC_CONFIG_PATH when it is set and the global
+// configuration file path otherwise. Unlike Path, it never resolves to a
+// repo-local .asc/config.json found by walking up from the working directory.
+func TrustedPath() (string, error) {
+	if envPath := strings.TrimSpace(os.Getenv(configPathEnvVar)); envPath != "" {
+		return cleanConfigPath(envPath)
+	}
+	return GlobalPath()
+}
+
 // LocalPath returns the local configuration file path.
 func LocalPath() (string, error) {
 	baseDir, err := localConfigBaseDir()
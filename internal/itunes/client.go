@@ -35,6 +35,8 @@ type AppRatings struct {
 	RatingCount          int64         `json:"ratingCount"`
 	CurrentVersionRating float64       `json:"currentVersionRating,omitempty"`
 	CurrentVersionCount  int64         `json:"currentVersionCount,omitempty"`
+	Version              string        `json:"version,omitempty"`
+	ReleaseDate          string        `json:"currentVersionReleaseDate,omitempty"`
 	Histogram            map[int]int64 `json:"histogram,omitempty"`
 }
 
@@ -45,6 +47,8 @@ type GlobalRatings struct {
 	AverageRating float64       `json:"averageRating"`
 	TotalCount    int64         `json:"totalCount"`
 	CountryCount  int           `json:"countryCount"`
+	Version       string        `json:"version,omitempty"`
+	ReleaseDate   string        `json:"currentVersionReleaseDate,omitempty"`
 	Histogram     map[int]int64 `json:"histogram,omitempty"`
 	ByCountry     []AppRatings  `json:"byCountry"`
 }
@@ -63,6 +67,8 @@ type lookupResult struct {
 	UserRatingCount                    int64   `json:"userRatingCount"`
 	AverageUserRatingForCurrentVersion float64 `json:"averageUserRatingForCurrentVersion"`
 	UserRatingCountForCurrentVersion   int64   `json:"userRatingCountForCurrentVersion"`
+	Version                            string  `json:"version"`
+	CurrentVersionReleaseDate          string  `json:"currentVersionReleaseDate"`
 }
 
 // GetRatings fetches rating statistics for an app in a specific country.
@@ -110,6 +116,8 @@ func (c *Client) GetRatings(ctx context.Context, appID, country string) (*AppRat
 		RatingCount:          app.UserRatingCount,
 		CurrentVersionRating: app.AverageUserRatingForCurrentVersion,
 		CurrentVersionCount:  app.UserRatingCountForCurrentVersion,
+		Version:              app.Version,
+		ReleaseDate:          app.CurrentVersionReleaseDate,
 		Histogram:            make(map[int]int64),
 	}
 
@@ -267,12 +275,15 @@ func (c *Client) GetAllRatings(ctx context.Context, appID string, workers int) (
 		byCountry[i] = *r
 	}
 
+	// The largest storefront reports the version most ratings are for.
 	return &GlobalRatings{
 		AppID:         appIDInt,
 		AppName:       appName,
 		AverageRating: globalAvg,
 		TotalCount:    total,
 		CountryCount:  len(results),
+		Version:       results[0].Version,
+		ReleaseDate:   results[0].ReleaseDate,
 		Histogram:     histogram,
 		ByCountry:     byCountry,
 	}, nil
@@ -17,7 +17,9 @@ func TestGetRatings_Success(t *testing.T) {
 			"averageUserRating": 4.75,
 			"userRatingCount": 71,
 			"averageUserRatingForCurrentVersion": 4.75,
-			"userRatingCountForCurrentVersion": 71
+			"userRatingCountForCurrentVersion": 71,
+			"version": "2.1.0",
+			"currentVersionReleaseDate": "2026-01-15T08:00:00Z"
 		}]
 	}`
 
@@ -76,6 +78,9 @@ func TestGetRatings_Success(t *testing.T) {
 	if ratings.Country != "US" {
 		t.Errorf("Country = %q, want %q", ratings.Country, "US")
 	}
+	if ratings.Version != "2.1.0" || ratings.ReleaseDate != "2026-01-15T08:00:00Z" {
+		t.Errorf("Version = %q, ReleaseDate = %q", ratings.Version, ratings.ReleaseDate)
+	}
 
 	// Check histogram
 	if ratings.Histogram[5] != 61 {
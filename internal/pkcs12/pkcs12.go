@@ -0,0 +1,367 @@
+// Package pkcs12 encodes a private key and certificate into a
+// password-protected PKCS#12 (.p12) file.
+//
+// The output uses the legacy algorithms that every keychain implementation
+// accepts: the key is shrouded with pbeWithSHAAnd3-KeyTripleDES-CBC, the
+// certificate is encrypted with the same scheme, and integrity is protected
+// by an HMAC-SHA1 computed with the PKCS#12 key derivation function.
+package pkcs12
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"unicode/utf16"
+)
+
+// DefaultIterations is the KDF iteration count used for encryption and MAC.
+const DefaultIterations = 2048
+
+var (
+	oidDataContentType          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidEncryptedDataContentType = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 6}
+	oidPBEWithSHAAnd3KeyTDES    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 1, 3}
+	oidPKCS8ShroudedKeyBag      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 2}
+	oidCertBag                  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 3}
+	oidCertTypeX509             = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 22, 1}
+	oidFriendlyName             = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 20}
+	oidLocalKeyID               = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 21}
+	oidSHA1                     = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+)
+
+const (
+	kdfKeyID = 1
+	kdfIVID  = 2
+	kdfMACID = 3
+)
+
+type pfxPdu struct {
+	Version  int
+	AuthSafe contentInfo
+	MacData  macData
+}
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"optional"`
+}
+
+type encryptedData struct {
+	Version              int
+	EncryptedContentInfo encryptedContentInfo
+}
+
+type encryptedContentInfo struct {
+	ContentType                asn1.ObjectIdentifier
+	ContentEncryptionAlgorithm algorithmIdentifier
+	EncryptedContent           []byte `asn1:"tag:0,optional"`
+}
+
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+type pbeParams struct {
+	Salt       []byte
+	Iterations int
+}
+
+type macData struct {
+	Mac        digestInfo
+	MacSalt    []byte
+	Iterations int `asn1:"optional,default:1"`
+}
+
+type digestInfo struct {
+	Algorithm algorithmIdentifier
+	Digest    []byte
+}
+
+type safeBag struct {
+	ID         asn1.ObjectIdentifier
+	Value      asn1.RawValue
+	Attributes []pkcs12Attribute `asn1:"set,optional"`
+}
+
+type pkcs12Attribute struct {
+	ID    asn1.ObjectIdentifier
+	Value asn1.RawValue `asn1:"set"`
+}
+
+type certBag struct {
+	ID   asn1.ObjectIdentifier
+	Data []byte `asn1:"tag:0,explicit"`
+}
+
+type encryptedPrivateKeyInfo struct {
+	AlgorithmIdentifier algorithmIdentifier
+	EncryptedData       []byte
+}
+
+// Encode returns a PKCS#12 file holding key and cert, protected by password.
+// friendlyName labels both entries when it is not empty.
+func Encode(key crypto.PrivateKey, cert *x509.Certificate, password, friendlyName string) ([]byte, error) {
+	if cert == nil {
+		return nil, errors.New("pkcs12: certificate is required")
+	}
+	encodedPassword, err := bmpString(password)
+	if err != nil {
+		return nil, err
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs12: marshal private key: %w", err)
+	}
+
+	localKeyID := sha1.Sum(cert.Raw)
+	attributes, err := bagAttributes(localKeyID[:], friendlyName)
+	if err != nil {
+		return nil, err
+	}
+
+	certBagDER, err := asn1.Marshal(certBag{ID: oidCertTypeX509, Data: cert.Raw})
+	if err != nil {
+		return nil, err
+	}
+	certSafeContents, err := asn1.Marshal([]safeBag{{
+		ID:         oidCertBag,
+		Value:      explicitTag(certBagDER),
+		Attributes: attributes,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	keyAlgorithm, encryptedKey, err := pbEncrypt(keyDER, encodedPassword)
+	if err != nil {
+		return nil, err
+	}
+	keyBagDER, err := asn1.Marshal(encryptedPrivateKeyInfo{AlgorithmIdentifier: keyAlgorithm, EncryptedData: encryptedKey})
+	if err != nil {
+		return nil, err
+	}
+	keySafeContents, err := asn1.Marshal([]safeBag{{
+		ID:         oidPKCS8ShroudedKeyBag,
+		Value:      explicitTag(keyBagDER),
+		Attributes: attributes,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	certContentInfo, err := encryptedContentInfoFor(certSafeContents, encodedPassword)
+	if err != nil {
+		return nil, err
+	}
+	keyContentInfo, err := dataContentInfo(keySafeContents)
+	if err != nil {
+		return nil, err
+	}
+	authenticatedSafe, err := asn1.Marshal([]contentInfo{certContentInfo, keyContentInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	authSafe, err := dataContentInfo(authenticatedSafe)
+	if err != nil {
+		return nil, err
+	}
+
+	macSalt := make([]byte, 8)
+	if _, err := rand.Read(macSalt); err != nil {
+		return nil, err
+	}
+	macKey := deriveKey(macSalt, encodedPassword, DefaultIterations, kdfMACID, sha1.Size)
+	mac := hmac.New(sha1.New, macKey)
+	mac.Write(authenticatedSafe)
+
+	return asn1.Marshal(pfxPdu{
+		Version:  3,
+		AuthSafe: authSafe,
+		MacData: macData{
+			Mac: digestInfo{
+				Algorithm: algorithmIdentifier{Algorithm: oidSHA1, Parameters: asn1.NullRawValue},
+				Digest:    mac.Sum(nil),
+			},
+			MacSalt:    macSalt,
+			Iterations: DefaultIterations,
+		},
+	})
+}
+
+func bagAttributes(localKeyID []byte, friendlyName string) ([]pkcs12Attribute, error) {
+	keyIDValue, err := asn1.Marshal(localKeyID)
+	if err != nil {
+		return nil, err
+	}
+	attributes := []pkcs12Attribute{{
+		ID:    oidLocalKeyID,
+		Value: asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: keyIDValue},
+	}}
+	if friendlyName == "" {
+		return attributes, nil
+	}
+
+	name, err := bmpString(friendlyName)
+	if err != nil {
+		return nil, err
+	}
+	// Drop the two-byte terminator: BMPString attribute values are not
+	// null-terminated, unlike the KDF password encoding.
+	nameValue, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagBMPString, Bytes: name[:len(name)-2]})
+	if err != nil {
+		return nil, err
+	}
+	attributes = append(attributes, pkcs12Attribute{
+		ID:    oidFriendlyName,
+		Value: asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: nameValue},
+	})
+	return attributes, nil
+}
+
+// explicitTag wraps an encoded value in an [0] EXPLICIT tag. encoding/asn1
+// ignores struct tags on RawValue fields, so the wrapper is built by hand.
+func explicitTag(der []byte) asn1.RawValue {
+	return asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: der}
+}
+
+func dataContentInfo(content []byte) (contentInfo, error) {
+	octets, err := asn1.Marshal(content)
+	if err != nil {
+		return contentInfo{}, err
+	}
+	return contentInfo{
+		ContentType: oidDataContentType,
+		Content:     explicitTag(octets),
+	}, nil
+}
+
+func encryptedContentInfoFor(content, password []byte) (contentInfo, error) {
+	algorithm, encrypted, err := pbEncrypt(content, password)
+	if err != nil {
+		return contentInfo{}, err
+	}
+	data, err := asn1.Marshal(encryptedData{
+		Version: 0,
+		EncryptedContentInfo: encryptedContentInfo{
+			ContentType:                oidDataContentType,
+			ContentEncryptionAlgorithm: algorithm,
+			EncryptedContent:           encrypted,
+		},
+	})
+	if err != nil {
+		return contentInfo{}, err
+	}
+	return contentInfo{
+		ContentType: oidEncryptedDataContentType,
+		Content:     explicitTag(data),
+	}, nil
+}
+
+// pbEncrypt encrypts data with pbeWithSHAAnd3-KeyTripleDES-CBC.
+func pbEncrypt(data, password []byte) (algorithmIdentifier, []byte, error) {
+	salt := make([]byte, 8)
+	if _, err := rand.Read(salt); err != nil {
+		return algorithmIdentifier{}, nil, err
+	}
+	params, err := asn1.Marshal(pbeParams{Salt: salt, Iterations: DefaultIterations})
+	if err != nil {
+		return algorithmIdentifier{}, nil, err
+	}
+
+	block, err := des.NewTripleDESCipher(deriveKey(salt, password, DefaultIterations, kdfKeyID, 24))
+	if err != nil {
+		return algorithmIdentifier{}, nil, err
+	}
+	iv := deriveKey(salt, password, DefaultIterations, kdfIVID, block.BlockSize())
+
+	padding := block.BlockSize() - len(data)%block.BlockSize()
+	plaintext := append(append([]byte{}, data...), bytes.Repeat([]byte{byte(padding)}, padding)...)
+	encrypted := make([]byte, len(plaintext))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted, plaintext)
+
+	return algorithmIdentifier{Algorithm: oidPBEWithSHAAnd3KeyTDES, Parameters: asn1.RawValue{FullBytes: params}}, encrypted, nil
+}
+
+// deriveKey implements the PKCS#12 key derivation function from RFC 7292
+// appendix B.2 with SHA-1.
+func deriveKey(salt, password []byte, iterations int, id byte, size int) []byte {
+	const (
+		u = sha1.Size
+		v = 64
+	)
+
+	D := bytes.Repeat([]byte{id}, v)
+	S := fillBlocks(salt, v)
+	P := fillBlocks(password, v)
+	I := append(S, P...)
+
+	c := (size + u - 1) / u
+	A := make([]byte, 0, c*u)
+	one := big.NewInt(1)
+	for i := 1; i <= c; i++ {
+		hash := sha1.New()
+		hash.Write(D)
+		hash.Write(I)
+		Ai := hash.Sum(nil)
+		for j := 1; j < iterations; j++ {
+			sum := sha1.Sum(Ai)
+			Ai = sum[:]
+		}
+		A = append(A, Ai...)
+
+		if i < c {
+			B := new(big.Int).SetBytes(fillBlocks(Ai, v)[:v])
+			B.Add(B, one)
+			for j := 0; j < len(I)/v; j++ {
+				Ij := new(big.Int).SetBytes(I[j*v : (j+1)*v])
+				Ij.Add(Ij, B)
+				sum := Ij.Bytes()
+				if len(sum) > v {
+					sum = sum[len(sum)-v:]
+				}
+				block := I[j*v : (j+1)*v]
+				clear(block)
+				copy(block[v-len(sum):], sum)
+			}
+		}
+	}
+	return A[:size]
+}
+
+// fillBlocks repeats data to the smallest multiple of v bytes that holds it.
+func fillBlocks(data []byte, v int) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+	size := v * ((len(data) + v - 1) / v)
+	out := make([]byte, size)
+	for i := range out {
+		out[i] = data[i%len(data)]
+	}
+	return out
+}
+
+// bmpString encodes s as null-terminated UTF-16BE, as PKCS#12 requires for
+// passwords.
+func bmpString(s string) ([]byte, error) {
+	out := make([]byte, 0, 2*len(s)+2)
+	for _, r := range s {
+		if r > 0xFFFF || utf16.IsSurrogate(r) {
+			return nil, fmt.Errorf("pkcs12: character %q is not allowed in a BMPString", r)
+		}
+		out = append(out, byte(r>>8), byte(r))
+	}
+	return append(out, 0, 0), nil
+}
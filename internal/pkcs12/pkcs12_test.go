@@ -0,0 +1,190 @@
+package pkcs12
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestDeriveKey_MatchesOpenSSL(t *testing.T) {
+	salt, _ := hex.DecodeString("0a58cf64530d823f")
+	tests := []struct {
+		name       string
+		iterations int
+		id         byte
+		size       int
+		want       string
+	}{
+		// openssl kdf -keylen N -kdfopt digest:SHA1 -kdfopt pass:sesame
+		//   -kdfopt hexsalt:0a58cf64530d823f -kdfopt iter:I -kdfopt id:ID PKCS12KDF
+		{name: "key", iterations: 2048, id: kdfKeyID, size: 24, want: "08a6a003caa69a9c035009e24fa001b9a0ea8e005a0e865e"},
+		{name: "iv", iterations: 2048, id: kdfIVID, size: 24, want: "5d6be3d9e8ab7e0ccceedb9c4b1cd2be9184d58365539c93"},
+		{name: "mac", iterations: 2048, id: kdfMACID, size: 24, want: "93e8445d206812d494fc989cc4abefb8117273ab07d98d53"},
+		{name: "multiple blocks", iterations: 3, id: kdfKeyID, size: 41, want: "93c051bf1b946e209a73564698f51e06104f3f22655b61b0ee6693dde40d557db9ade169867d0c3600"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := hex.EncodeToString(deriveKey(salt, []byte("sesame"), test.iterations, test.id, test.size))
+			if got != test.want {
+				t.Fatalf("deriveKey() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	key, cert := testIdentity(t)
+	password := "pässword"
+
+	data, err := Encode(key, cert, password, "Apple Distribution: Example")
+	if err != nil {
+		t.Fatalf("Encode() error: %v", err)
+	}
+
+	var pfx pfxPdu
+	if _, err := asn1.Unmarshal(data, &pfx); err != nil {
+		t.Fatalf("unmarshal pfx: %v", err)
+	}
+	if pfx.Version != 3 || !pfx.AuthSafe.ContentType.Equal(oidDataContentType) {
+		t.Fatalf("unexpected pfx header: version %d, content type %v", pfx.Version, pfx.AuthSafe.ContentType)
+	}
+
+	var authenticatedSafe []byte
+	if _, err := asn1.Unmarshal(pfx.AuthSafe.Content.Bytes, &authenticatedSafe); err != nil {
+		t.Fatalf("unmarshal auth safe: %v", err)
+	}
+	encodedPassword, _ := bmpString(password)
+	mac := hmac.New(sha1.New, deriveKey(pfx.MacData.MacSalt, encodedPassword, pfx.MacData.Iterations, kdfMACID, sha1.Size))
+	mac.Write(authenticatedSafe)
+	if !hmac.Equal(mac.Sum(nil), pfx.MacData.Mac.Digest) {
+		t.Fatal("MAC does not verify with the password")
+	}
+
+	var contents []contentInfo
+	if _, err := asn1.Unmarshal(authenticatedSafe, &contents); err != nil {
+		t.Fatalf("unmarshal contents: %v", err)
+	}
+	if len(contents) != 2 || !contents[0].ContentType.Equal(oidEncryptedDataContentType) || !contents[1].ContentType.Equal(oidDataContentType) {
+		t.Fatalf("unexpected content types: %+v", contents)
+	}
+
+	var encrypted encryptedData
+	if _, err := asn1.Unmarshal(contents[0].Content.Bytes, &encrypted); err != nil {
+		t.Fatalf("unmarshal encrypted data: %v", err)
+	}
+	certBags := decodeSafeBags(t, pbDecrypt(t, encrypted.EncryptedContentInfo.ContentEncryptionAlgorithm, encrypted.EncryptedContentInfo.EncryptedContent, encodedPassword))
+	if len(certBags) != 1 || !certBags[0].ID.Equal(oidCertBag) {
+		t.Fatalf("unexpected certificate bags: %+v", certBags)
+	}
+	var bag certBag
+	if _, err := asn1.Unmarshal(certBags[0].Value.Bytes, &bag); err != nil {
+		t.Fatalf("unmarshal cert bag: %v", err)
+	}
+	if !bytes.Equal(bag.Data, cert.Raw) {
+		t.Fatal("certificate bag does not hold the certificate")
+	}
+
+	var keyContents []byte
+	if _, err := asn1.Unmarshal(contents[1].Content.Bytes, &keyContents); err != nil {
+		t.Fatalf("unmarshal key contents: %v", err)
+	}
+	keyBags := decodeSafeBags(t, keyContents)
+	if len(keyBags) != 1 || !keyBags[0].ID.Equal(oidPKCS8ShroudedKeyBag) {
+		t.Fatalf("unexpected key bags: %+v", keyBags)
+	}
+	var shrouded encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(keyBags[0].Value.Bytes, &shrouded); err != nil {
+		t.Fatalf("unmarshal shrouded key: %v", err)
+	}
+	decoded, err := x509.ParsePKCS8PrivateKey(pbDecrypt(t, shrouded.AlgorithmIdentifier, shrouded.EncryptedData, encodedPassword))
+	if err != nil {
+		t.Fatalf("parse decrypted key: %v", err)
+	}
+	if !key.Equal(decoded) {
+		t.Fatal("decrypted key does not match the input key")
+	}
+
+	if !bytes.Equal(keyBags[0].Attributes[0].Value.Bytes, certBags[0].Attributes[0].Value.Bytes) {
+		t.Fatal("expected key and certificate bags to share a localKeyID")
+	}
+}
+
+func TestBMPString(t *testing.T) {
+	got, err := bmpString("Beavis")
+	if err != nil {
+		t.Fatalf("bmpString() error: %v", err)
+	}
+	if want := "0042006500610076006900730000"; hex.EncodeToString(got) != want {
+		t.Fatalf("bmpString() = %x, want %s", got, want)
+	}
+	if _, err := bmpString("emoji 😀"); err == nil {
+		t.Fatal("expected characters outside the BMP to be rejected")
+	}
+}
+
+func testIdentity(t *testing.T) (*ecdsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Apple Distribution: Example"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+	return key, cert
+}
+
+func decodeSafeBags(t *testing.T, data []byte) []safeBag {
+	t.Helper()
+	var bags []safeBag
+	if _, err := asn1.Unmarshal(data, &bags); err != nil {
+		t.Fatalf("unmarshal safe bags: %v", err)
+	}
+	return bags
+}
+
+func pbDecrypt(t *testing.T, algorithm algorithmIdentifier, data, password []byte) []byte {
+	t.Helper()
+	if !algorithm.Algorithm.Equal(oidPBEWithSHAAnd3KeyTDES) {
+		t.Fatalf("unexpected algorithm %v", algorithm.Algorithm)
+	}
+	var params pbeParams
+	if _, err := asn1.Unmarshal(algorithm.Parameters.FullBytes, &params); err != nil {
+		t.Fatalf("unmarshal pbe params: %v", err)
+	}
+	block, err := des.NewTripleDESCipher(deriveKey(params.Salt, password, params.Iterations, kdfKeyID, 24))
+	if err != nil {
+		t.Fatalf("create cipher: %v", err)
+	}
+	iv := deriveKey(params.Salt, password, params.Iterations, kdfIVID, block.BlockSize())
+	plaintext := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, data)
+	padding := int(plaintext[len(plaintext)-1])
+	if padding < 1 || padding > block.BlockSize() {
+		t.Fatalf("invalid padding %d", padding)
+	}
+	return plaintext[:len(plaintext)-padding]
+}
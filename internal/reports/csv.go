@@ -0,0 +1,51 @@
+package reports
+
+import (
+	"encoding/csv"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// CSVHeader returns the CSV column names for a row type: the JSON names of
+// its fields, in declaration order. Extra is not included.
+func CSVHeader[T any]() []string {
+	rowType := reflect.TypeFor[T]()
+	header := make([]string, 0, rowType.NumField())
+	for index := range rowType.NumField() {
+		field := rowType.Field(index)
+		if field.Type.Kind() == reflect.Map {
+			continue
+		}
+		header = append(header, jsonName(field))
+	}
+	return header
+}
+
+// WriteCSV writes rows as CSV with a CSVHeader header line.
+func WriteCSV[T any](w io.Writer, rows []T) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(CSVHeader[T]()); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		value := reflect.ValueOf(row)
+		record := make([]string, 0, value.NumField())
+		for index := range value.NumField() {
+			field := value.Field(index)
+			switch field.Kind() {
+			case reflect.Map:
+				continue
+			case reflect.Float64:
+				record = append(record, strconv.FormatFloat(field.Float(), 'f', -1, 64))
+			default:
+				record = append(record, field.String())
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,60 @@
+package reports
+
+import "strings"
+
+// Product categories returned by DecodeProductType.
+const (
+	ProductCategoryApp           = "app"
+	ProductCategoryUpdate        = "update"
+	ProductCategoryRedownload    = "redownload"
+	ProductCategoryInAppPurchase = "in_app_purchase"
+	ProductCategorySubscription  = "subscription"
+	ProductCategoryBundle        = "bundle"
+)
+
+type productType struct {
+	description string
+	category    string
+}
+
+// productTypes maps Product Type Identifiers to descriptions.
+// Source: https://developer.apple.com/help/app-store-connect/reference/product-type-identifiers/
+var productTypes = map[string]productType{
+	"1":     {"Free or paid app (iPhone and iPod touch)", ProductCategoryApp},
+	"1F":    {"Free or paid app (Universal)", ProductCategoryApp},
+	"1T":    {"Free or paid app (iPad)", ProductCategoryApp},
+	"F1":    {"Free or paid app (Mac)", ProductCategoryApp},
+	"1E":    {"Paid app (custom iPhone and iPod touch)", ProductCategoryApp},
+	"1EP":   {"Paid app (custom iPad)", ProductCategoryApp},
+	"1EU":   {"Paid app (custom Universal)", ProductCategoryApp},
+	"1-B":   {"App bundle (iOS)", ProductCategoryBundle},
+	"F1-B":  {"App bundle (Mac)", ProductCategoryBundle},
+	"3":     {"Redownload (iPhone and iPod touch)", ProductCategoryRedownload},
+	"3F":    {"Redownload (Universal)", ProductCategoryRedownload},
+	"3T":    {"Redownload (iPad)", ProductCategoryRedownload},
+	"F3":    {"Redownload (Mac)", ProductCategoryRedownload},
+	"7":     {"Update (iPhone and iPod touch)", ProductCategoryUpdate},
+	"7F":    {"Update (Universal)", ProductCategoryUpdate},
+	"7T":    {"Update (iPad)", ProductCategoryUpdate},
+	"F7":    {"Update (Mac)", ProductCategoryUpdate},
+	"IA1":   {"In-app purchase", ProductCategoryInAppPurchase},
+	"IA1-M": {"In-app purchase (Mac)", ProductCategoryInAppPurchase},
+	"FI1":   {"In-app purchase (Mac)", ProductCategoryInAppPurchase},
+	"IA9":   {"Non-renewing subscription", ProductCategorySubscription},
+	"IA9-M": {"Non-renewing subscription (Mac)", ProductCategorySubscription},
+	"IAY":   {"Auto-renewable subscription", ProductCategorySubscription},
+	"IAY-M": {"Auto-renewable subscription (Mac)", ProductCategorySubscription},
+	"IAC":   {"Free subscription", ProductCategorySubscription},
+	"IAC-M": {"Free subscription (Mac)", ProductCategorySubscription},
+}
+
+// DecodeProductType returns a human-readable description and a coarse
+// category for a Product Type Identifier. Unknown identifiers return empty
+// strings.
+func DecodeProductType(identifier string) (description, category string) {
+	entry, ok := productTypes[strings.ToUpper(strings.TrimSpace(identifier))]
+	if !ok {
+		return "", ""
+	}
+	return entry.description, entry.category
+}
@@ -0,0 +1,361 @@
+// Package reports parses App Store Connect sales, subscription, and finance
+// report files into typed, normalized rows.
+package reports
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind identifies the layout of a report file.
+type Kind string
+
+const (
+	KindSales             Kind = "SALES"
+	KindSubscription      Kind = "SUBSCRIPTION"
+	KindSubscriptionEvent Kind = "SUBSCRIPTION_EVENT"
+	KindFinancial         Kind = "FINANCIAL"
+)
+
+// Report is a parsed report file.
+type Report[T any] struct {
+	Kind Kind `json:"kind"`
+	// Columns are the header cells as they appear in the file.
+	Columns []string `json:"columns"`
+	Rows    []T      `json:"rows"`
+	// Totals holds trailing summary lines such as Total_Amount, keyed by name.
+	Totals map[string]float64 `json:"totals,omitempty"`
+}
+
+// HasColumn reports whether the file had a column with the given header,
+// ignoring case, spaces, and punctuation.
+func (r *Report[T]) HasColumn(header string) bool {
+	target := normalizeColumnName(header)
+	for _, column := range r.Columns {
+		if normalizeColumnName(column) == target {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseOption configures how report cells are parsed.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	lenient bool
+}
+
+// Lenient keeps rows whose number or date cells cannot be parsed instead of
+// failing the report. The field is left zero and the raw cell is recorded in
+// the row's Extra map under its column header.
+func Lenient() ParseOption {
+	return func(opts *parseOptions) {
+		opts.lenient = true
+	}
+}
+
+// ParseSales parses a SALES (Summary, Detailed, or Opt-In) report.
+func ParseSales(reader io.Reader, opts ...ParseOption) (*Report[SalesRow], error) {
+	return parseReport[SalesRow](reader, KindSales, opts)
+}
+
+// ParseSubscription parses a SUBSCRIPTION report.
+func ParseSubscription(reader io.Reader, opts ...ParseOption) (*Report[SubscriptionRow], error) {
+	return parseReport[SubscriptionRow](reader, KindSubscription, opts)
+}
+
+// ParseSubscriptionEvent parses a SUBSCRIPTION_EVENT report.
+func ParseSubscriptionEvent(reader io.Reader, opts ...ParseOption) (*Report[SubscriptionEventRow], error) {
+	return parseReport[SubscriptionEventRow](reader, KindSubscriptionEvent, opts)
+}
+
+// ParseFinancial parses a FINANCIAL or FINANCE_DETAIL report. Trailing
+// Total_Rows/Total_Amount/Total_Units summary lines go to Report.Totals.
+func ParseFinancial(reader io.Reader, opts ...ParseOption) (*Report[FinancialRow], error) {
+	return parseReport[FinancialRow](reader, KindFinancial, opts)
+}
+
+// normalizer is implemented by row types that derive fields after parsing.
+type normalizer interface {
+	normalize()
+}
+
+func parseReport[T any](reader io.Reader, kind Kind, opts []ParseOption) (*Report[T], error) {
+	var options parseOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	records, err := readTSV(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	headerIdx := -1
+	for index, record := range records {
+		if !isEmptyRecord(record) {
+			headerIdx = index
+			break
+		}
+	}
+	if headerIdx < 0 {
+		return nil, fmt.Errorf("report is empty")
+	}
+	headers := records[headerIdx]
+
+	fields := rowFields(reflect.TypeFor[T]())
+	columnFields := make([]*rowField, len(headers))
+	matched := 0
+	for index, header := range headers {
+		normalized := normalizeColumnName(header)
+		for fieldIdx := range fields {
+			if fields[fieldIdx].matches(normalized) {
+				columnFields[index] = &fields[fieldIdx]
+				matched++
+				break
+			}
+		}
+	}
+	if matched == 0 {
+		return nil, fmt.Errorf("report header does not look like a %s report", kind)
+	}
+
+	report := &Report[T]{Kind: kind, Columns: headers, Rows: []T{}}
+	for line, record := range records[headerIdx+1:] {
+		if isEmptyRecord(record) {
+			continue
+		}
+		if isTotalsRecord(record) {
+			if len(record) > 1 {
+				if value, ok := ParseNumber(record[1]); ok {
+					if report.Totals == nil {
+						report.Totals = map[string]float64{}
+					}
+					report.Totals[strings.TrimSpace(record[0])] = value
+				}
+			}
+			continue
+		}
+		var row T
+		value := reflect.ValueOf(&row).Elem()
+		for index, cell := range record {
+			if index >= len(columnFields) {
+				break
+			}
+			field := columnFields[index]
+			if field == nil {
+				setExtra(value, headers[index], cell)
+				continue
+			}
+			if err := field.set(value, cell); err != nil {
+				if options.lenient {
+					setExtra(value, headers[index], cell)
+					continue
+				}
+				return nil, fmt.Errorf("line %d, column %q: %w", headerIdx+line+2, headers[index], err)
+			}
+		}
+		if n, ok := any(&row).(normalizer); ok {
+			n.normalize()
+		}
+		report.Rows = append(report.Rows, row)
+	}
+	return report, nil
+}
+
+// readTSV reads tab-separated records from a plain or gzip-compressed stream.
+func readTSV(reader io.Reader) ([][]string, error) {
+	buffered := bufio.NewReader(reader)
+	var source io.Reader = buffered
+	if magic, err := buffered.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gzipReader, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("read gzip report: %w", err)
+		}
+		defer func() { _ = gzipReader.Close() }()
+		source = gzipReader
+	}
+
+	tsvReader := csv.NewReader(source)
+	tsvReader.Comma = '\t'
+	tsvReader.FieldsPerRecord = -1
+	tsvReader.LazyQuotes = true
+	records, err := tsvReader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parse report rows: %w", err)
+	}
+	if len(records) > 0 && len(records[0]) > 0 {
+		records[0][0] = strings.TrimPrefix(records[0][0], "\ufeff")
+	}
+	return records, nil
+}
+
+type fieldKind int
+
+const (
+	fieldString fieldKind = iota
+	fieldNumber
+	fieldDate
+)
+
+type rowField struct {
+	index   int
+	kind    fieldKind
+	headers []string
+}
+
+func (f rowField) matches(normalizedHeader string) bool {
+	for _, header := range f.headers {
+		if header == normalizedHeader {
+			return true
+		}
+	}
+	return false
+}
+
+func (f rowField) set(row reflect.Value, cell string) error {
+	cell = strings.TrimSpace(cell)
+	target := row.Field(f.index)
+	switch f.kind {
+	case fieldNumber:
+		if cell == "" {
+			return nil
+		}
+		value, ok := ParseNumber(cell)
+		if !ok {
+			return fmt.Errorf("invalid number %q", cell)
+		}
+		target.SetFloat(value)
+	case fieldDate:
+		value, err := ParseDate(cell)
+		if err != nil {
+			return err
+		}
+		target.SetString(value)
+	default:
+		target.SetString(cell)
+	}
+	return nil
+}
+
+// rowFields reads the `report:"Header|Alias,kind"` tags of a row struct.
+func rowFields(rowType reflect.Type) []rowField {
+	var fields []rowField
+	for index := range rowType.NumField() {
+		structField := rowType.Field(index)
+		tag, ok := structField.Tag.Lookup("report")
+		if !ok || tag == "-" {
+			continue
+		}
+		names, option, _ := strings.Cut(tag, ",")
+		field := rowField{index: index}
+		for name := range strings.SplitSeq(names, "|") {
+			field.headers = append(field.headers, normalizeColumnName(name))
+		}
+		switch {
+		case option == "date":
+			field.kind = fieldDate
+		case structField.Type.Kind() == reflect.Float64:
+			field.kind = fieldNumber
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+func setExtra(row reflect.Value, header, cell string) {
+	cell = strings.TrimSpace(cell)
+	header = strings.TrimSpace(header)
+	if cell == "" || header == "" {
+		return
+	}
+	extra := row.FieldByName("Extra")
+	if !extra.IsValid() || extra.Kind() != reflect.Map {
+		return
+	}
+	if extra.IsNil() {
+		extra.Set(reflect.MakeMap(extra.Type()))
+	}
+	extra.SetMapIndex(reflect.ValueOf(header), reflect.ValueOf(cell))
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// ParseNumber parses report amounts and units. It accepts thousands
+// separators, currency symbols, and accounting-style negatives "(1.00)".
+func ParseNumber(value string) (float64, bool) {
+	normalized := strings.TrimSpace(value)
+	if normalized == "" {
+		return 0, false
+	}
+
+	negative := false
+	if strings.HasPrefix(normalized, "(") && strings.HasSuffix(normalized, ")") {
+		negative = true
+		normalized = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(normalized, "("), ")"))
+	}
+	for _, token := range []string{",", "$", "€", "£", "¥"} {
+		normalized = strings.ReplaceAll(normalized, token, "")
+	}
+
+	parsed, err := strconv.ParseFloat(normalized, 64)
+	if err != nil {
+		return 0, false
+	}
+	if negative {
+		parsed = -parsed
+	}
+	return parsed, true
+}
+
+var reportDateLayouts = []string{"01/02/2006", "2006-01-02", "1/2/2006", "20060102"}
+
+// ParseDate converts the date formats used in report files (MM/DD/YYYY and
+// YYYY-MM-DD) to YYYY-MM-DD. Empty values stay empty.
+func ParseDate(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	for _, layout := range reportDateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed.Format("2006-01-02"), nil
+		}
+	}
+	return "", fmt.Errorf("invalid date %q", value)
+}
+
+func normalizeColumnName(value string) string {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	for _, token := range []string{" ", "_", "-", "/"} {
+		normalized = strings.ReplaceAll(normalized, token, "")
+	}
+	return normalized
+}
+
+func isEmptyRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func isTotalsRecord(record []string) bool {
+	return len(record) > 0 && strings.HasPrefix(strings.TrimSpace(record[0]), "Total_")
+}
@@ -0,0 +1,158 @@
+package reports
+
+import (
+	"bytes"
+	"compress/gzip"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func gzipText(t *testing.T, text string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(text)); err != nil {
+		t.Fatalf("gzip write error: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("gzip close error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseSales(t *testing.T) {
+	text := strings.Join([]string{
+		"Provider\tSKU\tTitle\tProduct Type Identifier\tUnits\tDeveloper Proceeds\tBegin Date\tEnd Date\tCustomer Currency\tApple Identifier\tCustomer Price\tNew Column",
+		"APPLE\tapp.sku\tMy App\t1F\t12\t0\t01/02/2026\t01/02/2026\tUSD\t123\t0\tsurprise",
+		"APPLE\tpro.monthly\tPro\tIAY\t(1)\t(6.99)\t01/02/2026\t01/02/2026\tEUR\t456\t9.99\t",
+		"",
+	}, "\n")
+
+	report, err := ParseSales(bytes.NewReader(gzipText(t, text)))
+	if err != nil {
+		t.Fatalf("ParseSales() error: %v", err)
+	}
+	if report.Kind != KindSales || len(report.Rows) != 2 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if !report.HasColumn("developer_proceeds") || report.HasColumn("Parent Identifier") {
+		t.Fatalf("unexpected column detection for %v", report.Columns)
+	}
+
+	app := report.Rows[0]
+	if app.Units != 12 || app.BeginDate != "2026-01-02" || app.ProductCategory != ProductCategoryApp || app.ProductType != "Free or paid app (Universal)" {
+		t.Fatalf("unexpected app row %+v", app)
+	}
+	if !reflect.DeepEqual(app.Extra, map[string]string{"New Column": "surprise"}) {
+		t.Fatalf("expected unknown columns in Extra, got %v", app.Extra)
+	}
+	refund := report.Rows[1]
+	if refund.Units != -1 || refund.DeveloperProceeds != -6.99 || refund.CustomerPrice != 9.99 || refund.ProductCategory != ProductCategorySubscription {
+		t.Fatalf("unexpected refund row %+v", refund)
+	}
+}
+
+func TestParseFinancialSkipsTotals(t *testing.T) {
+	text := strings.Join([]string{
+		"Start Date\tEnd Date\tVendor Identifier\tQuantity\tPartner Share\tExtended Partner Share\tPartner Share Currency\tSales or Return\tApple Identifier\tProduct Type Identifier\tCountry Of Sale",
+		"12/28/2025\t01/31/2026\tpro.monthly\t3\t6.99\t20.97\tUSD\tS\t456\tIAY\tUS",
+		"12/28/2025\t01/31/2026\tpro.monthly\t-1\t6.99\t-6.99\tUSD\tR\t456\tIAY\tUS",
+		"",
+		"Total_Rows\t2",
+		"Total_Amount\t13.98",
+		"Total_Units\t2",
+	}, "\n")
+
+	report, err := ParseFinancial(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("ParseFinancial() error: %v", err)
+	}
+	if len(report.Rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(report.Rows))
+	}
+	if report.Totals["Total_Amount"] != 13.98 || report.Totals["Total_Rows"] != 2 {
+		t.Fatalf("unexpected totals %v", report.Totals)
+	}
+	if row := report.Rows[0]; row.StartDate != "2025-12-28" || row.ExtendedPartnerShare != 20.97 || row.VendorIdentifier != "pro.monthly" || row.IsReturn() {
+		t.Fatalf("unexpected sale row %+v", row)
+	}
+	if !report.Rows[1].IsReturn() || report.Rows[1].Quantity != -1 {
+		t.Fatalf("unexpected return row %+v", report.Rows[1])
+	}
+}
+
+func TestParseSubscriptionEvent(t *testing.T) {
+	text := "Event Date\tEvent\tApp Apple ID\tSubscription Name\tConsecutive Paid Periods\tCountry\tQuantity\n" +
+		"2026-01-02\tCancel\t123\tPro Monthly\t4\tUS\t2\n"
+
+	report, err := ParseSubscriptionEvent(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("ParseSubscriptionEvent() error: %v", err)
+	}
+	row := report.Rows[0]
+	if row.EventDate != "2026-01-02" || row.Event != "Cancel" || row.ConsecutivePaidPeriods != 4 || row.Quantity != 2 {
+		t.Fatalf("unexpected row %+v", row)
+	}
+}
+
+func TestParseSubscription(t *testing.T) {
+	text := "App Name\tSubscription Name\tActive Standard Price Subscriptions\tActive Free Trial Introductory Offer Subscriptions\tBilling Retry\tSubscribers\n" +
+		"My App\tPro\t1,200\t30\t5\t1235\n"
+
+	report, err := ParseSubscription(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("ParseSubscription() error: %v", err)
+	}
+	row := report.Rows[0]
+	if row.ActiveSubscriptions() != 1230 || row.BillingRetry != 5 || row.Subscribers != 1235 {
+		t.Fatalf("unexpected row %+v", row)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	if _, err := ParseSales(strings.NewReader("\n\n")); err == nil || !strings.Contains(err.Error(), "report is empty") {
+		t.Fatalf("expected empty report error, got %v", err)
+	}
+	if _, err := ParseSales(strings.NewReader("foo\tbar\n1\t2\n")); err == nil || !strings.Contains(err.Error(), "does not look like a SALES report") {
+		t.Fatalf("expected header error, got %v", err)
+	}
+	if _, err := ParseSales(strings.NewReader("SKU\tUnits\nabc\tlots\n")); err == nil || !strings.Contains(err.Error(), `line 2, column "Units": invalid number "lots"`) {
+		t.Fatalf("expected number error, got %v", err)
+	}
+}
+
+func TestParseLenientKeepsInvalidCells(t *testing.T) {
+	report, err := ParseSales(strings.NewReader("SKU\tUnits\tBegin Date\nabc\tlots\tsoon\ndef\t3\t01/02/2026\n"), Lenient())
+	if err != nil {
+		t.Fatalf("ParseSales() error: %v", err)
+	}
+	if len(report.Rows) != 2 {
+		t.Fatalf("expected both rows, got %+v", report.Rows)
+	}
+	invalid := report.Rows[0]
+	if invalid.Units != 0 || invalid.BeginDate != "" || !reflect.DeepEqual(invalid.Extra, map[string]string{"Units": "lots", "Begin Date": "soon"}) {
+		t.Fatalf("expected invalid cells in Extra, got %+v", invalid)
+	}
+	if report.Rows[1].Units != 3 || report.Rows[1].BeginDate != "2026-01-02" {
+		t.Fatalf("unexpected valid row %+v", report.Rows[1])
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	rows := []SubscriptionEventRow{{EventDate: "2026-01-02", Event: "Subscribe", Quantity: 1.5}}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, rows); err != nil {
+		t.Fatalf("WriteCSV() error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected header and one row, got %q", buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "eventDate,event,appName,") || strings.Contains(lines[0], "extra") {
+		t.Fatalf("unexpected header %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "2026-01-02,Subscribe,") || !strings.HasSuffix(lines[1], ",1.5") {
+		t.Fatalf("unexpected row %q", lines[1])
+	}
+}
@@ -0,0 +1,185 @@
+package reports
+
+// Row fields are matched to report columns through `report` tags: one or more
+// header names separated by "|" (compared ignoring case, spaces, and
+// punctuation), optionally followed by ",date". float64 fields are parsed as
+// numbers and date fields are normalized to YYYY-MM-DD. Columns without a
+// field are kept in Extra so newer report versions lose nothing.
+
+// SalesRow is one line of a SALES report.
+type SalesRow struct {
+	Provider              string            `json:"provider" report:"Provider"`
+	ProviderCountry       string            `json:"providerCountry" report:"Provider Country"`
+	SKU                   string            `json:"sku" report:"SKU"`
+	Developer             string            `json:"developer" report:"Developer"`
+	Title                 string            `json:"title" report:"Title"`
+	Version               string            `json:"version" report:"Version"`
+	ProductTypeIdentifier string            `json:"productTypeIdentifier" report:"Product Type Identifier"`
+	ProductType           string            `json:"productType"`
+	ProductCategory       string            `json:"productCategory"`
+	Units                 float64           `json:"units" report:"Units"`
+	DeveloperProceeds     float64           `json:"developerProceeds" report:"Developer Proceeds"`
+	BeginDate             string            `json:"beginDate" report:"Begin Date,date"`
+	EndDate               string            `json:"endDate" report:"End Date,date"`
+	CustomerCurrency      string            `json:"customerCurrency" report:"Customer Currency"`
+	CountryCode           string            `json:"countryCode" report:"Country Code"`
+	ProceedsCurrency      string            `json:"proceedsCurrency" report:"Currency of Proceeds"`
+	AppleIdentifier       string            `json:"appleIdentifier" report:"Apple Identifier"`
+	CustomerPrice         float64           `json:"customerPrice" report:"Customer Price"`
+	PromoCode             string            `json:"promoCode" report:"Promo Code"`
+	ParentIdentifier      string            `json:"parentIdentifier" report:"Parent Identifier"`
+	Subscription          string            `json:"subscription" report:"Subscription"`
+	Period                string            `json:"period" report:"Period"`
+	Category              string            `json:"category" report:"Category"`
+	CMB                   string            `json:"cmb" report:"CMB"`
+	Device                string            `json:"device" report:"Device"`
+	SupportedPlatforms    string            `json:"supportedPlatforms" report:"Supported Platforms"`
+	ProceedsReason        string            `json:"proceedsReason" report:"Proceeds Reason"`
+	PreservedPricing      string            `json:"preservedPricing" report:"Preserved Pricing"`
+	Client                string            `json:"client" report:"Client"`
+	OrderType             string            `json:"orderType" report:"Order Type"`
+	Extra                 map[string]string `json:"extra,omitempty"`
+}
+
+func (r *SalesRow) normalize() {
+	r.ProductType, r.ProductCategory = DecodeProductType(r.ProductTypeIdentifier)
+}
+
+// SubscriptionRow is one line of a SUBSCRIPTION report (active subscription
+// counts by offer type).
+type SubscriptionRow struct {
+	AppName                                 string            `json:"appName" report:"App Name"`
+	AppAppleID                              string            `json:"appAppleId" report:"App Apple ID"`
+	SubscriptionName                        string            `json:"subscriptionName" report:"Subscription Name"`
+	SubscriptionAppleID                     string            `json:"subscriptionAppleId" report:"Subscription Apple ID"`
+	SubscriptionGroupID                     string            `json:"subscriptionGroupId" report:"Subscription Group ID"`
+	StandardSubscriptionDuration            string            `json:"standardSubscriptionDuration" report:"Standard Subscription Duration"`
+	SubscriptionOfferName                   string            `json:"subscriptionOfferName" report:"Subscription Offer Name"`
+	PromotionalOfferID                      string            `json:"promotionalOfferId" report:"Promotional Offer ID"`
+	CustomerPrice                           float64           `json:"customerPrice" report:"Customer Price"`
+	CustomerCurrency                        string            `json:"customerCurrency" report:"Customer Currency"`
+	DeveloperProceeds                       float64           `json:"developerProceeds" report:"Developer Proceeds"`
+	ProceedsCurrency                        string            `json:"proceedsCurrency" report:"Proceeds Currency"`
+	PreservedPricing                        string            `json:"preservedPricing" report:"Preserved Pricing"`
+	ProceedsReason                          string            `json:"proceedsReason" report:"Proceeds Reason"`
+	Client                                  string            `json:"client" report:"Client"`
+	Device                                  string            `json:"device" report:"Device"`
+	State                                   string            `json:"state" report:"State"`
+	Country                                 string            `json:"country" report:"Country"`
+	ActiveStandardPriceSubscriptions        float64           `json:"activeStandardPriceSubscriptions" report:"Active Standard Price Subscriptions"`
+	ActiveFreeTrialIntroductoryOffers       float64           `json:"activeFreeTrialIntroductoryOffers" report:"Active Free Trial Introductory Offer Subscriptions"`
+	ActivePayUpFrontIntroductoryOffers      float64           `json:"activePayUpFrontIntroductoryOffers" report:"Active Pay Up Front Introductory Offer Subscriptions"`
+	ActivePayAsYouGoIntroductoryOffers      float64           `json:"activePayAsYouGoIntroductoryOffers" report:"Active Pay As You Go Introductory Offer Subscriptions"`
+	FreeTrialPromotionalOfferSubscriptions  float64           `json:"freeTrialPromotionalOfferSubscriptions" report:"Free Trial Promotional Offer Subscriptions"`
+	PayUpFrontPromotionalOfferSubscriptions float64           `json:"payUpFrontPromotionalOfferSubscriptions" report:"Pay Up Front Promotional Offer Subscriptions"`
+	PayAsYouGoPromotionalOfferSubscriptions float64           `json:"payAsYouGoPromotionalOfferSubscriptions" report:"Pay As You Go Promotional Offer Subscriptions"`
+	FreeTrialOfferCodeSubscriptions         float64           `json:"freeTrialOfferCodeSubscriptions" report:"Free Trial Offer Code Subscriptions"`
+	PayUpFrontOfferCodeSubscriptions        float64           `json:"payUpFrontOfferCodeSubscriptions" report:"Pay Up Front Offer Code Subscriptions"`
+	PayAsYouGoOfferCodeSubscriptions        float64           `json:"payAsYouGoOfferCodeSubscriptions" report:"Pay As You Go Offer Code Subscriptions"`
+	FreeTrialWinBackOfferSubscriptions      float64           `json:"freeTrialWinBackOfferSubscriptions" report:"Free Trial Win-Back Offer Subscriptions"`
+	PayUpFrontWinBackOfferSubscriptions     float64           `json:"payUpFrontWinBackOfferSubscriptions" report:"Pay Up Front Win-Back Offer Subscriptions"`
+	PayAsYouGoWinBackOfferSubscriptions     float64           `json:"payAsYouGoWinBackOfferSubscriptions" report:"Pay As You Go Win-Back Offer Subscriptions"`
+	MarketingOptIns                         float64           `json:"marketingOptIns" report:"Marketing Opt-Ins"`
+	BillingRetry                            float64           `json:"billingRetry" report:"Billing Retry"`
+	GracePeriod                             float64           `json:"gracePeriod" report:"Grace Period"`
+	Subscribers                             float64           `json:"subscribers" report:"Subscribers"`
+	Extra                                   map[string]string `json:"extra,omitempty"`
+}
+
+// ActiveSubscriptions is the number of active paid and introductory
+// subscriptions on the row, excluding billing retry and grace period.
+func (r SubscriptionRow) ActiveSubscriptions() float64 {
+	return r.ActiveStandardPriceSubscriptions +
+		r.ActiveFreeTrialIntroductoryOffers +
+		r.ActivePayUpFrontIntroductoryOffers +
+		r.ActivePayAsYouGoIntroductoryOffers +
+		r.FreeTrialPromotionalOfferSubscriptions +
+		r.PayUpFrontPromotionalOfferSubscriptions +
+		r.PayAsYouGoPromotionalOfferSubscriptions +
+		r.FreeTrialOfferCodeSubscriptions +
+		r.PayUpFrontOfferCodeSubscriptions +
+		r.PayAsYouGoOfferCodeSubscriptions +
+		r.FreeTrialWinBackOfferSubscriptions +
+		r.PayUpFrontWinBackOfferSubscriptions +
+		r.PayAsYouGoWinBackOfferSubscriptions
+}
+
+// SubscriptionEventRow is one line of a SUBSCRIPTION_EVENT report.
+type SubscriptionEventRow struct {
+	EventDate                    string            `json:"eventDate" report:"Event Date,date"`
+	Event                        string            `json:"event" report:"Event"`
+	AppName                      string            `json:"appName" report:"App Name"`
+	AppAppleID                   string            `json:"appAppleId" report:"App Apple ID"`
+	SubscriptionName             string            `json:"subscriptionName" report:"Subscription Name"`
+	SubscriptionAppleID          string            `json:"subscriptionAppleId" report:"Subscription Apple ID"`
+	SubscriptionGroupID          string            `json:"subscriptionGroupId" report:"Subscription Group ID"`
+	StandardSubscriptionDuration string            `json:"standardSubscriptionDuration" report:"Standard Subscription Duration"`
+	SubscriptionOfferType        string            `json:"subscriptionOfferType" report:"Subscription Offer Type"`
+	SubscriptionOfferDuration    string            `json:"subscriptionOfferDuration" report:"Subscription Offer Duration"`
+	MarketingOptIn               string            `json:"marketingOptIn" report:"Marketing Opt-In"`
+	MarketingOptInDuration       string            `json:"marketingOptInDuration" report:"Marketing Opt-In Duration"`
+	PreservedPricing             string            `json:"preservedPricing" report:"Preserved Pricing"`
+	ProceedsReason               string            `json:"proceedsReason" report:"Proceeds Reason"`
+	PromotionalOfferName         string            `json:"promotionalOfferName" report:"Promotional Offer Name"`
+	PromotionalOfferID           string            `json:"promotionalOfferId" report:"Promotional Offer ID"`
+	SubscriptionOfferName        string            `json:"subscriptionOfferName" report:"Subscription Offer Name|Offer Code Name"`
+	ConsecutivePaidPeriods       float64           `json:"consecutivePaidPeriods" report:"Consecutive Paid Periods"`
+	OriginalStartDate            string            `json:"originalStartDate" report:"Original Start Date,date"`
+	Device                       string            `json:"device" report:"Device"`
+	Client                       string            `json:"client" report:"Client"`
+	State                        string            `json:"state" report:"State"`
+	Country                      string            `json:"country" report:"Country"`
+	PreviousSubscriptionName     string            `json:"previousSubscriptionName" report:"Previous Subscription Name"`
+	PreviousSubscriptionAppleID  string            `json:"previousSubscriptionAppleId" report:"Previous Subscription Apple ID"`
+	DaysBeforeCanceling          float64           `json:"daysBeforeCanceling" report:"Days Before Canceling"`
+	CancellationReason           string            `json:"cancellationReason" report:"Cancellation Reason"`
+	DaysCanceled                 float64           `json:"daysCanceled" report:"Days Canceled"`
+	Quantity                     float64           `json:"quantity" report:"Quantity"`
+	Extra                        map[string]string `json:"extra,omitempty"`
+}
+
+// FinancialRow is one line of a FINANCIAL or FINANCE_DETAIL report. Columns
+// that only one of the two layouts has are left empty for the other.
+type FinancialRow struct {
+	StartDate             string            `json:"startDate" report:"Start Date,date"`
+	EndDate               string            `json:"endDate" report:"End Date,date"`
+	TransactionDate       string            `json:"transactionDate" report:"Transaction Date,date"`
+	SettlementDate        string            `json:"settlementDate" report:"Settlement Date,date"`
+	UPC                   string            `json:"upc" report:"UPC"`
+	ISRC                  string            `json:"isrc" report:"ISRC/ISBN|ISRC"`
+	VendorIdentifier      string            `json:"vendorIdentifier" report:"Vendor Identifier|SKU"`
+	Quantity              float64           `json:"quantity" report:"Quantity"`
+	PartnerShare          float64           `json:"partnerShare" report:"Partner Share"`
+	ExtendedPartnerShare  float64           `json:"extendedPartnerShare" report:"Extended Partner Share"`
+	PartnerShareCurrency  string            `json:"partnerShareCurrency" report:"Partner Share Currency"`
+	SalesOrReturn         string            `json:"salesOrReturn" report:"Sales or Return|Sale or Return"`
+	AppleIdentifier       string            `json:"appleIdentifier" report:"Apple Identifier"`
+	Developer             string            `json:"developer" report:"Artist/Show/Developer/Author|Developer Name"`
+	Title                 string            `json:"title" report:"Title"`
+	Label                 string            `json:"label" report:"Label/Studio/Network/Developer/Publisher"`
+	Grid                  string            `json:"grid" report:"Grid"`
+	ProductTypeIdentifier string            `json:"productTypeIdentifier" report:"Product Type Identifier"`
+	ProductType           string            `json:"productType"`
+	ProductCategory       string            `json:"productCategory"`
+	ISAN                  string            `json:"isan" report:"ISAN/Other Identifier"`
+	CountryOfSale         string            `json:"countryOfSale" report:"Country Of Sale"`
+	PreOrderFlag          string            `json:"preOrderFlag" report:"Pre-order Flag"`
+	PromoCode             string            `json:"promoCode" report:"Promo Code"`
+	CustomerPrice         float64           `json:"customerPrice" report:"Customer Price"`
+	CustomerCurrency      string            `json:"customerCurrency" report:"Customer Currency"`
+	OrderType             string            `json:"orderType" report:"Order Type"`
+	Region                string            `json:"region" report:"Region"`
+	ParentIdentifier      string            `json:"parentIdentifier" report:"Parent Identifier"`
+	ExchangeRate          float64           `json:"exchangeRate" report:"Exchange Rate"`
+	PaymentCurrency       string            `json:"paymentCurrency" report:"Payment Currency|Bank Currency"`
+	Extra                 map[string]string `json:"extra,omitempty"`
+}
+
+func (r *FinancialRow) normalize() {
+	r.ProductType, r.ProductCategory = DecodeProductType(r.ProductTypeIdentifier)
+}
+
+// IsReturn reports whether the row is a refund ("R" in Sales or Return).
+func (r FinancialRow) IsReturn() bool {
+	return r.SalesOrReturn == "R"
+}
@@ -0,0 +1,746 @@
+package warehouse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Result is the output of a query.
+type Result struct {
+	Table   string
+	Columns []string
+	Rows    [][]any
+}
+
+// MarshalJSON renders rows as objects whose keys keep the column order.
+func (r *Result) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(`{"table":`)
+	if err := writeJSON(&buf, r.Table); err != nil {
+		return nil, err
+	}
+	buf.WriteString(`,"columns":`)
+	if err := writeJSON(&buf, r.Columns); err != nil {
+		return nil, err
+	}
+	buf.WriteString(`,"rows":[`)
+	for i, row := range r.Rows {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('{')
+		for j, column := range r.Columns {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(&buf, column); err != nil {
+				return nil, err
+			}
+			buf.WriteByte(':')
+			if err := writeJSON(&buf, row[j]); err != nil {
+				return nil, err
+			}
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteString("]}")
+	return buf.Bytes(), nil
+}
+
+func writeJSON(buf *bytes.Buffer, value any) error {
+	if number, ok := value.(float64); ok && (math.IsNaN(number) || math.IsInf(number, 0)) {
+		value = nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	buf.Write(encoded)
+	return nil
+}
+
+// FormatValue renders a result value for table output.
+func FormatValue(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		if typed == math.Trunc(typed) && math.Abs(typed) < 1e15 {
+			return strconv.FormatFloat(typed, 'f', 0, 64)
+		}
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case bool:
+		if typed {
+			return "1"
+		}
+		return "0"
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+// Query parses and runs a SELECT statement against the store.
+func (s *Store) Query(query string) (*Result, error) {
+	stmt, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	table, err := s.Load(stmt.table)
+	if err != nil {
+		return nil, err
+	}
+	return execute(stmt, table)
+}
+
+type evalContext struct {
+	table *Table
+	row   []any
+	group [][]any
+}
+
+type outputRow struct {
+	values []any
+	keys   []any
+}
+
+func execute(stmt *selectStatement, table *Table) (*Result, error) {
+	result := &Result{Table: stmt.table}
+
+	var items []selectItem
+	for _, item := range stmt.items {
+		if !item.star {
+			items = append(items, item)
+			continue
+		}
+		for _, column := range table.Columns {
+			items = append(items, selectItem{expr: columnExpr{name: column.Name}, name: column.Name})
+		}
+	}
+	result.Columns = uniqueColumnNames(items)
+
+	if stmt.where != nil && containsAggregate(stmt.where) {
+		return nil, fmt.Errorf("aggregate functions are not allowed in WHERE (use HAVING)")
+	}
+	rows := make([][]any, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		if stmt.where != nil {
+			value, err := eval(stmt.where, evalContext{table: table, row: row})
+			if err != nil {
+				return nil, err
+			}
+			if !truthy(value) {
+				continue
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	grouped := len(stmt.groupBy) > 0 || stmt.having != nil
+	for _, item := range items {
+		grouped = grouped || containsAggregate(item.expr)
+	}
+	for _, item := range stmt.orderBy {
+		grouped = grouped || containsAggregate(item.expr)
+	}
+	for _, item := range stmt.items {
+		if item.star && grouped {
+			return nil, fmt.Errorf("SELECT * cannot be combined with GROUP BY or aggregate functions")
+		}
+	}
+
+	var contexts []evalContext
+	if grouped {
+		groups, err := groupRows(stmt.groupBy, table, rows)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range groups {
+			ctx := evalContext{table: table, group: group}
+			if len(group) > 0 {
+				ctx.row = group[0]
+			} else {
+				ctx.row = make([]any, len(table.Columns))
+			}
+			if stmt.having != nil {
+				value, err := eval(stmt.having, ctx)
+				if err != nil {
+					return nil, err
+				}
+				if !truthy(value) {
+					continue
+				}
+			}
+			contexts = append(contexts, ctx)
+		}
+	} else {
+		for _, row := range rows {
+			contexts = append(contexts, evalContext{table: table, row: row})
+		}
+	}
+
+	outputs := make([]outputRow, 0, len(contexts))
+	seen := map[string]bool{}
+	for _, ctx := range contexts {
+		out := outputRow{values: make([]any, len(items))}
+		for i, item := range items {
+			value, err := eval(item.expr, ctx)
+			if err != nil {
+				return nil, err
+			}
+			out.values[i] = value
+		}
+		if stmt.distinct {
+			key := valuesKey(out.values)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		for _, order := range stmt.orderBy {
+			value, err := orderValue(order.expr, items, out.values, ctx)
+			if err != nil {
+				return nil, err
+			}
+			out.keys = append(out.keys, value)
+		}
+		outputs = append(outputs, out)
+	}
+
+	if len(stmt.orderBy) > 0 {
+		sort.SliceStable(outputs, func(i, j int) bool {
+			for k, order := range stmt.orderBy {
+				cmp := compareForSort(outputs[i].keys[k], outputs[j].keys[k])
+				if cmp == 0 {
+					continue
+				}
+				if order.desc {
+					return cmp > 0
+				}
+				return cmp < 0
+			}
+			return false
+		})
+	}
+	if stmt.limit >= 0 && len(outputs) > stmt.limit {
+		outputs = outputs[:stmt.limit]
+	}
+
+	result.Rows = make([][]any, 0, len(outputs))
+	for _, out := range outputs {
+		result.Rows = append(result.Rows, out.values)
+	}
+	return result, nil
+}
+
+func uniqueColumnNames(items []selectItem) []string {
+	names := make([]string, 0, len(items))
+	used := map[string]bool{}
+	for _, item := range items {
+		name := item.name
+		for n := 2; used[name]; n++ {
+			name = fmt.Sprintf("%s_%d", item.name, n)
+		}
+		used[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// groupRows buckets rows by the GROUP BY values in first-seen order. Without
+// GROUP BY all rows form one group, even when there are none.
+func groupRows(groupBy []expr, table *Table, rows [][]any) ([][][]any, error) {
+	if len(groupBy) == 0 {
+		return [][][]any{rows}, nil
+	}
+	for _, item := range groupBy {
+		if containsAggregate(item) {
+			return nil, fmt.Errorf("aggregate functions are not allowed in GROUP BY")
+		}
+	}
+
+	index := map[string]int{}
+	var groups [][][]any
+	for _, row := range rows {
+		values := make([]any, len(groupBy))
+		for i, item := range groupBy {
+			value, err := eval(item, evalContext{table: table, row: row})
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		key := valuesKey(values)
+		position, ok := index[key]
+		if !ok {
+			position = len(groups)
+			index[key] = position
+			groups = append(groups, nil)
+		}
+		groups[position] = append(groups[position], row)
+	}
+	return groups, nil
+}
+
+// orderValue resolves an ORDER BY item: a select alias or output column name,
+// a 1-based column position, or an expression.
+func orderValue(node expr, items []selectItem, values []any, ctx evalContext) (any, error) {
+	switch typed := node.(type) {
+	case columnExpr:
+		for i, item := range items {
+			if item.alias && strings.EqualFold(item.name, typed.name) {
+				return values[i], nil
+			}
+		}
+		if ctx.table.ColumnIndex(typed.name) < 0 {
+			for i, item := range items {
+				if strings.EqualFold(item.name, typed.name) {
+					return values[i], nil
+				}
+			}
+		}
+	case literalExpr:
+		if position, ok := typed.value.(float64); ok {
+			if position < 1 || int(position) > len(values) || position != math.Trunc(position) {
+				return nil, fmt.Errorf("ORDER BY position %v is out of range", position)
+			}
+			return values[int(position)-1], nil
+		}
+	}
+	return eval(node, ctx)
+}
+
+func valuesKey(values []any) string {
+	var builder strings.Builder
+	for _, value := range values {
+		switch typed := value.(type) {
+		case nil:
+			builder.WriteString("n;")
+		case float64:
+			builder.WriteString("f" + strconv.FormatFloat(typed, 'g', -1, 64) + ";")
+		case bool:
+			builder.WriteString("b" + strconv.FormatBool(typed) + ";")
+		default:
+			text := fmt.Sprint(typed)
+			builder.WriteString("s" + strconv.Itoa(len(text)) + ":" + text + ";")
+		}
+	}
+	return builder.String()
+}
+
+func eval(node expr, ctx evalContext) (any, error) {
+	switch typed := node.(type) {
+	case literalExpr:
+		return typed.value, nil
+	case columnExpr:
+		index := ctx.table.ColumnIndex(typed.name)
+		if index < 0 {
+			return nil, unknownColumnError(typed.name, ctx.table)
+		}
+		return ctx.row[index], nil
+	case unaryExpr:
+		operand, err := eval(typed.operand, ctx)
+		if err != nil || operand == nil {
+			return nil, err
+		}
+		if typed.op == "not" {
+			return !truthy(operand), nil
+		}
+		number, ok := toNumber(operand)
+		if !ok {
+			return nil, nil
+		}
+		return -number, nil
+	case binaryExpr:
+		return evalBinary(typed, ctx)
+	case inExpr:
+		operand, err := eval(typed.operand, ctx)
+		if err != nil || operand == nil {
+			return nil, err
+		}
+		found := false
+		for _, item := range typed.list {
+			value, err := eval(item, ctx)
+			if err != nil {
+				return nil, err
+			}
+			if cmp, ok := compareValues(operand, value); ok && cmp == 0 {
+				found = true
+				break
+			}
+		}
+		return found != typed.negate, nil
+	case betweenExpr:
+		operand, err := eval(typed.operand, ctx)
+		if err != nil {
+			return nil, err
+		}
+		low, err := eval(typed.low, ctx)
+		if err != nil {
+			return nil, err
+		}
+		high, err := eval(typed.high, ctx)
+		if err != nil {
+			return nil, err
+		}
+		lowCmp, lowOK := compareValues(operand, low)
+		highCmp, highOK := compareValues(operand, high)
+		if !lowOK || !highOK {
+			return nil, nil
+		}
+		return (lowCmp >= 0 && highCmp <= 0) != typed.negate, nil
+	case isNullExpr:
+		operand, err := eval(typed.operand, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return (operand == nil) != typed.negate, nil
+	case callExpr:
+		if aggregateFunctions[typed.name] {
+			return evalAggregate(typed, ctx)
+		}
+		return evalScalar(typed, ctx)
+	default:
+		return nil, fmt.Errorf("unsupported expression %T", node)
+	}
+}
+
+func unknownColumnError(name string, table *Table) error {
+	names := make([]string, 0, len(table.Columns))
+	for _, column := range table.Columns {
+		names = append(names, column.Name)
+	}
+	return fmt.Errorf("unknown column %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+func evalBinary(node binaryExpr, ctx evalContext) (any, error) {
+	left, err := eval(node.left, ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch node.op {
+	case "and":
+		if left != nil && !truthy(left) {
+			return false, nil
+		}
+	case "or":
+		if truthy(left) {
+			return true, nil
+		}
+	}
+	right, err := eval(node.right, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch node.op {
+	case "and":
+		if right != nil && !truthy(right) {
+			return false, nil
+		}
+		if left == nil || right == nil {
+			return nil, nil
+		}
+		return true, nil
+	case "or":
+		if truthy(right) {
+			return true, nil
+		}
+		if left == nil || right == nil {
+			return nil, nil
+		}
+		return false, nil
+	case "like":
+		if left == nil || right == nil {
+			return nil, nil
+		}
+		return likeMatch(FormatValue(left), FormatValue(right)), nil
+	case "=", "!=", "<", "<=", ">", ">=":
+		cmp, ok := compareValues(left, right)
+		if !ok {
+			return nil, nil
+		}
+		switch node.op {
+		case "=":
+			return cmp == 0, nil
+		case "!=":
+			return cmp != 0, nil
+		case "<":
+			return cmp < 0, nil
+		case "<=":
+			return cmp <= 0, nil
+		case ">":
+			return cmp > 0, nil
+		default:
+			return cmp >= 0, nil
+		}
+	}
+
+	a, aOK := toNumber(left)
+	b, bOK := toNumber(right)
+	if !aOK || !bOK {
+		return nil, nil
+	}
+	switch node.op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	case "/":
+		if b == 0 {
+			return nil, nil
+		}
+		return a / b, nil
+	case "%":
+		if b == 0 {
+			return nil, nil
+		}
+		return math.Mod(a, b), nil
+	}
+	return nil, fmt.Errorf("unsupported operator %s", node.op)
+}
+
+func evalAggregate(call callExpr, ctx evalContext) (any, error) {
+	if ctx.group == nil {
+		return nil, fmt.Errorf("%s() is not allowed here", call.name)
+	}
+	if call.star {
+		return float64(len(ctx.group)), nil
+	}
+
+	var (
+		count    float64
+		sum      float64
+		extreme  any
+		distinct = map[string]bool{}
+	)
+	for _, row := range ctx.group {
+		value, err := eval(call.args[0], evalContext{table: ctx.table, row: row})
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+		switch call.name {
+		case "count":
+			if call.distinct {
+				distinct[valuesKey([]any{value})] = true
+			}
+			count++
+		case "sum", "avg":
+			number, ok := toNumber(value)
+			if !ok {
+				continue
+			}
+			sum += number
+			count++
+		case "min", "max":
+			if extreme == nil {
+				extreme = value
+				continue
+			}
+			cmp := compareForSort(value, extreme)
+			if (call.name == "min" && cmp < 0) || (call.name == "max" && cmp > 0) {
+				extreme = value
+			}
+		}
+	}
+
+	switch call.name {
+	case "count":
+		if call.distinct {
+			return float64(len(distinct)), nil
+		}
+		return count, nil
+	case "sum":
+		if count == 0 {
+			return nil, nil
+		}
+		return sum, nil
+	case "avg":
+		if count == 0 {
+			return nil, nil
+		}
+		return sum / count, nil
+	default:
+		return extreme, nil
+	}
+}
+
+func evalScalar(call callExpr, ctx evalContext) (any, error) {
+	args := make([]any, len(call.args))
+	for i, arg := range call.args {
+		value, err := eval(arg, ctx)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = value
+	}
+
+	if call.name == "coalesce" {
+		for _, value := range args {
+			if value != nil {
+				return value, nil
+			}
+		}
+		return nil, nil
+	}
+	if args[0] == nil {
+		return nil, nil
+	}
+
+	switch call.name {
+	case "lower":
+		return strings.ToLower(FormatValue(args[0])), nil
+	case "upper":
+		return strings.ToUpper(FormatValue(args[0])), nil
+	case "length":
+		return float64(len([]rune(FormatValue(args[0])))), nil
+	case "substr":
+		runes := []rune(FormatValue(args[0]))
+		start, ok := toNumber(args[1])
+		if !ok {
+			return nil, nil
+		}
+		from := max(int(start)-1, 0)
+		to := len(runes)
+		if len(args) == 3 {
+			length, ok := toNumber(args[2])
+			if !ok {
+				return nil, nil
+			}
+			to = min(from+max(int(length), 0), len(runes))
+		}
+		if from >= len(runes) {
+			return "", nil
+		}
+		return string(runes[from:to]), nil
+	}
+
+	number, ok := toNumber(args[0])
+	if !ok {
+		return nil, nil
+	}
+	switch call.name {
+	case "abs":
+		return math.Abs(number), nil
+	case "round":
+		digits := 0.0
+		if len(args) == 2 {
+			if digits, ok = toNumber(args[1]); !ok {
+				return nil, nil
+			}
+		}
+		scale := math.Pow(10, math.Trunc(digits))
+		return math.Round(number*scale) / scale, nil
+	}
+	return nil, fmt.Errorf("unknown function %s", call.name)
+}
+
+func truthy(value any) bool {
+	switch typed := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return typed
+	case float64:
+		return typed != 0
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(typed), 64)
+		return err == nil && number != 0
+	default:
+		return false
+	}
+}
+
+func toNumber(value any) (float64, bool) {
+	switch typed := value.(type) {
+	case float64:
+		return typed, true
+	case bool:
+		if typed {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(typed), 64)
+		return number, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// compareValues compares two non-NULL values. Numbers compare numerically,
+// also against numeric strings; everything else compares as text.
+func compareValues(a, b any) (int, bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+	_, aString := a.(string)
+	_, bString := b.(string)
+	if !aString || !bString {
+		if x, ok := toNumber(a); ok {
+			if y, ok := toNumber(b); ok {
+				switch {
+				case x < y:
+					return -1, true
+				case x > y:
+					return 1, true
+				default:
+					return 0, true
+				}
+			}
+		}
+	}
+	return strings.Compare(FormatValue(a), FormatValue(b)), true
+}
+
+// compareForSort orders NULL before every other value.
+func compareForSort(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	cmp, _ := compareValues(a, b)
+	return cmp
+}
+
+// likeMatch implements SQL LIKE with % and _ wildcards, ignoring case.
+func likeMatch(value, pattern string) bool {
+	text := []rune(strings.ToLower(value))
+	glob := []rune(strings.ToLower(pattern))
+	// matches[j] reports whether text[:i] matches glob[:j] for the current i.
+	matches := make([]bool, len(glob)+1)
+	matches[0] = true
+	for j := 1; j <= len(glob) && glob[j-1] == '%'; j++ {
+		matches[j] = true
+	}
+	for i := 1; i <= len(text); i++ {
+		previous := matches[0]
+		matches[0] = false
+		for j := 1; j <= len(glob); j++ {
+			current := matches[j]
+			switch glob[j-1] {
+			case '%':
+				matches[j] = matches[j-1] || matches[j]
+			case '_':
+				matches[j] = previous
+			default:
+				matches[j] = previous && glob[j-1] == text[i-1]
+			}
+			previous = current
+		}
+	}
+	return matches[len(glob)]
+}
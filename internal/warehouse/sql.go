@@ -0,0 +1,702 @@
+package warehouse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The query language is a SELECT-only SQL subset:
+//
+//	SELECT [DISTINCT] * | expr [[AS] alias], ...
+//	FROM table
+//	[WHERE expr]
+//	[GROUP BY expr, ...]
+//	[HAVING expr]
+//	[ORDER BY expr [ASC|DESC], ...]
+//	[LIMIT n]
+//
+// Expressions support column names, 'string' and numeric literals, NULL,
+// arithmetic (+ - * /), comparisons (= != <> < <= > >=), AND/OR/NOT,
+// [NOT] LIKE, [NOT] IN (...), [NOT] BETWEEN, IS [NOT] NULL, the aggregates
+// count, sum, avg, min, max, and the functions lower, upper, length, substr,
+// round, abs, and coalesce.
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenSymbol
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	start int
+	end   int
+}
+
+func (t token) is(keyword string) bool {
+	return t.kind == tokenIdent && strings.EqualFold(t.text, keyword)
+}
+
+func (t token) symbol(value string) bool {
+	return t.kind == tokenSymbol && t.text == value
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(input); {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '-' && i+1 < len(input) && input[i+1] == '-':
+			for i < len(input) && input[i] != '\n' {
+				i++
+			}
+		case isIdentStart(c):
+			start := i
+			for i < len(input) && isIdentPart(input[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: input[start:i], start: start, end: i})
+		case c == '"' || c == '`':
+			start := i
+			end := strings.IndexByte(input[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quoted identifier at position %d", start+1)
+			}
+			i += end + 2
+			tokens = append(tokens, token{kind: tokenIdent, text: input[start+1 : i-1], start: start, end: i})
+		case isDigit(c) || (c == '.' && i+1 < len(input) && isDigit(input[i+1])):
+			start := i
+			for i < len(input) && (isDigit(input[i]) || input[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: input[start:i], start: start, end: i})
+		case c == '\'':
+			start := i
+			var builder strings.Builder
+			i++
+			for {
+				if i >= len(input) {
+					return nil, fmt.Errorf("unterminated string at position %d", start+1)
+				}
+				if input[i] == '\'' {
+					if i+1 < len(input) && input[i+1] == '\'' {
+						builder.WriteByte('\'')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				builder.WriteByte(input[i])
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenString, text: builder.String(), start: start, end: i})
+		default:
+			start := i
+			two := ""
+			if i+1 < len(input) {
+				two = input[i : i+2]
+			}
+			switch {
+			case two == "!=" || two == "<>" || two == "<=" || two == ">=":
+				i += 2
+			case strings.IndexByte("(),*+-/=<>;%", c) >= 0:
+				i++
+			default:
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i+1)
+			}
+			tokens = append(tokens, token{kind: tokenSymbol, text: input[start:i], start: start, end: i})
+		}
+	}
+	tokens = append(tokens, token{kind: tokenEOF, start: len(input), end: len(input)})
+	return tokens, nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// expr is a parsed expression node.
+type expr interface{}
+
+type (
+	literalExpr struct{ value any }
+	columnExpr  struct{ name string }
+	unaryExpr   struct {
+		op      string
+		operand expr
+	}
+	binaryExpr struct {
+		op          string
+		left, right expr
+	}
+	inExpr struct {
+		operand expr
+		list    []expr
+		negate  bool
+	}
+	betweenExpr struct {
+		operand, low, high expr
+		negate             bool
+	}
+	isNullExpr struct {
+		operand expr
+		negate  bool
+	}
+	callExpr struct {
+		name     string
+		args     []expr
+		star     bool
+		distinct bool
+	}
+)
+
+type selectItem struct {
+	expr  expr
+	star  bool
+	name  string
+	alias bool
+}
+
+type orderItem struct {
+	expr expr
+	desc bool
+}
+
+type selectStatement struct {
+	distinct bool
+	items    []selectItem
+	table    string
+	where    expr
+	groupBy  []expr
+	having   expr
+	orderBy  []orderItem
+	limit    int
+}
+
+type parser struct {
+	input  string
+	tokens []token
+	pos    int
+}
+
+func parseQuery(input string) (*selectStatement, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{input: input, tokens: tokens}
+	stmt, err := p.parseSelect()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().symbol(";") {
+		p.next()
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorf(tok, "unexpected %q", tok.text)
+	}
+	return stmt, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) accept(keyword string) bool {
+	if p.peek().is(keyword) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) acceptSymbol(symbol string) bool {
+	if p.peek().symbol(symbol) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(keyword string) error {
+	if !p.accept(keyword) {
+		return p.errorf(p.peek(), "expected %s", strings.ToUpper(keyword))
+	}
+	return nil
+}
+
+func (p *parser) expectSymbol(symbol string) error {
+	if !p.acceptSymbol(symbol) {
+		return p.errorf(p.peek(), "expected %q", symbol)
+	}
+	return nil
+}
+
+func (p *parser) errorf(tok token, format string, args ...any) error {
+	message := fmt.Sprintf(format, args...)
+	if tok.kind == tokenEOF {
+		return fmt.Errorf("%s at end of query", message)
+	}
+	return fmt.Errorf("%s at position %d", message, tok.start+1)
+}
+
+var reservedWords = map[string]bool{
+	"select": true, "distinct": true, "from": true, "where": true, "group": true, "by": true,
+	"having": true, "order": true, "limit": true, "as": true, "and": true, "or": true, "not": true,
+	"asc": true, "desc": true, "like": true, "in": true, "between": true, "is": true, "null": true,
+}
+
+func (p *parser) parseSelect() (*selectStatement, error) {
+	if err := p.expect("select"); err != nil {
+		return nil, err
+	}
+	stmt := &selectStatement{limit: -1}
+	stmt.distinct = p.accept("distinct")
+
+	for {
+		item, err := p.parseSelectItem()
+		if err != nil {
+			return nil, err
+		}
+		stmt.items = append(stmt.items, item)
+		if !p.acceptSymbol(",") {
+			break
+		}
+	}
+
+	if err := p.expect("from"); err != nil {
+		return nil, err
+	}
+	table := p.next()
+	if table.kind != tokenIdent {
+		return nil, p.errorf(table, "expected table name")
+	}
+	stmt.table = strings.ToLower(table.text)
+
+	var err error
+	if p.accept("where") {
+		if stmt.where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.accept("group") {
+		if err := p.expect("by"); err != nil {
+			return nil, err
+		}
+		if stmt.groupBy, err = p.parseExprList(); err != nil {
+			return nil, err
+		}
+	}
+	if p.accept("having") {
+		if stmt.having, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.accept("order") {
+		if err := p.expect("by"); err != nil {
+			return nil, err
+		}
+		for {
+			value, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			item := orderItem{expr: value}
+			if p.accept("desc") {
+				item.desc = true
+			} else {
+				p.accept("asc")
+			}
+			stmt.orderBy = append(stmt.orderBy, item)
+			if !p.acceptSymbol(",") {
+				break
+			}
+		}
+	}
+	if p.accept("limit") {
+		tok := p.next()
+		limit, convErr := strconv.Atoi(tok.text)
+		if tok.kind != tokenNumber || convErr != nil || limit < 0 {
+			return nil, p.errorf(tok, "LIMIT expects a non-negative integer")
+		}
+		stmt.limit = limit
+	}
+	return stmt, nil
+}
+
+func (p *parser) parseSelectItem() (selectItem, error) {
+	if p.acceptSymbol("*") {
+		return selectItem{star: true}, nil
+	}
+	start := p.peek().start
+	value, err := p.parseExpr()
+	if err != nil {
+		return selectItem{}, err
+	}
+	item := selectItem{expr: value, name: strings.TrimSpace(p.input[start:p.tokens[p.pos-1].end])}
+	if column, ok := value.(columnExpr); ok {
+		item.name = column.name
+	}
+	if p.accept("as") || (p.peek().kind == tokenIdent && !reservedWords[strings.ToLower(p.peek().text)]) {
+		alias := p.next()
+		if alias.kind != tokenIdent {
+			return selectItem{}, p.errorf(alias, "expected alias")
+		}
+		item.name = alias.text
+		item.alias = true
+	}
+	return item, nil
+}
+
+func (p *parser) parseExprList() ([]expr, error) {
+	var list []expr
+	for {
+		value, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+		if !p.acceptSymbol(",") {
+			return list, nil
+		}
+	}
+}
+
+func (p *parser) parseExpr() (expr, error) {
+	return p.parseOr()
+}
+
+func (p *parser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (expr, error) {
+	if p.accept("not") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return unaryExpr{op: "not", operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (expr, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	if tok.kind == tokenSymbol {
+		switch tok.text {
+		case "=", "!=", "<>", "<", "<=", ">", ">=":
+			p.next()
+			right, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			op := tok.text
+			if op == "<>" {
+				op = "!="
+			}
+			return binaryExpr{op: op, left: left, right: right}, nil
+		}
+	}
+
+	if p.accept("is") {
+		negate := p.accept("not")
+		if err := p.expect("null"); err != nil {
+			return nil, err
+		}
+		return isNullExpr{operand: left, negate: negate}, nil
+	}
+
+	negate := false
+	if p.peek().is("not") {
+		following := p.tokens[p.pos+1]
+		if following.is("like") || following.is("in") || following.is("between") {
+			p.next()
+			negate = true
+		}
+	}
+	switch {
+	case p.accept("like"):
+		pattern, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		var result expr = binaryExpr{op: "like", left: left, right: pattern}
+		if negate {
+			result = unaryExpr{op: "not", operand: result}
+		}
+		return result, nil
+	case p.accept("in"):
+		if err := p.expectSymbol("("); err != nil {
+			return nil, err
+		}
+		list, err := p.parseExprList()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+		return inExpr{operand: left, list: list, negate: negate}, nil
+	case p.accept("between"):
+		low, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("and"); err != nil {
+			return nil, err
+		}
+		high, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return betweenExpr{operand: left, low: low, high: high, negate: negate}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseAdditive() (expr, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().symbol("+") || p.peek().symbol("-") {
+		op := p.next().text
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseMultiplicative() (expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().symbol("*") || p.peek().symbol("/") || p.peek().symbol("%") {
+		op := p.next().text
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	if p.acceptSymbol("-") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryExpr{op: "-", operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, p.errorf(tok, "invalid number %q", tok.text)
+		}
+		return literalExpr{value: value}, nil
+	case tokenString:
+		return literalExpr{value: tok.text}, nil
+	case tokenSymbol:
+		if tok.text == "(" {
+			inner, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectSymbol(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		}
+		return nil, p.errorf(tok, "unexpected %q", tok.text)
+	case tokenIdent:
+		if tok.is("null") {
+			return literalExpr{value: nil}, nil
+		}
+		quoted := p.input[tok.start] == '"' || p.input[tok.start] == '`'
+		if !quoted && p.peek().symbol("(") {
+			return p.parseCall(tok)
+		}
+		if !quoted && reservedWords[strings.ToLower(tok.text)] {
+			return nil, p.errorf(tok, "unexpected %s", strings.ToUpper(tok.text))
+		}
+		return columnExpr{name: tok.text}, nil
+	default:
+		return nil, p.errorf(tok, "unexpected end of query")
+	}
+}
+
+func (p *parser) parseCall(name token) (expr, error) {
+	call := callExpr{name: strings.ToLower(name.text)}
+	if !isFunctionName(call.name) {
+		return nil, p.errorf(name, "unknown function %s", name.text)
+	}
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	switch {
+	case p.acceptSymbol("*"):
+		if call.name != "count" {
+			return nil, p.errorf(name, "%s(*) is not supported", call.name)
+		}
+		call.star = true
+	case p.peek().symbol(")"):
+	default:
+		call.distinct = p.accept("distinct")
+		args, err := p.parseExprList()
+		if err != nil {
+			return nil, err
+		}
+		call.args = args
+	}
+	if err := p.expectSymbol(")"); err != nil {
+		return nil, err
+	}
+	if err := validateCall(call); err != nil {
+		return nil, p.errorf(name, "%v", err)
+	}
+	return call, nil
+}
+
+var aggregateFunctions = map[string]bool{"count": true, "sum": true, "avg": true, "min": true, "max": true}
+
+var scalarFunctionArity = map[string][2]int{
+	"lower":    {1, 1},
+	"upper":    {1, 1},
+	"length":   {1, 1},
+	"abs":      {1, 1},
+	"round":    {1, 2},
+	"substr":   {2, 3},
+	"coalesce": {1, -1},
+}
+
+func isFunctionName(name string) bool {
+	name = strings.ToLower(name)
+	_, scalar := scalarFunctionArity[name]
+	return aggregateFunctions[name] || scalar
+}
+
+func validateCall(call callExpr) error {
+	if aggregateFunctions[call.name] {
+		if !call.star && len(call.args) != 1 {
+			return fmt.Errorf("%s expects one argument", call.name)
+		}
+		if call.distinct && call.name != "count" {
+			return fmt.Errorf("DISTINCT is only supported in count()")
+		}
+		for _, arg := range call.args {
+			if containsAggregate(arg) {
+				return fmt.Errorf("aggregate functions cannot be nested")
+			}
+		}
+		return nil
+	}
+	if call.distinct {
+		return fmt.Errorf("DISTINCT is only supported in count()")
+	}
+	arity := scalarFunctionArity[call.name]
+	if len(call.args) < arity[0] || (arity[1] >= 0 && len(call.args) > arity[1]) {
+		return fmt.Errorf("wrong number of arguments to %s", call.name)
+	}
+	return nil
+}
+
+func containsAggregate(node expr) bool {
+	switch typed := node.(type) {
+	case callExpr:
+		if aggregateFunctions[typed.name] {
+			return true
+		}
+		for _, arg := range typed.args {
+			if containsAggregate(arg) {
+				return true
+			}
+		}
+	case unaryExpr:
+		return containsAggregate(typed.operand)
+	case binaryExpr:
+		return containsAggregate(typed.left) || containsAggregate(typed.right)
+	case inExpr:
+		if containsAggregate(typed.operand) {
+			return true
+		}
+		for _, item := range typed.list {
+			if containsAggregate(item) {
+				return true
+			}
+		}
+	case betweenExpr:
+		return containsAggregate(typed.operand) || containsAggregate(typed.low) || containsAggregate(typed.high)
+	case isNullExpr:
+		return containsAggregate(typed.operand)
+	}
+	return false
+}
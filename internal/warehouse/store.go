@@ -0,0 +1,289 @@
+// Package warehouse is a small embedded store for report and review data
+// with a SQL-like query language, so questions can be answered offline.
+//
+// A warehouse is a directory holding one sub-directory per table. Each table
+// is split into partitions (one JSON file per downloaded report or source),
+// so re-syncing a source replaces its rows instead of duplicating them.
+package warehouse
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	dirName            = "warehouse"
+	partitionExtension = ".json"
+)
+
+// ColumnType is the value type of a column.
+type ColumnType string
+
+const (
+	TypeText   ColumnType = "text"
+	TypeNumber ColumnType = "number"
+)
+
+// Column describes one table column.
+type Column struct {
+	Name        string     `json:"name"`
+	Type        ColumnType `json:"type"`
+	Description string     `json:"description,omitempty"`
+}
+
+// Table is an in-memory table. Values are string, float64, or nil.
+type Table struct {
+	Columns []Column `json:"columns"`
+	Rows    [][]any  `json:"rows"`
+}
+
+// ColumnIndex returns the index of the named column (case-insensitive), or -1.
+func (t *Table) ColumnIndex(name string) int {
+	for i, column := range t.Columns {
+		if strings.EqualFold(column.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// partition is the on-disk form of a table partition.
+type partition struct {
+	Source   string `json:"source"`
+	SyncedAt string `json:"syncedAt"`
+	Table
+}
+
+// TableInfo summarizes a stored table.
+type TableInfo struct {
+	Name       string   `json:"name"`
+	Columns    []Column `json:"columns"`
+	Rows       int      `json:"rows"`
+	Partitions int      `json:"partitions"`
+	SyncedAt   string   `json:"syncedAt,omitempty"`
+}
+
+// Store is a warehouse directory.
+type Store struct {
+	dir string
+}
+
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// DefaultDir returns ~/.asc/warehouse.
+func DefaultDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".asc", dirName), nil
+}
+
+// Open returns the store rooted at dir. The directory is created on the
+// first write.
+func Open(dir string) *Store {
+	return &Store{dir: filepath.Clean(dir)}
+}
+
+// Dir returns the store directory.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// HasPartition reports whether a partition has been stored.
+func (s *Store) HasPartition(table, key string) bool {
+	_, err := os.Stat(s.partitionPath(table, key))
+	return err == nil
+}
+
+// Put replaces one partition of a table.
+func (s *Store) Put(table, key, source string, data *Table) error {
+	if !namePattern.MatchString(table) {
+		return fmt.Errorf("invalid table name %q", table)
+	}
+	dir := filepath.Join(s.dir, table)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(partition{
+		Source:   source,
+		SyncedAt: time.Now().UTC().Format(time.RFC3339),
+		Table:    *data,
+	})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".partition-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+	if _, err := tmp.Write(encoded); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.partitionPath(table, key))
+}
+
+// Load reads every partition of a table. Columns are the union of the
+// partition columns in first-seen order.
+func (s *Store) Load(table string) (*Table, error) {
+	partitions, err := s.readPartitions(strings.ToLower(table))
+	if err != nil {
+		return nil, err
+	}
+	if len(partitions) == 0 {
+		return nil, fmt.Errorf("table %q does not exist (run 'asc warehouse sync' or see 'asc warehouse tables')", table)
+	}
+
+	merged := &Table{}
+	for _, part := range partitions {
+		mapping := make([]int, len(part.Columns))
+		for i, column := range part.Columns {
+			index := merged.ColumnIndex(column.Name)
+			if index < 0 {
+				merged.Columns = append(merged.Columns, column)
+				index = len(merged.Columns) - 1
+			}
+			mapping[i] = index
+		}
+		for _, row := range part.Rows {
+			out := make([]any, len(merged.Columns))
+			for i, value := range row {
+				if i < len(mapping) {
+					out[mapping[i]] = normalizeStoredValue(value)
+				}
+			}
+			merged.Rows = append(merged.Rows, out)
+		}
+	}
+	// Rows from earlier partitions are shorter when later partitions add columns.
+	for i, row := range merged.Rows {
+		if len(row) < len(merged.Columns) {
+			merged.Rows[i] = append(row, make([]any, len(merged.Columns)-len(row))...)
+		}
+	}
+	return merged, nil
+}
+
+// Tables lists the stored tables.
+func (s *Store) Tables() ([]TableInfo, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []TableInfo{}, nil
+		}
+		return nil, err
+	}
+
+	infos := []TableInfo{}
+	for _, entry := range entries {
+		if !entry.IsDir() || !namePattern.MatchString(entry.Name()) {
+			continue
+		}
+		partitions, err := s.readPartitions(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if len(partitions) == 0 {
+			continue
+		}
+		table, err := s.Load(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		info := TableInfo{
+			Name:       entry.Name(),
+			Columns:    table.Columns,
+			Rows:       len(table.Rows),
+			Partitions: len(partitions),
+		}
+		for _, part := range partitions {
+			if part.SyncedAt > info.SyncedAt {
+				info.SyncedAt = part.SyncedAt
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func (s *Store) readPartitions(table string) ([]partition, error) {
+	if !namePattern.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+	entries, err := os.ReadDir(filepath.Join(s.dir, table))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && strings.HasSuffix(entry.Name(), partitionExtension) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	partitions := make([]partition, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(s.dir, table, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var part partition
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&part); err != nil {
+			return nil, fmt.Errorf("invalid partition %s: %w", path, err)
+		}
+		partitions = append(partitions, part)
+	}
+	return partitions, nil
+}
+
+func (s *Store) partitionPath(table, key string) string {
+	return filepath.Join(s.dir, table, partitionFileName(key))
+}
+
+var unsafeKeyChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func partitionFileName(key string) string {
+	safe := strings.Trim(unsafeKeyChars.ReplaceAllString(key, "_"), "._")
+	if safe == "" {
+		safe = "default"
+	}
+	return safe + partitionExtension
+}
+
+func normalizeStoredValue(value any) any {
+	switch typed := value.(type) {
+	case json.Number:
+		number, err := typed.Float64()
+		if err != nil {
+			return typed.String()
+		}
+		return number
+	case string, float64, nil:
+		return typed
+	default:
+		return fmt.Sprint(typed)
+	}
+}
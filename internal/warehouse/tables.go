@@ -0,0 +1,237 @@
+package warehouse
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/reports"
+)
+
+// Built-in table names. Analytics reports are stored as one table per report,
+// named AnalyticsTablePrefix followed by the report name in snake case.
+const (
+	TableSales           = "sales"
+	TableFinance         = "finance"
+	TableReviews         = "reviews"
+	AnalyticsTablePrefix = "analytics_"
+)
+
+// Schema documents a built-in table.
+type Schema struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Columns     []Column `json:"columns"`
+}
+
+var salesColumns = []Column{
+	{Name: "vendor_number", Type: TypeText, Description: "Vendor number the report was downloaded for"},
+	{Name: "begin_date", Type: TypeText, Description: "First day of the report period (YYYY-MM-DD)"},
+	{Name: "end_date", Type: TypeText, Description: "Last day of the report period (YYYY-MM-DD)"},
+	{Name: "sku", Type: TypeText, Description: "Product SKU"},
+	{Name: "title", Type: TypeText, Description: "Product title"},
+	{Name: "apple_identifier", Type: TypeText, Description: "Product Apple ID"},
+	{Name: "parent_identifier", Type: TypeText, Description: "Parent app SKU for in-app purchases"},
+	{Name: "product_type_identifier", Type: TypeText, Description: "Raw product type code"},
+	{Name: "product_category", Type: TypeText, Description: "app, update, redownload, in_app_purchase, subscription, or bundle"},
+	{Name: "territory", Type: TypeText, Description: "Two-letter country code of the customer"},
+	{Name: "device", Type: TypeText, Description: "Device type"},
+	{Name: "version", Type: TypeText, Description: "App version"},
+	{Name: "subscription", Type: TypeText, Description: "New or Renewal for subscriptions"},
+	{Name: "units", Type: TypeNumber, Description: "Units (negative for refunds)"},
+	{Name: "developer_proceeds", Type: TypeNumber, Description: "Proceeds per unit in proceeds_currency"},
+	{Name: "proceeds_currency", Type: TypeText, Description: "Currency of developer_proceeds"},
+	{Name: "customer_price", Type: TypeNumber, Description: "Price per unit in customer_currency"},
+	{Name: "customer_currency", Type: TypeText, Description: "Currency the customer paid in"},
+}
+
+var financeColumns = []Column{
+	{Name: "vendor_number", Type: TypeText, Description: "Vendor number the report was downloaded for"},
+	{Name: "fiscal_month", Type: TypeText, Description: "Fiscal month of the report (YYYY-MM)"},
+	{Name: "region", Type: TypeText, Description: "Finance report region code"},
+	{Name: "start_date", Type: TypeText, Description: "First day of the fiscal period (YYYY-MM-DD)"},
+	{Name: "end_date", Type: TypeText, Description: "Last day of the fiscal period (YYYY-MM-DD)"},
+	{Name: "sku", Type: TypeText, Description: "Vendor identifier (SKU)"},
+	{Name: "title", Type: TypeText, Description: "Product title"},
+	{Name: "apple_identifier", Type: TypeText, Description: "Product Apple ID"},
+	{Name: "parent_identifier", Type: TypeText, Description: "Parent app SKU for in-app purchases"},
+	{Name: "product_type_identifier", Type: TypeText, Description: "Raw product type code"},
+	{Name: "product_category", Type: TypeText, Description: "app, update, redownload, in_app_purchase, subscription, or bundle"},
+	{Name: "territory", Type: TypeText, Description: "Country of sale"},
+	{Name: "sales_or_return", Type: TypeText, Description: "S for sales, R for returns"},
+	{Name: "quantity", Type: TypeNumber, Description: "Units (negative for returns)"},
+	{Name: "partner_share", Type: TypeNumber, Description: "Proceeds per unit in partner_share_currency"},
+	{Name: "extended_partner_share", Type: TypeNumber, Description: "quantity * partner_share"},
+	{Name: "partner_share_currency", Type: TypeText, Description: "Currency of the partner share"},
+	{Name: "customer_price", Type: TypeNumber, Description: "Price per unit in customer_currency"},
+	{Name: "customer_currency", Type: TypeText, Description: "Currency the customer paid in"},
+}
+
+var reviewsColumns = []Column{
+	{Name: "id", Type: TypeText, Description: "Customer review ID"},
+	{Name: "app_id", Type: TypeText, Description: "App Store Connect app ID"},
+	{Name: "rating", Type: TypeNumber, Description: "Star rating (1-5)"},
+	{Name: "title", Type: TypeText, Description: "Review title"},
+	{Name: "body", Type: TypeText, Description: "Review text"},
+	{Name: "reviewer", Type: TypeText, Description: "Reviewer nickname"},
+	{Name: "territory", Type: TypeText, Description: "Three-letter App Store territory code"},
+	{Name: "created_date", Type: TypeText, Description: "Creation timestamp (RFC 3339)"},
+}
+
+// Schemas returns the documented built-in tables.
+func Schemas() []Schema {
+	return []Schema{
+		{Name: TableSales, Description: "Daily SALES summary report rows", Columns: salesColumns},
+		{Name: TableFinance, Description: "Monthly FINANCIAL report rows for all regions (ZZ)", Columns: financeColumns},
+		{Name: TableReviews, Description: "Customer reviews", Columns: reviewsColumns},
+		{
+			Name:        AnalyticsTablePrefix + "<report>",
+			Description: "One table per mirrored analytics report, e.g. analytics_app_downloads_standard; columns follow the report CSV header in snake case, plus report_date and source_file",
+		},
+	}
+}
+
+// SalesTable converts parsed SALES rows.
+func SalesTable(vendor string, rows []reports.SalesRow) *Table {
+	table := &Table{Columns: salesColumns, Rows: make([][]any, 0, len(rows))}
+	for _, row := range rows {
+		table.Rows = append(table.Rows, []any{
+			vendor, row.BeginDate, row.EndDate, row.SKU, row.Title, row.AppleIdentifier,
+			row.ParentIdentifier, row.ProductTypeIdentifier, row.ProductCategory, row.CountryCode,
+			row.Device, row.Version, row.Subscription, row.Units, row.DeveloperProceeds,
+			row.ProceedsCurrency, row.CustomerPrice, row.CustomerCurrency,
+		})
+	}
+	return table
+}
+
+// FinanceTable converts parsed FINANCIAL rows.
+func FinanceTable(vendor, fiscalMonth, region string, rows []reports.FinancialRow) *Table {
+	table := &Table{Columns: financeColumns, Rows: make([][]any, 0, len(rows))}
+	for _, row := range rows {
+		table.Rows = append(table.Rows, []any{
+			vendor, fiscalMonth, region, row.StartDate, row.EndDate, row.VendorIdentifier, row.Title,
+			row.AppleIdentifier, row.ParentIdentifier, row.ProductTypeIdentifier, row.ProductCategory,
+			row.CountryOfSale, row.SalesOrReturn, row.Quantity, row.PartnerShare,
+			row.ExtendedPartnerShare, row.PartnerShareCurrency, row.CustomerPrice, row.CustomerCurrency,
+		})
+	}
+	return table
+}
+
+// ReviewsTable converts customer reviews.
+func ReviewsTable(appID string, reviews []asc.Resource[asc.ReviewAttributes]) *Table {
+	table := &Table{Columns: reviewsColumns, Rows: make([][]any, 0, len(reviews))}
+	for _, review := range reviews {
+		attrs := review.Attributes
+		table.Rows = append(table.Rows, []any{
+			review.ID, appID, float64(attrs.Rating), attrs.Title, attrs.Body,
+			attrs.ReviewerNickname, attrs.Territory, attrs.CreatedDate,
+		})
+	}
+	return table
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ColumnName converts a report header or report name to a snake_case
+// identifier usable in queries.
+func ColumnName(header string) string {
+	name := strings.Trim(nonIdentifierChars.ReplaceAllString(strings.ToLower(header), "_"), "_")
+	if name == "" {
+		return "column"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "c_" + name
+	}
+	return name
+}
+
+// AnalyticsTable parses an analytics report CSV (tab- or comma-separated).
+// Columns whose non-empty values are all numeric are stored as numbers.
+func AnalyticsTable(reportDate, sourceFile string, reader io.Reader) (*Table, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+
+	csvReader := csv.NewReader(bytes.NewReader(data))
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+	if bytes.Contains(firstLine, []byte("\t")) {
+		csvReader.Comma = '\t'
+	}
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("report is empty")
+	}
+
+	header := records[0]
+	columns := []Column{
+		{Name: "report_date", Type: TypeText},
+		{Name: "source_file", Type: TypeText},
+	}
+	for _, name := range header {
+		name = ColumnName(name)
+		for base, n := name, 2; columnIndex(columns, name) >= 0; n++ {
+			name = fmt.Sprintf("%s_%d", base, n)
+		}
+		columns = append(columns, Column{Name: name, Type: TypeNumber})
+	}
+
+	body := records[1:]
+	for i := range header {
+		for _, record := range body {
+			if i >= len(record) || strings.TrimSpace(record[i]) == "" {
+				continue
+			}
+			if _, ok := reports.ParseNumber(record[i]); !ok {
+				columns[i+2].Type = TypeText
+				break
+			}
+		}
+	}
+
+	table := &Table{Columns: columns, Rows: make([][]any, 0, len(body))}
+	for _, record := range body {
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		row := make([]any, len(columns))
+		row[0], row[1] = reportDate, sourceFile
+		for i := range header {
+			if i >= len(record) {
+				continue
+			}
+			value := strings.TrimSpace(record[i])
+			switch {
+			case value == "":
+				row[i+2] = nil
+			case columns[i+2].Type == TypeNumber:
+				row[i+2], _ = reports.ParseNumber(value)
+			default:
+				row[i+2] = value
+			}
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table, nil
+}
+
+func columnIndex(columns []Column, name string) int {
+	for i, column := range columns {
+		if column.Name == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,182 @@
+package warehouse
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/reports"
+)
+
+func testStore(t *testing.T) *Store {
+	t.Helper()
+	store := Open(t.TempDir())
+	day1 := SalesTable("123", []reports.SalesRow{
+		{BeginDate: "2026-01-01", SKU: "app", CountryCode: "US", Units: 10, DeveloperProceeds: 0, ProductCategory: reports.ProductCategoryApp},
+		{BeginDate: "2026-01-01", SKU: "pro", CountryCode: "US", Units: 2, DeveloperProceeds: 6.99, ProductCategory: reports.ProductCategorySubscription},
+		{BeginDate: "2026-01-01", SKU: "app", CountryCode: "DE", Units: 4, ProductCategory: reports.ProductCategoryApp},
+	})
+	day2 := SalesTable("123", []reports.SalesRow{
+		{BeginDate: "2026-01-02", SKU: "app", CountryCode: "DE", Units: 7, ProductCategory: reports.ProductCategoryApp},
+		{BeginDate: "2026-01-02", SKU: "app", CountryCode: "FR", Units: -1, ProductCategory: reports.ProductCategoryApp},
+	})
+	if err := store.Put(TableSales, "123_2026-01-01", "sales", day1); err != nil {
+		t.Fatalf("Put() error: %v", err)
+	}
+	if err := store.Put(TableSales, "123_2026-01-02", "sales", day2); err != nil {
+		t.Fatalf("Put() error: %v", err)
+	}
+	return store
+}
+
+func TestStorePutReplacesPartition(t *testing.T) {
+	store := testStore(t)
+	if !store.HasPartition(TableSales, "123_2026-01-02") || store.HasPartition(TableSales, "123_2026-01-03") {
+		t.Fatal("unexpected partition presence")
+	}
+	if err := store.Put(TableSales, "123_2026-01-02", "sales", SalesTable("123", nil)); err != nil {
+		t.Fatalf("Put() error: %v", err)
+	}
+
+	tables, err := store.Tables()
+	if err != nil {
+		t.Fatalf("Tables() error: %v", err)
+	}
+	if len(tables) != 1 || tables[0].Name != TableSales || tables[0].Rows != 3 || tables[0].Partitions != 2 {
+		t.Fatalf("unexpected tables %+v", tables)
+	}
+}
+
+func TestQueryGroupByOrderLimit(t *testing.T) {
+	store := testStore(t)
+	result, err := store.Query(`SELECT territory, sum(units) AS units, count(*) n
+		FROM sales
+		WHERE product_category = 'app' AND units > 0
+		GROUP BY territory
+		ORDER BY units DESC
+		LIMIT 2`)
+	if err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+	if strings.Join(result.Columns, ",") != "territory,units,n" {
+		t.Fatalf("unexpected columns %v", result.Columns)
+	}
+	if len(result.Rows) != 2 {
+		t.Fatalf("expected 2 rows, got %v", result.Rows)
+	}
+	if result.Rows[0][0] != "DE" || result.Rows[0][1] != 11.0 || result.Rows[0][2] != 2.0 {
+		t.Fatalf("unexpected first row %v", result.Rows[0])
+	}
+	if result.Rows[1][0] != "US" || result.Rows[1][1] != 10.0 {
+		t.Fatalf("unexpected second row %v", result.Rows[1])
+	}
+}
+
+func TestQueryExpressions(t *testing.T) {
+	store := testStore(t)
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"SELECT count(*) FROM sales", "5"},
+		{"SELECT count(DISTINCT territory) FROM sales", "3"},
+		{"SELECT sum(units * developer_proceeds) FROM sales WHERE sku = 'pro'", "13.98"},
+		{"SELECT round(avg(units), 1) FROM sales", "4.4"},
+		{"SELECT min(begin_date), max(units) FROM sales", "2026-01-01|10"},
+		{"SELECT upper(territory) FROM sales WHERE territory IN ('fr', 'FR')", "FR"},
+		{"SELECT territory FROM sales WHERE territory LIKE 'd%' LIMIT 1", "DE"},
+		{"SELECT DISTINCT sku FROM sales ORDER BY 1", "app;pro"},
+		{"SELECT sku FROM sales WHERE units BETWEEN 3 AND 5", "app"},
+		{"SELECT territory, sum(units) FROM sales GROUP BY territory HAVING sum(units) < 0", "FR|-1"},
+		{"SELECT sum(units) FROM sales WHERE sku = 'none'", ""},
+		{"SELECT coalesce(sum(units), 0) total FROM sales WHERE sku = 'none'", "0"},
+		{`SELECT "sku", substr(begin_date, 1, 7) month FROM sales WHERE NOT units >= 0`, "app|2026-01"},
+	}
+	for _, test := range tests {
+		result, err := store.Query(test.query)
+		if err != nil {
+			t.Fatalf("Query(%q) error: %v", test.query, err)
+		}
+		rows := make([]string, 0, len(result.Rows))
+		for _, row := range result.Rows {
+			values := make([]string, 0, len(row))
+			for _, value := range row {
+				values = append(values, FormatValue(value))
+			}
+			rows = append(rows, strings.Join(values, "|"))
+		}
+		if got := strings.Join(rows, ";"); got != test.want {
+			t.Fatalf("Query(%q) = %q, want %q", test.query, got, test.want)
+		}
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	store := testStore(t)
+	tests := map[string]string{
+		"DELETE FROM sales":                              "expected SELECT",
+		"SELECT nope FROM sales":                         `unknown column "nope"`,
+		"SELECT * FROM missing":                          `table "missing" does not exist`,
+		"SELECT * FROM sales GROUP BY sku":               "SELECT * cannot be combined",
+		"SELECT sku FROM sales WHERE sum(units) > 1":     "not allowed in WHERE",
+		"SELECT median(units) FROM sales":                "unknown function median",
+		"SELECT sku FROM sales WHERE territory = 'US":    "unterminated string",
+		"SELECT sku FROM sales LIMIT -1":                 "LIMIT expects a non-negative integer",
+		"SELECT sum(count(units)) FROM sales":            "cannot be nested",
+		"SELECT sku FROM sales ORDER BY 3":               "out of range",
+		"SELECT sku FROM sales WHERE territory = 'US' x": `unexpected "x"`,
+	}
+	for query, want := range tests {
+		if _, err := store.Query(query); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("Query(%q) error = %v, want %q", query, err, want)
+		}
+	}
+}
+
+func TestResultMarshalJSONKeepsColumnOrder(t *testing.T) {
+	result := &Result{Table: "sales", Columns: []string{"z", "a"}, Rows: [][]any{{"x", 1.5}}}
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	if string(data) != `{"table":"sales","columns":["z","a"],"rows":[{"z":"x","a":1.5}]}` {
+		t.Fatalf("unexpected JSON %s", data)
+	}
+}
+
+func TestLikeMatch(t *testing.T) {
+	tests := []struct {
+		value, pattern string
+		want           bool
+	}{
+		{"Great app", "%APP", true},
+		{"Great app", "great_app", true},
+		{"Great app", "%crash%", false},
+		{"", "%", true},
+		{"abc", "a%c%", true},
+		{"abc", "a_", false},
+	}
+	for _, test := range tests {
+		if got := likeMatch(test.value, test.pattern); got != test.want {
+			t.Fatalf("likeMatch(%q, %q) = %v", test.value, test.pattern, got)
+		}
+	}
+}
+
+func TestAnalyticsTable(t *testing.T) {
+	text := "Date\tApp Name\tTerritory\tCounts\n2026-01-01\tMy App\tUS\t12\n2026-01-01\tMy App\tDE\t\n"
+	table, err := AnalyticsTable("2026-01-01", "app-usage/app-downloads/2026-01-01.csv", strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("AnalyticsTable() error: %v", err)
+	}
+	names := make([]string, 0, len(table.Columns))
+	for _, column := range table.Columns {
+		names = append(names, column.Name+":"+string(column.Type))
+	}
+	if strings.Join(names, ",") != "report_date:text,source_file:text,date:text,app_name:text,territory:text,counts:number" {
+		t.Fatalf("unexpected columns %v", names)
+	}
+	if len(table.Rows) != 2 || table.Rows[0][5] != 12.0 || table.Rows[1][5] != nil {
+		t.Fatalf("unexpected rows %v", table.Rows)
+	}
+}